@@ -0,0 +1,28 @@
+package goht
+
+// Attr is a single attribute in an Attrs list.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// Attrs is an ordered list of attributes that can be given to the
+// @attributes command. The attributes are written in the order of the list.
+//
+// The value of an attribute may be a string, a bool, an integer, a float, a
+// fmt.Stringer, nil or one of the trusted types. A true value is written as
+// a boolean attribute, and a false or nil value leaves the attribute out;
+// the boolean values of aria attributes are written as "true" or "false".
+// Strings are escaped for the context of the attribute, the same as any
+// other dynamic attribute value. A map value is expanded into attributes
+// that are prefixed with the key, the same as a map given to a data or aria
+// attribute. A key with characters other than ASCII letters, digits and
+// "_:.-" returns an error when the attributes are written.
+type Attrs []Attr
+
+// Attributer is implemented by types that contribute attributes to an
+// element, such as the props of a component. An Attributer can be given
+// directly to the @attributes command.
+type Attributer interface {
+	Attributes() Attrs
+}
@@ -0,0 +1,49 @@
+package goht_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stackus/goht"
+	"github.com/stackus/goht/compiler/testdata"
+)
+
+func TestBlocks(t *testing.T) {
+	tests := map[string]struct {
+		template goht.Template
+		want     string
+	}{
+		"default content": {
+			template: testdata.BlockTest(),
+			want:     "<title>\nDefault</title>\n<h1>\nNested</h1>\n",
+		},
+		"overridden": {
+			template: testdata.PageBlockTest(),
+			want:     "<title>\nPage</title>\n<h1>\nNested</h1>\n",
+		},
+		"not overridden": {
+			template: testdata.OtherBlockTest(),
+			want:     "<title>\nDefault</title>\n<h1>\nNested</h1>\n",
+		},
+		"most extended template wins": {
+			template: testdata.ExtendedBlockTest(),
+			want:     "<title>\nExtended</title>\n<h1>\nNested</h1>\n",
+		},
+		"inherited through a template without the block": {
+			template: testdata.InheritedBlockTest(),
+			want:     "<title>\nInherited</title>\n<h1>\nNested</h1>\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.template.Render(context.Background(), &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("want %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
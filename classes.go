@@ -0,0 +1,37 @@
+package goht
+
+// Classes is an ordered list of classes that can be given as a class value.
+//
+// The list is built up with Add and If, which makes it easy to compose
+// utility classes:
+//
+//	goht.Classes{"btn", "px-4"}.If(primary, "bg-blue-500").If(disabled, "opacity-50")
+type Classes []string
+
+// Add returns a copy of the list with the classes added to the end.
+func (c Classes) Add(classes ...string) Classes {
+	// the capacity is limited so that lists built from the same list never
+	// share the classes that are added to them
+	return append(c[:len(c):len(c)], classes...)
+}
+
+// If returns a copy of the list with the classes added to the end when the
+// condition is true.
+func (c Classes) If(condition bool, classes ...string) Classes {
+	if !condition {
+		return c
+	}
+	return c.Add(classes...)
+}
+
+// ClassList returns the classes of the list.
+func (c Classes) ClassList() []string {
+	return c
+}
+
+// ClassLister is implemented by types that contribute classes to an
+// element, such as the props of a component. A ClassLister can be given
+// directly as a class value.
+type ClassLister interface {
+	ClassList() []string
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/stackus/goht/compiler"
+)
+
+// ConfigFileName is the config file that is read from the working directory
+// when the --config flag is not given.
+const ConfigFileName = "goht.json"
+
+type config struct {
+	// Filters are the custom filters, by name, that can be used in the templates
+	Filters map[string]filterConfig `json:"filters"`
+}
+
+type filterConfig struct {
+	// Func is the Go function that converts the body of the filter when the
+	// template is rendered (e.g. "md.Render")
+	Func string `json:"func"`
+	// Import is the import path of the package of Func
+	Import string `json:"import"`
+}
+
+var configFile string
+
+// loadConfig reads the config file and registers its filters. A missing config
+// file is only an error when the file was named with the --config flag.
+func loadConfig(fileName string) error {
+	required := fileName != ""
+	if !required {
+		fileName = ConfigFileName
+	}
+
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var cfg config
+	if err := json.Unmarshal(contents, &cfg); err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Filters)) {
+		filter := cfg.Filters[name]
+		if err := compiler.RegisterFilter(name, compiler.Filter{
+			Func:   filter.Func,
+			Import: filter.Import,
+		}); err != nil {
+			return fmt.Errorf("%s: %w", fileName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigRegistersFilters(t *testing.T) {
+	root := t.TempDir()
+	configName := filepath.Join(root, "filters.json")
+	writeFile(t, configName, `{"filters": {"configmarkdown": {"func": "md.Render", "import": "example.com/md"}}}`)
+	if err := loadConfig(configName); err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	writeFile(t, filepath.Join(root, "page.goht"), "package main\n\n@haml Page() {\n\t:configmarkdown\n\t\t# Title\n}\n")
+	withGenerateState(t, generateFlags{path: root}, 1, func() {
+		if err := runGenerateContext(context.Background()); err != nil {
+			t.Fatalf("runGenerateContext() error = %v", err)
+		}
+	})
+
+	got := string(readFile(t, filepath.Join(root, "page.goht.go")))
+	for _, want := range []string{`"example.com/md"`, `md.Render("# Title\n")`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated file is missing %s\n%s", want, got)
+		}
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	root := t.TempDir()
+	tests := map[string]struct {
+		contents string
+		want     string
+	}{
+		"invalid json": {
+			contents: `{"filters": `,
+			want:     "unexpected end of JSON input",
+		},
+		"built in filter": {
+			contents: `{"filters": {"css": {"func": "css.Minify"}}}`,
+			want:     "the css filter is built in",
+		},
+		"missing func": {
+			contents: `{"filters": {"nofunc": {}}}`,
+			want:     "must have either a Transform or a Func",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			configName := filepath.Join(root, strings.ReplaceAll(name, " ", "_")+".json")
+			writeFile(t, configName, tt.contents)
+			err := loadConfig(configName)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("loadConfig() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileIsOptional(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Fatalf("restore working directory: %v", err)
+		}
+	})
+	root := t.TempDir()
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadConfig(""); err != nil {
+		t.Errorf("loadConfig() without a config file error = %v", err)
+	}
+	if err := loadConfig(filepath.Join(root, "missing.json")); err == nil {
+		t.Error("loadConfig() with a missing --config file error = nil")
+	}
+}
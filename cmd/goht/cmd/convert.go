@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/stackus/goht/compiler"
+)
+
+type convertFlags struct {
+	to string
+}
+
+var convertOptions convertFlags
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert [files]",
+	Short: "Converts Goht templates to another dialect",
+	Long: `Converts the templates of Goht files to the Haml, Slim or EGO dialect. The
+Go code of the files is kept as it is, and each template is rewritten to render
+the same bytes, keeping its Go code, commands and comments. The whitespace
+between the tags is kept with the whitespace markers of Haml and Slim.
+
+A template with content or whitespace that can't be written in the dialect,
+such as a newline in Slim, is left as it is and reported; the other templates
+of the file are still converted.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConvert(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringVar(&convertOptions.to, "to", "", "The dialect to convert to: haml, slim or ego.")
+	_ = convertCmd.MarkFlagRequired("to")
+}
+
+func runConvert(fileNames []string) error {
+	switch convertOptions.to {
+	case "haml", "slim", "ego":
+	default:
+		return fmt.Errorf("unknown dialect: %q, expected haml, slim or ego", convertOptions.to)
+	}
+
+	var errs []error
+	for _, fileName := range fileNames {
+		changed, err := convertFile(fileName, convertOptions.to)
+		if changed {
+			log.Infof("converted: '%s'", fileName)
+		}
+		if err != nil {
+			log.Errorf("failed to convert: '%s': %s", fileName, errorSummary(err))
+			if diagnostics := formatDiagnostics(fileName, err); diagnostics != "" {
+				fmt.Fprint(os.Stderr, diagnostics)
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", fileName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// convertFile converts the templates of the file to the dialect and reports
+// whether the file was changed. The templates that can be converted are
+// written even when the others return errors.
+func convertFile(fileName, dialect string) (bool, error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return false, err
+	}
+	converted, err := compiler.Convert(src, dialect)
+	if converted == nil || bytes.Equal(src, converted) {
+		return false, err
+	}
+	if writeErr := os.WriteFile(fileName, converted, 0644); writeErr != nil {
+		return false, writeErr
+	}
+	return true, err
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const hamlGoht = "package test\n\n@haml Page(title string) {\n\t%p.title= title\n}\n"
+
+const slimGoht = "package test\n\n@slim Page(title string) {\n\tp.title= title\n}\n"
+
+func TestConvertRewritesFiles(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "page.goht")
+	writeFile(t, fileName, hamlGoht)
+
+	withConvertState(t, convertFlags{to: "slim"}, func() {
+		if err := runConvert([]string{fileName}); err != nil {
+			t.Fatalf("runConvert() error = %v", err)
+		}
+	})
+
+	if got := string(readFile(t, fileName)); got != slimGoht {
+		t.Errorf("converted file = %q, want %q", got, slimGoht)
+	}
+}
+
+func TestConvertReturnsErrors(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "page.goht")
+	src := "package test\n\n@haml Card(obj any) {\n\t%p[obj] text\n}\n"
+	writeFile(t, fileName, src+"\n"+strings.TrimPrefix(hamlGoht, "package test\n\n"))
+
+	withConvertState(t, convertFlags{to: "slim"}, func() {
+		if err := runConvert([]string{fileName}); err == nil {
+			t.Fatal("runConvert() error = nil")
+		}
+	})
+
+	want := src + "\n" + strings.TrimPrefix(slimGoht, "package test\n\n")
+	if got := string(readFile(t, fileName)); got != want {
+		t.Errorf("converted file = %q, want %q", got, want)
+	}
+}
+
+func TestConvertKeepsFilesThatDoNotParse(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "page.goht")
+	src := "package test\n\n@haml Page() {\n\t%p{id: bar}\n}\n"
+	writeFile(t, fileName, src)
+
+	withConvertState(t, convertFlags{to: "slim"}, func() {
+		if err := runConvert([]string{fileName}); err == nil {
+			t.Fatal("runConvert() error = nil")
+		}
+	})
+
+	if got := string(readFile(t, fileName)); got != src {
+		t.Errorf("file with errors was changed: %q", got)
+	}
+}
+
+func TestConvertRejectsUnknownDialects(t *testing.T) {
+	withConvertState(t, convertFlags{to: "pug"}, func() {
+		if err := runConvert([]string{"page.goht"}); err == nil {
+			t.Fatal("runConvert() error = nil")
+		}
+	})
+}
+
+func withConvertState(t *testing.T, options convertFlags, fn func()) {
+	t.Helper()
+
+	oldOptions := convertOptions
+	convertOptions = options
+	defer func() {
+		convertOptions = oldOptions
+	}()
+
+	fn()
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/stackus/goht/compiler"
+)
+
+type fmtFlags struct {
+	path     string
+	skipDirs []string
+	check    bool
+}
+
+var fmtOptions fmtFlags
+
+// fmtCmd represents the fmt command
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Formats Goht files",
+	Long: `Formats Goht files into their canonical layout. The Go code is formatted
+with gofmt and the imports are sorted. The attribute lists of Haml and Slim
+templates are written with single spaces and double-quoted values, and the
+whitespace at the ends of the lines is removed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFmt()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fmtCmd)
+
+	fmtCmd.Flags().StringVar(&fmtOptions.path, "path", ".", "The path to the templates directory.")
+	fmtCmd.Flags().StringSliceVar(&fmtOptions.skipDirs, "skip-dirs", []string{
+		"vendor", "node_modules",
+	}, "The directories to skip.")
+	fmtCmd.Flags().BoolVar(&fmtOptions.check, "check", false, "List the files that are not formatted without changing them.")
+}
+
+func runFmt() error {
+	var fileNames []string
+	err := filepath.WalkDir(fmtOptions.path, func(entryName string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entryName == fmtOptions.path {
+				return nil
+			}
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || slices.Contains(fmtOptions.skipDirs, name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(entryName, GohtFileExtension) {
+			fileNames = append(fileNames, entryName)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var unformatted []string
+	for _, fileName := range fileNames {
+		changed, err := formatFile(fileName, !fmtOptions.check)
+		if err != nil {
+			log.Errorf("failed to format: '%s': %s", fileName, errorSummary(err))
+			if diagnostics := formatDiagnostics(fileName, err); diagnostics != "" {
+				fmt.Fprint(os.Stderr, diagnostics)
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", fileName, err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if fmtOptions.check {
+			fmt.Println(fileName)
+			unformatted = append(unformatted, fileName)
+		} else {
+			log.Infof("formatted: '%s'", fileName)
+		}
+	}
+
+	if len(unformatted) > 0 {
+		errs = append(errs, fmt.Errorf("%d files are not formatted", len(unformatted)))
+	}
+	return errors.Join(errs...)
+}
+
+// formatFile formats the template file and reports whether the formatted file
+// is different. The file is only rewritten when write is true.
+func formatFile(fileName string, write bool) (bool, error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := compiler.Format(src)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(src, formatted) {
+		return false, nil
+	}
+	if write {
+		return true, os.WriteFile(fileName, formatted, 0644)
+	}
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const unformattedGoht = "package test\n\nimport (\n\"strings\"\n\"fmt\"\n)\n\n@haml Page( title string ) {\n\t%p{id:\"a\",class :`b`} #{strings.ToUpper(title)}\n\t\n\t%p= fmt.Sprint(1)\n}\n"
+
+const formattedGoht = "package test\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\n@haml Page(title string) {\n\t%p{id: \"a\", class: \"b\"} #{strings.ToUpper(title)}\n\n\t%p= fmt.Sprint(1)\n}\n"
+
+func TestFmtFormatsFiles(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "pages", "page.goht")
+	writeFile(t, fileName, unformattedGoht)
+	skipped := filepath.Join(root, "vendor", "skipped.goht")
+	writeFile(t, skipped, unformattedGoht)
+
+	withFmtState(t, fmtFlags{path: root, skipDirs: []string{"vendor"}}, func() {
+		if err := runFmt(); err != nil {
+			t.Fatalf("runFmt() error = %v", err)
+		}
+	})
+
+	if got := string(readFile(t, fileName)); got != formattedGoht {
+		t.Errorf("formatted file = %q, want %q", got, formattedGoht)
+	}
+	if got := string(readFile(t, skipped)); got != unformattedGoht {
+		t.Errorf("skipped file was formatted: %q", got)
+	}
+}
+
+func TestFmtCheckReportsUnformattedFiles(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "page.goht")
+	writeFile(t, fileName, unformattedGoht)
+	writeFile(t, filepath.Join(root, "done.goht"), formattedGoht)
+
+	withFmtState(t, fmtFlags{path: root, check: true}, func() {
+		err := runFmt()
+		if err == nil || !strings.Contains(err.Error(), "1 files are not formatted") {
+			t.Fatalf("runFmt() error = %v, want the unformatted files", err)
+		}
+	})
+
+	if got := string(readFile(t, fileName)); got != unformattedGoht {
+		t.Errorf("check changed the file: %q", got)
+	}
+}
+
+func TestFmtReturnsParseErrors(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "bad.goht"), "package test\n\n@haml Broken() {\n\t%p{id: bar}\n}\n")
+
+	withFmtState(t, fmtFlags{path: root}, func() {
+		if err := runFmt(); err == nil {
+			t.Fatal("runFmt() error = nil")
+		}
+	})
+}
+
+func withFmtState(t *testing.T, options fmtFlags, fn func()) {
+	t.Helper()
+
+	oldOptions := fmtOptions
+	fmtOptions = options
+	defer func() {
+		fmtOptions = oldOptions
+	}()
+
+	fn()
+}
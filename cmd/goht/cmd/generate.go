@@ -107,7 +107,10 @@ func runGenerateContext(ctx context.Context) error {
 				start := time.Now()
 				fileHash, wrote, err := processFile(generateOptions.path, fileName, files.get(fileName).lastHash)
 				if err != nil {
-					log.Errorf("failed to process: '%s': %s", fileName, err)
+					log.Errorf("failed to process: '%s': %s", fileName, errorSummary(err))
+					if diagnostics := formatDiagnostics(fileName, err); diagnostics != "" {
+						fmt.Fprint(os.Stderr, diagnostics)
+					}
 					if !generateOptions.watch {
 						processingErrsMu.Lock()
 						processingErrs = append(processingErrs, fmt.Errorf("%s: %w", fileName, err))
@@ -292,6 +295,33 @@ func processFile(path, fileName string, lastHash [sha256.Size]byte) (fileHash [s
 	}
 }
 
+// errorSummary returns the error, or the number of errors when the template file
+// has parse errors that are written out by formatDiagnostics.
+func errorSummary(err error) string {
+	if errList, ok := errors.AsType[compiler.ErrorList](err); ok {
+		if len(errList) == 1 {
+			return "1 error"
+		}
+		return fmt.Sprintf("%d errors", len(errList))
+	}
+	return err.Error()
+}
+
+// formatDiagnostics returns the parse errors of a template file with the source
+// lines that caused them and their hints.
+func formatDiagnostics(fileName string, err error) string {
+	errList, ok := errors.AsType[compiler.ErrorList](err)
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	for _, d := range errList.Diagnostics() {
+		b.WriteString(d.Format(fileName))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func newFileInfos() *fileInfos {
 	return &fileInfos{
 		files: make(map[string]fileInfo),
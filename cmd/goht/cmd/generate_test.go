@@ -91,6 +91,32 @@ func TestGenerateReturnsOneShotProcessingErrors(t *testing.T) {
 	})
 }
 
+func TestFormatDiagnosticsWritesEachParseError(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "bad.goht"), "package test\n\n@haml Broken() {\n\t%p{id: bar}\n\t:unknown\n}\n")
+
+	_, _, err := processFile(root, "bad.goht", [sha256.Size]byte{})
+	if err == nil {
+		t.Fatal("processFile() error = nil")
+	}
+	if got := errorSummary(err); got != "2 errors" {
+		t.Errorf("errorSummary() = %q, want %q", got, "2 errors")
+	}
+	got := formatDiagnostics("bad.goht", err)
+	for _, want := range []string{
+		"error[GOHT001]: unexpected character: 'b'",
+		" --> bad.goht:4:9",
+		"4 | \t%p{id: bar}",
+		"error[GOHT010]: unknown filter: unknown",
+		"5 | \t:unknown",
+		"= hint: ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatDiagnostics() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
 func TestProcessFileReturnsLastHashWhenUnchanged(t *testing.T) {
 	root := t.TempDir()
 	writeGohtFile(t, filepath.Join(root, "example.goht"), "same")
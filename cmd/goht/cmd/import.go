@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/stackus/goht/compiler"
+)
+
+type importFlags struct {
+	to    string
+	pkg   string
+	force bool
+}
+
+var importOptions = importFlags{
+	to: "haml",
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [files]",
+	Short: "Imports HTML and html/template files as Goht files",
+	Long: `Imports HTML files and html/template files, such as .html, .tmpl and .gohtml
+files, as Goht files with Haml, Slim or EGO templates. Each file is written next
+to the imported file with the .goht extension.
+
+The main template is named after the file, and each template that is defined
+with {{define}} or {{block}} becomes a template of its own. The actions of the
+templates become Go code, and the data of a template becomes a parameter of the
+type any, which should be replaced with the real type. Anything that can't be
+imported is left in the templates as a TODO comment.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importOptions.to, "to", importOptions.to, "The dialect to import to: haml, slim or ego.")
+	importCmd.Flags().StringVar(&importOptions.pkg, "package", "", "The package of the Goht files. (default: the name of the directory of each file)")
+	importCmd.Flags().BoolVar(&importOptions.force, "force", false, "Overwrite Goht files that already exist.")
+}
+
+func runImport(fileNames []string) error {
+	switch importOptions.to {
+	case "haml", "slim", "ego":
+	default:
+		return fmt.Errorf("unknown dialect: %q, expected haml, slim or ego", importOptions.to)
+	}
+
+	var errs []error
+	for _, fileName := range fileNames {
+		gohtFileName, err := importFile(fileName)
+		if err != nil {
+			log.Errorf("failed to import: '%s': %s", fileName, errorSummary(err))
+			errs = append(errs, fmt.Errorf("%s: %w", fileName, err))
+			continue
+		}
+		log.Infof("imported: '%s' as '%s'", fileName, gohtFileName)
+	}
+	return errors.Join(errs...)
+}
+
+// importFile imports the file as a Goht file and returns the name of the Goht
+// file.
+func importFile(fileName string) (string, error) {
+	src, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	dir, base := filepath.Split(fileName)
+	name, _, _ := strings.Cut(base, ".")
+	gohtFileName := filepath.Join(dir, name+".goht")
+	if !importOptions.force {
+		if _, err := os.Stat(gohtFileName); err == nil {
+			return "", fmt.Errorf("%s already exists; use --force to overwrite it", gohtFileName)
+		}
+	}
+
+	pkg := importOptions.pkg
+	if pkg == "" {
+		if pkg, err = importPackage(dir); err != nil {
+			return "", err
+		}
+	}
+
+	imported, err := compiler.Import(src, compiler.ImportOptions{
+		Package: pkg,
+		Name:    name,
+		Dialect: importOptions.to,
+	})
+	if err != nil {
+		return "", err
+	}
+	return gohtFileName, os.WriteFile(gohtFileName, imported, 0644)
+}
+
+// importPackage returns a package name from the name of the directory.
+func importPackage(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	pkg := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(abs))
+	if !token.IsIdentifier(pkg) {
+		return "templates", nil
+	}
+	return pkg, nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const pageTmpl = "<h1>{{.Title}}</h1>\n"
+
+const pageGoht = "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t%h1= data.Title\n}\n"
+
+func TestImportWritesGohtFiles(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "pages", "page.html.tmpl")
+	writeFile(t, fileName, pageTmpl)
+
+	withImportState(t, importFlags{to: "haml"}, func() {
+		if err := runImport([]string{fileName}); err != nil {
+			t.Fatalf("runImport() error = %v", err)
+		}
+	})
+
+	if got := string(readFile(t, filepath.Join(root, "pages", "page.goht"))); got != pageGoht {
+		t.Errorf("imported file = %q, want %q", got, pageGoht)
+	}
+}
+
+func TestImportKeepsExistingFiles(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "pages", "page.html")
+	writeFile(t, fileName, pageTmpl)
+	gohtFileName := filepath.Join(root, "pages", "page.goht")
+	writeFile(t, gohtFileName, "existing")
+
+	withImportState(t, importFlags{to: "haml"}, func() {
+		if err := runImport([]string{fileName}); err == nil {
+			t.Fatal("runImport() error = nil")
+		}
+	})
+	if got := string(readFile(t, gohtFileName)); got != "existing" {
+		t.Errorf("existing file was overwritten: %q", got)
+	}
+
+	withImportState(t, importFlags{to: "haml", pkg: "views", force: true}, func() {
+		if err := runImport([]string{fileName}); err != nil {
+			t.Fatalf("runImport() error = %v", err)
+		}
+	})
+	want := "package views" + pageGoht[len("package pages"):]
+	if got := string(readFile(t, gohtFileName)); got != want {
+		t.Errorf("imported file = %q, want %q", got, want)
+	}
+}
+
+func TestImportReturnsErrors(t *testing.T) {
+	root := t.TempDir()
+	fileName := filepath.Join(root, "page.tmpl")
+	writeFile(t, fileName, "{{if .A}}<p>a</p>\n")
+
+	withImportState(t, importFlags{to: "haml"}, func() {
+		if err := runImport([]string{fileName}); err == nil {
+			t.Fatal("runImport() error = nil")
+		}
+	})
+
+	if _, err := os.Stat(filepath.Join(root, "page.goht")); !os.IsNotExist(err) {
+		t.Errorf("a file was written for a template with errors: %v", err)
+	}
+}
+
+func TestImportRejectsUnknownDialects(t *testing.T) {
+	withImportState(t, importFlags{to: "pug"}, func() {
+		if err := runImport([]string{"page.html"}); err == nil {
+			t.Fatal("runImport() error = nil")
+		}
+	})
+}
+
+func withImportState(t *testing.T, options importFlags, fn func()) {
+	t.Helper()
+
+	oldOptions := importOptions
+	importOptions = options
+	defer func() {
+		importOptions = oldOptions
+	}()
+
+	fn()
+}
@@ -16,6 +16,9 @@ var rootCmd = &cobra.Command{
 	Long: `Goht is a templating language for Go. It's designed to be simple and easy to use.
 It combines Go and Haml to create a powerful templating language that's easy to learn.`,
 	Version: goht.Version(),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(configFile)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -29,6 +32,7 @@ func Execute() {
 
 func init() {
 	log.SetReportTimestamp(false)
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "The config file of the custom filters. (default: "+ConfigFileName+" when it exists)")
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
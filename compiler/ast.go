@@ -0,0 +1,208 @@
+package compiler
+
+import (
+	"html"
+	"strings"
+
+	"github.com/stackus/goht/compiler/ast"
+)
+
+// ParseAST parses a Goht file into a syntax tree.
+//
+// A file that has errors is not parsed, and the errors are returned as an
+// ErrorList.
+func ParseAST(src []byte) (*ast.File, error) {
+	t, err := parseBytes(src)
+	if err != nil {
+		return nil, err
+	}
+
+	root := t.Root.(*RootNode)
+	f := &ast.File{}
+	if root.pkg.line > 0 {
+		f.Package = root.pkg.lit
+	}
+	for _, i := range root.userImports {
+		f.Imports = append(f.Imports, &ast.Import{Span: astSpan(i), Path: i.lit})
+	}
+
+	b := &astBuilder{lines: strings.Split(string(src), "\n")}
+	templates := newFormatter(src).templates
+	for _, n := range root.children {
+		switch n := n.(type) {
+		case *CodeNode:
+			code := &ast.GoCode{Span: astSpan(n.tokens[0]), Code: n.text.String()}
+			code.To = astSpan(n.tokens[len(n.tokens)-1]).To
+			if f.Package != "" && code.From.Line < root.pkg.line {
+				f.Doc = code
+				continue
+			}
+			f.Decls = append(f.Decls, code)
+		case *TemplateNode:
+			ft := templates[0]
+			templates = templates[1:]
+			t := &ast.Template{
+				Span:    ast.Span{From: ast.Pos{Line: ft.startLine, Column: 1}, To: ast.Pos{Line: ft.endLine, Column: 2}},
+				Keyword: ft.keyword,
+				Decl:    strings.TrimSpace(n.decl),
+				Name:    n.name(),
+				Body:    b.content(n.children),
+			}
+			f.Decls = append(f.Decls, t)
+		}
+	}
+	f.From = ast.Pos{Line: 1, Column: 1}
+	f.To = f.From
+	if len(f.Decls) > 0 {
+		f.To = f.Decls[len(f.Decls)-1].End()
+	}
+
+	return f, nil
+}
+
+// astSpan returns the span of the token.
+func astSpan(t token) ast.Span {
+	return ast.Span{
+		From: ast.Pos{Line: t.line, Column: t.col},
+		To:   ast.Pos{Line: t.endLine, Column: t.endCol},
+	}
+}
+
+// astNodeSpan returns the span of the node, which ends at the end of its
+// last child when that is after the end of its origin.
+func astNodeSpan(n nodeBase, children []ast.Content) ast.Span {
+	span := astSpan(n.Origin())
+	if len(children) > 0 {
+		end := children[len(children)-1].End()
+		if end.Line > span.To.Line || end.Line == span.To.Line && end.Column > span.To.Column {
+			span.To = end
+		}
+	}
+	return span
+}
+
+// astBuilder builds the syntax tree of a file from its parsed nodes.
+type astBuilder struct {
+	lines []string
+}
+
+func (b *astBuilder) content(nodes []nodeBase) []ast.Content {
+	var content []ast.Content
+	for _, n := range nodes {
+		if c := b.node(n); c != nil {
+			content = append(content, c)
+		}
+	}
+	return content
+}
+
+func (b *astBuilder) node(n nodeBase) ast.Content {
+	switch n := n.(type) {
+	case *NewLineNode:
+		return &ast.NewLine{Span: astSpan(n.origin)}
+	case *DoctypeNode:
+		return &ast.Doctype{Span: astSpan(n.origin), Value: n.doctype}
+	case *ElementNode:
+		return b.element(n)
+	case *CommentNode:
+		children := b.content(n.children)
+		return &ast.Comment{Span: astNodeSpan(n, children), Value: n.text, Children: children}
+	case *TextNode:
+		kind := ast.EscapedText
+		switch {
+		case n.isDynamic:
+			kind = ast.DynamicText
+		case n.isPlain:
+			kind = ast.PlainText
+		case n.isPreserve:
+			kind = ast.PreserveText
+		}
+		return &ast.Text{Span: astSpan(n.origin), Kind: kind, Value: n.text}
+	case *RawTextNode:
+		return &ast.Text{Span: astSpan(n.origin), Kind: ast.RawText, Value: n.text}
+	case *UnescapeNode:
+		children := b.content(n.children)
+		return &ast.Unescape{Span: astNodeSpan(n, children), Children: children}
+	case *SilentScriptNode:
+		children := b.content(n.children)
+		return &ast.SilentScript{Span: astNodeSpan(n, children), Code: n.code, Children: children}
+	case *ScriptNode:
+		return &ast.Script{Span: astSpan(n.origin), Code: n.code}
+	case *RenderCommandNode:
+		children := b.content(n.children)
+		return &ast.RenderCommand{Span: astNodeSpan(n, children), Call: strings.TrimSpace(n.command), Children: children}
+	case *ChildrenCommandNode:
+		return &ast.ChildrenCommand{Span: astSpan(n.origin)}
+	case *SlotCommandNode:
+		children := b.content(n.children)
+		return &ast.SlotCommand{Span: astNodeSpan(n, children), Name: n.slot, Value: n.value, ValueType: n.valueType, Children: children}
+	case *FlushCommandNode:
+		return &ast.FlushCommand{Span: astSpan(n.origin)}
+	case *FragmentCommandNode:
+		children := b.content(n.children)
+		return &ast.FragmentCommand{Span: astNodeSpan(n, children), Name: strings.TrimSpace(n.fragment), Children: children}
+	case *ExtendsCommandNode:
+		return &ast.ExtendsCommand{Span: astSpan(n.origin), Template: strings.TrimSpace(n.template)}
+	case *BlockCommandNode:
+		children := b.content(n.children)
+		return &ast.BlockCommand{Span: astNodeSpan(n, children), Name: strings.TrimSpace(n.block), Children: children}
+	case *PushCommandNode:
+		children := b.content(n.children)
+		return &ast.PushCommand{Span: astNodeSpan(n, children), Stack: n.stack, Key: n.key, Children: children}
+	case *JsonCommandNode:
+		return &ast.JSONCommand{Span: astSpan(n.origin), Value: n.value}
+	case *StackCommandNode:
+		return &ast.StackCommand{Span: astSpan(n.origin), Name: n.stack}
+	case *FormatCommandNode:
+		return &ast.FormatCommand{Span: astSpan(n.origin), Format: n.name}
+	case *JavaScriptFilterNode, *CssFilterNode, *TextFilterNode, *CustomFilterNode:
+		f := &ast.Filter{Name: n.Origin().lit}
+		var children []ast.Content
+		for _, c := range n.Children() {
+			if t, ok := b.node(c).(*ast.Text); ok {
+				f.Body = append(f.Body, t)
+				children = append(children, t)
+			}
+		}
+		f.Span = astNodeSpan(n, children)
+		return f
+	}
+	return nil
+}
+
+func (b *astBuilder) element(n *ElementNode) *ast.Element {
+	el := &ast.Element{
+		Tag:                 n.tag,
+		ID:                  html.UnescapeString(n.id),
+		AttributesCmd:       n.attributesCmd,
+		SelfClosing:         n.isSelfClosing,
+		NukeInnerWhitespace: n.nukeInnerWhitespace,
+		NukeOuterWhitespace: n.nukeOuterWhitespace,
+		AddWhitespaceBefore: n.addWhitespaceBefore,
+		AddWhitespaceAfter:  n.addWhitespaceAfter,
+		Children:            b.content(n.children),
+	}
+	for _, c := range n.classes {
+		el.Classes = append(el.Classes, c.lit)
+	}
+	if n.objectRef != nil {
+		el.ObjectRef = n.objectRef.lit
+	}
+	_ = n.attributes.Range(func(_ string, a attribute) (bool, error) {
+		el.Attributes = append(el.Attributes, &ast.Attribute{
+			Span:    astSpan(a.origin),
+			Name:    a.name,
+			Value:   a.value,
+			Dynamic: a.isDynamic,
+			Boolean: a.isBoolean,
+		})
+		return true, nil
+	})
+	el.Span = astNodeSpan(n, el.Children)
+	// the element starts at the symbol of its tag, id or class
+	if o := n.Origin(); o.typ != tAttrName && o.line <= len(b.lines) && o.col > 1 && o.col-2 < len(b.lines[o.line-1]) &&
+		strings.IndexByte("%#.", b.lines[o.line-1][o.col-2]) >= 0 {
+		el.From.Column--
+	}
+	return el
+}
@@ -0,0 +1,346 @@
+// Package ast declares the types that represent the syntax trees of Goht
+// files.
+//
+// The trees are built by compiler.ParseAST and are read-only snapshots of the
+// parsed templates; changing a tree does not change how the compiler sees the
+// file. A tree can be written back out as Goht source with Fprint.
+package ast
+
+import (
+	"fmt"
+)
+
+// Pos is a position in a Goht file. Lines and columns start at 1, and columns
+// are counted in bytes.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Pos) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Node is a node of the syntax tree.
+type Node interface {
+	// Pos returns the position of the first character of the node.
+	Pos() Pos
+	// End returns the position just after the last character of the node,
+	// including its children.
+	End() Pos
+}
+
+// Decl is a declaration of a Goht file: Go code or a template.
+type Decl interface {
+	Node
+	declNode()
+}
+
+// Content is a node of the body of a template.
+type Content interface {
+	Node
+	contentNode()
+}
+
+// Span is the range of the source of a node.
+type Span struct {
+	From Pos
+	To   Pos
+}
+
+// Pos returns the start of the span.
+func (s Span) Pos() Pos { return s.From }
+
+// End returns the end of the span.
+func (s Span) End() Pos { return s.To }
+
+// File is a Goht file.
+type File struct {
+	Span
+	// Doc is the Go code before the package clause, such as the
+	// documentation of the package, or nil.
+	Doc *GoCode
+	// Package is the name of the package, or empty when the file has no
+	// package clause.
+	Package string
+	Imports []*Import
+	// Decls are the Go code and the templates of the file, in the order of
+	// the source.
+	Decls []Decl
+}
+
+// Import is an import of a Goht file.
+type Import struct {
+	Span
+	// Path is the import as written in the source, including its quotes and
+	// an optional name, such as `"fmt"` or `str "strings"`.
+	Path string
+}
+
+// GoCode is Go code between the templates of a file.
+type GoCode struct {
+	Span
+	Code string
+}
+
+// Template is a template of a Goht file.
+type Template struct {
+	Span
+	// Keyword is the keyword that starts the template: "@goht", "@haml",
+	// "@slim" or "@ego".
+	Keyword string
+	// Decl is the signature of the template, such as "Page(title string)".
+	Decl string
+	// Name is the name of the template, which has the type of the receiver
+	// for a method, such as "Page" or "User.Details".
+	Name string
+	Body []Content
+}
+
+// Dialect returns the dialect of the template: "haml", "slim" or "ego".
+func (t *Template) Dialect() string {
+	switch t.Keyword {
+	case "@slim":
+		return "slim"
+	case "@ego":
+		return "ego"
+	default:
+		return "haml"
+	}
+}
+
+// Doctype is a doctype, which has the value that follows `!!!` or `doctype`.
+type Doctype struct {
+	Span
+	Value string
+}
+
+// Element is an HTML element.
+type Element struct {
+	Span
+	Tag     string
+	ID      string
+	Classes []string
+	// ObjectRef is the Go expression of an object reference.
+	ObjectRef  string
+	Attributes []*Attribute
+	// AttributesCmd is the Go expression of the @attributes command.
+	AttributesCmd string
+	SelfClosing   bool
+	// NukeInnerWhitespace and NukeOuterWhitespace are the Haml `<` and `>`
+	// markers, and AddWhitespaceBefore and AddWhitespaceAfter are the Slim
+	// `<` and `>` markers.
+	NukeInnerWhitespace bool
+	NukeOuterWhitespace bool
+	AddWhitespaceBefore bool
+	AddWhitespaceAfter  bool
+	Children            []Content
+}
+
+// Attribute is an attribute of an element.
+type Attribute struct {
+	Span
+	Name string
+	// Value is the value of a static attribute, or the Go expression of a
+	// dynamic or boolean attribute. It is empty for an attribute without a
+	// value.
+	Value   string
+	Dynamic bool
+	// Boolean is set for an attribute that is written when its Go condition
+	// is true.
+	Boolean bool
+}
+
+// TextKind is the kind of a text node.
+type TextKind int
+
+const (
+	// PlainText is text that is written as it is.
+	PlainText TextKind = iota
+	// EscapedText is text that is HTML escaped.
+	EscapedText
+	// PreserveText is text whose newlines are preserved.
+	PreserveText
+	// DynamicText is an interpolation; its value is the Go expression, which
+	// may start with a format such as "%d".
+	DynamicText
+	// RawText is the text of an EGO template.
+	RawText
+)
+
+func (k TextKind) String() string {
+	switch k {
+	case PlainText:
+		return "PlainText"
+	case EscapedText:
+		return "EscapedText"
+	case PreserveText:
+		return "PreserveText"
+	case DynamicText:
+		return "DynamicText"
+	case RawText:
+		return "RawText"
+	}
+	return fmt.Sprintf("TextKind(%d)", int(k))
+}
+
+// Text is text or an interpolation.
+type Text struct {
+	Span
+	Kind  TextKind
+	Value string
+}
+
+// Comment is an HTML comment, which has either a value or children.
+type Comment struct {
+	Span
+	Value    string
+	Children []Content
+}
+
+// Script is Go code whose value is written, such as `= name`. The code may
+// start with a format such as "%d".
+type Script struct {
+	Span
+	Code string
+}
+
+// Unescape writes its children without escaping them, such as `!= name`.
+type Unescape struct {
+	Span
+	Children []Content
+}
+
+// SilentScript is Go code that is run, such as `- if ok`, along with the
+// content that is nested in it.
+type SilentScript struct {
+	Span
+	Code     string
+	Children []Content
+}
+
+// RenderCommand renders another template, with its children as the nested
+// content.
+type RenderCommand struct {
+	Span
+	// Call is the Go expression of the template, such as "Layout(title)".
+	Call     string
+	Children []Content
+}
+
+// ChildrenCommand renders the nested content that is passed by @render.
+type ChildrenCommand struct {
+	Span
+}
+
+// SlotCommand renders a slot, or its children when the slot is not given.
+type SlotCommand struct {
+	Span
+	Name string
+	// Value is the Go expression that is passed to a scoped slot.
+	Value string
+	// ValueType is the Go type of the value when the slot names it.
+	ValueType string
+	Children  []Content
+}
+
+// FlushCommand flushes the output of a streamed template.
+type FlushCommand struct {
+	Span
+}
+
+// FragmentCommand marks its children as a fragment that can be rendered on
+// its own.
+type FragmentCommand struct {
+	Span
+	Name     string
+	Children []Content
+}
+
+// ExtendsCommand extends another template.
+type ExtendsCommand struct {
+	Span
+	// Template is the Go expression of the extended template.
+	Template string
+}
+
+// BlockCommand is a block that a template that extends the template can
+// replace.
+type BlockCommand struct {
+	Span
+	Name     string
+	Children []Content
+}
+
+// PushCommand pushes its children onto a stack.
+type PushCommand struct {
+	Span
+	Stack string
+	// Key is the Go expression that de-duplicates the pushes, or empty.
+	Key      string
+	Children []Content
+}
+
+// StackCommand renders the content that is pushed onto a stack.
+type StackCommand struct {
+	Span
+	Name string
+}
+
+// JSONCommand writes a Go value as JSON in a script element.
+type JSONCommand struct {
+	Span
+	// Value is the Go expression of the value.
+	Value string
+}
+
+// FormatCommand sets the output format of a template.
+type FormatCommand struct {
+	Span
+	Format string
+}
+
+// NewLine is the end of a line of a Haml template, which is written to the
+// output as a newline.
+type NewLine struct {
+	Span
+}
+
+// Filter is a filter, such as `:javascript`, with the text of its body.
+type Filter struct {
+	Span
+	Name string
+	Body []*Text
+}
+
+func (*GoCode) declNode()   {}
+func (*Template) declNode() {}
+
+func (*Doctype) contentNode()         {}
+func (*Element) contentNode()         {}
+func (*Text) contentNode()            {}
+func (*Comment) contentNode()         {}
+func (*Script) contentNode()          {}
+func (*Unescape) contentNode()        {}
+func (*SilentScript) contentNode()    {}
+func (*RenderCommand) contentNode()   {}
+func (*ChildrenCommand) contentNode() {}
+func (*SlotCommand) contentNode()     {}
+func (*FlushCommand) contentNode()    {}
+func (*FragmentCommand) contentNode() {}
+func (*ExtendsCommand) contentNode()  {}
+func (*BlockCommand) contentNode()    {}
+func (*PushCommand) contentNode()     {}
+func (*StackCommand) contentNode()    {}
+func (*JSONCommand) contentNode()     {}
+func (*FormatCommand) contentNode()   {}
+func (*NewLine) contentNode()         {}
+func (*Filter) contentNode()          {}
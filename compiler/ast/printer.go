@@ -0,0 +1,638 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fprint writes the file as Goht source to w.
+//
+// Each template is written in the dialect of its keyword. The layout of the
+// source is not kept: the package clause and the imports are written first,
+// followed by the Go code and the templates with a blank line between them,
+// and the templates are indented with tabs. The source that is written parses
+// back to a tree that generates the same Go code as the file did. The tree
+// has no template comments, such as `-#` lines, so they are not written;
+// compiler.Format keeps the layout and the comments by editing the source
+// instead of printing its tree.
+//
+// An error is returned when a node can't be written in the dialect of its
+// template, such as an element in an EGO template.
+func Fprint(w io.Writer, f *File) error {
+	p := &printer{}
+	p.file(f)
+	if p.err != nil {
+		return p.err
+	}
+	_, err := io.WriteString(w, p.buf.String())
+	return err
+}
+
+// voidTags are the elements that are self-closing without the `/` marker.
+var voidTags = []string{
+	"area", "base", "basefont", "br", "col",
+	"embed", "frame", "hr", "img", "input",
+	"isindex", "keygen", "link", "menuitem",
+	"meta", "param", "source", "track", "wbr",
+}
+
+type printer struct {
+	buf strings.Builder
+	// xml is set when the template has the XML format, where the void tags
+	// are not self-closing without the `/` marker
+	xml bool
+	err error
+}
+
+func (p *printer) errorf(n Node, format string, args ...any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("%s: %s", n.Pos(), fmt.Sprintf(format, args...))
+	}
+}
+
+func (p *printer) line(depth int, s string) {
+	p.buf.WriteString(strings.Repeat("\t", depth))
+	p.buf.WriteString(s)
+	p.buf.WriteByte('\n')
+}
+
+func (p *printer) file(f *File) {
+	// a blank line is written between two sections unless the Go code of
+	// either is attached to the other, such as the doc comment of a template
+	type section struct {
+		text                    string
+		blankBefore, blankAfter bool
+	}
+	var sections []section
+	if f.Doc != nil {
+		if doc := strings.TrimSpace(f.Doc.Code); doc != "" {
+			trail := f.Doc.Code[strings.Index(f.Doc.Code, doc)+len(doc):]
+			sections = append(sections, section{doc + "\n", true, !isDocComment(doc, trail)})
+		}
+	}
+	if f.Package != "" {
+		sections = append(sections, section{"package " + f.Package + "\n", true, true})
+	}
+	switch len(f.Imports) {
+	case 0:
+	case 1:
+		sections = append(sections, section{"import " + f.Imports[0].Path + "\n", true, true})
+	default:
+		var b strings.Builder
+		b.WriteString("import (\n")
+		for _, i := range f.Imports {
+			b.WriteString("\t" + i.Path + "\n")
+		}
+		b.WriteString(")\n")
+		sections = append(sections, section{b.String(), true, true})
+	}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *GoCode:
+			code := strings.TrimSpace(d.Code)
+			if code == "" {
+				continue
+			}
+			lead := d.Code[:strings.Index(d.Code, code)]
+			trail := d.Code[len(lead)+len(code):]
+			sections = append(sections, section{code + "\n", strings.Count(lead, "\n") != 1, !isDocComment(code, trail)})
+		case *Template:
+			sections = append(sections, section{p.template(d), true, true})
+		}
+	}
+
+	for i, s := range sections {
+		if i > 0 && sections[i-1].blankAfter && s.blankBefore {
+			p.buf.WriteByte('\n')
+		}
+		p.buf.WriteString(s.text)
+	}
+}
+
+// isDocComment reports whether the Go code ends with a comment that is
+// followed by the next line, which makes it the doc comment of what follows.
+func isDocComment(code, trail string) bool {
+	last := code[strings.LastIndex(code, "\n")+1:]
+	return strings.Count(trail, "\n") == 1 && (strings.HasPrefix(strings.TrimSpace(last), "//") || strings.HasSuffix(last, "*/"))
+}
+
+func (p *printer) template(t *Template) string {
+	outer := p.buf
+	p.buf = strings.Builder{}
+	p.xml = false
+	for _, n := range t.Body {
+		if n, ok := n.(*FormatCommand); ok && strings.EqualFold(n.Format, "xml") {
+			p.xml = true
+		}
+	}
+
+	p.buf.WriteString(t.Keyword + " " + t.Decl + " {\n")
+	switch t.Dialect() {
+	case "slim":
+		p.slimBody(t.Body, 1)
+	case "ego":
+		p.egoTemplate(t.Body)
+	default:
+		p.hamlBody(t.Body, 1)
+	}
+	p.buf.WriteString("}\n")
+
+	s := p.buf.String()
+	p.buf = outer
+	return s
+}
+
+// textRun returns the text nodes that start the nodes and are written on a
+// single line. Plain text that follows plain text starts a new line, as it
+// can only have come from another line.
+func textRun(nodes []Content) []*Text {
+	var run []*Text
+	for _, n := range nodes {
+		t, ok := n.(*Text)
+		if !ok || len(run) > 0 && t.Kind != DynamicText && run[len(run)-1].Kind != DynamicText {
+			break
+		}
+		run = append(run, t)
+	}
+	return run
+}
+
+// code returns Go code of a line at the depth. Code that is continued over
+// several lines has its continuation lines indented one level deeper, and the
+// lines that don't end with a comma end with a backslash.
+func code(code string, depth int) string {
+	code = strings.TrimSpace(strings.ReplaceAll(code, "\r", ""))
+	lines := strings.Split(code, "\n")
+	for i := range lines[:len(lines)-1] {
+		if !strings.HasSuffix(lines[i], ",") {
+			lines[i] += "\\"
+		}
+	}
+	return strings.Join(lines, "\n"+strings.Repeat("\t", depth+1))
+}
+
+// stackName returns the name of a stack as it is written in a command, which
+// is quoted when it is not a single word.
+func stackName(name, key string) string {
+	if name == "" || strings.ContainsAny(name, " \t\"`") {
+		name = strconv.Quote(name)
+	}
+	if key != "" {
+		name += " " + key
+	}
+	return name
+}
+
+// slotName returns the name of a slot with the value that is passed to it.
+func slotName(n *SlotCommand) string {
+	if n.Value == "" {
+		return n.Name
+	}
+	if n.ValueType != "" {
+		return n.Name + "[" + n.ValueType + "](" + n.Value + ")"
+	}
+	return n.Name + "(" + n.Value + ")"
+}
+
+// command returns the command and its argument in the syntax of Haml and
+// Slim, or false when the node is not a command.
+func command(n Content, depth int) (string, bool) {
+	switch n := n.(type) {
+	case *RenderCommand:
+		return "@render " + code(n.Call, depth), true
+	case *ChildrenCommand:
+		return "@children", true
+	case *SlotCommand:
+		return "@slot " + slotName(n), true
+	case *FlushCommand:
+		return "@flush", true
+	case *FragmentCommand:
+		return "@fragment " + n.Name, true
+	case *ExtendsCommand:
+		return "@extends " + n.Template, true
+	case *BlockCommand:
+		return "@block " + n.Name, true
+	case *PushCommand:
+		return "@push " + stackName(n.Stack, n.Key), true
+	case *StackCommand:
+		return "@stack " + stackName(n.Name, ""), true
+	case *JSONCommand:
+		return "@json(" + n.Value + ")", true
+	case *FormatCommand:
+		return "@format " + n.Format, true
+	}
+	return "", false
+}
+
+// firstScript returns the first node when it is a script, which is the code
+// of an unescape.
+func firstScript(nodes []Content) (*Script, bool) {
+	if len(nodes) == 0 {
+		return nil, false
+	}
+	s, ok := nodes[0].(*Script)
+	return s, ok
+}
+
+// children returns the nested content of a node.
+func children(n Content) []Content {
+	switch n := n.(type) {
+	case *Element:
+		return n.Children
+	case *Comment:
+		return n.Children
+	case *Unescape:
+		return n.Children
+	case *SilentScript:
+		return n.Children
+	case *RenderCommand:
+		return n.Children
+	case *SlotCommand:
+		return n.Children
+	case *FragmentCommand:
+		return n.Children
+	case *BlockCommand:
+		return n.Children
+	case *PushCommand:
+		return n.Children
+	}
+	return nil
+}
+
+// head returns the tag, the id and the classes of an element, leaving out a
+// div tag when the element has an id or a class.
+func head(n *Element, tagPrefix string) string {
+	var b strings.Builder
+	if n.Tag != "div" || n.ID == "" && len(n.Classes) == 0 {
+		b.WriteString(tagPrefix + n.Tag)
+	}
+	if n.ID != "" {
+		b.WriteString("#" + n.ID)
+	}
+	for _, c := range n.Classes {
+		b.WriteString("." + c)
+	}
+	return b.String()
+}
+
+// attributes returns the attributes of an element as a Ruby style attribute
+// list, which both Haml and Slim read.
+func attributes(n *Element) string {
+	var attrs []string
+	for _, a := range n.Attributes {
+		name := a.Name
+		if name == "" || name[0] == '@' || strings.ContainsAny(name, "?:,{}\"` \t\n\r") {
+			name = strconv.Quote(name)
+		}
+		switch {
+		case a.Boolean:
+			attrs = append(attrs, name+"? #{"+a.Value+"}")
+		case a.Dynamic:
+			attrs = append(attrs, name+": #{"+a.Value+"}")
+		case a.Value != "":
+			attrs = append(attrs, name+": "+strconv.Quote(a.Value))
+		default:
+			attrs = append(attrs, name)
+		}
+	}
+	if n.AttributesCmd != "" {
+		attrs = append(attrs, "@attributes: #{"+n.AttributesCmd+"}")
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(attrs, ", ") + "}"
+}
+
+// selfClosing returns the `/` marker of a self-closing element when the
+// element is not self-closing without it.
+func (p *printer) selfClosing(n *Element) string {
+	if n.SelfClosing && (p.xml || !slices.Contains(voidTags, n.Tag)) {
+		return "/"
+	}
+	return ""
+}
+
+// filterLines returns the lines of the body of a filter, with the
+// interpolations written as `#{}`.
+func filterLines(n *Filter) []string {
+	var b strings.Builder
+	for _, t := range n.Body {
+		if t.Kind == DynamicText {
+			b.WriteString("#{" + t.Value + "}")
+		} else {
+			b.WriteString(t.Value)
+		}
+	}
+	lines := strings.Split(strings.ReplaceAll(b.String(), "\r", ""), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func (p *printer) filter(n *Filter, depth int) {
+	p.line(depth, ":"+n.Name)
+	for _, l := range filterLines(n) {
+		if l == "" {
+			p.buf.WriteByte('\n')
+			continue
+		}
+		p.line(depth+1, l)
+	}
+}
+
+func (p *printer) hamlBody(nodes []Content, depth int) {
+	for len(nodes) > 0 {
+		if run := textRun(nodes); len(run) > 0 {
+			p.line(depth, hamlLineStart(hamlText(run)))
+			nodes = nodes[len(run):]
+			continue
+		}
+		p.hamlNode(nodes[0], depth)
+		nodes = nodes[1:]
+	}
+}
+
+// hamlText returns a line of Haml text, escaping the interpolations that are
+// part of the plain text.
+func hamlText(run []*Text) string {
+	var b strings.Builder
+	for i, t := range run {
+		if t.Kind == DynamicText {
+			b.WriteString("#{" + t.Value + "}")
+			continue
+		}
+		s := strings.ReplaceAll(t.Value, `\#`, `\\#`)
+		s = strings.ReplaceAll(s, "#{", `\#{`)
+		// a backslash before an interpolation would escape it
+		if i+1 < len(run) && strings.HasSuffix(s, `\`) {
+			s += `\`
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// hamlLineStart escapes the first character of a line of text when it would
+// start something other than text.
+func hamlLineStart(text string) string {
+	if text != "" && strings.ContainsRune("%#.\\!-=/:{&~[", rune(text[0])) {
+		return "\\" + text
+	}
+	return text
+}
+
+func (p *printer) hamlNode(n Content, depth int) {
+	if cmd, ok := command(n, depth); ok {
+		p.line(depth, "= "+cmd)
+		p.hamlBody(children(n), depth+1)
+		return
+	}
+
+	switch n := n.(type) {
+	case *NewLine:
+	case *Doctype:
+		p.line(depth, strings.TrimSpace("!!! "+n.Value))
+	case *Element:
+		line := head(n, "%")
+		if n.ObjectRef != "" {
+			line += "[" + n.ObjectRef + "]"
+		}
+		line += attributes(n)
+		if n.NukeInnerWhitespace {
+			line += "<"
+		}
+		if n.NukeOuterWhitespace {
+			line += ">"
+		}
+		line += p.selfClosing(n)
+		nested := n.Children
+		if len(nested) > 0 {
+			inline, rest := p.hamlInline(depth, nested)
+			line += inline
+			nested = rest
+		}
+		p.line(depth, line)
+		p.hamlBody(nested, depth+1)
+	case *Comment:
+		p.line(depth, strings.TrimSpace("/ "+n.Value))
+		p.hamlBody(n.Children, depth+1)
+	case *Text:
+		p.line(depth, hamlLineStart(hamlText([]*Text{n})))
+	case *Script:
+		p.line(depth, "= "+code(n.Code, depth))
+	case *Unescape:
+		inline, rest := p.hamlInline(depth, n.Children)
+		p.line(depth, "!"+inline)
+		p.hamlBody(rest, depth)
+	case *SilentScript:
+		p.line(depth, "- "+code(n.Code, depth))
+		p.hamlBody(n.Children, depth+1)
+	case *Filter:
+		p.filter(n, depth)
+	default:
+		p.errorf(n, "%T can't be written in a Haml template", n)
+	}
+}
+
+// hamlInline returns the content that is written on the line of an element or
+// an unescape, along with the content that is nested in it.
+func (p *printer) hamlInline(depth int, nodes []Content) (string, []Content) {
+	if len(nodes) == 0 {
+		return "", nodes
+	}
+	if run := textRun(nodes); len(run) > 0 {
+		return " " + hamlText(run), nodes[len(run):]
+	}
+	if cmd, ok := command(nodes[0], depth); ok && len(children(nodes[0])) == 0 {
+		return "= " + cmd, nodes[1:]
+	}
+	switch n := nodes[0].(type) {
+	case *Script:
+		return "= " + code(n.Code, depth), nodes[1:]
+	case *Unescape:
+		if inline, rest := p.hamlInline(depth, n.Children); inline != "" && len(rest) == 0 {
+			return "!" + inline, nodes[1:]
+		}
+	}
+	return "", nodes
+}
+
+func (p *printer) slimBody(nodes []Content, depth int) {
+	for len(nodes) > 0 {
+		if run := textRun(nodes); len(run) > 0 {
+			p.line(depth, "| "+slimText(run))
+			nodes = nodes[len(run):]
+			continue
+		}
+		p.slimNode(nodes[0], depth)
+		nodes = nodes[1:]
+	}
+}
+
+func slimText(run []*Text) string {
+	var b strings.Builder
+	for _, t := range run {
+		if t.Kind == DynamicText {
+			b.WriteString("#{" + t.Value + "}")
+		} else {
+			b.WriteString(t.Value)
+		}
+	}
+	return b.String()
+}
+
+// slimInline returns the content that is written on the line of an element,
+// which is a script or a line of text that can't be mistaken for an
+// attribute.
+func slimInline(nodes []Content, depth int) (string, bool) {
+	if run := textRun(nodes); len(run) > 0 && len(run) == len(nodes) {
+		text := slimText(run)
+		if strings.TrimLeft(text, " \t") != text || strings.Contains(text, "=") {
+			return "", false
+		}
+		return " " + text, true
+	}
+	if len(nodes) != 1 {
+		return "", false
+	}
+	switch n := nodes[0].(type) {
+	case *Script:
+		return "= " + code(n.Code, depth), true
+	case *Unescape:
+		if s, ok := firstScript(n.Children); ok && len(n.Children) == 1 {
+			return "== " + code(s.Code, depth), true
+		}
+	}
+	return "", false
+}
+
+func (p *printer) slimNode(n Content, depth int) {
+	if cmd, ok := command(n, depth); ok {
+		p.line(depth, "= "+cmd)
+		p.slimBody(children(n), depth+1)
+		return
+	}
+
+	switch n := n.(type) {
+	case *NewLine:
+	case *Doctype:
+		p.line(depth, strings.TrimSpace("doctype "+n.Value))
+	case *Element:
+		if n.ObjectRef != "" {
+			p.errorf(n, "object references can't be written in a Slim template")
+		}
+		line := head(n, "") + attributes(n)
+		if n.AddWhitespaceBefore {
+			line += "<"
+		}
+		if n.AddWhitespaceAfter {
+			line += ">"
+		}
+		line += p.selfClosing(n)
+		if inline, ok := slimInline(n.Children, depth); ok {
+			p.line(depth, line+inline)
+			return
+		}
+		p.line(depth, line)
+		p.slimBody(n.Children, depth+1)
+	case *Comment:
+		p.line(depth, strings.TrimSpace("/! "+n.Value))
+		p.slimBody(n.Children, depth+1)
+	case *Script:
+		p.line(depth, "= "+code(n.Code, depth))
+	case *Unescape:
+		rest := n.Children
+		if s, ok := firstScript(rest); ok {
+			p.line(depth, "== "+code(s.Code, depth))
+			rest = rest[1:]
+		}
+		p.slimBody(rest, depth)
+	case *SilentScript:
+		p.line(depth, "- "+code(n.Code, depth))
+		p.slimBody(n.Children, depth+1)
+	case *Filter:
+		p.filter(n, depth)
+	default:
+		p.errorf(n, "%T can't be written in a Slim template", n)
+	}
+}
+
+// egoTemplate writes the body of an EGO template, with each line indented by
+// a tab.
+func (p *printer) egoTemplate(nodes []Content) {
+	outer := p.buf
+	p.buf = strings.Builder{}
+	p.egoBody(nodes)
+	body := strings.TrimRight(p.buf.String(), " \t\r\n")
+	p.buf = outer
+
+	if body == "" {
+		return
+	}
+	for _, l := range strings.Split(body, "\n") {
+		p.line(1, l)
+	}
+}
+
+func (p *printer) egoBody(nodes []Content) {
+	for _, n := range nodes {
+		p.egoNode(n)
+	}
+}
+
+func (p *printer) egoNode(n Content) {
+	switch n := n.(type) {
+	case *Text:
+		p.buf.WriteString(strings.ReplaceAll(n.Value, "<%", "<%%"))
+	case *Script:
+		p.buf.WriteString("<%= " + n.Code + " %>")
+	case *Unescape:
+		rest := n.Children
+		if s, ok := firstScript(rest); ok {
+			p.buf.WriteString("<%! " + s.Code + " %>")
+			rest = rest[1:]
+		}
+		p.egoBody(rest)
+	case *SilentScript:
+		p.buf.WriteString("<% " + n.Code + " %>")
+		p.egoBody(n.Children)
+	case *RenderCommand:
+		p.buf.WriteString("<%@render " + n.Call + " %>")
+		p.egoBody(n.Children)
+	case *ChildrenCommand:
+		p.buf.WriteString("<%@children %>")
+	case *SlotCommand:
+		p.egoBlock("slot "+slotName(n), n.Children)
+	case *FlushCommand:
+		p.buf.WriteString("<%@flush %>")
+	case *FragmentCommand:
+		p.egoBlock("fragment "+n.Name, n.Children)
+	case *ExtendsCommand:
+		p.buf.WriteString("<%@extends " + n.Template + " %>")
+	case *BlockCommand:
+		p.egoBlock("block "+n.Name, n.Children)
+	case *PushCommand:
+		p.egoBlock("push "+stackName(n.Stack, n.Key), n.Children)
+	case *StackCommand:
+		p.buf.WriteString("<%@stack " + stackName(n.Name, "") + " %>")
+	case *JSONCommand:
+		p.buf.WriteString("<%@json " + n.Value + " %>")
+	default:
+		p.errorf(n, "%T can't be written in an EGO template", n)
+	}
+}
+
+// egoBlock writes a command that has its children in a block; the block is
+// closed by the script that follows it.
+func (p *printer) egoBlock(cmd string, children []Content) {
+	if len(children) > 0 {
+		cmd += " {"
+	}
+	p.buf.WriteString("<%@" + cmd + " %>")
+	p.egoBody(children)
+}
@@ -0,0 +1,134 @@
+package ast
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_Fprint(t *testing.T) {
+	tests := map[string]struct {
+		file    *File
+		want    string
+		wantErr bool
+	}{
+		"haml": {
+			file: testFile(),
+			want: "package test\n\nimport \"fmt\"\n\nvar x = 1\n\n@haml Test() {\n\t%p{id: \"a\"} Hi #{name}\n\t- if x > 0\n\t\t= fmt.Sprint(x)\n\t:css\n\t\tp { color: red; }\n}\n",
+		},
+		"imports": {
+			file: &File{
+				Package: "test",
+				Imports: []*Import{{Path: `"fmt"`}, {Path: `str "strings"`}},
+			},
+			want: "package test\n\nimport (\n\t\"fmt\"\n\tstr \"strings\"\n)\n",
+		},
+		"doc comments": {
+			file: &File{
+				Doc:     &GoCode{Code: "// Package test is a test.\n"},
+				Package: "test",
+				Decls: []Decl{
+					&GoCode{Code: "\n\n// Test is a test.\n"},
+					&Template{Keyword: "@goht", Decl: "Test()"},
+				},
+			},
+			want: "// Package test is a test.\npackage test\n\n// Test is a test.\n@goht Test() {\n}\n",
+		},
+		"haml elements": {
+			file: template("@haml",
+				&Doctype{},
+				&Element{Tag: "div", ID: "main", Classes: []string{"a", "b"}, ObjectRef: "user", NukeOuterWhitespace: true, Children: []Content{
+					&NewLine{},
+					&Element{Tag: "img", SelfClosing: true, Attributes: []*Attribute{
+						{Name: "src", Value: "src", Dynamic: true},
+						{Name: "@click", Value: "go()"},
+						{Name: "hidden", Value: "x", Dynamic: true, Boolean: true},
+						{Name: "required"},
+					}, AttributesCmd: "attrs"},
+					&Element{Tag: "x-item", SelfClosing: true},
+				}},
+				&Element{Tag: "p", Children: []Content{&Unescape{Children: []Content{&Script{Code: "html"}}}}},
+				&Element{Tag: "p", Children: []Content{&ChildrenCommand{}}},
+			),
+			want: "@haml Test() {\n\t!!!\n\t#main.a.b[user]>\n\t\t%img{src: #{src}, \"@click\": \"go()\", hidden? #{x}, required, @attributes: #{attrs}}\n\t\t%x-item/\n\t%p!= html\n\t%p= @children\n}\n",
+		},
+		"haml text": {
+			file: template("@haml",
+				&Text{Kind: DynamicText, Value: "greeting"},
+				&Text{Kind: PlainText, Value: ", #{literal} \\# and \\"},
+				&Text{Kind: DynamicText, Value: "name"},
+				&NewLine{},
+				&Text{Kind: PlainText, Value: "- not code"},
+				&NewLine{},
+				&Comment{Value: "note"},
+				&Unescape{Children: []Content{&Text{Kind: PlainText, Value: "<em>hi</em>"}}},
+			),
+			want: "@haml Test() {\n\t\\#{greeting}, \\#{literal} \\\\# and \\\\#{name}\n\t\\- not code\n\t/ note\n\t! <em>hi</em>\n}\n",
+		},
+		"haml code": {
+			file: template("@haml",
+				&SilentScript{Code: "x := longType{\n\ttitle: t,\n}", Children: nil},
+				&Script{Code: "fmt.Sprintf(\"%s\",\n\tx.title,\n)"},
+				&RenderCommand{Call: "Card(title)", Children: []Content{&Element{Tag: "p"}}},
+				&SlotCommand{Name: "row", Value: "item"},
+				&SlotCommand{Name: "cell", Value: "item", ValueType: "Item"},
+				&PushCommand{Stack: "scripts", Key: "\"chart\""},
+				&StackCommand{Name: "head scripts"},
+				&JSONCommand{Value: "settings"},
+			),
+			want: "@haml Test() {\n\t- x := longType{\\\n\t\t\ttitle: t,\n\t\t}\n\t= fmt.Sprintf(\"%s\",\n\t\t\tx.title,\n\t\t)\n\t= @render Card(title)\n\t\t%p\n\t= @slot row(item)\n\t= @slot cell[Item](item)\n\t= @push scripts \"chart\"\n\t= @stack \"head scripts\"\n\t= @json(settings)\n}\n",
+		},
+		"slim": {
+			file: template("@slim",
+				&Doctype{Value: "html"},
+				&Element{Tag: "p", ID: "a", AddWhitespaceAfter: true, Children: []Content{&Text{Kind: PlainText, Value: "Hi "}, &Text{Kind: DynamicText, Value: "name"}}},
+				&Element{Tag: "p", Children: []Content{&Text{Kind: PlainText, Value: "a=b"}}},
+				&Element{Tag: "p", Children: []Content{&Unescape{Children: []Content{&Script{Code: "html"}}}}},
+				&Comment{Value: "note"},
+				&Filter{Name: "javascript", Body: []*Text{{Kind: PlainText, Value: "var x = "}, {Kind: DynamicText, Value: "x"}, {Kind: PlainText, Value: ";\n\nrun();\n"}}},
+			),
+			want: "@slim Test() {\n\tdoctype html\n\tp#a> Hi #{name}\n\tp\n\t\t| a=b\n\tp== html\n\t/! note\n\t:javascript\n\t\tvar x = #{x};\n\n\t\trun();\n}\n",
+		},
+		"ego": {
+			file: template("@ego",
+				&Text{Kind: RawText, Value: "<ul>\n\t"},
+				&SilentScript{Code: "for _, item := range items {", Children: []Content{
+					&Text{Kind: RawText, Value: "<li>"},
+					&Script{Code: "item"},
+					&Text{Kind: RawText, Value: "</li> <%\n"},
+				}},
+				&SilentScript{Code: "}"},
+				&Text{Kind: RawText, Value: "</ul>\n"},
+				&BlockCommand{Name: "footer", Children: []Content{&Unescape{Children: []Content{&Script{Code: "html"}}}}},
+				&SilentScript{Code: "}"},
+			),
+			want: "@ego Test() {\n\t<ul>\n\t\t<% for _, item := range items { %><li><%= item %></li> <%%\n\t<% } %></ul>\n\t<%@block footer { %><%! html %><% } %>\n}\n",
+		},
+		"element in ego": {
+			file:    template("@ego", &Element{Tag: "p"}),
+			wantErr: true,
+		},
+		"object reference in slim": {
+			file:    template("@slim", &Element{Tag: "p", ObjectRef: "user"}),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Fprint(&buf, tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Fprint() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func template(keyword string, body ...Content) *File {
+	return &File{Decls: []Decl{&Template{Keyword: keyword, Decl: "Test()", Body: body}}}
+}
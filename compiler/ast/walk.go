@@ -0,0 +1,80 @@
+package ast
+
+// A Visitor's Visit method is called for each node of a tree by Walk. When
+// the visitor that it returns is not nil, Walk visits the children of the
+// node with that visitor, followed by a call of Visit(nil).
+type Visitor interface {
+	Visit(n Node) (w Visitor)
+}
+
+// Walk traverses a tree in depth-first order, starting with a call of
+// v.Visit(n).
+func Walk(v Visitor, n Node) {
+	if v = v.Visit(n); v == nil {
+		return
+	}
+
+	switch n := n.(type) {
+	case *File:
+		if n.Doc != nil {
+			Walk(v, n.Doc)
+		}
+		for _, i := range n.Imports {
+			Walk(v, i)
+		}
+		for _, d := range n.Decls {
+			Walk(v, d)
+		}
+	case *Template:
+		walkContent(v, n.Body)
+	case *Element:
+		for _, a := range n.Attributes {
+			Walk(v, a)
+		}
+		walkContent(v, n.Children)
+	case *Comment:
+		walkContent(v, n.Children)
+	case *Unescape:
+		walkContent(v, n.Children)
+	case *SilentScript:
+		walkContent(v, n.Children)
+	case *RenderCommand:
+		walkContent(v, n.Children)
+	case *SlotCommand:
+		walkContent(v, n.Children)
+	case *FragmentCommand:
+		walkContent(v, n.Children)
+	case *BlockCommand:
+		walkContent(v, n.Children)
+	case *PushCommand:
+		walkContent(v, n.Children)
+	case *Filter:
+		for _, t := range n.Body {
+			Walk(v, t)
+		}
+	}
+
+	v.Visit(nil)
+}
+
+func walkContent(v Visitor, nodes []Content) {
+	for _, n := range nodes {
+		Walk(v, n)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(n Node) Visitor {
+	if f(n) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses a tree in depth-first order, starting with a call of
+// f(n). When f returns true, Inspect visits the children of the node,
+// followed by a call of f(nil).
+func Inspect(n Node, f func(Node) bool) {
+	Walk(inspector(f), n)
+}
@@ -0,0 +1,97 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func testFile() *File {
+	return &File{
+		Package: "test",
+		Imports: []*Import{{Path: `"fmt"`}},
+		Decls: []Decl{
+			&GoCode{Code: "var x = 1\n"},
+			&Template{
+				Keyword: "@haml",
+				Decl:    "Test()",
+				Name:    "Test",
+				Body: []Content{
+					&Element{
+						Tag:        "p",
+						Attributes: []*Attribute{{Name: "id", Value: "a"}},
+						Children:   []Content{&Text{Kind: PlainText, Value: "Hi "}, &Text{Kind: DynamicText, Value: "name"}},
+					},
+					&SilentScript{
+						Code:     "if x > 0",
+						Children: []Content{&Script{Code: "fmt.Sprint(x)"}},
+					},
+					&Filter{Name: "css", Body: []*Text{{Kind: PlainText, Value: "p { color: red; }\n"}}},
+				},
+			},
+		},
+	}
+}
+
+func describe(n Node) string {
+	switch n := n.(type) {
+	case nil:
+		return "end"
+	case *Element:
+		return "Element " + n.Tag
+	case *Attribute:
+		return "Attribute " + n.Name
+	case *Text:
+		return "Text " + n.Value
+	default:
+		return fmt.Sprintf("%T", n)[5:]
+	}
+}
+
+func Test_Walk(t *testing.T) {
+	var got []string
+	Inspect(testFile(), func(n Node) bool {
+		got = append(got, describe(n))
+		return true
+	})
+
+	want := []string{
+		"File",
+		"Import", "end",
+		"GoCode", "end",
+		"Template",
+		"Element p",
+		"Attribute id", "end",
+		"Text Hi ", "end",
+		"Text name", "end",
+		"end",
+		"SilentScript",
+		"Script", "end",
+		"end",
+		"Filter",
+		"Text p { color: red; }\n", "end",
+		"end",
+		"end",
+		"end",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Inspect() visited\n%q\nwant\n%q", got, want)
+	}
+}
+
+func Test_Walk_SkipChildren(t *testing.T) {
+	var got []string
+	Inspect(testFile(), func(n Node) bool {
+		if n != nil {
+			got = append(got, describe(n))
+		}
+		_, isElement := n.(*Element)
+		_, isScript := n.(*SilentScript)
+		return !isElement && !isScript
+	})
+
+	want := []string{"File", "Import", "GoCode", "Template", "Element p", "SilentScript", "Filter", "Text p { color: red; }\n"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Inspect() visited\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package compiler
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stackus/goht/compiler/ast"
+)
+
+func Test_ParseAST(t *testing.T) {
+	src := "// Package test is a test.\npackage test\n\nimport \"fmt\"\n\nvar x = 1\n\n@haml Test(name string) {\n\t%p#a.b{href: #{name}, disabled? #{x > 1}} Hi #{name}\n\t- if x > 0\n\t\t= fmt.Sprint(x)\n\t:css\n\t\tp { color: red; }\n}\n\n@ego (u *User) Ego() {\n\t<p><%= u.Name %></p>\n}\n"
+	f, err := ParseAST([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseAST() error = %v", err)
+	}
+
+	if f.Package != "test" {
+		t.Errorf("Package = %q, want %q", f.Package, "test")
+	}
+	if f.Doc == nil || f.Doc.Code != "// Package test is a test.\n" {
+		t.Errorf("Doc = %v, want the package documentation", f.Doc)
+	}
+	if len(f.Imports) != 1 || f.Imports[0].Path != `"fmt"` {
+		t.Errorf("Imports = %v, want [\"fmt\"]", f.Imports)
+	}
+
+	var templates []*ast.Template
+	for _, d := range f.Decls {
+		if tpl, ok := d.(*ast.Template); ok {
+			templates = append(templates, tpl)
+		}
+	}
+	if len(templates) != 2 {
+		t.Fatalf("got %d templates, want 2", len(templates))
+	}
+
+	haml := templates[0]
+	if haml.Keyword != "@haml" || haml.Decl != "Test(name string)" || haml.Name != "Test" || haml.Dialect() != "haml" {
+		t.Errorf("template = %q %q %q, want @haml Test(name string) Test", haml.Keyword, haml.Decl, haml.Name)
+	}
+	if want := (ast.Pos{Line: 8, Column: 1}); haml.Pos() != want {
+		t.Errorf("template Pos() = %v, want %v", haml.Pos(), want)
+	}
+	if want := (ast.Pos{Line: 14, Column: 2}); haml.End() != want {
+		t.Errorf("template End() = %v, want %v", haml.End(), want)
+	}
+
+	el, ok := haml.Body[0].(*ast.Element)
+	if !ok {
+		t.Fatalf("Body[0] = %T, want *ast.Element", haml.Body[0])
+	}
+	if el.Tag != "p" || el.ID != "a" || !reflect.DeepEqual(el.Classes, []string{"b"}) {
+		t.Errorf("element = %q #%q %v, want p #a [b]", el.Tag, el.ID, el.Classes)
+	}
+	if want := (ast.Pos{Line: 9, Column: 2}); el.Pos() != want {
+		t.Errorf("element Pos() = %v, want %v", el.Pos(), want)
+	}
+	wantAttrs := []ast.Attribute{
+		{Name: "href", Value: "name", Dynamic: true},
+		{Name: "disabled", Value: "x > 1", Dynamic: true, Boolean: true},
+	}
+	if len(el.Attributes) != len(wantAttrs) {
+		t.Fatalf("got %d attributes, want %d", len(el.Attributes), len(wantAttrs))
+	}
+	for i, a := range el.Attributes {
+		a.Span = ast.Span{}
+		if !reflect.DeepEqual(*a, wantAttrs[i]) {
+			t.Errorf("Attributes[%d] = %+v, want %+v", i, *a, wantAttrs[i])
+		}
+	}
+	var texts []string
+	for _, c := range el.Children {
+		if text, ok := c.(*ast.Text); ok {
+			texts = append(texts, text.Kind.String()+":"+text.Value)
+		}
+	}
+	if want := []string{"PlainText:Hi ", "DynamicText:name"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("texts = %v, want %v", texts, want)
+	}
+
+	var script *ast.SilentScript
+	var filter *ast.Filter
+	for _, c := range haml.Body {
+		switch c := c.(type) {
+		case *ast.SilentScript:
+			script = c
+		case *ast.Filter:
+			filter = c
+		}
+	}
+	if script == nil || script.Code != "if x > 0" || len(script.Children) == 0 {
+		t.Errorf("script = %+v, want if x > 0 with children", script)
+	} else if s, ok := script.Children[0].(*ast.Script); !ok || s.Code != "fmt.Sprint(x)" {
+		t.Errorf("script.Children[0] = %+v, want fmt.Sprint(x)", script.Children[0])
+	}
+	if filter == nil || filter.Name != "css" || len(filter.Body) != 1 || filter.Body[0].Value != "p { color: red; }\n" {
+		t.Errorf("filter = %+v, want css filter", filter)
+	}
+
+	ego := templates[1]
+	if ego.Dialect() != "ego" || ego.Name != "User.Ego" {
+		t.Errorf("template = %q %q, want ego User.Ego", ego.Dialect(), ego.Name)
+	}
+	if len(ego.Body) != 3 {
+		t.Fatalf("got %d nodes, want 3", len(ego.Body))
+	}
+	if text, ok := ego.Body[0].(*ast.Text); !ok || text.Kind != ast.RawText || text.Value != "<p>" {
+		t.Errorf("Body[0] = %+v, want raw text <p>", ego.Body[0])
+	}
+}
+
+func Test_ParseAST_Errors(t *testing.T) {
+	_, err := ParseAST([]byte("@haml Test() {\n\t%p{\n}\n"))
+	if err == nil {
+		t.Fatal("ParseAST() error = nil, want an error")
+	}
+	if _, ok := err.(ErrorList); !ok {
+		t.Errorf("ParseAST() error = %T, want ErrorList", err)
+	}
+}
+
+// Test_ParseAST_RoundTrip prints the trees of the examples and the test data,
+// and checks that the printed source generates the same Go code and prints
+// the same again.
+func Test_ParseAST_RoundTrip(t *testing.T) {
+	examples, err := filepath.Glob("../examples/*/*.goht")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testdata, err := filepath.Glob("testdata/*.goht")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range append(examples, testdata...) {
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := generateFormatted(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			printed := printAST(t, src)
+			got, err := generateFormatted(printed)
+			if err != nil {
+				t.Fatalf("generate printed source: %v\n%s", err, printed)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("printed source generates different code:\n%s", printed)
+			}
+			if again := printAST(t, printed); !bytes.Equal(again, printed) {
+				t.Errorf("printing is not stable:\n%s\nprinted again:\n%s", printed, again)
+			}
+		})
+	}
+}
+
+func printAST(t *testing.T, src []byte) []byte {
+	t.Helper()
+	f, err := ParseAST(src)
+	if err != nil {
+		t.Fatalf("ParseAST() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := ast.Fprint(&buf, f); err != nil {
+		t.Fatalf("Fprint() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func generateFormatted(src []byte) ([]byte, error) {
+	tpl, err := parseBytes(src)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Generate(&buf); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,1041 @@
+package compiler
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dialectKeywords are the keywords that start the templates of each dialect.
+var dialectKeywords = map[string]string{
+	"haml": "@haml",
+	"slim": "@slim",
+	"ego":  "@ego",
+}
+
+// Convert returns the template file with its templates rewritten in the
+// dialect, which is one of "haml", "slim" or "ego".
+//
+// The Go code of the file is kept as it is, and each template is written so
+// that it renders the same bytes. The whitespace between the tags and the
+// text is kept with the whitespace markers of Haml and Slim, and with lines
+// that only add whitespace where the markers can't be used; whitespace that
+// the dialect can't write, such as a newline in Slim, is reported as an
+// error. The Go code, the commands and the comments of the templates are
+// kept; code that was written across several lines is joined into a single
+// line. A template is converted to EGO by writing the HTML that
+// it renders, with the Go code, the commands and the comments in EGO tags.
+//
+// A file that has errors is not converted, and the errors are returned. A
+// template with content that can't be written in the dialect is left as it
+// is; the file is returned with the other templates converted, along with the
+// errors of the templates that were left.
+func Convert(src []byte, dialect string) ([]byte, error) {
+	keyword, ok := dialectKeywords[dialect]
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect: %q, expected haml, slim or ego", dialect)
+	}
+
+	t, err := parseBytes(src)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &converter{
+		formatter: newFormatter(src),
+		keyword:   keyword,
+	}
+	c.nodes = templateNodes(t)
+
+	return c.convert()
+}
+
+// templateNodes returns the parsed templates of the file.
+func templateNodes(t *Template) []*TemplateNode {
+	var nodes []*TemplateNode
+	for _, n := range t.Root.Children() {
+		if n, ok := n.(*TemplateNode); ok {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// converter writes the templates of a file in another dialect. It reads the
+// parse tree and the lines of the source rather than the syntax tree of
+// ParseAST: the template comments are only in the source, and the newlines
+// that Haml renders after its lines, which the converted templates have to
+// render as well, are only known to the parse tree.
+type converter struct {
+	*formatter
+	// keyword is the keyword of the dialect the templates are converted to
+	keyword string
+	// nodes are the parsed templates, in the same order as the templates of
+	// the formatter
+	nodes []*TemplateNode
+	errs  ErrorList
+}
+
+func (c *converter) convert() ([]byte, error) {
+	var buf strings.Builder
+	line := 1
+	for i, t := range c.templates {
+		for ; line < t.startLine; line++ {
+			buf.WriteString(c.line(line))
+		}
+		keyword, body := c.convertTemplate(t, c.nodes[i])
+		buf.WriteString(strings.Replace(c.line(t.startLine), t.keyword, keyword, 1))
+		buf.WriteString(body)
+		// a template that is closed on the line it starts on has no more lines
+		line = max(t.endLine, t.startLine+1)
+	}
+	for ; line <= len(c.lineStarts); line++ {
+		buf.WriteString(c.line(line))
+	}
+
+	return []byte(buf.String()), c.errs.Err()
+}
+
+// convertTemplate returns the keyword and the lines of the template in the
+// dialect that the file is converted to. A template that can't be converted
+// is returned as it is.
+func (c *converter) convertTemplate(t formatterTemplate, n *TemplateNode) (string, string) {
+	var body string
+	if t.endLine > t.startLine {
+		body = c.src[c.lineStarts[t.startLine]:c.lineStarts[t.endLine-1]]
+	}
+
+	switch {
+	case t.keyword == c.keyword || t.keyword == "@goht" && c.keyword == "@haml":
+		return t.keyword, body
+	case body == "":
+		return c.keyword, body
+	}
+
+	errs := len(c.errs)
+	var converted string
+	if c.keyword == "@ego" {
+		converted = c.egoBody(t, n)
+	} else {
+		converted = c.linesBody(t, n)
+	}
+	if len(c.errs) > errs {
+		return t.keyword, body
+	}
+	return c.keyword, converted
+}
+
+// errorf records an error for content that can't be converted.
+func (c *converter) errorf(t token, format string, args ...interface{}) {
+	err := errorAt(t, ErrConversion, format, args...).(PositionalError)
+	err.Source = strings.TrimRight(c.line(t.line), "\r\n")
+	c.errs = append(c.errs, err)
+}
+
+func dialectName(keyword string) string {
+	switch keyword {
+	case "@slim":
+		return "Slim"
+	case "@ego":
+		return "EGO"
+	default:
+		return "Haml"
+	}
+}
+
+// convertLine is a line of a Haml or Slim template together with the lines
+// that belong to it, such as the content of a filter or the continuation of a
+// line of code.
+type convertLine struct {
+	depth  int
+	tokens []token
+	// start and end are the first and the last source lines
+	start int
+	end   int
+}
+
+// lines returns the lines of the template; a line starts at each indent.
+func (f *formatter) lines(t formatterTemplate) []convertLine {
+	var lines []convertLine
+	for _, tok := range t.tokens {
+		switch {
+		case tok.Type() == tIndent:
+			lines = append(lines, convertLine{depth: len(tok.lit), start: tok.line})
+		case len(lines) == 0, tok.Type() == tNewLine, tok.Type() == tFilterEnd:
+		default:
+			l := &lines[len(lines)-1]
+			l.tokens = append(l.tokens, tok)
+		}
+	}
+	for i := range lines {
+		end := t.endLine - 1
+		if i+1 < len(lines) {
+			end = lines[i+1].start - 1
+		}
+		for end > lines[i].start && strings.TrimSpace(f.line(end)) == "" {
+			end--
+		}
+		lines[i].end = end
+	}
+	return lines
+}
+
+// content returns the first line of the template line without its indent.
+func (f *formatter) content(l convertLine) string {
+	return strings.TrimRight(f.line(l.start), "\r\n")[l.depth:]
+}
+
+// restLines returns the source lines of the template line from the first
+// line given, with the indent of the line removed.
+func (f *formatter) restLines(l convertLine, first int) []string {
+	var lines []string
+	for line := first; line <= l.end; line++ {
+		text := strings.TrimRight(f.line(line), "\r\n")
+		lines = append(lines, strings.TrimPrefix(text, strings.Repeat("\t", l.depth)))
+	}
+	return lines
+}
+
+// convertComment is a comment of a template, which is not rendered.
+type convertComment struct {
+	line  int
+	lines []string
+}
+
+// comments returns the comments of the template.
+func (f *formatter) comments(t formatterTemplate) []convertComment {
+	if t.keyword == "@ego" {
+		return f.egoComments(t)
+	}
+
+	var comments []convertComment
+	for _, l := range f.lines(t) {
+		if len(l.tokens) == 0 || l.tokens[0].Type() != tRubyComment {
+			continue
+		}
+		text := strings.TrimPrefix(f.content(l), "-#")
+		if t.keyword == "@slim" {
+			text = strings.TrimPrefix(f.content(l), "/")
+		}
+		lines := append([]string{strings.TrimSpace(text)}, f.restLines(l, l.start+1)...)
+		comments = append(comments, convertComment{line: l.start, lines: lines})
+	}
+	return comments
+}
+
+// convertKind is the kind of a convertNode.
+type convertKind int
+
+const (
+	// cvSpace is whitespace between the content
+	cvSpace convertKind = iota
+	// cvText is HTML without whitespace at its ends
+	cvText
+	// cvOutput is the output of Go code
+	cvOutput
+	cvElement
+	// cvCode is a line of Go code with the content of its block
+	cvCode
+	cvCommand
+	// cvComment is an HTML comment
+	cvComment
+	cvDoctype
+	// cvFilter is a filter that is written as it is, such as markdown
+	cvFilter
+	// cvNote is a comment of the template, which is not rendered
+	cvNote
+)
+
+// convertNode is a part of what a template renders. The nodes of a template
+// of any dialect are read into convert nodes, which are written as a Haml or
+// Slim template.
+type convertNode struct {
+	kind convertKind
+	// text is the whitespace of a space, the HTML of text, the Go code of an
+	// output or of code, the argument of a command, the text of a comment or
+	// the value of a doctype
+	text string
+	// name is the tag of an element, or the name of a command or a filter
+	name      string
+	unescaped bool
+	children  []*convertNode
+	// lines are the lines of a filter or a template comment
+	lines  []string
+	origin token
+
+	// the attributes of an element
+	attrs         []convertAttr
+	objectRef     *token
+	attributesCmd string
+	selfClosing   bool
+
+	// trimStart and trimEnd are set for an output or a command with its
+	// whitespace removed by Haml while the template renders
+	trimStart bool
+	trimEnd   bool
+}
+
+// inPlace returns true for the code and the commands with content that is
+// rendered where they are; the content of the other commands is rendered
+// somewhere else, such as in the template that a command renders.
+func (n *convertNode) inPlace() bool {
+	switch n.name {
+	case "render", "children", "block", "push", "stack", "json", "slot":
+		return n.kind != cvCommand
+	}
+	return true
+}
+
+// isTransparent returns true for the nodes that don't render anything of
+// their own where they are written, so that the whitespace around them and
+// around their content is whitespace between the content before and after
+// them. The commands that render other content where they are written, such
+// as the children of a template, are not transparent.
+func (n *convertNode) isTransparent() bool {
+	switch n.kind {
+	case cvCode, cvNote:
+		return true
+	case cvCommand:
+		return n.inPlace() || n.name == "push"
+	}
+	return false
+}
+
+// rendersSpace returns true for an output or a command that may render
+// whitespace at its ends, which Haml can only remove while the template
+// renders.
+func (n *convertNode) rendersSpace() bool {
+	switch n.kind {
+	case cvOutput:
+		s, err := strconv.Unquote(n.text)
+		return err != nil || strings.TrimSpace(s) != s
+	case cvCommand:
+		return !n.isTransparent()
+	}
+	return false
+}
+
+type convertAttrKind int
+
+const (
+	// attrBare is an attribute without a value
+	attrBare convertAttrKind = iota
+	attrStatic
+	attrDynamic
+	// attrCond is an attribute without a value that is written when its code
+	// is true
+	attrCond
+	attrClass
+)
+
+type convertAttr struct {
+	name string
+	kind convertAttrKind
+	// value is the value of a static attribute, or the Go code of the value
+	// or the condition of the others
+	value   string
+	classes []convertClass
+	origin  token
+}
+
+// convertClass is a class of an element; a class is either a static name or
+// the Go code of a value of goht.BuildClassList.
+type convertClass struct {
+	name string
+	code string
+}
+
+// convertFrame is a list of nodes that is being read.
+type convertFrame struct {
+	node  *convertNode
+	nodes []*convertNode
+}
+
+// convertReader reads the nodes of a template into convert nodes.
+//
+// The reader writes the whitespace of the nodes the same way the nodes write
+// it when the template is generated. The whitespace that Haml removes with
+// its markers is removed while the template renders, so the whitespace is
+// removed from the content of the blocks of code around the marker as well
+// as from the content before or after the blocks.
+type convertReader struct {
+	c        *converter
+	comments []convertComment
+	// lines are the lines of the template by their first source line
+	lines     map[int]convertLine
+	frames    []*convertFrame
+	unescaped bool
+	trimNext  bool
+
+	// the HTML of an EGO template
+	ego egoHTML
+}
+
+func newConvertReader(c *converter, t formatterTemplate) *convertReader {
+	r := &convertReader{
+		c:        c,
+		comments: c.comments(t),
+		lines:    make(map[int]convertLine),
+		frames:   []*convertFrame{{}},
+	}
+	for _, l := range c.lines(t) {
+		r.lines[l.start] = l
+	}
+	return r
+}
+
+func (r *convertReader) frame() *convertFrame {
+	return r.frames[len(r.frames)-1]
+}
+
+// add adds the node to the list that is being read.
+func (r *convertReader) add(n *convertNode) {
+	if n.kind != cvNote {
+		r.flushComments(n.origin.line)
+	}
+	if n.kind != cvSpace && !n.isTransparent() {
+		n.trimStart = r.trimNext && n.rendersSpace()
+		r.trimNext = false
+	}
+	f := r.frame()
+	f.nodes = append(f.nodes, n)
+}
+
+// flushComments adds the comments of the template that come before the line.
+func (r *convertReader) flushComments(line int) {
+	for len(r.comments) > 0 && r.comments[0].line < line {
+		comment := r.comments[0]
+		r.comments = r.comments[1:]
+		r.add(&convertNode{kind: cvNote, lines: comment.lines, origin: token{line: comment.line}})
+	}
+}
+
+// push starts reading the content of the node.
+func (r *convertReader) push(n *convertNode) {
+	r.frames = append(r.frames, &convertFrame{node: n})
+}
+
+// pop ends reading the content of the node that was pushed last.
+func (r *convertReader) pop() {
+	f := r.frame()
+	r.frames = r.frames[:len(r.frames)-1]
+	f.node.children = f.nodes
+}
+
+// block reads the content of a block of code or of a command. The block may
+// not be rendered, so whitespace that is removed at its start is removed after
+// it as well.
+func (r *convertReader) block(n *convertNode, read func()) {
+	r.add(n)
+	trimNext := r.trimNext
+	r.push(n)
+	read()
+	r.pop()
+	r.trimNext = r.trimNext || trimNext
+}
+
+func (r *convertReader) space(s string, origin token) {
+	if r.trimNext || s == "" {
+		return
+	}
+	r.add(&convertNode{kind: cvSpace, text: s, origin: origin})
+}
+
+// text adds HTML; the whitespace at its ends, and the whitespace with
+// newlines in it, is added as spaces.
+func (r *convertReader) text(s string, origin token) {
+	if r.trimNext {
+		s = strings.TrimLeft(s, spaceChars)
+	}
+	for _, n := range splitText(s, origin) {
+		if n.kind == cvSpace {
+			r.space(n.text, origin)
+		} else {
+			r.add(n)
+		}
+	}
+}
+
+// splitText returns the HTML as text and spaces; the whitespace at the ends
+// of the HTML, and the whitespace with newlines in it, are spaces.
+func splitText(s string, origin token) []*convertNode {
+	var nodes []*convertNode
+	for s != "" {
+		if n := len(s) - len(strings.TrimLeft(s, spaceChars)); n > 0 {
+			nodes = append(nodes, &convertNode{kind: cvSpace, text: s[:n], origin: origin})
+			s = s[n:]
+			continue
+		}
+		end := len(s)
+		for i := 0; i < len(s); i++ {
+			if !strings.ContainsRune(spaceChars, rune(s[i])) {
+				continue
+			}
+			j := i + len(s[i:]) - len(strings.TrimLeft(s[i:], spaceChars))
+			if j == len(s) || strings.ContainsAny(s[i:j], "\n\r") {
+				end = i
+				break
+			}
+			i = j
+		}
+		nodes = append(nodes, &convertNode{kind: cvText, text: s[:end], origin: origin})
+		s = s[end:]
+	}
+	return nodes
+}
+
+// spaceChars are the characters of whitespace.
+const spaceChars = " \t\n\f\r"
+
+// trimBefore removes the whitespace before the content that is added next,
+// back to the start of the element that it is in.
+func (r *convertReader) trimBefore() {
+	for i := len(r.frames) - 1; i >= 0; i-- {
+		f := r.frames[i]
+		f.nodes = trimSpaceBefore(f.nodes)
+		if trailingContent(f.nodes) {
+			return
+		}
+		if i > 0 && !f.node.isTransparent() {
+			// the content of a command is rendered somewhere else, with
+			// the whitespace before it removed there
+			f.node.trimStart = f.node.trimStart || f.node.rendersSpace()
+			return
+		}
+	}
+}
+
+// trimSpaceBefore removes the whitespace at the end of the nodes, and at the
+// end of the content of the blocks that they end with.
+func trimSpaceBefore(nodes []*convertNode) []*convertNode {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		switch n := nodes[i]; {
+		case n.kind == cvSpace:
+			nodes = slices.Delete(nodes, i, i+1)
+		case n.isTransparent():
+			n.children = trimSpaceBefore(n.children)
+		default:
+			n.trimEnd = n.trimEnd || n.rendersSpace()
+			return nodes
+		}
+	}
+	return nodes
+}
+
+// trailingContent returns true when the nodes end with content that is
+// always rendered.
+func trailingContent(nodes []*convertNode) bool {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if !nodes[i].isTransparent() {
+			return true
+		}
+	}
+	return false
+}
+
+// finish returns the nodes that were read.
+func (r *convertReader) finish() []*convertNode {
+	r.flushComments(1 << 30)
+	return r.frame().nodes
+}
+
+// read reads the nodes of a Haml or Slim template.
+func (r *convertReader) read(nodes []nodeBase) []*convertNode {
+	r.nodes(nodes)
+	return r.finish()
+}
+
+func (r *convertReader) nodes(nodes []nodeBase) {
+	for i := 0; i < len(nodes); i++ {
+		s, ok := nodes[i].(*SilentScriptNode)
+		if !ok || len(s.children) > 0 || !isCaseClause(strings.TrimSpace(s.code)) {
+			r.node(nodes[i])
+			continue
+		}
+		// the lines of a case are read as its content, like the lines of the
+		// other code
+		end := i + 1
+		for end < len(nodes) && !isClauseEnd(nodes[end]) {
+			end++
+		}
+		code := &convertNode{kind: cvCode, text: strings.TrimSpace(s.code), origin: s.origin}
+		r.block(code, func() { r.nodes(nodes[i+1 : end]) })
+		i = end - 1
+	}
+}
+
+func (r *convertReader) node(n nodeBase) {
+	switch n := n.(type) {
+	case *DoctypeNode:
+		r.add(&convertNode{kind: cvDoctype, text: n.doctype, origin: n.origin})
+	case *ElementNode:
+		r.element(n)
+	case *NewLineNode:
+		r.space("\n", n.origin)
+	case *CommentNode:
+		r.comment(n)
+	case *TextNode:
+		r.textNode(n)
+	case *UnescapeNode:
+		r.unescaped = true
+		r.nodes(n.children)
+		r.unescaped = false
+	case *SilentScriptNode:
+		code := &convertNode{kind: cvCode, text: strings.TrimSpace(n.code), origin: n.origin}
+		r.block(code, func() { r.nodes(n.children) })
+	case *ScriptNode:
+		r.add(&convertNode{kind: cvOutput, text: n.code, unescaped: r.unescaped, origin: n.origin})
+	case *JavaScriptFilterNode:
+		r.scriptFilter("script", n.origin, n.children)
+	case *CssFilterNode:
+		r.scriptFilter("style", n.origin, n.children)
+	case *TextFilterNode:
+		r.unescaped = n.isUnescaped
+		r.nodes(n.children)
+		r.unescaped = false
+		if n.origin.lit == "preserve" {
+			r.space("\n", n.origin)
+		}
+	case *CustomFilterNode:
+		l := r.lines[n.origin.line]
+		// the output of a filter that is converted when the template is
+		// generated is read as its content
+		filter := &convertNode{kind: cvFilter, name: n.origin.lit, lines: r.c.restLines(l, l.start+1), origin: n.origin}
+		r.block(filter, func() { r.nodes(n.parts) })
+	default:
+		if name, arg, ok := commandOf(n); ok {
+			cmd := &convertNode{kind: cvCommand, name: name, text: arg, origin: n.Origin()}
+			r.block(cmd, func() { r.nodes(n.Children()) })
+		}
+	}
+}
+
+// commandOf returns the name and the argument of a command.
+func commandOf(n nodeBase) (string, string, bool) {
+	switch n := n.(type) {
+	case *RenderCommandNode:
+		return "render", strings.TrimRight(n.command, " \t{"), true
+	case *ChildrenCommandNode:
+		return "children", "", true
+	case *FlushCommandNode:
+		return "flush", "", true
+	case *FragmentCommandNode:
+		return "fragment", n.fragment, true
+	case *ExtendsCommandNode:
+		return "extends", n.template, true
+	case *BlockCommandNode:
+		return "block", n.block, true
+	case *PushCommandNode:
+		return "push", n.origin.lit, true
+	case *StackCommandNode:
+		return "stack", n.origin.lit, true
+	case *JsonCommandNode:
+		return "json", n.value, true
+	case *SlotCommandNode:
+		return "slot", n.origin.lit, true
+	case *FormatCommandNode:
+		return "format", n.origin.lit, true
+	}
+	return "", "", false
+}
+
+func (r *convertReader) element(n *ElementNode) {
+	if n.nukeOuterWhitespace {
+		r.trimBefore()
+	}
+	if n.addWhitespaceBefore {
+		r.space(" ", n.origin)
+	}
+
+	el := &convertNode{
+		kind:          cvElement,
+		name:          n.tag,
+		attrs:         elementAttrs(n),
+		objectRef:     n.objectRef,
+		attributesCmd: n.attributesCmd,
+		selfClosing:   n.isSelfClosing,
+		origin:        n.origin,
+	}
+	r.add(el)
+	if n.isSelfClosing {
+		return
+	}
+
+	r.push(el)
+	if n.nukeInnerWhitespace {
+		r.trimNext = true
+	}
+	if !(len(n.children) == 1 && n.children[0].Type() == nNewLine) {
+		r.nodes(n.children)
+	}
+	if n.nukeInnerWhitespace {
+		r.trimBefore()
+	}
+	r.pop()
+	r.trimNext = false
+
+	if n.nukeOuterWhitespace {
+		r.trimNext = true
+	} else if n.keepNewlines {
+		r.space("\n", n.origin)
+	}
+	if n.addWhitespaceAfter {
+		r.space(" ", n.origin)
+	}
+}
+
+// elementAttrs returns the attributes of the element, with its id and its
+// classes.
+func elementAttrs(n *ElementNode) []convertAttr {
+	var attrs []convertAttr
+	if n.id != "" {
+		attrs = append(attrs, convertAttr{name: "id", kind: attrStatic, value: n.id, origin: n.origin})
+	}
+
+	classes := slices.Clone(n.classes)
+	if class, ok := n.attributes.Get("class"); ok {
+		classes = append(classes, class.origin)
+	}
+	if len(classes) > 0 {
+		attr := convertAttr{name: "class", kind: attrClass, origin: classes[0]}
+		for _, class := range classes {
+			switch class.Type() {
+			case tAttrDynamicValue:
+				attr.classes = append(attr.classes, convertClass{code: class.lit})
+			case tAttrEscapedValue:
+				name, _ := strconv.Unquote(class.lit)
+				attr.classes = append(attr.classes, convertClass{name: name})
+			default:
+				attr.classes = append(attr.classes, convertClass{name: class.lit})
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+
+	for _, key := range n.attributes.keys {
+		a := n.attributes.values[key]
+		attr := convertAttr{name: a.name, value: a.value, origin: a.origin}
+		switch {
+		case key == "class":
+			continue
+		case a.value == "":
+			attr.kind = attrBare
+		case a.isBoolean:
+			attr.kind = attrCond
+		case a.isDynamic, a.isHash:
+			attr.kind = attrDynamic
+		default:
+			attr.kind = attrStatic
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+func (r *convertReader) comment(n *CommentNode) {
+	if n.text != "" {
+		r.add(&convertNode{kind: cvComment, text: n.text, origin: n.origin})
+		if n.keepNewlines {
+			r.space("\n", n.origin)
+		}
+	}
+	if len(n.children) == 0 || len(n.children) == 1 && n.children[0].Type() == nNewLine {
+		return
+	}
+	// the content of a comment with nested lines is HTML
+	r.add(&convertNode{kind: cvText, text: "<!--", origin: n.origin})
+	r.nodes(n.children)
+	r.add(&convertNode{kind: cvText, text: "-->", origin: n.origin})
+	if n.keepNewlines {
+		r.space("\n", n.origin)
+	}
+}
+
+func (r *convertReader) textNode(n *TextNode) {
+	if n.isDynamic {
+		r.add(&convertNode{kind: cvOutput, text: n.text, unescaped: r.unescaped, origin: n.origin})
+		return
+	}
+	s := n.text
+	if n.isPreserve {
+		trimmed := strings.TrimSuffix(s, "\n")
+		s = trimmed + strings.Repeat("&#x000A;", len(s)-len(trimmed))
+	}
+	if !n.isPlain && !n.isPreserve && !r.unescaped {
+		s = html.EscapeString(s)
+	}
+	r.text(s, n.origin)
+}
+
+// scriptFilter adds the element of a javascript or css filter, which has the
+// lines of the filter as its content.
+func (r *convertReader) scriptFilter(tag string, origin token, children []nodeBase) {
+	el := &convertNode{kind: cvElement, name: tag, origin: origin}
+	r.add(el)
+	r.push(el)
+	r.add(&convertNode{kind: cvText, text: "\n", origin: origin})
+	for _, c := range children {
+		if t, ok := c.(*TextNode); ok && t.isDynamic {
+			r.add(&convertNode{kind: cvOutput, text: t.text, origin: t.origin})
+		} else if ok {
+			r.add(&convertNode{kind: cvText, text: t.text, origin: t.origin})
+		}
+	}
+	r.pop()
+}
+
+// sketchToken is a part of the HTML that a template renders: a space, a
+// piece of HTML that has the outputs of the template written as their code,
+// or a marker for the start or the end of a block of code or of a command,
+// or for whitespace that is removed while the template renders.
+type sketchToken struct {
+	space  bool
+	marker bool
+	text   string
+	origin token
+}
+
+// sketch returns what the nodes render, in a form that is the same for the
+// templates that render the same HTML. The whitespace is kept as it is, and
+// the attributes of the elements are sorted by their names.
+func sketch(nodes []*convertNode) []sketchToken {
+	var s sketcher
+	s.nodes(nodes)
+
+	// the runs of text and of whitespace are joined
+	var tokens []sketchToken
+	for _, t := range s.tokens {
+		if n := len(tokens); n > 0 && !t.marker && !tokens[n-1].marker && tokens[n-1].space == t.space {
+			tokens[n-1].text += t.text
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+type sketcher struct {
+	tokens []sketchToken
+}
+
+func (s *sketcher) add(t sketchToken) {
+	s.tokens = append(s.tokens, t)
+}
+
+// html adds the HTML with its whitespace as spaces.
+func (s *sketcher) html(text string, origin token) {
+	for text != "" {
+		if n := len(text) - len(strings.TrimLeft(text, spaceChars)); n > 0 {
+			s.add(sketchToken{space: true, text: text[:n], origin: origin})
+			text = text[n:]
+			continue
+		}
+		end := strings.IndexAny(text, spaceChars)
+		if end < 0 {
+			end = len(text)
+		}
+		s.add(sketchToken{text: text[:end], origin: origin})
+		text = text[end:]
+	}
+}
+
+// isTrim returns true for the marker of whitespace that is removed while
+// the template renders.
+func (t sketchToken) isTrim() bool {
+	return t.marker && t.text == "trim"
+}
+
+// trim adds a marker for the whitespace that is removed next to the output
+// or the command while the template renders.
+func (s *sketcher) trim(n *convertNode, trim bool) {
+	if trim {
+		s.add(sketchToken{marker: true, text: "trim", origin: n.origin})
+	}
+}
+
+// sketchTemplate returns what the nodes of a template of the dialect render.
+// Slim and EGO templates end with a newline that isn't one of their nodes,
+// which is found at the end.
+func sketchTemplate(keyword string, nodes []*convertNode, end token) []sketchToken {
+	tokens := sketch(nodes)
+	if keyword != "@slim" && keyword != "@ego" {
+		return tokens
+	}
+	if n := len(tokens); n > 0 && tokens[n-1].space {
+		tokens[n-1].text += "\n"
+		return tokens
+	}
+	return append(tokens, sketchToken{space: true, text: "\n", origin: end})
+}
+
+// sketchCode returns Go code on a single line.
+func sketchCode(code string) string {
+	return strings.Join(strings.Fields(code), " ")
+}
+
+func (s *sketcher) nodes(nodes []*convertNode) {
+	for _, n := range nodes {
+		switch n.kind {
+		case cvSpace:
+			s.add(sketchToken{space: true, text: n.text, origin: n.origin})
+		case cvText:
+			s.html(n.text, n.origin)
+		case cvOutput:
+			out := "{="
+			if n.unescaped {
+				out = "{!"
+			}
+			s.trim(n, n.trimStart)
+			s.add(sketchToken{text: out + sketchCode(n.text) + "}", origin: n.origin})
+			s.trim(n, n.trimEnd)
+		case cvElement:
+			s.add(sketchToken{text: "<" + n.name + sketchAttrs(n) + ">", origin: n.origin})
+			if n.selfClosing {
+				continue
+			}
+			s.nodes(n.children)
+			s.add(sketchToken{text: "</" + n.name + ">", origin: n.origin})
+		case cvCode, cvCommand:
+			name := "-"
+			if n.kind == cvCommand {
+				name = "@" + n.name
+			}
+			s.trim(n, n.trimStart)
+			s.add(sketchToken{marker: true, text: name + " " + sketchCode(n.text), origin: n.origin})
+			if !n.inPlace() {
+				// the whitespace at the ends of the content is not rendered
+				// next to the command
+				s.tokens = append(s.tokens, sketch(n.children)...)
+				s.add(sketchToken{marker: true, text: "end", origin: n.origin})
+				s.trim(n, n.trimEnd)
+				continue
+			}
+			s.nodes(n.children)
+			if n.kind == cvCode && strings.HasPrefix(n.text, "for ") {
+				// the content of a loop is next to itself
+				s.add(sketchToken{marker: true, text: "next", origin: n.origin})
+				s.nodes(n.children)
+			}
+			s.add(sketchToken{marker: true, text: "end", origin: n.origin})
+		case cvComment:
+			s.add(sketchToken{text: "<!--" + html.EscapeString(n.text) + "-->", origin: n.origin})
+		case cvDoctype:
+			doctype, _ := strconv.Unquote(`"` + formatHTML5.doctype(n.text) + `"`)
+			s.add(sketchToken{text: doctype, origin: n.origin})
+		case cvFilter:
+			if len(n.children) > 0 {
+				s.nodes(n.children)
+				continue
+			}
+			s.add(sketchToken{marker: true, text: ":" + n.name + "\n" + strings.Join(n.lines, "\n"), origin: n.origin})
+		}
+	}
+}
+
+// sketchAttrs returns the attributes of the element sorted by their names.
+func sketchAttrs(n *convertNode) string {
+	var attrs []string
+	for _, a := range n.attrs {
+		switch a.kind {
+		case attrBare:
+			attrs = append(attrs, a.name)
+		case attrStatic:
+			attrs = append(attrs, a.name+`="`+html.EscapeString(a.value)+`"`)
+		case attrDynamic:
+			attrs = append(attrs, a.name+`="{=`+sketchCode(a.value)+`}"`)
+		case attrCond:
+			attrs = append(attrs, a.name+"{?"+sketchCode(a.value)+"}")
+		case attrClass:
+			var classes []string
+			for _, c := range a.classes {
+				if c.code != "" {
+					classes = append(classes, "{="+sketchCode(c.code)+"}")
+					continue
+				}
+				classes = append(classes, strings.Fields(c.name)...)
+			}
+			attrs = append(attrs, `class="`+strings.Join(classes, " ")+`"`)
+		}
+	}
+	sort.Strings(attrs)
+	if n.objectRef != nil {
+		attrs = append(attrs, "["+sketchCode(n.objectRef.lit)+"]")
+	}
+	if n.attributesCmd != "" {
+		attrs = append(attrs, "{@attributes "+sketchCode(n.attributesCmd)+"}")
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " " + strings.Join(attrs, " ")
+}
+
+// verify checks that the body that was written for the nodes of the template
+// renders the same HTML, and records an error for the first content or
+// whitespace that doesn't.
+func (c *converter) verify(t formatterTemplate, nodes []*convertNode, body string) {
+	src := c.keyword + " Converted() {\n" + body + "}\n"
+	tpl, err := parseBytes([]byte(src))
+	if err != nil {
+		c.errorf(token{line: t.startLine, col: 1, endLine: t.startLine, endCol: len(t.keyword) + 1},
+			"the template can't be written in %s", dialectName(c.keyword))
+		return
+	}
+	v := &converter{formatter: newFormatter([]byte(src)), keyword: c.keyword}
+	r := newConvertReader(v, v.templates[0])
+	var written []*convertNode
+	if c.keyword == "@ego" {
+		written = r.readEgo(templateNodes(tpl)[0].children)
+	} else {
+		written = r.read(templateNodes(tpl)[0].children)
+	}
+
+	end := token{line: t.endLine, col: 1, endLine: t.endLine, endCol: 2}
+	want, got := sketchTemplate(t.keyword, nodes, end), sketchTemplate(c.keyword, written, end)
+	for i, w := range want {
+		var g, gotNext, wantNext sketchToken
+		if i < len(got) {
+			g = got[i]
+		}
+		if i+1 < len(got) {
+			gotNext = got[i+1]
+		}
+		if i+1 < len(want) {
+			wantNext = want[i+1]
+		}
+		if i < len(got) && g.space == w.space && g.marker == w.marker && g.text == w.text {
+			continue
+		}
+		// when one text starts with the other, the content after the
+		// shorter one is what is different
+		var after sketchToken
+		switch {
+		case i >= len(got) || w.marker || g.marker || w.space || g.space:
+		case strings.HasPrefix(w.text, g.text):
+			after = gotNext
+		case strings.HasPrefix(g.text, w.text):
+			after = wantNext
+		}
+		switch {
+		case w.isTrim() || g.isTrim() || after.isTrim():
+			c.errorf(w.origin, "the removal of the whitespace next to this content can't be written in %s without changing what the template renders", dialectName(c.keyword))
+		case w.space:
+			c.errorf(w.origin, "the whitespace %q can't be written in %s without changing what the template renders", w.text, dialectName(c.keyword))
+		case g.space || after.space:
+			c.errorf(w.origin, "the whitespace in this content can't be written in %s without changing what the template renders", dialectName(c.keyword))
+		default:
+			c.errorf(w.origin, "this content can't be written in %s without changing what the template renders", dialectName(c.keyword))
+		}
+		return
+	}
+	if len(got) > len(want) {
+		origin := end
+		if len(want) > 0 {
+			origin = want[len(want)-1].origin
+		}
+		c.errorf(origin, "the end of the template can't be written in %s without changing what it renders", dialectName(c.keyword))
+	}
+}
@@ -0,0 +1,1102 @@
+package compiler
+
+import (
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// egoPrinter writes the nodes of a Haml or Slim template as the body of an
+// EGO template that renders the same HTML.
+type egoPrinter struct {
+	c *converter
+	b strings.Builder
+	// textStart is the offset of the text written since the last tag, or -1
+	// when the last thing written was a tag
+	textStart int
+	trimNext  bool
+	unescaped bool
+	comments  []egoComment
+	// closes are the else statements that close the statement before them
+	closes map[*SilentScriptNode]bool
+	// skips are the closing braces that were written with the command
+	// before them
+	skips map[*SilentScriptNode]bool
+}
+
+type egoComment struct {
+	line int
+	text string
+}
+
+// egoBody returns the lines of the template written as an EGO template. The
+// written template is compared with the template the same way as the Haml and
+// Slim templates are.
+func (c *converter) egoBody(t formatterTemplate, n *TemplateNode) string {
+	errs := len(c.errs)
+	p := &egoPrinter{
+		c:      c,
+		closes: make(map[*SilentScriptNode]bool),
+		skips:  make(map[*SilentScriptNode]bool),
+	}
+	for _, comment := range c.comments(t) {
+		p.comments = append(p.comments, egoComment{
+			line: comment.line,
+			text: strings.TrimSpace(strings.Join(comment.lines, "\n")),
+		})
+	}
+
+	p.nodes(n.children)
+	// EGO templates end with a newline in place of their trailing whitespace
+	p.trimBefore()
+	p.flushComments(t.endLine)
+
+	var b strings.Builder
+	lines := strings.Split(p.b.String(), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for _, line := range lines {
+		b.WriteString("\t" + line + "\n")
+	}
+	if len(c.errs) == errs {
+		c.verify(t, newConvertReader(c, t).read(n.children), b.String())
+	}
+	return b.String()
+}
+
+// text writes HTML.
+func (p *egoPrinter) text(s string) {
+	if p.trimNext {
+		if s = strings.TrimLeft(s, " \t\r\n"); s == "" {
+			return
+		}
+		p.trimNext = false
+	}
+	if p.textStart < 0 {
+		p.textStart = p.b.Len()
+	}
+	p.b.WriteString(strings.ReplaceAll(s, "<%", "<%%"))
+}
+
+// literal writes the content of a Go string literal.
+func (p *egoPrinter) literal(s string) {
+	if s, err := strconv.Unquote(`"` + s + `"`); err == nil {
+		p.text(s)
+	}
+}
+
+// trimBefore removes the whitespace of the text written since the last tag.
+func (p *egoPrinter) trimBefore() {
+	if p.textStart < 0 {
+		return
+	}
+	s := p.b.String()
+	p.b.Reset()
+	p.b.WriteString(s[:p.textStart] + strings.TrimRight(s[p.textStart:], " \t\r\n"))
+}
+
+// tag writes an EGO tag. Tags that don't write anything are written on a
+// line of their own when they start a line.
+func (p *egoPrinter) tag(open, code string) {
+	p.trimNext = false
+	p.textStart = -1
+	if code != "" {
+		open += " " + code
+	}
+	s := p.b.String()
+	// the open of a tag without code is only "<%"
+	if (strings.HasPrefix(open, "<% ") || strings.HasPrefix(open, "<%#")) && (s == "" || strings.HasSuffix(s, "\n")) {
+		p.b.WriteString(open + " $%>\n")
+		return
+	}
+	p.b.WriteString(open + " %>")
+}
+
+// code writes an EGO tag with a single line of Go code.
+func (p *egoPrinter) code(open, code string) {
+	p.tag(open, strings.TrimSpace(strings.ReplaceAll(code, "\n", " ")))
+}
+
+func (p *egoPrinter) output(code string, unescaped bool) {
+	if unescaped {
+		p.code("<%!", code)
+		return
+	}
+	p.code("<%=", code)
+}
+
+func (p *egoPrinter) flushComments(line int) {
+	for len(p.comments) > 0 && p.comments[0].line < line {
+		p.tag("<%#", p.comments[0].text)
+		p.comments = p.comments[1:]
+	}
+}
+
+func (p *egoPrinter) nodes(nodes []nodeBase) {
+	for i, n := range nodes {
+		var next nodeBase
+		if i+1 < len(nodes) {
+			next = nodes[i+1]
+		}
+		p.flushComments(n.Origin().line)
+		p.node(n, next)
+	}
+}
+
+// block writes the command and its children, which are closed with a brace.
+func (p *egoPrinter) block(open, code string, children []nodeBase, next nodeBase) {
+	p.code(open, code+" {")
+	p.nodes(children)
+	p.tag("<%", "}")
+	if next, ok := next.(*SilentScriptNode); ok && strings.TrimSpace(next.code) == "}" {
+		p.skips[next] = true
+	}
+}
+
+func (p *egoPrinter) node(n nodeBase, next nodeBase) {
+	switch n := n.(type) {
+	case *DoctypeNode:
+		p.literal(formatHTML5.doctype(n.doctype))
+	case *ElementNode:
+		p.element(n)
+	case *NewLineNode:
+		p.text("\n")
+	case *CommentNode:
+		p.comment(n)
+	case *TextNode:
+		p.textNode(n)
+	case *UnescapeNode:
+		p.unescaped = true
+		p.nodes(n.children)
+		p.unescaped = false
+	case *SilentScriptNode:
+		p.silentScript(n, next)
+	case *ScriptNode:
+		p.output(n.code, p.unescaped)
+	case *RenderCommandNode:
+		if len(n.children) == 0 {
+			p.code("<%@render", n.command)
+			return
+		}
+		p.block("<%@render", strings.TrimRight(n.command, " \t{"), n.children, next)
+	case *ChildrenCommandNode:
+		p.tag("<%@children", "")
+	case *FlushCommandNode:
+		p.tag("<%@flush", "")
+	case *FragmentCommandNode:
+		p.block("<%@fragment", n.fragment, n.children, next)
+	case *ExtendsCommandNode:
+		p.code("<%@extends", n.template)
+	case *BlockCommandNode:
+		if len(n.children) == 0 {
+			p.code("<%@block", n.block)
+			return
+		}
+		p.block("<%@block", n.block, n.children, next)
+	case *PushCommandNode:
+		p.block("<%@push", n.origin.lit, n.children, next)
+	case *StackCommandNode:
+		p.code("<%@stack", n.origin.lit)
+	case *JsonCommandNode:
+		p.code("<%@json", n.value)
+	case *SlotCommandNode:
+		if len(n.children) == 0 {
+			p.code("<%@slot", n.origin.lit)
+			return
+		}
+		p.block("<%@slot", n.origin.lit, n.children, next)
+	case *FormatCommandNode:
+		p.c.errorf(n.origin, "the format command can't be written in EGO")
+	case *JavaScriptFilterNode:
+		p.text("<script>\n")
+		p.nodes(n.children)
+		p.text("</script>")
+	case *CssFilterNode:
+		p.text("<style>\n")
+		p.nodes(n.children)
+		p.text("</style>")
+	case *TextFilterNode:
+		p.unescaped = n.isUnescaped
+		p.nodes(n.children)
+		p.unescaped = false
+		if n.origin.lit == "preserve" {
+			p.text("\n")
+		}
+	case *CustomFilterNode:
+		if n.filter.Transform == nil {
+			p.c.errorf(n.origin, "the %s filter can't be written in EGO", n.origin.lit)
+			return
+		}
+		// the output of the filter is written with its interpolated values
+		p.nodes(n.parts)
+	}
+}
+
+func (p *egoPrinter) element(n *ElementNode) {
+	if n.nukeOuterWhitespace {
+		p.trimBefore()
+	}
+	if n.addWhitespaceBefore {
+		p.text(" ")
+	}
+	p.text("<" + n.tag)
+	p.attributes(n)
+	if n.isSelfClosing && !slices.Contains(selfClosedTags, strings.ToLower(n.tag)) {
+		p.text("/")
+	}
+	p.text(">")
+	if n.isSelfClosing {
+		return
+	}
+
+	if n.nukeInnerWhitespace {
+		p.trimNext = true
+	}
+	if !(len(n.children) == 1 && n.children[0].Type() == nNewLine) {
+		p.nodes(n.children)
+	}
+	if n.nukeInnerWhitespace {
+		p.trimBefore()
+	}
+
+	p.text("</" + n.tag + ">")
+	if n.nukeOuterWhitespace {
+		p.trimNext = true
+	} else if n.keepNewlines {
+		p.text("\n")
+	}
+	if n.addWhitespaceAfter {
+		p.text(" ")
+	}
+}
+
+func (p *egoPrinter) attributes(n *ElementNode) {
+	if n.objectRef != nil {
+		p.c.errorf(*n.objectRef, "object references can't be written in EGO")
+	}
+	if n.id != "" {
+		p.text(` id="` + n.id + `"`)
+	}
+	p.classes(n)
+	for _, key := range n.attributes.keys {
+		attr := n.attributes.values[key]
+		switch {
+		case key == "class":
+		case attr.value == "":
+			p.text(" " + attr.name)
+		case attr.isBoolean:
+			p.code("<%", "if "+attr.value+" {")
+			p.text(" " + attr.name)
+			p.tag("<%", "}")
+		case attr.isHash:
+			p.c.errorf(attr.origin, "the %s attribute hash can't be written in EGO", attr.name)
+		case attr.isDynamic:
+			p.text(" " + attr.name + `="`)
+			p.output(attr.value, false)
+			p.text(`"`)
+		default:
+			p.text(" " + attr.name + `="` + html.EscapeString(attr.value) + `"`)
+		}
+	}
+	if n.attributesCmd != "" {
+		p.c.errorf(n.origin, "the attributes command can't be written in EGO")
+	}
+}
+
+// classes writes the class attribute. Dynamic classes are written with
+// goht.BuildClassList, in an unescaped tag because it also returns an error.
+func (p *egoPrinter) classes(n *ElementNode) {
+	classes := slices.Clone(n.classes)
+	if class, ok := n.attributes.Get("class"); ok {
+		classes = append(classes, class.origin)
+	}
+	if len(classes) == 0 {
+		return
+	}
+
+	names := make([]string, len(classes))
+	static := true
+	for i, class := range classes {
+		switch class.Type() {
+		case tClass:
+			names[i] = strconv.Quote(class.lit)
+		case tAttrDynamicValue:
+			names[i] = class.lit
+			static = false
+		default:
+			names[i] = class.lit
+		}
+	}
+	if !static {
+		p.text(` class="`)
+		p.code("<%!", "goht.CaptureErrors(goht.BuildClassList("+strings.Join(names, ", ")+"))")
+		p.text(`"`)
+		return
+	}
+	for i, name := range names {
+		names[i], _ = strconv.Unquote(name)
+	}
+	p.text(` class="` + strings.Join(names, " ") + `"`)
+}
+
+func (p *egoPrinter) comment(n *CommentNode) {
+	if n.text != "" {
+		p.text("<!--" + html.EscapeString(n.text) + "-->")
+		if n.keepNewlines {
+			p.text("\n")
+		}
+	}
+	if len(n.children) == 0 || len(n.children) == 1 && n.children[0].Type() == nNewLine {
+		return
+	}
+	p.text("<!--")
+	p.nodes(n.children)
+	p.text("-->")
+	if n.keepNewlines {
+		p.text("\n")
+	}
+}
+
+func (p *egoPrinter) textNode(n *TextNode) {
+	if n.isDynamic {
+		p.output(n.text, p.unescaped)
+		return
+	}
+	s := n.text
+	if n.isPreserve {
+		trimmed := strings.TrimSuffix(s, "\n")
+		s = trimmed + strings.Repeat("&#x000A;", len(s)-len(trimmed))
+	}
+	if n.isPlain || n.isPreserve || p.unescaped {
+		p.text(s)
+		return
+	}
+	p.text(html.EscapeString(s))
+}
+
+// silentScript writes the Go code with the braces that Haml and Slim add to
+// the statements that have nested content.
+func (p *egoPrinter) silentScript(n *SilentScriptNode, next nodeBase) {
+	if p.skips[n] {
+		return
+	}
+	code := strings.TrimSpace(strings.ReplaceAll(n.code, "\n", " "))
+	isOpening := n.allowChildren()
+	if p.closes[n] && !strings.HasPrefix(code, "}") {
+		code = "} " + code
+	}
+	if len(n.children) > 0 && isOpening && !strings.HasSuffix(code, "{") {
+		code += " {"
+	}
+	p.tag("<%", code)
+	if len(n.children) == 0 {
+		return
+	}
+	p.nodes(n.children)
+	if !isOpening {
+		return
+	}
+
+	if next, ok := next.(*SilentScriptNode); ok {
+		if strings.HasPrefix(strings.TrimSpace(next.code), "}") {
+			return
+		}
+		for _, stmt := range elseStatements {
+			if strings.HasPrefix(next.code, stmt) {
+				p.closes[next] = true
+				return
+			}
+		}
+	}
+	p.tag("<%", "}")
+}
+
+// egoComments returns the comments of an EGO template.
+func (f *formatter) egoComments(t formatterTemplate) []convertComment {
+	if t.endLine <= t.startLine {
+		return nil
+	}
+	body := f.src[f.lineStarts[t.startLine]:f.lineStarts[t.endLine-1]]
+
+	var comments []convertComment
+	for i := 0; ; {
+		j := strings.Index(body[i:], "<%")
+		if j < 0 {
+			return comments
+		}
+		i += j
+		end := strings.Index(body[i:], "%>")
+		switch {
+		case strings.HasPrefix(body[i:], "<%%"):
+			i += 3
+			continue
+		case end < 0:
+			return comments
+		case !strings.HasPrefix(body[i:], "<%#"):
+			i += end + 2
+			continue
+		}
+
+		text := strings.TrimRight(body[i+3:i+end], "-$")
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		for k := 1; k < len(lines); k++ {
+			// the lines of the comment are nested in the comment
+			if line := strings.TrimSpace(lines[k]); line != "" {
+				lines[k] = "\t" + line
+			} else {
+				lines[k] = ""
+			}
+		}
+		lines[0] = strings.TrimSpace(lines[0])
+		comments = append(comments, convertComment{
+			line:  t.startLine + 1 + strings.Count(body[:i], "\n"),
+			lines: lines,
+		})
+		i += end + 2
+	}
+}
+
+// egoHTML is the HTML of an EGO template that is being read. The HTML
+// continues across the EGO tags, so its state is kept between the text of
+// the template.
+type egoHTML struct {
+	state htmlState
+	name  strings.Builder
+	// tag is the element of the start tag that is being read, and raw is the
+	// start tag as HTML, which is written as text when the tag can't be
+	// written as an element
+	tag     *convertNode
+	raw     []*convertNode
+	rawText strings.Builder
+	// rawOnly is set for a tag with content that can't be written as
+	// attributes
+	rawOnly bool
+	slash   bool
+	attr    *htmlAttr
+	attrs   []*htmlAttr
+	quote   byte
+	// classes are the classes of a class attribute that is written with
+	// goht.BuildClassList
+	classes []convertClass
+	// rawTag is the element with raw text that is open, such as a script
+	rawTag string
+	// rawComment is set for a comment with outputs in it, which is written
+	// as text
+	rawComment bool
+	// starts are the start tags of the elements that are open
+	starts map[*convertNode][]*convertNode
+	// line and col are the position of the HTML that is read next
+	line, col int
+}
+
+func (e *egoHTML) pos() token {
+	return token{line: e.line, col: e.col, endLine: e.line, endCol: e.col + 1}
+}
+
+// advance moves the position past the HTML; the EGO lexer removes the indent
+// of the template from each line.
+func (e *egoHTML) advance(s string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			e.line, e.col = e.line+1, 2
+		} else {
+			e.col++
+		}
+	}
+}
+
+// inTag returns true while a start tag is being read.
+func (e *egoHTML) inTag() bool {
+	switch e.state {
+	case htmlTagName, htmlInTag, htmlAttrName, htmlBeforeValue, htmlAttrValue:
+		return true
+	}
+	return false
+}
+
+// readEgo reads the nodes of an EGO template. The elements that are opened
+// and closed in the same block are read as elements, and the others are read
+// as text.
+func (r *convertReader) readEgo(nodes []nodeBase) []*convertNode {
+	r.ego.starts = make(map[*convertNode][]*convertNode)
+	r.egoNodes(nodes)
+	r.egoEnd(nil)
+	return r.finish()
+}
+
+func (r *convertReader) egoNodes(nodes []nodeBase) {
+	for i := 0; i < len(nodes); i++ {
+		switch n := nodes[i].(type) {
+		case *RawTextNode:
+			r.ego.line, r.ego.col = n.origin.line, n.origin.col
+			r.egoText(n.text)
+		case *ScriptNode:
+			r.egoOutput(n.code, false, n.origin)
+		case *UnescapeNode:
+			for _, c := range n.children {
+				if s, ok := c.(*ScriptNode); ok {
+					r.egoOutput(s.code, true, s.origin)
+				}
+			}
+		case *SilentScriptNode:
+			i += r.egoCode(n, nodes[i+1:])
+		default:
+			name, arg, ok := commandOf(n)
+			if !ok {
+				continue
+			}
+			if r.ego.state != htmlText && r.ego.state != htmlRawText {
+				r.c.errorf(n.Origin(), "the %s command can't be converted inside of a tag", name)
+				continue
+			}
+			cmd := &convertNode{kind: cvCommand, name: name, text: arg, origin: n.Origin()}
+			r.block(cmd, func() {
+				r.egoNodes(n.Children())
+				r.egoEnd(cmd)
+			})
+		}
+	}
+}
+
+func firstChild(nodes []nodeBase) nodeBase {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// egoCode reads a line of Go code, and returns the number of the nodes after
+// it that were read as its content.
+func (r *convertReader) egoCode(n *SilentScriptNode, next []nodeBase) int {
+	code := strings.TrimSpace(strings.ReplaceAll(n.code, "\n", " "))
+	if code == "}" {
+		// the end of a block is written by the dialect
+		return 0
+	}
+	if r.ego.inTag() {
+		return r.egoCodeInTag(n, next)
+	}
+	switch r.ego.state {
+	case htmlText, htmlRawText:
+	default:
+		r.c.errorf(n.origin, "Go code can't be converted inside of %s", r.egoWhere())
+		return 0
+	}
+
+	code = strings.TrimSpace(strings.TrimPrefix(code, "}"))
+	isBlock := strings.HasSuffix(code, "{")
+	code = strings.TrimSpace(strings.TrimSuffix(code, "{"))
+	node := &convertNode{kind: cvCode, text: code, origin: n.origin}
+
+	children, read := n.children, 0
+	if !isBlock && len(children) == 0 && isCaseClause(code) {
+		// the statements of a case run to the next case
+		for read < len(next) && !isClauseEnd(next[read]) {
+			read++
+		}
+		children = next[:read]
+	}
+	if !isBlock && len(children) == 0 {
+		r.add(node)
+		return read
+	}
+	r.block(node, func() {
+		r.egoNodes(children)
+		r.egoEnd(node)
+	})
+	return read
+}
+
+func isCaseClause(code string) bool {
+	return strings.HasSuffix(code, ":") && (strings.HasPrefix(code, "case ") || code == "default:")
+}
+
+// isClauseEnd returns true for the code that ends the statements of a case.
+func isClauseEnd(n nodeBase) bool {
+	s, ok := n.(*SilentScriptNode)
+	if !ok {
+		return false
+	}
+	code := strings.TrimSpace(s.code)
+	return strings.HasPrefix(code, "}") || isCaseClause(code)
+}
+
+// egoCodeInTag reads a block of code in a start tag. A block with a single
+// attribute, and no else, is read as a conditional attribute; the tag of
+// other blocks of attributes is written as text.
+func (r *convertReader) egoCodeInTag(n *SilentScriptNode, next []nodeBase) int {
+	e := &r.ego
+	code := strings.TrimSpace(strings.ReplaceAll(n.code, "\n", " "))
+	isBlock := strings.HasSuffix(code, "{")
+	code = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(code, "}"), "{"))
+
+	var text string
+	for _, c := range n.children {
+		t, ok := c.(*RawTextNode)
+		if !ok {
+			text = "<"
+			break
+		}
+		text += t.text
+	}
+	if e.state != htmlInTag || !isBlock || strings.ContainsAny(text, "<>") ||
+		strings.Count(text, `"`)%2 != 0 || strings.Count(text, "'")%2 != 0 {
+		r.c.errorf(n.origin, "Go code can't be converted inside of %s", r.egoWhere())
+		return 0
+	}
+
+	_, closed := firstChild(next).(*SilentScriptNode)
+	if name := strings.TrimSpace(text); closed && strings.TrimSpace(next[0].(*SilentScriptNode).code) == "}" &&
+		strings.HasPrefix(code, "if ") && reHTMLAttrName.MatchString(name) {
+		e.attrs = append(e.attrs, &htmlAttr{name: name, cond: strings.TrimPrefix(code, "if ")})
+	} else {
+		e.rawOnly = true
+	}
+	r.egoFlushRaw()
+	node := &convertNode{kind: cvCode, text: code, origin: n.origin}
+	node.children = splitText(text, n.origin)
+	e.raw = append(e.raw, node)
+	return 0
+}
+
+// egoWhere describes the HTML that is being read.
+func (r *convertReader) egoWhere() string {
+	switch e := &r.ego; e.state {
+	case htmlComment:
+		return "an HTML comment"
+	case htmlDecl:
+		return "an HTML declaration"
+	case htmlEndTag:
+		return "an end tag"
+	default:
+		if e.tag != nil && e.tag.name != "" {
+			return "the <" + e.tag.name + "> tag"
+		}
+		return "a tag"
+	}
+}
+
+// egoOutput reads the output of Go code.
+func (r *convertReader) egoOutput(code string, unescaped bool, origin token) {
+	e := &r.ego
+	out := &convertNode{kind: cvOutput, text: code, unescaped: unescaped, origin: origin}
+	switch e.state {
+	case htmlText, htmlRawText:
+		r.add(out)
+	case htmlComment:
+		// a comment with outputs is written as text
+		r.text("<!--"+e.name.String(), origin)
+		e.name.Reset()
+		e.rawComment = true
+		r.add(out)
+	case htmlBeforeValue, htmlAttrValue:
+		if e.state == htmlBeforeValue {
+			e.state, e.quote = htmlAttrValue, 0
+		}
+		e.attr.value = append(e.attr.value, htmlPart{code: code})
+		if classes, ok := egoClassList(code); ok && unescaped && strings.EqualFold(e.attr.name, "class") && e.classes == nil {
+			e.classes = classes
+		} else if unescaped {
+			e.rawOnly = true
+		}
+		r.egoFlushRaw()
+		e.raw = append(e.raw, out)
+	case htmlTagName, htmlInTag, htmlAttrName:
+		e.rawOnly = true
+		r.egoFlushRaw()
+		e.raw = append(e.raw, out)
+	default:
+		r.c.errorf(origin, "Go code can't be converted inside of %s", r.egoWhere())
+	}
+}
+
+// egoFlushRaw adds the HTML of the tag that was read since the last output
+// to the start tag.
+func (r *convertReader) egoFlushRaw() {
+	e := &r.ego
+	e.raw = append(e.raw, splitText(e.rawText.String(), e.tag.origin)...)
+	e.rawText.Reset()
+}
+
+// egoText reads the HTML of an EGO template.
+func (r *convertReader) egoText(s string) {
+	e := &r.ego
+	for len(s) > 0 {
+		// n is the length of the HTML that is read by the state
+		n := len(s)
+		switch e.state {
+		case htmlText:
+			i := strings.IndexByte(s, '<')
+			if i != 0 {
+				if i < 0 {
+					i = len(s)
+				}
+				r.text(s[:i], e.pos())
+				n = i
+				break
+			}
+			switch {
+			case strings.HasPrefix(s, "<!--"):
+				e.state, n = htmlComment, 4
+				e.name.Reset()
+				e.rawComment = false
+			case strings.HasPrefix(s, "</") && len(s) > 2 && isHTMLLetter(s[2]):
+				e.state, n = htmlEndTag, 2
+				e.name.Reset()
+				e.rawText.Reset()
+				e.rawText.WriteString("</")
+			case strings.HasPrefix(s, "<!"):
+				e.state, n = htmlDecl, 2
+				e.name.Reset()
+			case len(s) > 1 && isHTMLLetter(s[1]):
+				e.state, n = htmlTagName, 1
+				e.name.Reset()
+				e.tag = &convertNode{kind: cvElement, origin: e.pos()}
+				e.raw, e.rawOnly, e.slash = nil, false, false
+				e.attr, e.attrs, e.classes = nil, nil, nil
+				e.rawText.Reset()
+				e.rawText.WriteString("<")
+			default:
+				r.text("<", e.pos())
+				n = 1
+			}
+		case htmlTagName:
+			if i := strings.IndexAny(s, " \t\n\r/>"); i >= 0 {
+				n = i
+				e.state = htmlInTag
+			}
+			e.name.WriteString(s[:n])
+			e.tag.name = e.name.String()
+			e.rawText.WriteString(s[:n])
+		case htmlInTag:
+			n = 1
+			switch c := s[0]; {
+			case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+				e.rawText.WriteByte(c)
+			case c == '/':
+				e.slash = true
+				e.rawText.WriteByte(c)
+			case c == '>':
+				e.rawText.WriteByte(c)
+				r.egoStartTag()
+			case c == '=' && e.attr != nil && !e.attr.hasValue:
+				e.attr.hasValue = true
+				e.state = htmlBeforeValue
+				e.rawText.WriteByte(c)
+			default:
+				e.slash = false
+				e.attr = &htmlAttr{name: ""}
+				e.attrs = append(e.attrs, e.attr)
+				e.name.Reset()
+				e.state, n = htmlAttrName, 0
+			}
+		case htmlAttrName:
+			if i := strings.IndexAny(s, " \t\n\r=/>"); i >= 0 {
+				n = i
+				e.state = htmlInTag
+			}
+			e.name.WriteString(s[:n])
+			e.attr.name = e.name.String()
+			e.rawText.WriteString(s[:n])
+		case htmlBeforeValue:
+			n = 1
+			switch c := s[0]; c {
+			case ' ', '\t', '\n', '\r':
+				e.rawText.WriteByte(c)
+			case '"', '\'':
+				e.state, e.quote = htmlAttrValue, c
+				e.rawText.WriteByte(c)
+			case '>':
+				e.state, n = htmlInTag, 0
+			default:
+				e.state, e.quote, n = htmlAttrValue, 0, 0
+			}
+		case htmlAttrValue:
+			i := strings.IndexAny(s, " \t\n\r>")
+			if e.quote != 0 {
+				i = strings.IndexByte(s, e.quote)
+			}
+			if i >= 0 {
+				n = i
+				e.state = htmlInTag
+			}
+			if n > 0 {
+				e.attr.value = append(e.attr.value, htmlPart{text: s[:n]})
+			}
+			e.rawText.WriteString(s[:n])
+			if i >= 0 && e.quote != 0 {
+				e.rawText.WriteByte(e.quote)
+				n++
+			}
+		case htmlEndTag:
+			if i := strings.IndexByte(s, '>'); i >= 0 {
+				n = i + 1
+				e.state = htmlText
+			}
+			e.name.WriteString(strings.TrimSuffix(s[:n], ">"))
+			e.rawText.WriteString(s[:n])
+			if e.state == htmlText {
+				r.egoEndTag(strings.TrimSpace(e.name.String()), e.rawText.String())
+			}
+		case htmlComment:
+			if i := strings.Index(s, "-->"); i >= 0 {
+				n = i + 3
+				e.state = htmlText
+			}
+			e.name.WriteString(strings.TrimSuffix(s[:n], "-->"))
+			if e.state == htmlText {
+				r.egoComment(e.name.String())
+			}
+		case htmlDecl:
+			if i := strings.IndexByte(s, '>'); i >= 0 {
+				n = i + 1
+				e.state = htmlText
+			}
+			e.name.WriteString(strings.TrimSuffix(s[:n], ">"))
+			if e.state == htmlText {
+				decl := "<!" + e.name.String() + ">"
+				if doctype, _ := strconv.Unquote(`"` + formatHTML5.doctype("") + `"`); decl == doctype {
+					r.add(&convertNode{kind: cvDoctype, origin: e.pos()})
+				} else {
+					r.text(decl, e.pos())
+				}
+			}
+		case htmlRawText:
+			i := strings.Index(strings.ToLower(s), "</"+e.rawTag)
+			if i != 0 {
+				if i < 0 {
+					i = len(s)
+				}
+				r.egoRawText(s[:i])
+				n = i
+				break
+			}
+			e.state, n = htmlEndTag, 2
+			e.name.Reset()
+			e.rawText.Reset()
+			e.rawText.WriteString("</")
+		}
+		e.advance(s[:n])
+		s = s[n:]
+	}
+}
+
+// egoRawText reads the content of an element with raw text. The content of
+// scripts and styles is kept as it is, so that it can be written as a filter.
+func (r *convertReader) egoRawText(s string) {
+	if s == "" {
+		return
+	}
+	if r.ego.rawTag == "script" || r.ego.rawTag == "style" {
+		r.add(&convertNode{kind: cvText, text: s, origin: r.ego.pos()})
+		return
+	}
+	r.text(s, r.ego.pos())
+}
+
+// egoStartTag reads the end of a start tag.
+func (r *convertReader) egoStartTag() {
+	e := &r.ego
+	el := e.tag
+	r.egoFlushRaw()
+	e.state = htmlText
+	tag := strings.ToLower(el.name)
+	if slices.Contains(htmlRawTextTags, tag) {
+		e.state, e.rawTag = htmlRawText, tag
+	}
+
+	attrs, ok := egoAttrs(e.attrs, e.classes)
+	if !ok || e.rawOnly {
+		for _, n := range e.raw {
+			r.add(n)
+		}
+		return
+	}
+	el.attrs = attrs
+	el.selfClosing = e.slash || slices.Contains(selfClosedTags, tag)
+	r.add(el)
+	if el.selfClosing {
+		e.state = htmlText
+		return
+	}
+	e.starts[el] = e.raw
+	r.push(el)
+}
+
+// egoAttrs returns the attributes of a start tag, or false when they can't
+// be written as the attributes of an element. The classes are the classes of
+// a class attribute with an output of goht.BuildClassList.
+func egoAttrs(attrs []*htmlAttr, classes []convertClass) ([]convertAttr, bool) {
+	var converted []convertAttr
+	names := make(map[string]bool)
+	for _, a := range attrs {
+		name := strings.ToLower(a.name)
+		if names[name] || !reHTMLAttrName.MatchString(a.name) {
+			return nil, false
+		}
+		names[name] = true
+
+		var text, code []string
+		for _, p := range a.value {
+			if p.code != "" {
+				code = append(code, p.code)
+			} else {
+				text = append(text, p.text)
+			}
+		}
+		attr := convertAttr{name: a.name}
+		switch {
+		case a.cond != "":
+			attr.kind, attr.value = attrCond, a.cond
+		case !a.hasValue:
+			attr.kind = attrBare
+		case len(code) == 0 && strings.Join(text, "") == "":
+			// an empty value is written as a string, as an attribute with
+			// an empty static value has no value
+			attr.kind, attr.value = attrDynamic, `""`
+		case len(code) == 0 && name == "class":
+			attr.kind = attrClass
+			attr.classes = []convertClass{{name: html.UnescapeString(strings.Join(text, ""))}}
+		case len(code) == 0:
+			attr.kind, attr.value = attrStatic, html.UnescapeString(strings.Join(text, ""))
+		case len(code) == 1 && len(text) == 0 && name == "class" && classes != nil:
+			attr.kind, attr.classes = attrClass, classes
+		case len(code) == 1 && len(text) == 0:
+			attr.kind, attr.value = attrDynamic, code[0]
+		default:
+			return nil, false
+		}
+		if name == "class" && !classFirst(converted) {
+			return nil, false
+		}
+		converted = append(converted, attr)
+	}
+	return converted, true
+}
+
+// classFirst returns true when the attributes before a class attribute are
+// written before it. The classes of an element are written after its
+// shorthand id and before the other attributes.
+func classFirst(attrs []convertAttr) bool {
+	switch len(attrs) {
+	case 0:
+		return true
+	case 1:
+		return attrs[0].name == "id" && attrs[0].kind == attrStatic && reHTMLIdentifier.MatchString(attrs[0].value)
+	}
+	return false
+}
+
+// egoClassList returns the classes of the Go code of a class attribute that
+// the classes of an element are written as.
+func egoClassList(code string) ([]convertClass, bool) {
+	const start, end = "goht.CaptureErrors(goht.BuildClassList(", "))"
+	code = strings.TrimSpace(code)
+	if !strings.HasPrefix(code, start) || !strings.HasSuffix(code, end) {
+		return nil, false
+	}
+	args := code[len(start) : len(code)-len(end)]
+
+	var classes []convertClass
+	add := func(arg string) {
+		arg = strings.TrimSpace(arg)
+		if name, err := strconv.Unquote(arg); err == nil && arg[0] == '"' {
+			classes = append(classes, convertClass{name: name})
+		} else {
+			classes = append(classes, convertClass{code: arg})
+		}
+	}
+	depth, last := 0, 0
+	var quote byte
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth < 0 {
+				return nil, false
+			}
+		case c == ',' && depth == 0:
+			add(args[last:i])
+			last = i + 1
+		}
+	}
+	if depth != 0 || quote != 0 || strings.TrimSpace(args[last:]) == "" {
+		return nil, false
+	}
+	add(args[last:])
+	return classes, true
+}
+
+// egoEndTag reads an end tag, which closes the element that is open in the
+// block. An end tag without an element is read as text.
+func (r *convertReader) egoEndTag(name, raw string) {
+	for i := len(r.frames) - 1; i > 0; i-- {
+		n := r.frames[i].node
+		if n.isTransparent() {
+			break
+		}
+		if strings.EqualFold(n.name, name) {
+			for len(r.frames)-1 > i {
+				r.egoUnwrap()
+			}
+			r.pop()
+			return
+		}
+	}
+	r.text(raw, r.ego.pos())
+}
+
+// egoUnwrap replaces the element that is open with its start tag and its
+// content, for an element that isn't closed in the block it is opened in.
+func (r *convertReader) egoUnwrap() {
+	f := r.frame()
+	el := f.node
+	r.frames = r.frames[:len(r.frames)-1]
+	parent := r.frame()
+	i := slices.Index(parent.nodes, el)
+	parent.nodes = slices.Replace(parent.nodes, i, i+1, append(slices.Clone(r.ego.starts[el]), f.nodes...)...)
+}
+
+// egoEnd ends the block of the node, or the template when the node is nil.
+func (r *convertReader) egoEnd(block *convertNode) {
+	for n := r.frame().node; n != block && n.kind == cvElement; n = r.frame().node {
+		r.egoUnwrap()
+	}
+	switch e := &r.ego; e.state {
+	case htmlText, htmlRawText:
+	default:
+		origin := e.pos()
+		if e.tag != nil && e.inTag() {
+			origin = e.tag.origin
+		}
+		r.c.errorf(origin, "the HTML of %s must end in the block of Go code it starts in", r.egoWhere())
+		e.state = htmlText
+	}
+}
+
+// egoComment reads an HTML comment. A comment that is written the way Haml
+// and Slim write comments is read as a comment, and the others as text.
+func (r *convertReader) egoComment(text string) {
+	e := &r.ego
+	switch {
+	case e.rawComment:
+		r.text(text+"-->", e.pos())
+	case text != "" && text == strings.TrimSpace(text) && !strings.ContainsAny(text, "\n\r") && html.EscapeString(text) == text:
+		r.add(&convertNode{kind: cvComment, text: text, origin: e.pos()})
+	default:
+		r.text("<!--"+text+"-->", e.pos())
+	}
+}
@@ -0,0 +1,1129 @@
+package compiler
+
+import (
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// linesBody returns the lines of the template written as a Haml or Slim
+// template. The written template is read back and compared with the template,
+// and the content that renders differently is recorded as an error.
+func (c *converter) linesBody(t formatterTemplate, n *TemplateNode) string {
+	errs := len(c.errs)
+	r := newConvertReader(c, t)
+	var nodes []*convertNode
+	if t.keyword == "@ego" {
+		nodes = r.readEgo(n.children)
+	} else {
+		nodes = r.read(n.children)
+	}
+	if len(c.errs) > errs {
+		return ""
+	}
+
+	w := &lineWriter{c: c, slim: c.keyword == "@slim"}
+	content := nodes
+	if t.keyword == "@slim" || t.keyword == "@ego" {
+		// the newline that ends the template is rendered by its content
+		content = append(slices.Clip(nodes), &convertNode{kind: cvSpace, text: "\n", origin: n.origin})
+	}
+	items := w.items(content)
+	root := &lineItem{kind: liBlock, items: items}
+	w.solve(root)
+	w.lines(root.items, 0)
+	body := w.b.String()
+	if len(c.errs) == errs {
+		c.verify(t, nodes, body)
+	}
+	return body
+}
+
+// lineKind is the kind of a lineItem.
+type lineKind int
+
+const (
+	// liSpace is whitespace between the lines, which is not written
+	liSpace lineKind = iota
+	// liText is a line of text and outputs
+	liText
+	liElement
+	// liBlock is a line of Go code or a command, with its content rendered
+	// in its place
+	liBlock
+	// liCommand is a command that renders something in its place; its
+	// content is rendered somewhere else
+	liCommand
+	liComment
+	liDoctype
+	liFilter
+	// liScript is a script or a style written as a filter
+	liScript
+	liNote
+	// liBlank is a line that only writes whitespace
+	liBlank
+)
+
+// lineItem is the content of a line of a Haml or Slim template, together
+// with the lines that are nested in it.
+type lineItem struct {
+	kind lineKind
+	node *convertNode
+	// text are the text, the outputs, the spaces and the inlined elements of a
+	// line of text
+	text  []*convertNode
+	items []*lineItem
+	// inline is set for an element with its content on its line
+	inline bool
+
+	// trimOuter and trimInner are the whitespace removal markers of Haml
+	trimOuter bool
+	trimInner bool
+	// spaceBefore and spaceAfter are the whitespace markers of the elements
+	// of Slim, and the spaces that Slim writes around its text
+	spaceBefore bool
+	spaceAfter  bool
+}
+
+// isContent returns true for the items that render something.
+func (it *lineItem) isContent() bool {
+	switch it.kind {
+	case liSpace, liBlock, liNote:
+		return false
+	}
+	return true
+}
+
+// unescaped returns true for a line of text with unescaped outputs.
+func (it *lineItem) unescaped() bool {
+	for _, n := range it.text {
+		if n.kind == cvOutput && n.unescaped {
+			return true
+		}
+	}
+	return false
+}
+
+// output returns the output of a line of text that only has an output that
+// can be written as Go code.
+func (it *lineItem) output() *convertNode {
+	if len(it.text) == 1 && it.text[0].kind == cvOutput && !reFmtText.MatchString(it.text[0].text) {
+		return it.text[0]
+	}
+	return nil
+}
+
+// lineWriter writes convert nodes as the lines of a Haml or a Slim template.
+type lineWriter struct {
+	c    *converter
+	slim bool
+	b    strings.Builder
+}
+
+// items returns the nodes as the lines they are written on.
+func (w *lineWriter) items(nodes []*convertNode) []*lineItem {
+	var items []*lineItem
+	var text []*convertNode
+	// notes are written after the line of text they are found in
+	var notes []*lineItem
+
+	space := func(n *convertNode) {
+		if len(items) > 0 && items[len(items)-1].kind == liSpace {
+			last := items[len(items)-1]
+			last.node = &convertNode{kind: cvSpace, text: last.node.text + n.text, origin: last.node.origin}
+			return
+		}
+		items = append(items, &lineItem{kind: liSpace, node: n})
+	}
+	endText := func() {
+		var trailing *convertNode
+		for len(text) > 0 && text[len(text)-1].kind == cvSpace {
+			trailing = text[len(text)-1]
+			text = text[:len(text)-1]
+		}
+		items = append(items, w.textLines(text)...)
+		text = nil
+		items = append(items, notes...)
+		notes = nil
+		if trailing != nil {
+			space(trailing)
+		}
+	}
+	add := func(it *lineItem) {
+		if it.kind == liNote && len(text) > 0 {
+			notes = append(notes, it)
+			return
+		}
+		endText()
+		items = append(items, it)
+	}
+
+	for _, n := range nodes {
+		switch n.kind {
+		case cvSpace:
+			if len(text) == 0 || strings.ContainsAny(n.text, "\n\r") {
+				endText()
+				space(n)
+				continue
+			}
+			text = append(text, n)
+		case cvText:
+			for _, part := range splitText(n.text, n.origin) {
+				if part.kind == cvSpace {
+					if len(text) == 0 || strings.ContainsAny(part.text, "\n\r") {
+						endText()
+						space(part)
+						continue
+					}
+				}
+				text = append(text, part)
+			}
+		case cvOutput:
+			text = append(text, n)
+		case cvElement:
+			add(w.elementItem(n))
+		case cvCode:
+			add(&lineItem{kind: liBlock, node: n, items: w.items(n.children)})
+		case cvCommand:
+			kind := liBlock
+			if !n.inPlace() {
+				kind = liCommand
+			}
+			add(&lineItem{kind: kind, node: n, items: w.items(n.children)})
+		case cvComment:
+			add(&lineItem{kind: liComment, node: n})
+		case cvDoctype:
+			add(&lineItem{kind: liDoctype, node: n})
+		case cvFilter:
+			add(&lineItem{kind: liFilter, node: n})
+		case cvNote:
+			add(&lineItem{kind: liNote, node: n})
+		}
+	}
+	endText()
+	return items
+}
+
+// textLines returns the lines that the text is written on. An output that
+// is unescaped is written on a line of its own in Slim, and in Haml it is
+// written on a line of its own when it is next to an escaped output.
+func (w *lineWriter) textLines(text []*convertNode) []*lineItem {
+	if len(text) == 0 {
+		return nil
+	}
+	var items []*lineItem
+	start := 0
+	split := func(i int) {
+		// the spaces between the lines are whitespace between the items
+		end := i
+		for end > start && text[end-1].kind == cvSpace {
+			end--
+		}
+		if end > start {
+			items = append(items, &lineItem{kind: liText, text: text[start:end]})
+		}
+		if end < i {
+			var space strings.Builder
+			for _, n := range text[end:i] {
+				space.WriteString(n.text)
+			}
+			items = append(items, &lineItem{kind: liSpace, node: &convertNode{kind: cvSpace, text: space.String(), origin: text[end].origin}})
+		}
+		start = i
+	}
+
+	escaped, unescaped := false, false
+	for i, n := range text {
+		if n.kind != cvOutput {
+			if w.slim && i > 0 && text[i-1].kind == cvOutput && text[i-1].unescaped {
+				split(i)
+			}
+			continue
+		}
+		switch {
+		case w.slim && n.unescaped:
+			split(i)
+		case n.unescaped && escaped, !n.unescaped && unescaped:
+			split(i)
+			escaped, unescaped = false, false
+		}
+		if n.unescaped {
+			unescaped = true
+		} else {
+			escaped = true
+		}
+	}
+	split(len(text))
+	return items
+}
+
+// elementItem returns the item of an element and its content.
+func (w *lineWriter) elementItem(n *convertNode) *lineItem {
+	if it := w.scriptItem(n); it != nil {
+		return it
+	}
+	it := &lineItem{kind: liElement, node: n, items: w.items(n.children)}
+	switch {
+	case len(it.items) == 0:
+		it.inline = true
+	case len(it.items) == 1 && it.items[0].kind == liText && w.inlineText(it.items[0]):
+		it.inline = true
+		it.trimInner = trimsSpace(it.items[0], false) || trimsSpace(it.items[0], true)
+	case len(it.items) == 1 && it.items[0].kind == liSpace && it.items[0].node.text == "\n\n" && !w.slim:
+		// Haml writes a newline around the lines in an element, but an
+		// element without lines is empty
+		space := it.items[0].node
+		it.items = []*lineItem{
+			{kind: liSpace, node: &convertNode{kind: cvSpace, text: "\n", origin: space.origin}},
+			{kind: liBlank},
+			{kind: liSpace, node: &convertNode{kind: cvSpace, text: "\n", origin: space.origin}},
+		}
+	}
+	return it
+}
+
+// inlineText returns true when the text can be written on the line of the
+// element.
+func (w *lineWriter) inlineText(text *lineItem) bool {
+	if w.slim {
+		return text.output() != nil || !isSlimBareAttribute(w.textLine(text))
+	}
+	return !text.unescaped() || text.output() != nil
+}
+
+// scriptItem returns the item of a script or a style with content that is
+// written as a filter, or nil.
+func (w *lineWriter) scriptItem(n *convertNode) *lineItem {
+	if n.name != "script" && n.name != "style" || len(n.attrs) > 0 || n.objectRef != nil || n.attributesCmd != "" || n.selfClosing {
+		return nil
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		switch c.kind {
+		case cvText, cvSpace:
+			if strings.Contains(c.text, "#{") {
+				return nil
+			}
+			b.WriteString(c.text)
+		case cvOutput:
+			if c.unescaped {
+				return nil
+			}
+			b.WriteString("#{" + c.text + "}")
+		default:
+			return nil
+		}
+	}
+	// a filter writes a newline after the start tag and after each line
+	content := b.String()
+	lead := content[:len(content)-len(strings.TrimLeft(content, spaceChars))]
+	if !strings.Contains(lead, "\n") || strings.TrimRight(content, spaceChars) == content || strings.TrimSpace(content) == "" {
+		return nil
+	}
+	return &lineItem{kind: liScript, node: n}
+}
+
+// solve sets the whitespace markers of the items, and adds the lines that
+// only write whitespace, so that the lines write the whitespace of the
+// content. It returns the gap that Haml can't write the whitespace of.
+func (w *lineWriter) solve(container *lineItem) *lineGap {
+	// the content of an element is solved first, as an element that can't
+	// be written with the whitespace of its content is written as text
+	inlined := false
+	var solveItems func(items []*lineItem)
+	solveItems = func(items []*lineItem) {
+		for _, it := range items {
+			switch {
+			case it.kind == liElement && !it.inline:
+				g := w.solve(it)
+				switch {
+				case g != nil && (g.left == nil || g.right == nil) && rawElement(it.node):
+					it.kind, it.text, it.items = liText, []*convertNode{it.node}, nil
+					inlined = true
+				case !w.slim && len(it.items) == 1 && it.items[0].kind == liText && w.inlineText(it.items[0]):
+					// the elements of the content were written as text, which
+					// is written on the line of the element
+					it.inline = true
+					it.trimInner = trimsSpace(it.items[0], false) || trimsSpace(it.items[0], true)
+				}
+			case it.kind == liCommand:
+				w.solve(it)
+			case it.kind == liBlock:
+				solveItems(it.items)
+			}
+		}
+	}
+	solveItems(container.items)
+	if inlined {
+		container.items = joinText(container.items)
+	}
+
+	if w.slim {
+		w.solveSlim(container)
+		return nil
+	}
+	// the elements that can't have their whitespace removed are written as
+	// text, until they can or there are no more of them
+	for {
+		conflict := w.solveHaml(container, false)
+		if conflict == nil || !w.inlineElement(container, conflict) {
+			break
+		}
+	}
+	return w.solveHaml(container, true)
+}
+
+// lineGap is the place between two items that are next to each other in
+// what is rendered; the left item is nil at the start of an element, and the
+// right item is nil at its end.
+type lineGap struct {
+	left, right *lineItem
+	space       bool
+	// text is the whitespace of the gap
+	text string
+	// leftNested and rightNested are set when the item is in a block of code
+	// that the other item isn't in, where the whitespace that Haml removes
+	// around it is removed from the other branches of the block too
+	leftNested, rightNested bool
+	// leftLoop and rightLoop are set when the item is in a loop that the
+	// other item isn't in, where its markers change the whitespace between
+	// the runs of the loop too
+	leftLoop, rightLoop bool
+	// leftFragment is set when the left item is in a fragment that the
+	// right item isn't in, where the whitespace is written in the fragment
+	// and only the markers of the left item remove it when the fragment is
+	// rendered on its own
+	leftFragment bool
+	// after is the item that a line that writes whitespace is added after
+	after *lineItem
+}
+
+// gapStart is an item that the next item in what is rendered comes after,
+// with the whitespace that is between them so far.
+type gapStart struct {
+	left  *lineItem
+	space bool
+	text  string
+}
+
+// gaps returns the gaps between the items of the container, and the items
+// of the blocks in it. The content of a loop is next to itself, and the
+// content after an if or a switch is next to the content of each of its
+// branches.
+func gaps(container *lineItem) []lineGap {
+	var gaps []lineGap
+	var blocks, fragments []*lineItem
+	blocksOf := make(map[*lineItem][]*lineItem)
+	fragmentsOf := make(map[*lineItem][]*lineItem)
+	var walk func(items []*lineItem, starts []gapStart) []gapStart
+	walk = func(items []*lineItem, starts []gapStart) []gapStart {
+		for i := 0; i < len(items); i++ {
+			it := items[i]
+			switch {
+			case it.kind == liSpace:
+				for j := range starts {
+					starts[j].space = true
+					starts[j].text += it.node.text
+				}
+			case it.kind == liBlock && isFragment(it):
+				// the content of a fragment is rendered in its place
+				fragments = append(fragments, it)
+				starts = walk(it.items, starts)
+				fragments = fragments[:len(fragments)-1]
+			case it.kind == liBlock && isLoop(it):
+				blocks = append(blocks, it)
+				starts = walk(it.items, walk(it.items, starts))
+				blocks = blocks[:len(blocks)-1]
+			case it.kind == liBlock && isSwitch(it):
+				blocks = append(blocks, it)
+				var ends []gapStart
+				for _, c := range it.items {
+					if c.kind == liBlock {
+						blocks = append(blocks, c)
+						ends = append(ends, walk(c.items, slices.Clone(starts))...)
+						blocks = blocks[:len(blocks)-1]
+					}
+				}
+				if len(ends) > 0 {
+					starts = ends
+				}
+				blocks = blocks[:len(blocks)-1]
+			case it.kind == liBlock:
+				// the branches of an if are the blocks of its else
+				blocks = append(blocks, it)
+				ends := walk(it.items, slices.Clone(starts))
+				for i+1 < len(items) && codeKeyword(items[i+1]) == "else" {
+					i++
+					blocks[len(blocks)-1] = items[i]
+					ends = append(ends, walk(items[i].items, slices.Clone(starts))...)
+				}
+				blocks = blocks[:len(blocks)-1]
+				starts = ends
+			case it.isContent():
+				for _, start := range starts {
+					gaps = append(gaps, lineGap{left: start.left, right: it, space: start.space, text: start.text})
+				}
+				starts = []gapStart{{left: it}}
+				blocksOf[it] = slices.Clone(blocks)
+				fragmentsOf[it] = slices.Clone(fragments)
+			}
+		}
+		return starts
+	}
+	for _, start := range walk(container.items, []gapStart{{}}) {
+		gaps = append(gaps, lineGap{left: start.left, space: start.space, text: start.text})
+	}
+
+	for i := range gaps {
+		g := &gaps[i]
+		l, r := blocksOf[g.left], blocksOf[g.right]
+		common := 0
+		for common < len(l) && common < len(r) && l[common] == r[common] {
+			common++
+		}
+		g.leftNested, g.rightNested = len(l) > common, len(r) > common
+		g.after = g.left
+		for _, b := range l[common:] {
+			if isLoop(b) {
+				g.leftLoop, g.after = true, b
+				break
+			}
+		}
+		g.rightLoop = slices.ContainsFunc(r[common:], isLoop)
+		g.leftFragment = slices.ContainsFunc(fragmentsOf[g.left], func(f *lineItem) bool {
+			return !slices.Contains(fragmentsOf[g.right], f)
+		})
+	}
+	return gaps
+}
+
+// isLoop returns true for the block of a for statement.
+func isLoop(it *lineItem) bool {
+	return codeKeyword(it) == "for"
+}
+
+// isFragment returns true for the block of a fragment, which is rendered in
+// its place and on its own.
+func isFragment(it *lineItem) bool {
+	return it.node != nil && it.node.kind == cvCommand && it.node.name == "fragment"
+}
+
+// isSwitch returns true for the block of a switch or a select statement,
+// which has the blocks of its cases in it.
+func isSwitch(it *lineItem) bool {
+	return codeKeyword(it) == "switch" || codeKeyword(it) == "select"
+}
+
+// codeKeyword returns the first word of the Go code of a block.
+func codeKeyword(it *lineItem) string {
+	if it.node == nil || it.node.kind != cvCode {
+		return ""
+	}
+	word, _, _ := strings.Cut(it.node.text, " ")
+	return word
+}
+
+// hamlSpace returns true when Haml writes whitespace after the item; the
+// item is nil for the start of an element with nested lines.
+func hamlSpace(it *lineItem) bool {
+	switch {
+	case it == nil:
+		return true
+	case it.kind == liElement:
+		return !it.node.selfClosing
+	case it.kind == liScript, it.kind == liCommand:
+		return false
+	case it.kind == liFilter:
+		f, _ := lookupFilter(it.node.name)
+		return f.Transform != nil
+	}
+	return true
+}
+
+// trimsSpace returns true when the whitespace at the end of the item, or at
+// its start, is removed while the template renders.
+func trimsSpace(it *lineItem, end bool) bool {
+	if it == nil {
+		return false
+	}
+	n := it.node
+	if it.kind == liText {
+		n = it.text[0]
+		if end {
+			n = it.text[len(it.text)-1]
+		}
+	}
+	if n == nil {
+		return false
+	}
+	if end {
+		return n.trimEnd
+	}
+	return n.trimStart
+}
+
+// trims returns the markers that remove the whitespace of the gap, and the
+// markers of them that can be set to remove it.
+func (g lineGap) trims(container *lineItem) (trims, settable []*bool) {
+	if g.left != nil && g.left.kind == liElement && !g.left.node.selfClosing {
+		trims = append(trims, &g.left.trimOuter)
+		if !g.leftNested {
+			settable = append(settable, &g.left.trimOuter)
+		}
+	}
+	if g.leftFragment {
+		return trims, settable
+	}
+	if g.right != nil && g.right.kind == liElement {
+		trims = append(trims, &g.right.trimOuter)
+		if !g.rightNested {
+			settable = append(settable, &g.right.trimOuter)
+		}
+	}
+	if (g.left == nil || g.right == nil) && container.kind == liElement {
+		trims = append(trims, &container.trimInner)
+		settable = append(settable, &container.trimInner)
+	}
+	return trims, settable
+}
+
+// solveHaml finds the markers that remove the whitespace that Haml writes
+// where the content has none, and returns the first gap that can't have its
+// whitespace removed. The markers are set, and the lines that write the
+// whitespace that Haml doesn't write are added, when apply is true.
+func (w *lineWriter) solveHaml(container *lineItem, apply bool) *lineGap {
+	gs := gaps(container)
+	kept := make(map[*bool]bool)
+	for _, g := range gs {
+		if g.space {
+			trims, _ := g.trims(container)
+			for _, trim := range trims {
+				kept[trim] = true
+			}
+		}
+	}
+	var conflict *lineGap
+	set := make(map[*bool]bool)
+	for i, g := range gs {
+		// Haml writes nothing before the first line of the template and of
+		// the content of a command, and the whitespace next to an output or
+		// a command is removed when the content it renders has some
+		writes := hamlSpace(g.left) && (g.left != nil || container.kind == liElement) ||
+			trimsSpace(g.left, true) || trimsSpace(g.right, false)
+		switch {
+		case g.space && g.text != "\n":
+			// Haml only writes newlines between the lines
+			if conflict == nil {
+				conflict = &gs[i]
+			}
+			continue
+		case g.space:
+			if !writes && apply {
+				w.insertBlank(container, g.after)
+			}
+			continue
+		case !writes:
+			continue
+		}
+		trims, settable := g.trims(container)
+		if slices.ContainsFunc(trims, func(b *bool) bool { return set[b] }) {
+			continue
+		}
+		if j := slices.IndexFunc(settable, func(b *bool) bool { return !kept[b] }); j >= 0 {
+			set[settable[j]] = true
+		} else if conflict == nil {
+			conflict = &gs[i]
+		}
+	}
+	if apply {
+		for trim := range set {
+			*trim = true
+		}
+	}
+	return conflict
+}
+
+// solveSlim sets the markers that add the spaces that Slim doesn't write
+// where the content has them. Slim writes no other whitespace between the
+// lines, apart from the newline that ends the template.
+func (w *lineWriter) solveSlim(container *lineItem) {
+	for _, g := range gaps(container) {
+		if g.text != " " {
+			continue
+		}
+		// the markers of an item in a loop add whitespace to its other runs
+		left := g.left
+		if g.leftLoop {
+			left = nil
+		}
+		right := g.right
+		if g.rightLoop {
+			right = nil
+		}
+		switch {
+		case left != nil && left.kind == liElement && !left.node.selfClosing:
+			left.spaceAfter = true
+		case right != nil && right.kind == liElement:
+			right.spaceBefore = true
+		case left != nil && left.kind == liText && left.output() == nil:
+			left.spaceAfter = true
+		case right != nil && right.kind == liText && right.output() == nil:
+			right.spaceBefore = true
+		default:
+			w.insertBlank(container, g.after)
+		}
+	}
+}
+
+// insertBlank adds a line that writes whitespace after the item, or at the
+// start of the container when the item is nil.
+func (w *lineWriter) insertBlank(container *lineItem, after *lineItem) {
+	blank := &lineItem{kind: liBlank}
+	if after == nil {
+		container.items = slices.Insert(container.items, 0, blank)
+		return
+	}
+	var insert func(items []*lineItem) []*lineItem
+	insert = func(items []*lineItem) []*lineItem {
+		for i, it := range items {
+			if it == after {
+				return slices.Insert(items, i+1, blank)
+			}
+			if it.kind == liBlock {
+				it.items = insert(it.items)
+			}
+		}
+		return items
+	}
+	container.items = insert(container.items)
+}
+
+// inlineElement writes an element of the gap as text in the line of the
+// text next to it, which removes the whitespace that Haml writes after an
+// element. It returns false when neither element can be written as text.
+func (w *lineWriter) inlineElement(container *lineItem, g *lineGap) bool {
+	for _, it := range []*lineItem{g.left, g.right} {
+		if it == nil || it.kind != liElement || !rawElement(it.node) {
+			continue
+		}
+		it.kind, it.text, it.items = liText, []*convertNode{it.node}, nil
+		container.items = joinText(container.items)
+		return true
+	}
+	return false
+}
+
+// joinText joins the lines of text that are next to each other, or that
+// only have spaces between them.
+func joinText(items []*lineItem) []*lineItem {
+	for i := 0; i < len(items); i++ {
+		if items[i].kind == liBlock {
+			items[i].items = joinText(items[i].items)
+		}
+		if i > 1 && items[i].kind == liText && items[i-1].kind == liSpace && items[i-2].kind == liText &&
+			!strings.ContainsAny(items[i-1].node.text, "\n\r") && items[i].unescaped() == items[i-2].unescaped() {
+			items[i-2].text = append(items[i-2].text, items[i-1].node)
+			items = slices.Delete(items, i-1, i)
+			i--
+		}
+		if i > 0 && items[i].kind == liText && items[i-1].kind == liText &&
+			items[i].unescaped() == items[i-1].unescaped() {
+			items[i-1].text = append(items[i-1].text, items[i].text...)
+			items = slices.Delete(items, i, i+1)
+			i--
+		}
+	}
+	return items
+}
+
+// rawElement returns true for an element that can be written as HTML.
+func rawElement(n *convertNode) bool {
+	if n.objectRef != nil || n.attributesCmd != "" {
+		return false
+	}
+	for _, a := range n.attrs {
+		switch a.kind {
+		case attrCond:
+			return false
+		case attrClass:
+			for _, c := range a.classes {
+				if c.code != "" {
+					return false
+				}
+			}
+		}
+	}
+	for _, c := range n.children {
+		switch c.kind {
+		case cvText, cvSpace:
+			// the element is written on a line
+			if strings.ContainsAny(c.text, "\n\r") {
+				return false
+			}
+		case cvOutput:
+			if c.unescaped {
+				return false
+			}
+		case cvElement:
+			if !rawElement(c) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// lines writes the items.
+func (w *lineWriter) lines(items []*lineItem, depth int) {
+	for _, it := range items {
+		switch it.kind {
+		case liText:
+			w.text(it, depth)
+		case liBlank:
+			if w.slim {
+				w.line(depth, "'")
+			} else {
+				w.line(depth, "\\")
+			}
+		case liElement:
+			w.element(it, depth)
+		case liBlock, liCommand:
+			w.block(it, depth)
+		case liComment:
+			if w.slim {
+				w.line(depth, "/! "+it.node.text)
+			} else {
+				w.line(depth, "/ "+it.node.text)
+			}
+		case liDoctype:
+			doctype := "!!!"
+			if w.slim {
+				doctype = "doctype"
+			}
+			w.line(depth, strings.TrimSpace(doctype+" "+it.node.text))
+		case liFilter:
+			w.line(depth, ":"+it.node.name)
+			w.rawLines(it.node.lines, depth)
+		case liScript:
+			w.script(it.node, depth)
+		case liNote:
+			w.note(it.node, depth)
+		}
+	}
+}
+
+func (w *lineWriter) line(depth int, s string) {
+	w.b.WriteString(strings.Repeat("\t", depth+1) + s + "\n")
+}
+
+// rawLines writes lines that are nested in the line before them.
+func (w *lineWriter) rawLines(lines []string, depth int) {
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			w.b.WriteString("\n")
+			continue
+		}
+		w.b.WriteString(strings.Repeat("\t", depth+1) + line + "\n")
+	}
+}
+
+func (w *lineWriter) note(n *convertNode, depth int) {
+	first := n.lines[0]
+	switch {
+	case !w.slim:
+		w.line(depth, strings.TrimSpace("-# "+first))
+	case strings.HasPrefix(first, "!"):
+		// a comment that starts with a bang is an HTML comment in Slim
+		w.line(depth, "/ "+first)
+	default:
+		w.line(depth, strings.TrimSpace("/ "+first))
+	}
+	w.rawLines(n.lines[1:], depth)
+}
+
+func (w *lineWriter) block(it *lineItem, depth int) {
+	n := it.node
+	switch {
+	case n.kind == cvCode:
+		w.line(depth, strings.TrimSpace("- "+strings.Join(strings.Fields(n.text), " ")))
+	default:
+		w.line(depth, strings.TrimSpace("= @"+n.name+" "+n.text))
+	}
+	w.lines(it.items, depth+1)
+}
+
+// text writes a line of text.
+func (w *lineWriter) text(it *lineItem, depth int) {
+	if out := it.output(); out != nil {
+		w.line(depth, w.outputLine(out)+" "+out.text)
+		return
+	}
+	s := w.textLine(it)
+	switch {
+	case w.slim && it.spaceAfter:
+		s = "' " + w.leadingSpace(it) + s
+	case w.slim:
+		s = "| " + w.leadingSpace(it) + s
+	case it.unescaped():
+		s = "! " + s
+	default:
+		s = hamlText(s)
+	}
+	w.line(depth, s)
+}
+
+// outputLine returns the start of a line with an output.
+func (w *lineWriter) outputLine(out *convertNode) string {
+	switch {
+	case !out.unescaped:
+		return "="
+	case w.slim:
+		return "=="
+	default:
+		return "!="
+	}
+}
+
+func (w *lineWriter) leadingSpace(it *lineItem) string {
+	if it.spaceBefore {
+		return " "
+	}
+	return ""
+}
+
+// textLine returns the line of text with its outputs interpolated.
+func (w *lineWriter) textLine(it *lineItem) string {
+	var b strings.Builder
+	w.writeText(&b, it.text)
+	return b.String()
+}
+
+func (w *lineWriter) writeText(b *strings.Builder, nodes []*convertNode) {
+	for _, n := range nodes {
+		switch n.kind {
+		case cvText:
+			if w.slim {
+				b.WriteString(n.text)
+			} else {
+				b.WriteString(strings.ReplaceAll(n.text, "#{", `\#{`))
+			}
+		case cvSpace:
+			b.WriteString(n.text)
+		case cvOutput:
+			b.WriteString("#{" + n.text + "}")
+		case cvElement:
+			w.writeRawElement(b, n)
+		}
+	}
+}
+
+// writeRawElement writes an element as HTML.
+func (w *lineWriter) writeRawElement(b *strings.Builder, n *convertNode) {
+	b.WriteString("<" + n.name)
+	for _, a := range n.attrs {
+		switch a.kind {
+		case attrBare:
+			b.WriteString(" " + a.name)
+		case attrStatic:
+			b.WriteString(" " + a.name + `="` + strings.ReplaceAll(html.EscapeString(a.value), "#{", "&#35;{") + `"`)
+		case attrDynamic:
+			b.WriteString(" " + a.name + `="#{` + a.value + `}"`)
+		case attrClass:
+			var names []string
+			for _, c := range a.classes {
+				names = append(names, strings.Fields(c.name)...)
+			}
+			b.WriteString(` class="` + html.EscapeString(strings.Join(names, " ")) + `"`)
+		}
+	}
+	if n.selfClosing && !slices.Contains(selfClosedTags, strings.ToLower(n.name)) {
+		b.WriteString("/")
+	}
+	b.WriteString(">")
+	if n.selfClosing {
+		return
+	}
+	w.writeText(b, n.children)
+	b.WriteString("</" + n.name + ">")
+}
+
+// hamlText returns the text as Haml text, escaping the first character when
+// it would start something other than text.
+func hamlText(text string) string {
+	if text != "" && strings.ContainsRune("%#.\\!-=/:{", rune(text[0])) {
+		return "\\" + text
+	}
+	return text
+}
+
+func (w *lineWriter) element(it *lineItem, depth int) {
+	n := it.node
+	head := w.head(n)
+	if n.selfClosing && !slices.Contains(selfClosedTags, strings.ToLower(n.name)) {
+		head += "/"
+	}
+	text := firstItem(it.items)
+	if it.inline && !w.slim && text != nil && text.unescaped() && (text.output() == nil || it.trimOuter) {
+		// Haml has no markers before an unescaped line, so the line is
+		// nested with its whitespace removed
+		it.inline, it.trimInner = false, true
+	}
+	head += w.markers(it)
+	if !it.inline || len(it.items) == 0 {
+		w.line(depth, head)
+		w.lines(it.items, depth+1)
+		return
+	}
+
+	if out := text.output(); out != nil {
+		w.line(depth, head+w.outputLine(out)+" "+out.text)
+		return
+	}
+	s := w.textLine(text)
+	if text.unescaped() {
+		s = "! " + s
+	} else {
+		s = " " + s
+	}
+	w.line(depth, head+s)
+}
+
+func firstItem(items []*lineItem) *lineItem {
+	if len(items) == 0 {
+		return nil
+	}
+	return items[0]
+}
+
+// markers returns the whitespace markers of the element.
+func (w *lineWriter) markers(it *lineItem) string {
+	var s string
+	if w.slim {
+		if it.spaceBefore {
+			s += "<"
+		}
+		if it.spaceAfter {
+			s += ">"
+		}
+		return s
+	}
+	if it.trimOuter {
+		s += ">"
+	}
+	if it.trimInner {
+		s += "<"
+	}
+	return s
+}
+
+// head returns the tag of the element with its id, its classes and its
+// attributes.
+func (w *lineWriter) head(n *convertNode) string {
+	var id string
+	var classes, attrs []string
+	for i, a := range n.attrs {
+		name := a.name
+		if !reHTMLIdentifier.MatchString(name) {
+			name = strconv.Quote(name)
+		}
+		switch a.kind {
+		case attrStatic:
+			// the shorthand id is written before the classes and the
+			// attributes, so it is only used for an id that comes first
+			if a.name == "id" && i == 0 && reHTMLIdentifier.MatchString(a.value) {
+				id = a.value
+				continue
+			}
+			attrs = append(attrs, name+": "+strconv.Quote(a.value))
+		case attrBare:
+			attrs = append(attrs, name)
+		case attrDynamic:
+			attrs = append(attrs, name+": #{"+a.value+"}")
+		case attrCond:
+			attrs = append(attrs, name+"? #{"+a.value+"}")
+		case attrClass:
+			var names, codes []string
+			for _, c := range a.classes {
+				if c.code != "" {
+					codes = append(codes, c.code)
+					continue
+				}
+				for _, name := range strings.Fields(c.name) {
+					if reHTMLIdentifier.MatchString(name) {
+						classes = append(classes, name)
+					} else {
+						names = append(names, name)
+					}
+				}
+			}
+			switch {
+			case len(names) > 0:
+				attrs = append(attrs, "class: "+strconv.Quote(strings.Join(names, " ")))
+			case len(codes) > 0:
+				attrs = append(attrs, "class: #{"+strings.Join(codes, ", ")+"}")
+			}
+		}
+	}
+	if n.attributesCmd != "" {
+		attrs = append(attrs, "@attributes: #{"+n.attributesCmd+"}")
+	}
+
+	var b strings.Builder
+	if n.name != "div" || id == "" && len(classes) == 0 {
+		if !w.slim {
+			b.WriteString("%")
+		}
+		b.WriteString(n.name)
+	}
+	if id != "" {
+		b.WriteString("#" + id)
+	}
+	for _, c := range classes {
+		b.WriteString("." + c)
+	}
+	if n.objectRef != nil {
+		if w.slim {
+			w.c.errorf(*n.objectRef, "object references can't be written in Slim")
+		}
+		b.WriteString("[" + n.objectRef.lit + "]")
+	}
+	if len(attrs) > 0 {
+		b.WriteString("{" + strings.Join(attrs, ", ") + "}")
+	}
+	return b.String()
+}
+
+// script writes a script or a style as a filter.
+func (w *lineWriter) script(n *convertNode, depth int) {
+	filter := ":javascript"
+	if n.name == "style" {
+		filter = ":css"
+	}
+	w.line(depth, filter)
+
+	var b strings.Builder
+	w.writeText(&b, n.children)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	indent := ""
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		lines[i] = line
+		if i == 0 || line == "" {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if indent == "" || len(lead) < len(indent) {
+			indent = lead
+		}
+	}
+	for i, line := range lines {
+		if i > 0 {
+			line = strings.TrimPrefix(line, indent)
+		}
+		lines[i] = "\t" + line
+	}
+	w.rawLines(lines, depth)
+}
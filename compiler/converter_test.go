@@ -0,0 +1,319 @@
+package compiler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func Test_Convert(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		dialect string
+		want    string
+		wantErr bool
+	}{
+		"haml to slim": {
+			input:   "package test\n\n// Page is a page.\n@haml Page(title string) {\n\t-# a comment\n\t%body#main.page{lang: \"en\"}<\n\t\t%h1>= title\n\t\t%p.a> Hello #{title}\n\t\t%br\n\t\t- if title != \"\"\n\t\t\t%p>= title\n\t\t- else\n\t\t\t%p>< none\n}\n",
+			dialect: "slim",
+			want:    "package test\n\n// Page is a page.\n@slim Page(title string) {\n\t/ a comment\n\tbody#main.page{lang: \"en\"}\n\t\th1= title\n\t\tp.a Hello #{title}\n\t\tbr\n\t\t- if title != \"\"\n\t\t\tp= title\n\t\t- else\n\t\t\tp none\n}\n",
+		},
+		"slim to haml": {
+			input:   "@slim Page(title string) {\n\t/ a comment\n\tbody[lang=\"en\"]\n\t\tul: li: a href=\"/\" Home\n\t\tp\n\t\t\t| Hello #{title}\n\t\t\t\t and more\n\t\t\t| = not code\n\t\tp= title\n}\n",
+			dialect: "haml",
+			want:    "@haml Page(title string) {\n\t-# a comment\n\t%body{lang: \"en\"}\n\t\t%ul>\n\t\t\t%li>\n\t\t\t\t%a{href: \"/\"}> Home\n\t\t%p> Hello #{title}and more= not code\n\t\t%p>= title\n}\n",
+		},
+		"haml to ego": {
+			input:   "@haml Page(title string, on bool) {\n\t-# a comment\n\t%ul#list.a{class: #{title}, disabled? #{on}, href: #{title}}\n\t\t- for i := range 2\n\t\t\t%li= i\n\t%p<\n\t\t%span a & b <%\n\t= @render Other()\n\t%p after\n}\n",
+			dialect: "ego",
+			want:    "@ego Page(title string, on bool) {\n\t<%# a comment $%>\n\t<ul id=\"list\" class=\"<%! goht.CaptureErrors(goht.BuildClassList(\"a\", title)) %>\"<% if on { %> disabled<% } %> href=\"<%= title %>\">\n\t<% for i := range 2 { $%>\n\t<li><%= i %></li>\n\t<% } $%>\n\t</ul>\n\t<p><span>a & b <%%</span></p>\n\t<%@render Other() %><p>after</p>\n}\n",
+		},
+		"slim to ego": {
+			input:   "@slim Page(title string) {\n\tp.a Hi\n\tp= title\n}\n",
+			dialect: "ego",
+			want:    "@ego Page(title string) {\n\t<p class=\"a\">Hi</p><p><%= title %></p>\n}\n",
+		},
+		"markdown to ego": {
+			input:   "@haml Page(title string) {\n\t%div\n\t\t:markdown\n\t\t\t# #{title}\n\n\t\t\tSome *text*.\n\t%p after\n}\n",
+			dialect: "ego",
+			want:    "@ego Page(title string) {\n\t<div>\n\t<h1><%= title %></h1>\n\t<p>Some <em>text</em>.</p>\n\t</div>\n\t<p>after</p>\n}\n",
+		},
+		"json to ego": {
+			input:   "@haml Page(data map[string]int) {\n\t= @json(data)\n\t%p\n}\n",
+			dialect: "ego",
+			want:    "@ego Page(data map[string]int) {\n\t<%@json data %><p></p>\n}\n",
+		},
+		"same dialect": {
+			input:   "@goht Page() {\n\t%p hi\n}\n\n@slim Other() {\n\tp hi\n}\n",
+			dialect: "haml",
+			want:    "@goht Page() {\n\t%p hi\n}\n\n@haml Other() {\n\t%p hi\n}\n",
+		},
+		"ego to haml": {
+			input:   "@ego Page(items []string) {\n\t<ul>\n\t<% for _, item := range items { $%>\n\t<li><%= item %></li>\n\t<% } $%>\n\t</ul>\n\t<p>Hi <b>there</b></p>\n}\n",
+			dialect: "haml",
+			want:    "@haml Page(items []string) {\n\t%ul\n\t\t- for _, item := range items\n\t\t\t%li= item\n\t%p Hi <b>there</b>\n}\n",
+		},
+		"ego to slim": {
+			input:   "@ego Page(items []string) {\n\t<ul><% for _, item := range items { %><li><%= item %></li><% } %></ul><p>Hi <b>there</b></p>\n}\n",
+			dialect: "slim",
+			want:    "@slim Page(items []string) {\n\tul\n\t\t- for _, item := range items\n\t\t\tli= item\n\tp\n\t\t| Hi\n\t\tb< there\n}\n",
+		},
+		"attribute order to haml": {
+			input:   "@ego Page() {\n\t<p id=\"a\" class=\"b\">x</p>\n\t<p class=\"b\" id=\"a\">x</p>\n\t<p title=\"t\" class=\"b\">x</p>\n}\n",
+			dialect: "haml",
+			want:    "@haml Page() {\n\t%p#a.b x\n\t%p.b{id: \"a\"} x\n\t<p title=\"t\" class=\"b\">x</p>\n}\n",
+		},
+		"fragment to haml": {
+			input:   "@slim Page() {\n\tdiv\n\t\t= @fragment list\n\t\t\tul\n\t\t\t\tli x\n\t\tp y\n}\n",
+			dialect: "haml",
+			want:    "@haml Page() {\n\t%div\n\t\t= @fragment list\n\t\t\t%ul>\n\t\t\t\t%li> x\n\t\t%p> y\n}\n",
+		},
+		"empty template": {
+			input:   "@goht Empty() {}\n",
+			dialect: "ego",
+			want:    "@ego Empty() {}\n",
+		},
+		"code without a statement to ego": {
+			input:   "@haml Page() {\n\t%p a\n\t-\n\t%p b\n}\n",
+			dialect: "ego",
+			want:    "@ego Page() {\n\t<p>a</p>\n\t<% %><p>b</p>\n}\n",
+		},
+		"parse errors": {
+			input:   "@haml Page() {\n\t%p{id: bar}\n}\n",
+			dialect: "slim",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Convert([]byte(tt.input), tt.dialect)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(ErrorList); !ok {
+					t.Errorf("Convert() error = %T, want an ErrorList", err)
+				}
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Convert() = %q, want %q", got, tt.want)
+			}
+			if _, err := parseBytes(got); err != nil {
+				t.Errorf("Convert() result does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ConvertErrors(t *testing.T) {
+	input := "@haml Page() {\n\t%p[obj] hi\n\t%p after\n}\n\n@haml Other() {\n\t%p hi\n}\n"
+	got, err := Convert([]byte(input), "slim")
+	errList, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("Convert() error = %v, want an ErrorList", err)
+	}
+	want := []PositionalError{
+		{Line: 2, Column: 5, Code: ErrConversion},
+	}
+	if len(errList) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errList), len(want), errList)
+	}
+	for i, e := range errList {
+		if e.Line != want[i].Line || e.Column != want[i].Column || e.Code != want[i].Code {
+			t.Errorf("error %d = %s %d:%d, want %s %d:%d", i, e.Code, e.Line, e.Column, want[i].Code, want[i].Line, want[i].Column)
+		}
+		if e.Source == "" {
+			t.Errorf("error %d has no source line", i)
+		}
+	}
+	// the templates that can be converted are converted, and the others are
+	// left as they are
+	wantOutput := "@haml Page() {\n\t%p[obj] hi\n\t%p after\n}\n\n@slim Other() {\n\tp hi\n}\n"
+	if string(got) != wantOutput {
+		t.Errorf("Convert() = %q, want %q", got, wantOutput)
+	}
+
+	if _, err := Convert([]byte(input), "pug"); err == nil {
+		t.Error("Convert() error = nil, want an unknown dialect error")
+	}
+}
+
+// Test_ConvertWhitespaceErrors checks that the templates with whitespace
+// that the dialect can't write are left as they are.
+func Test_ConvertWhitespaceErrors(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		dialect   string
+		line, col int
+	}{
+		"newlines to slim": {
+			input:   "@haml Page(title string) {\n\t%body\n\t\t%h1= title\n\t\t%p hi\n}\n",
+			dialect: "slim",
+			line:    2,
+			col:     3,
+		},
+		"doctype to haml": {
+			input:   "@slim Page() {\n\tdoctype html\n\thtml\n}\n",
+			dialect: "haml",
+			line:    2,
+			col:     10,
+		},
+		"indented ego to haml": {
+			input:   "@ego Page() {\n\t<ul>\n\t\t<li>a</li>\n\t</ul>\n}\n",
+			dialect: "haml",
+			line:    2,
+			col:     6,
+		},
+		"end of the template to ego": {
+			input:   "@haml Page(data map[string]int) {\n\t= @json(data)\n}\n",
+			dialect: "ego",
+			line:    2,
+			col:     9,
+		},
+		"removed around an unescaped output": {
+			input:   "@slim Page(title string) {\n\tp== title\n\tp a\n}\n",
+			dialect: "haml",
+			line:    2,
+			col:     2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Convert([]byte(tt.input), tt.dialect)
+			errList, ok := err.(ErrorList)
+			if !ok || len(errList) != 1 {
+				t.Fatalf("Convert() error = %v, want one error", err)
+			}
+			if e := errList[0]; e.Line != tt.line || e.Column != tt.col || e.Code != ErrConversion {
+				t.Errorf("error = %s %d:%d, want %s %d:%d", e.Code, e.Line, e.Column, ErrConversion, tt.line, tt.col)
+			}
+			if string(got) != tt.input {
+				t.Errorf("Convert() = %q, want the template as it is", got)
+			}
+		})
+	}
+}
+
+// Test_ConvertTestdata converts the templates of testdata/convert to each
+// dialect; the converted templates are rendered by TestRenderConverted. The
+// templates with whitespace that a dialect can't write are left as they are.
+func Test_ConvertTestdata(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "convert", "convert.goht"))
+	if err != nil {
+		t.Fatalf("error reading the templates: %v", err)
+	}
+	// wantErrLines are the lines of the conversion errors of each dialect
+	wantErrLines := map[string][]int{
+		"haml": {79},
+		"slim": {8, 13, 79, 101},
+		"ego":  {9},
+	}
+	for _, dialect := range []string{"haml", "slim", "ego"} {
+		t.Run(dialect, func(t *testing.T) {
+			converted, err := Convert(input, dialect)
+			var errLines []int
+			if err != nil {
+				errList, ok := err.(ErrorList)
+				if !ok {
+					t.Fatalf("Convert() error = %v, want an ErrorList", err)
+				}
+				for _, e := range errList {
+					if e.Code != ErrConversion {
+						t.Errorf("Convert() error = %v, want a conversion error", e)
+					}
+					errLines = append(errLines, e.Line)
+				}
+			}
+			if !slices.Equal(errLines, wantErrLines[dialect]) {
+				t.Errorf("Convert() error = %v, want errors on the lines %v", err, wantErrLines[dialect])
+			}
+			got := bytes.Replace(converted, []byte("package convert\n"), []byte("package "+dialect+"\n"), 1)
+			goldenFileName := filepath.Join("testdata", "convert", dialect, "convert.goht")
+			want, err := goldenFile(t, goldenFileName, got, *update)
+			if err != nil {
+				t.Fatalf("error reading golden file: %v", err)
+			}
+			if !bytes.Equal(want, got) {
+				t.Errorf("Convert() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// Test_ConvertExamples converts the templates of the examples to each dialect
+// and runs the examples tests against the converted templates. The generated
+// code replaces the code of the examples with a build overlay, so the tests
+// check the converted templates against the same golden files.
+func Test_ConvertExamples(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the examples tests in short mode")
+	}
+	examples, err := filepath.Abs(filepath.Join("..", "examples"))
+	if err != nil {
+		t.Fatalf("error finding the examples: %v", err)
+	}
+	for _, dialect := range []string{"haml", "slim"} {
+		t.Run(dialect, func(t *testing.T) {
+			dir := t.TempDir()
+			overlay := map[string]string{}
+			err := filepath.WalkDir(examples, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() || filepath.Ext(path) != ".goht" {
+					return err
+				}
+				input, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				// templates with whitespace that the dialect can't write are
+				// left as they are, and the rest are converted
+				converted, err := Convert(input, dialect)
+				if err != nil {
+					var errList ErrorList
+					if !errors.As(err, &errList) || errList[0].Code != ErrConversion {
+						return fmt.Errorf("%s: %w", path, err)
+					}
+				}
+				tpl, err := ParseString(string(converted))
+				if err != nil {
+					return fmt.Errorf("%s: %w\n%s", path, err, converted)
+				}
+				tpl.Filename = filepath.Base(path)
+				var buf bytes.Buffer
+				if err = tpl.Generate(&buf); err != nil {
+					return fmt.Errorf("%s: %w\n%s", path, err, converted)
+				}
+				generated := filepath.Join(dir, strconv.Itoa(len(overlay))+".go")
+				overlay[path+".go"] = generated
+				return os.WriteFile(generated, buf.Bytes(), 0644)
+			})
+			if err != nil {
+				t.Fatalf("error converting the examples: %v", err)
+			}
+			overlayFile := filepath.Join(dir, "overlay.json")
+			data, err := json.Marshal(map[string]any{"Replace": overlay})
+			if err != nil {
+				t.Fatalf("error writing the overlay: %v", err)
+			}
+			if err = os.WriteFile(overlayFile, data, 0644); err != nil {
+				t.Fatalf("error writing the overlay: %v", err)
+			}
+			cmd := exec.Command("go", "test", "-count=1", "-overlay", overlayFile, ".")
+			cmd.Dir = examples
+			cmd.Env = append(os.Environ(), "GOHTTEST_UPDATE=")
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("the converted examples fail: %v\n%s", err, out)
+			}
+		})
+	}
+}
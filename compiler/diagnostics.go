@@ -0,0 +1,239 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCode is the stable code of a template error. Codes are never reused,
+// so they can be searched for and linked to.
+type ErrorCode string
+
+const (
+	ErrUnexpectedCharacter ErrorCode = "GOHT001"
+	ErrIndentSpaces        ErrorCode = "GOHT002"
+	ErrIndentTooDeep       ErrorCode = "GOHT003"
+	ErrIndentMissing       ErrorCode = "GOHT004"
+	ErrNotClosed           ErrorCode = "GOHT005"
+	ErrExpected            ErrorCode = "GOHT006"
+	ErrUnknownTemplate     ErrorCode = "GOHT007"
+	ErrUnknownCommand      ErrorCode = "GOHT008"
+	ErrCommandArguments    ErrorCode = "GOHT009"
+	ErrUnknownFilter       ErrorCode = "GOHT010"
+	ErrIllegalNesting      ErrorCode = "GOHT011"
+	ErrIncomplete          ErrorCode = "GOHT012"
+	ErrFormat              ErrorCode = "GOHT013"
+	ErrAttribute           ErrorCode = "GOHT014"
+	ErrExtends             ErrorCode = "GOHT015"
+	ErrUnexpectedToken     ErrorCode = "GOHT016"
+	ErrConversion          ErrorCode = "GOHT017"
+	ErrFilter              ErrorCode = "GOHT018"
+	ErrContext             ErrorCode = "GOHT019"
+)
+
+// ErrorCodeURL is the page that describes each of the error codes.
+const ErrorCodeURL = "https://github.com/stackus/goht/blob/main/docs/errors.md"
+
+type errorCodeInfo struct {
+	summary string
+	hint    string
+}
+
+var errorCodes = map[ErrorCode]errorCodeInfo{
+	ErrUnexpectedCharacter: {
+		summary: "unexpected character",
+		hint:    "remove the character, or quote the value or text that it is a part of",
+	},
+	ErrIndentSpaces: {
+		summary: "indented with spaces",
+		hint:    "indent the line with tabs instead of spaces",
+	},
+	ErrIndentTooDeep: {
+		summary: "indented too deep",
+		hint:    "indent the line one tab deeper than its parent at most",
+	},
+	ErrIndentMissing: {
+		summary: "template not indented",
+		hint:    "indent the content of the template with one tab",
+	},
+	ErrNotClosed: {
+		summary: "not closed",
+		hint:    "add the closing character before the end of the line or file",
+	},
+	ErrExpected: {
+		summary: "expected value missing",
+		hint:    "add the missing name or value after the command",
+	},
+	ErrUnknownTemplate: {
+		summary: "unknown template type",
+		hint:    "start the template with @goht, @haml, @slim or @ego",
+	},
+	ErrUnknownCommand: {
+		summary: "unknown command",
+		hint:    "check the spelling of the command; see the list of template directives",
+	},
+	ErrCommandArguments: {
+		summary: "unexpected command arguments",
+		hint:    "remove the arguments from the command",
+	},
+	ErrUnknownFilter: {
+		summary: "unknown filter",
+		hint:    "use one of the :javascript, :css, :plain, :escaped, :preserve or :markdown filters, or register the filter",
+	},
+	ErrIllegalNesting: {
+		summary: "illegal nesting",
+		hint:    "move the content on the same line to its own nested line, or remove the nested lines",
+	},
+	ErrIncomplete: {
+		summary: "incomplete template",
+		hint:    "close the template with a } on its own line",
+	},
+	ErrFormat: {
+		summary: "invalid format",
+		hint:    "use @format html5, html4, xhtml or xml as the first line of the template",
+	},
+	ErrAttribute: {
+		summary: "invalid attribute",
+		hint:    "give the attribute a quoted value or a #{} value, and only nest data and aria attributes",
+	},
+	ErrExtends: {
+		summary: "invalid template inheritance",
+		hint:    "extend a single template with one @extends command at the top level, and move other content into a block",
+	},
+	ErrUnexpectedToken: {
+		summary: "unexpected content",
+		hint:    "check the lines before this one for content that was not closed",
+	},
+	ErrConversion: {
+		summary: "cannot be converted",
+		hint:    "rewrite the content with a feature of the target dialect, or convert the template by hand",
+	},
+	ErrFilter: {
+		summary: "filter failed",
+		hint:    "check the body of the filter; the output of the filter must keep the interpolated values",
+	},
+	ErrContext: {
+		summary: "cannot be written here",
+		hint:    "move the command into the content of an element or a script element, or end the tag or attribute name with the dynamic value",
+	},
+}
+
+// Summary returns a short description of the error code.
+func (c ErrorCode) Summary() string {
+	return errorCodes[c].summary
+}
+
+// Hint returns a suggested fix for errors with the code.
+func (c ErrorCode) Hint() string {
+	return errorCodes[c].hint
+}
+
+// URL returns the link to the description of the error code.
+func (c ErrorCode) URL() string {
+	return ErrorCodeURL + "#" + strings.ToLower(string(c))
+}
+
+// Severity is the severity of a diagnostic. The values match the severities
+// of the Language Server Protocol.
+type Severity int
+
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInformation:
+		return "info"
+	case SeverityHint:
+		return "hint"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic describes a problem in a template file with the source that
+// caused it and a suggested fix.
+//
+// Lines and columns are one-based, and the end position is just after the end
+// of the source that caused the problem.
+type Diagnostic struct {
+	Code      ErrorCode
+	Severity  Severity
+	Message   string
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	// Source is the line of the template file that has the problem.
+	Source string
+	Hint   string
+}
+
+// String renders the diagnostic with its source line and a caret under the
+// source that caused the problem.
+func (d Diagnostic) String() string {
+	return d.Format("")
+}
+
+// Format renders the diagnostic like String, with the position prefixed by the
+// file name.
+func (d Diagnostic) Format(fileName string) string {
+	var b strings.Builder
+
+	b.WriteString(d.Severity.String())
+	if d.Code != "" {
+		fmt.Fprintf(&b, "[%s]", d.Code)
+	}
+	fmt.Fprintf(&b, ": %s\n", d.Message)
+
+	position := fmt.Sprintf("%d:%d", d.Line, d.Column)
+	if fileName != "" {
+		position = fileName + ":" + position
+	}
+	gutter := strings.Repeat(" ", len(fmt.Sprint(d.Line)))
+	fmt.Fprintf(&b, "%s--> %s\n", gutter, position)
+
+	if d.Source != "" {
+		fmt.Fprintf(&b, "%s |\n", gutter)
+		fmt.Fprintf(&b, "%d | %s\n", d.Line, d.Source)
+		fmt.Fprintf(&b, "%s | %s%s\n", gutter, d.caretIndent(), strings.Repeat("^", d.caretWidth()))
+	}
+	if d.Hint != "" {
+		fmt.Fprintf(&b, "%s = hint: %s\n", gutter, d.Hint)
+	}
+
+	return b.String()
+}
+
+// caretIndent returns the text that puts the caret under the start column.
+// Tabs in the source are kept so the caret lines up in any terminal.
+func (d Diagnostic) caretIndent() string {
+	runes := []rune(d.Source)
+	var b strings.Builder
+	for i := 0; i < d.Column-1 && i < len(runes); i++ {
+		if runes[i] == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
+
+// caretWidth returns the number of carets to write, which stops at the end of
+// the source line when the problem covers several lines.
+func (d Diagnostic) caretWidth() int {
+	width := d.EndColumn - d.Column
+	if d.EndLine > d.Line {
+		width = len([]rune(d.Source)) - d.Column + 1
+	}
+	return max(width, 1)
+}
@@ -0,0 +1,111 @@
+package compiler
+
+import (
+	"testing"
+)
+
+func Test_Diagnostic_Format(t *testing.T) {
+	tests := map[string]struct {
+		diagnostic Diagnostic
+		fileName   string
+		want       string
+	}{
+		"with source": {
+			diagnostic: Diagnostic{
+				Code:      ErrUnknownFilter,
+				Severity:  SeverityError,
+				Message:   "unknown filter: foo",
+				Line:      5,
+				Column:    3,
+				EndLine:   5,
+				EndColumn: 6,
+				Source:    "\t:foo",
+				Hint:      "use a supported filter",
+			},
+			fileName: "page.goht",
+			want: "error[GOHT010]: unknown filter: foo\n" +
+				" --> page.goht:5:3\n" +
+				"  |\n" +
+				"5 | \t:foo\n" +
+				"  | \t ^^^\n" +
+				"  = hint: use a supported filter\n",
+		},
+		"without source": {
+			diagnostic: Diagnostic{
+				Severity: SeverityWarning,
+				Message:  "something is off",
+				Line:     12,
+				Column:   1,
+			},
+			want: "warning: something is off\n" +
+				"  --> 12:1\n",
+		},
+		"across lines": {
+			diagnostic: Diagnostic{
+				Code:      ErrNotClosed,
+				Severity:  SeverityError,
+				Message:   "attributes not closed: eof",
+				Line:      1,
+				Column:    3,
+				EndLine:   3,
+				EndColumn: 1,
+				Source:    "%p{id: 1,",
+			},
+			want: "error[GOHT005]: attributes not closed: eof\n" +
+				" --> 1:3\n" +
+				"  |\n" +
+				"1 | %p{id: 1,\n" +
+				"  |   ^^^^^^^\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.diagnostic.Format(tt.fileName); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ErrorList_Diagnostics(t *testing.T) {
+	input := "@haml test() {\n\t%p ok\n\t:unknown\n}\n"
+	_, err := ParseString(input)
+	errList, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("ParseString() error = %v, want an ErrorList", err)
+	}
+	got := errList.Diagnostics()
+	want := []Diagnostic{
+		{
+			Code:      ErrUnknownFilter,
+			Severity:  SeverityError,
+			Message:   "unknown filter: unknown",
+			Line:      3,
+			Column:    3,
+			EndLine:   3,
+			EndColumn: 10,
+			Source:    "\t:unknown",
+			Hint:      ErrUnknownFilter.Hint(),
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diagnostics() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Diagnostics()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func Test_ErrorCode(t *testing.T) {
+	for code := range errorCodes {
+		if code.Summary() == "" || code.Hint() == "" {
+			t.Errorf("error code %s is missing a summary or a hint", code)
+		}
+	}
+	if got, want := ErrIndentSpaces.URL(), ErrorCodeURL+"#goht002"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
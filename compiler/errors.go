@@ -2,11 +2,21 @@ package compiler
 
 import (
 	"fmt"
+	"strings"
 )
 
 type PositionalError struct {
 	Line   int
 	Column int
+	// EndLine and EndColumn are the position just after the end of the
+	// source that caused the error. They are zero when the error only has a
+	// start position.
+	EndLine   int
+	EndColumn int
+	// Code is the stable code of the error.
+	Code ErrorCode
+	// Source is the line of the template file that has the error.
+	Source string
 	Err    error
 }
 
@@ -21,3 +31,61 @@ func (e PositionalError) Position() string {
 func (e PositionalError) Unwrap() error {
 	return e.Err
 }
+
+// Diagnostic returns the error as a diagnostic with the hint of its code.
+func (e PositionalError) Diagnostic() Diagnostic {
+	return Diagnostic{
+		Code:      e.Code,
+		Severity:  SeverityError,
+		Message:   e.Err.Error(),
+		Line:      e.Line,
+		Column:    e.Column,
+		EndLine:   e.EndLine,
+		EndColumn: e.EndColumn,
+		Source:    e.Source,
+		Hint:      e.Code.Hint(),
+	}
+}
+
+// ErrorList is the list of errors that were found while parsing a template
+// file, in the order that they were found.
+type ErrorList []PositionalError
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	errs := make([]string, len(l))
+	for i, err := range l {
+		errs[i] = err.Error()
+	}
+	return strings.Join(errs, "\n")
+}
+
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Diagnostics returns the errors of the list as diagnostics.
+func (l ErrorList) Diagnostics() []Diagnostic {
+	diagnostics := make([]Diagnostic, len(l))
+	for i, err := range l {
+		diagnostics[i] = err.Diagnostic()
+	}
+	return diagnostics
+}
+
+// Err returns the list as an error, or nil when the list is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
@@ -0,0 +1,581 @@
+package compiler
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/stackus/goht"
+)
+
+// escapeContext is where in the rendered document a dynamic value is written.
+type escapeContext struct {
+	kind   goht.EscapeContext
+	inAttr bool
+	// partial is true when the value follows other content in a URL attribute
+	partial bool
+	// inString is true when the value is written inside a quoted JavaScript string
+	inString bool
+	// unquoted is true when the value is written into an attribute value
+	// without quotes
+	unquoted bool
+	// inTagName and inAttrName are true when the value is written at the end
+	// of the name of a tag or an attribute, after the static namePrefix
+	inTagName  bool
+	inAttrName bool
+	namePrefix string
+	// err is set when the value can't be escaped where it is written
+	err string
+}
+
+// attributeContext returns the context for the value of the named attribute.
+func attributeContext(name string) escapeContext {
+	return escapeContext{
+		kind:   goht.AttributeContext(name),
+		inAttr: true,
+	}
+}
+
+// escapers returns the functions, outermost first, that a dynamic value must
+// be passed through before it is written into the context.
+func (c escapeContext) escapers() []string {
+	switch {
+	case c.inTagName:
+		return []string{"goht.EscapeTagName"}
+	case c.inAttrName:
+		return []string{"goht.EscapeAttrName"}
+	}
+	var escapers []string
+	switch {
+	case c.inAttr && c.unquoted:
+		escapers = append(escapers, "goht.EscapeUnquotedAttr")
+	case c.inAttr:
+		escapers = append(escapers, "goht.EscapeAttr")
+	case c.kind == goht.HTMLContext:
+		escapers = append(escapers, "goht.EscapeString")
+	}
+	switch c.kind {
+	case goht.URLContext:
+		if c.partial {
+			escapers = append(escapers, "goht.EscapeURLPart")
+		} else {
+			escapers = append(escapers, "goht.EscapeURL")
+		}
+	case goht.JSContext:
+		if c.inString {
+			escapers = append(escapers, "goht.EscapeJSString")
+		} else {
+			escapers = append(escapers, "goht.EscapeJS")
+		}
+	case goht.CSSContext:
+		escapers = append(escapers, "goht.EscapeCSS")
+	}
+	return escapers
+}
+
+// writeEscaped writes the value of the token passed through the escapers of the context.
+func writeEscaped(tw *templateWriter, c escapeContext, t token) error {
+	if c.err != "" {
+		return errorAt(t, ErrContext, "%s", c.err)
+	}
+	var escapers []string
+	if !tw.isUnescaped {
+		escapers = c.escapers()
+	}
+	for _, escaper := range escapers {
+		if _, err := tw.Write(escaper + "("); err != nil {
+			return err
+		}
+	}
+	if len(escapers) > 0 && (c.inTagName || c.inAttrName) {
+		// the name escapers check the value together with the start of the name
+		if _, err := tw.Write(strconv.Quote(c.namePrefix) + ", "); err != nil {
+			return err
+		}
+	}
+	if err := writeFormattedText(tw, t); err != nil {
+		return err
+	}
+	if len(escapers) > 0 {
+		if _, err := tw.Write(strings.Repeat(")", len(escapers))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type scanState int
+
+const (
+	stateText scanState = iota
+	stateTagOpen
+	stateTagName
+	stateEndTag
+	stateMarkup
+	stateComment
+	stateTag
+	stateAttrName
+	stateAfterAttrName
+	stateBeforeValue
+	stateAttrValue
+	stateScript
+	stateStyle
+)
+
+// contextScanner follows the HTML that a template writes to determine the
+// context of each dynamic value.
+//
+// Only the static content of the template is scanned; dynamic values are
+// assumed to have been escaped and will not change the context.
+type contextScanner struct {
+	state    scanState
+	tag      string
+	attr     string
+	quote    byte
+	valueLen int
+	// jsQuote is the quote of the JavaScript string that is open
+	jsQuote byte
+	// jsComment is '/' in a line comment, or '*' in a block comment
+	jsComment byte
+	jsEscape  bool
+	// jsRegexp is true inside of a regular expression literal, and jsClass
+	// inside of a character class of the regular expression
+	jsRegexp bool
+	jsClass  bool
+	// jsDivOp is true when a '/' is a division and not the start of a
+	// regular expression; jsLast and jsWord are the last byte and the last
+	// identifier or keyword of the code that decide it
+	jsDivOp bool
+	jsLast  byte
+	jsWord  string
+	// jsBraces are the open braces of each ${} substitution of a template
+	// literal
+	jsBraces []int
+	// name is the context of the dynamic value at the end of the current tag
+	// or attribute name
+	name *escapeContext
+}
+
+// scanContexts sets the escape context of each dynamic value in the nodes.
+func scanContexts(nodes []nodeBase) {
+	s := &contextScanner{}
+	s.walk(nodes, false)
+}
+
+func (s *contextScanner) walk(nodes []nodeBase, isUnescaped bool) {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *TextNode:
+			if n.isDynamic {
+				n.context = s.context()
+				s.dynamic(&n.context)
+				continue
+			}
+			if n.isPlain || n.isPreserve || isUnescaped {
+				s.scan(n.text)
+			} else {
+				s.scan(html.EscapeString(n.text))
+			}
+		case *RawTextNode:
+			s.scan(n.text)
+		case *ScriptNode:
+			n.context = s.context()
+			s.dynamic(&n.context)
+		case *JsonCommandNode:
+			n.context = s.context()
+			if n.context.kind == goht.JSContext {
+				s.dynamic(&n.context)
+			}
+		case *NewLineNode:
+			s.scan("\n")
+		case *UnescapeNode:
+			s.walk(n.children, true)
+		case *TextFilterNode:
+			s.walk(n.children, isUnescaped || n.isUnescaped)
+		case *ElementNode:
+			s.element(n.tag, n.children, isUnescaped)
+		case *JavaScriptFilterNode:
+			s.element("script", n.children, true)
+		case *CssFilterNode:
+			s.element("style", n.children, true)
+		case *CustomFilterNode:
+			if n.filter.Transform != nil {
+				s.walk(n.parts, true)
+				continue
+			}
+			// the output of the filter is not known until the template is
+			// rendered; the values are escaped for where the filter is written
+			c := s.context()
+			for _, child := range n.children {
+				if t, ok := child.(*TextNode); ok && t.isDynamic {
+					t.context = c
+				}
+			}
+			s.dynamic(&c)
+		case *CommentNode:
+			outer := *s
+			s.state = stateComment
+			s.walk(n.children, isUnescaped)
+			*s = outer
+		default:
+			s.walk(n.Children(), isUnescaped)
+		}
+	}
+}
+
+// element scans the children of an element that is written by the compiler.
+func (s *contextScanner) element(tag string, children []nodeBase, isUnescaped bool) {
+	outer := *s
+	*s = contextScanner{}
+	s.enter(strings.ToLower(tag))
+	s.walk(children, isUnescaped)
+	*s = outer
+}
+
+// context returns the context at the current position.
+func (s *contextScanner) context() escapeContext {
+	switch s.state {
+	case stateTagOpen, stateTagName, stateEndTag:
+		return escapeContext{kind: goht.HTMLContext, inTagName: true, namePrefix: s.tag}
+	case stateTag, stateAfterAttrName:
+		return escapeContext{kind: goht.HTMLContext, inAttrName: true}
+	case stateAttrName:
+		return escapeContext{kind: goht.HTMLContext, inAttrName: true, namePrefix: s.attr}
+	case stateBeforeValue, stateAttrValue:
+		c := attributeContext(s.attr)
+		c.partial = s.valueLen > 0
+		c.inString = s.jsQuote != 0
+		c.unquoted = s.state == stateBeforeValue || s.quote == 0
+		if c.kind == goht.JSContext && s.jsRegexp {
+			c.err = errJSRegexp
+		}
+		return c
+	case stateScript:
+		c := escapeContext{kind: goht.JSContext, inString: s.jsQuote != 0}
+		if s.jsRegexp {
+			c.err = errJSRegexp
+		}
+		return c
+	case stateStyle:
+		return escapeContext{kind: goht.CSSContext}
+	}
+	return escapeContext{kind: goht.HTMLContext}
+}
+
+const (
+	errTagNameEnd  = "a dynamic value can only be written at the end of a tag name"
+	errAttrNameEnd = "a dynamic value can only be written at the end of an attribute name"
+	errJSRegexp    = "a dynamic value can't be written into a JavaScript regular expression"
+)
+
+// dynamic records that a dynamic value with the context c has been written at
+// the current position.
+//
+// A dynamic value in a tag or attribute name is checked together with the
+// static start of the name, so it must be the end of the name.
+func (s *contextScanner) dynamic(c *escapeContext) {
+	switch s.state {
+	case stateTagOpen, stateTagName:
+		if s.name != nil {
+			c.err = errTagNameEnd
+		}
+		// the value is filtered, so the tag is neither a script nor a style
+		// element
+		s.state = stateTagName
+		s.tag = ""
+		s.name = c
+	case stateTag, stateAfterAttrName:
+		s.state = stateAttrName
+		s.attr = ""
+		s.name = c
+	case stateAttrName:
+		if s.name != nil {
+			c.err = errAttrNameEnd
+		}
+		s.name = c
+	case stateBeforeValue:
+		s.state = stateAttrValue
+		s.quote = 0
+		s.valueLen = 1
+		s.jsValue()
+	case stateAttrValue:
+		s.valueLen++
+		s.jsValue()
+	case stateScript:
+		s.jsValue()
+	}
+}
+
+// jsValue records that a value has been written into JavaScript code. The
+// value is written as a string or as trusted code, so a '/' after it is a
+// division.
+func (s *contextScanner) jsValue() {
+	if s.jsQuote == 0 && !s.jsRegexp && s.jsComment == 0 {
+		s.jsDivOp = true
+		s.jsLast = '"'
+		s.jsWord = ""
+	}
+}
+
+// resetJS clears the JavaScript state at the start of a script or an
+// attribute value.
+func (s *contextScanner) resetJS() {
+	s.jsQuote, s.jsComment, s.jsEscape = 0, 0, false
+	s.jsRegexp, s.jsClass = false, false
+	s.jsDivOp, s.jsLast, s.jsWord = false, 0, ""
+	s.jsBraces = nil
+}
+
+// enter moves into the content of the tag.
+func (s *contextScanner) enter(tag string) {
+	s.resetJS()
+	switch tag {
+	case "script":
+		s.state = stateScript
+	case "style":
+		s.state = stateStyle
+	default:
+		s.state = stateText
+	}
+}
+
+func (s *contextScanner) scan(text string) {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch s.state {
+		case stateText:
+			if c == '<' {
+				s.state = stateTagOpen
+				s.tag = ""
+			}
+		case stateTagOpen:
+			switch {
+			case isLetter(rune(c)):
+				s.state = stateTagName
+				s.tag = string(c)
+			case c == '/':
+				s.state = stateEndTag
+				s.tag = ""
+			case c == '!':
+				s.state = stateMarkup
+				if strings.HasPrefix(text[i+1:], "--") {
+					s.state = stateComment
+					i += 2
+				}
+			default:
+				s.state = stateText
+			}
+		case stateTagName:
+			switch {
+			case c == '>':
+				s.name = nil
+				s.enter(strings.ToLower(s.tag))
+			case isSpace(c) || c == '/':
+				s.name = nil
+				s.state = stateTag
+			default:
+				if s.name != nil {
+					s.name.err = errTagNameEnd
+				}
+				s.tag += string(c)
+			}
+		case stateEndTag:
+			if c == '>' {
+				s.state = stateText
+			} else {
+				s.tag += string(c)
+			}
+		case stateMarkup:
+			if c == '>' {
+				s.state = stateText
+			}
+		case stateComment:
+			if strings.HasPrefix(text[i:], "-->") {
+				s.state = stateText
+				i += 2
+			}
+		case stateTag:
+			switch {
+			case c == '>':
+				s.enter(strings.ToLower(s.tag))
+			case isSpace(c) || c == '/':
+			default:
+				s.state = stateAttrName
+				s.attr = string(c)
+			}
+		case stateAttrName:
+			switch {
+			case c == '>':
+				s.name = nil
+				s.enter(strings.ToLower(s.tag))
+			case c == '=':
+				s.name = nil
+				s.state = stateBeforeValue
+				s.valueLen = 0
+			case isSpace(c) || c == '/':
+				s.name = nil
+				s.state = stateAfterAttrName
+			default:
+				if s.name != nil {
+					s.name.err = errAttrNameEnd
+				}
+				s.attr += string(c)
+			}
+		case stateAfterAttrName:
+			switch {
+			case c == '>':
+				s.enter(strings.ToLower(s.tag))
+			case c == '=':
+				s.state = stateBeforeValue
+				s.valueLen = 0
+			case isSpace(c) || c == '/':
+			default:
+				s.state = stateAttrName
+				s.attr = string(c)
+			}
+		case stateBeforeValue:
+			switch {
+			case c == '>':
+				s.enter(strings.ToLower(s.tag))
+			case c == '"' || c == '\'':
+				s.state = stateAttrValue
+				s.quote = c
+				s.valueLen = 0
+			case isSpace(c):
+			default:
+				s.state = stateAttrValue
+				s.quote = 0
+				s.valueLen = 1
+			}
+			s.resetJS()
+		case stateAttrValue:
+			switch {
+			case s.quote != 0 && c == s.quote, s.quote == 0 && isSpace(c):
+				s.state = stateTag
+			case s.quote == 0 && c == '>':
+				s.enter(strings.ToLower(s.tag))
+			default:
+				s.valueLen++
+				if goht.AttributeContext(s.attr) == goht.JSContext {
+					i += s.js(text, i)
+				}
+			}
+		case stateScript, stateStyle:
+			end := "</script"
+			if s.state == stateStyle {
+				end = "</style"
+			}
+			if s.jsQuote == 0 && len(text)-i >= len(end) && strings.EqualFold(text[i:i+len(end)], end) {
+				s.state = stateEndTag
+				i += len(end) - 1
+				continue
+			}
+			if s.state == stateScript {
+				i += s.js(text, i)
+			}
+		}
+	}
+}
+
+// js follows the JavaScript strings, template literals, regular expressions
+// and comments at text[i]; returning the number of additional bytes that were
+// consumed.
+//
+// Whether a '/' starts a regular expression or is a division is decided by
+// the code before it, the same way as html/template does.
+func (s *contextScanner) js(text string, i int) int {
+	c := text[i]
+	switch {
+	case s.jsEscape:
+		s.jsEscape = false
+	case s.jsComment == '/':
+		if c == '\n' {
+			s.jsComment = 0
+		}
+	case s.jsComment == '*':
+		if strings.HasPrefix(text[i:], "*/") {
+			s.jsComment = 0
+			return 1
+		}
+	case s.jsRegexp:
+		switch {
+		case c == '\\':
+			s.jsEscape = true
+		case c == '[':
+			s.jsClass = true
+		case c == ']':
+			s.jsClass = false
+		case c == '/' && !s.jsClass:
+			s.jsRegexp = false
+			s.jsDivOp, s.jsLast, s.jsWord = true, c, ""
+		}
+	case s.jsQuote != 0:
+		switch {
+		case c == '\\':
+			s.jsEscape = true
+		case c == s.jsQuote:
+			s.jsQuote = 0
+			s.jsDivOp, s.jsLast, s.jsWord = true, c, ""
+		case s.jsQuote == '`' && strings.HasPrefix(text[i:], "${"):
+			s.jsQuote = 0
+			s.jsBraces = append(s.jsBraces, 0)
+			s.jsDivOp, s.jsLast, s.jsWord = false, '{', ""
+			return 1
+		}
+	case c == '"' || c == '\'' || c == '`':
+		s.jsQuote = c
+	case strings.HasPrefix(text[i:], "//"), strings.HasPrefix(text[i:], "/*"):
+		s.jsComment = text[i+1]
+		return 1
+	case c == '/' && !s.jsDivOp:
+		s.jsRegexp, s.jsClass = true, false
+	case isSpace(c):
+		s.jsLast = c
+	case c == '{' && len(s.jsBraces) > 0:
+		s.jsBraces[len(s.jsBraces)-1]++
+		s.jsDivOp, s.jsLast, s.jsWord = false, c, ""
+	case c == '}' && len(s.jsBraces) > 0:
+		n := len(s.jsBraces) - 1
+		if s.jsBraces[n] == 0 {
+			// the end of the substitution continues the template literal
+			s.jsBraces = s.jsBraces[:n]
+			s.jsQuote = '`'
+			return 0
+		}
+		s.jsBraces[n]--
+		s.jsDivOp, s.jsLast, s.jsWord = false, c, ""
+	case isJSIdentByte(c):
+		if isJSIdentByte(s.jsLast) {
+			s.jsWord += string(c)
+		} else {
+			s.jsWord = string(c)
+		}
+		s.jsDivOp = !jsRegexpKeywords[s.jsWord]
+		s.jsLast = c
+	default:
+		// a '/' after the end of an expression, or after ++ or --, is a
+		// division; after any other punctuation it starts a regular
+		// expression
+		s.jsDivOp = c == ')' || c == ']' || (c == '+' || c == '-') && s.jsLast == c
+		s.jsLast, s.jsWord = c, ""
+	}
+	return 0
+}
+
+// jsRegexpKeywords are the keywords that can be followed by a regular
+// expression.
+var jsRegexpKeywords = map[string]bool{
+	"await": true, "break": true, "case": true, "continue": true,
+	"delete": true, "do": true, "else": true, "export": true, "in": true,
+	"instanceof": true, "new": true, "return": true, "throw": true,
+	"typeof": true, "void": true, "yield": true,
+}
+
+func isJSIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' || c >= 0x80
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,111 @@
+package compiler
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Filter is a custom filter that can be used in Haml and Slim templates.
+//
+// A filter converts its body with either its Transform function when the
+// template is generated, or with its Func when the template is rendered.
+type Filter struct {
+	// Transform converts the body of the filter into the HTML that is
+	// written when the template is generated.
+	//
+	// Each interpolated value in the body is replaced by a placeholder of
+	// letters and digits, and the output must keep the placeholders so the
+	// values can be written in their place.
+	Transform func(body string) (string, error)
+	// Func is the Go function that converts the body of the filter into the
+	// HTML that is written when the template is rendered (e.g. "md.Render").
+	//
+	// The function is given the body as a string, with the interpolated
+	// values in it, and returns a string type and an optional error.
+	Func string
+	// Import is the import path of the package of Func; it is added to the
+	// imports of the templates that use the filter.
+	Import string
+}
+
+// builtinFilters are the built-in filters that are converted like custom
+// filters.
+var builtinFilters = map[string]Filter{
+	"markdown": {Transform: markdown},
+}
+
+var (
+	filters   = map[string]Filter{}
+	filtersMu sync.RWMutex
+)
+
+// RegisterFilter adds a custom filter that is used in the templates as
+// :name. Registering a filter with a name that is already registered
+// replaces it; the built-in filters cannot be replaced.
+func RegisterFilter(name string, filter Filter) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("invalid filter name: %q", name)
+	}
+	if slices.Contains(hamlFilters, name) || slices.Contains(slimFilters, name) {
+		return fmt.Errorf("the %s filter is built in and cannot be replaced", name)
+	}
+	if (filter.Transform == nil) == (filter.Func == "") {
+		return fmt.Errorf("the %s filter must have either a Transform or a Func", name)
+	}
+	if filter.Import != "" && filter.Func == "" {
+		return fmt.Errorf("the %s filter has an import but no Func", name)
+	}
+
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	filters[name] = filter
+	return nil
+}
+
+// lookupFilter returns the built-in or custom filter with the name.
+func lookupFilter(name string) (Filter, bool) {
+	if filter, ok := builtinFilters[name]; ok {
+		return filter, true
+	}
+	filtersMu.RLock()
+	defer filtersMu.RUnlock()
+	filter, ok := filters[name]
+	return filter, ok
+}
+
+// isFilter returns true when the name is one of the built-in filters of a
+// dialect or a custom filter.
+func isFilter(builtin []string, name string) bool {
+	if slices.Contains(builtin, name) {
+		return true
+	}
+	_, ok := lookupFilter(name)
+	return ok
+}
+
+// filterPlaceholder returns the placeholder of the interpolated value at the
+// index that is given to the Transform of a filter.
+func filterPlaceholder(i int) string {
+	return "GOHTFILTERVALUE" + strconv.Itoa(i) + "END"
+}
+
+// filterValue returns the index of the interpolated value of the placeholder
+// at the start of s, and the length of the placeholder.
+func filterValue(s string) (index, length int, ok bool) {
+	const prefix = "GOHTFILTERVALUE"
+	if !strings.HasPrefix(s, prefix) {
+		return 0, 0, false
+	}
+	end := strings.Index(s[len(prefix):], "END")
+	if end < 1 {
+		return 0, 0, false
+	}
+	index, err := strconv.Atoi(s[len(prefix) : len(prefix)+end])
+	if err != nil {
+		return 0, 0, false
+	}
+	return index, len(prefix) + end + len("END"), true
+}
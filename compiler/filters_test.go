@@ -0,0 +1,162 @@
+package compiler
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func registerTestFilter(t *testing.T, name string, filter Filter) {
+	t.Helper()
+	if err := RegisterFilter(name, filter); err != nil {
+		t.Fatalf("RegisterFilter() error = %v", err)
+	}
+	t.Cleanup(func() {
+		filtersMu.Lock()
+		defer filtersMu.Unlock()
+		delete(filters, name)
+	})
+}
+
+func Test_RegisterFilter(t *testing.T) {
+	upper := func(s string) (string, error) { return strings.ToUpper(s), nil }
+	tests := map[string]struct {
+		name   string
+		filter Filter
+	}{
+		"empty name": {
+			name:   "",
+			filter: Filter{Transform: upper},
+		},
+		"name with spaces": {
+			name:   "my filter",
+			filter: Filter{Transform: upper},
+		},
+		"built in": {
+			name:   "plain",
+			filter: Filter{Transform: upper},
+		},
+		"no conversion": {
+			name:   "upper",
+			filter: Filter{},
+		},
+		"transform and func": {
+			name:   "upper",
+			filter: Filter{Transform: upper, Func: "strings.ToUpper"},
+		},
+		"import without func": {
+			name:   "upper",
+			filter: Filter{Transform: upper, Import: "strings"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := RegisterFilter(tt.name, tt.filter); err == nil {
+				t.Errorf("RegisterFilter() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func Test_CustomFilter(t *testing.T) {
+	registerTestFilter(t, "upper", Filter{
+		Transform: func(s string) (string, error) { return "<p>" + strings.ToUpper(s) + "</p>", nil },
+	})
+	registerTestFilter(t, "md", Filter{Func: "md.Render", Import: "example.com/md"})
+
+	tests := map[string]struct {
+		template string
+		want     []string
+	}{
+		"haml transform": {
+			template: "@haml Test(name string) {\n\t:upper\n\t\thello #{name}\n\t\tbye\n}\n",
+			want: []string{
+				`__buf.WriteString("<p>HELLO ")`,
+				`goht.CaptureErrors(goht.EscapeString(name))`,
+				`__buf.WriteString("\nBYE\n</p>")`,
+			},
+		},
+		"slim transform": {
+			template: "@slim Test() {\n\t:upper\n\t\thello\n}\n",
+			want:     []string{`__buf.WriteString("<p>HELLO\n</p>\n")`},
+		},
+		"haml func": {
+			template: "@haml Test(name string) {\n\t:md\n\t\thello #{name}\n}\n",
+			want: []string{
+				`import "example.com/md"`,
+				`goht.CaptureErrors(goht.EscapeString(name))`,
+				`goht.CaptureErrors(md.Render("hello " + __var1 + "\n"))`,
+				`__buf.WriteString(__var2)`,
+			},
+		},
+		"slim func with import": {
+			template: "import \"example.com/md\"\n\n@slim Test() {\n\t:md\n\t\t# Title\n}\n",
+			want:     []string{`md.Render("# Title\n")`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString(tt.template)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tpl.Generate(&buf); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Generate() is missing %s\n%s", want, got)
+				}
+			}
+			if n := strings.Count(got, `"example.com/md"`); n > 1 {
+				t.Errorf("Generate() imports the filter package %d times", n)
+			}
+		})
+	}
+}
+
+func Test_CustomFilter_Errors(t *testing.T) {
+	registerTestFilter(t, "drop", Filter{
+		Transform: func(s string) (string, error) { return "dropped", nil },
+	})
+	registerTestFilter(t, "fail", Filter{
+		Transform: func(s string) (string, error) { return "", errors.New("bad body") },
+	})
+
+	tests := map[string]struct {
+		template string
+		code     ErrorCode
+		message  string
+	}{
+		"missing value": {
+			template: "@haml Test(name string) {\n\t:drop\n\t\thello #{name}\n}\n",
+			code:     ErrFilter,
+			message:  "the output of the drop filter is missing the interpolated value: name",
+		},
+		"failed": {
+			template: "@slim Test() {\n\t:fail\n\t\thello\n}\n",
+			code:     ErrFilter,
+			message:  "the fail filter failed: bad body",
+		},
+		"unknown": {
+			template: "@haml Test() {\n\t:unknown\n\t\thello\n}\n",
+			code:     ErrUnknownFilter,
+			message:  "unknown filter: unknown",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseString(tt.template)
+			var errList ErrorList
+			if !errors.As(err, &errList) || len(errList) != 1 {
+				t.Fatalf("ParseString() error = %v, want one error", err)
+			}
+			if errList[0].Code != tt.code || errList[0].Err.Error() != tt.message {
+				t.Errorf("ParseString() error = %s %v, want %s %s", errList[0].Code, errList[0].Err, tt.code, tt.message)
+			}
+		})
+	}
+}
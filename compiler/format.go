@@ -0,0 +1,119 @@
+package compiler
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// outputFormat is the format of the markup that a Haml or Slim template
+// writes; set with the @format command.
+type outputFormat int
+
+const (
+	formatHTML5 outputFormat = iota
+	formatHTML4
+	formatXHTML
+	formatXML
+)
+
+var outputFormats = map[string]outputFormat{
+	"html5": formatHTML5,
+	"html":  formatHTML5,
+	"html4": formatHTML4,
+	"xhtml": formatXHTML,
+	"xml":   formatXML,
+}
+
+func (f outputFormat) String() string {
+	switch f {
+	case formatHTML4:
+		return "html4"
+	case formatXHTML:
+		return "xhtml"
+	case formatXML:
+		return "xml"
+	default:
+		return "html5"
+	}
+}
+
+// isXML returns true when the format writes markup that must be well-formed
+// XML; boolean attributes are given values and the content of the script and
+// style filters is wrapped in CDATA sections.
+func (f outputFormat) isXML() bool {
+	return f == formatXHTML || f == formatXML
+}
+
+const (
+	doctypeHTML5 = `<!DOCTYPE html>`
+
+	doctypeHTML4Transitional = `<!DOCTYPE html PUBLIC \"-//W3C//DTD HTML 4.01 Transitional//EN\" \"http://www.w3.org/TR/html4/loose.dtd\">`
+	doctypeHTML4Strict       = `<!DOCTYPE html PUBLIC \"-//W3C//DTD HTML 4.01//EN\" \"http://www.w3.org/TR/html4/strict.dtd\">`
+	doctypeHTML4Frameset     = `<!DOCTYPE html PUBLIC \"-//W3C//DTD HTML 4.01 Frameset//EN\" \"http://www.w3.org/TR/html4/frameset.dtd\">`
+
+	doctypeXHTMLTransitional = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">`
+	doctypeXHTMLStrict       = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Strict//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-strict.dtd\">`
+	doctypeXHTMLFrameset     = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Frameset//EN\" \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-frameset.dtd\">`
+	doctypeXHTML11           = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.1//EN\" \"http://www.w3.org/TR/xhtml11/DTD/xhtml11.dtd\">`
+	doctypeXHTMLBasic        = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML Basic 1.1//EN\" \"http://www.w3.org/TR/xhtml-basic/xhtml-basic11.dtd\">`
+	doctypeXHTMLMobile       = `<!DOCTYPE html PUBLIC \"-//WAPFORUM//DTD XHTML Mobile 1.2//EN\" \"http://www.openmobilealliance.org/tech/DTD/xhtml-mobile12.dtd\">`
+	doctypeXHTMLRDFa         = `<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML+RDFa 1.0//EN\" \"http://www.w3.org/MarkUp/DTD/xhtml-rdfa-1.dtd\">`
+)
+
+// doctype returns the doctype, escaped for a string literal, for the value
+// that follows "!!!" or "doctype" in the format of the template.
+//
+// The XML prolog and the versioned XHTML doctypes are written in any format.
+// The other doctypes depend on the format, and any unknown value is the
+// default doctype of the format.
+func (f outputFormat) doctype(value string) string {
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 {
+		fields = []string{""}
+	}
+	switch fields[0] {
+	case "xml":
+		encoding := "utf-8"
+		if len(fields) > 1 {
+			encoding = strconv.Quote(html.EscapeString(fields[1]))
+			encoding = encoding[1 : len(encoding)-1]
+		}
+		return `<?xml version='1.0' encoding='` + encoding + `' ?>`
+	case "5", "html":
+		return doctypeHTML5
+	case "1.1":
+		return doctypeXHTML11
+	case "basic":
+		return doctypeXHTMLBasic
+	case "mobile":
+		return doctypeXHTMLMobile
+	case "rdfa":
+		return doctypeXHTMLRDFa
+	case "strict":
+		if f.isXML() {
+			return doctypeXHTMLStrict
+		}
+		return doctypeHTML4Strict
+	case "frameset":
+		if f.isXML() {
+			return doctypeXHTMLFrameset
+		}
+		return doctypeHTML4Frameset
+	case "transitional":
+		if f.isXML() {
+			return doctypeXHTMLTransitional
+		}
+		return doctypeHTML4Transitional
+	}
+	switch f {
+	case formatHTML4:
+		return doctypeHTML4Transitional
+	case formatXHTML:
+		return doctypeXHTMLTransitional
+	case formatXML:
+		return f.doctype("xml")
+	default:
+		return doctypeHTML5
+	}
+}
@@ -0,0 +1,77 @@
+package compiler
+
+import (
+	"testing"
+)
+
+func Test_outputFormat_doctype(t *testing.T) {
+	tests := map[string]struct {
+		format outputFormat
+		value  string
+		want   string
+	}{
+		"html5 default": {
+			format: formatHTML5,
+			want:   doctypeHTML5,
+		},
+		"html5 strict": {
+			format: formatHTML5,
+			value:  "Strict",
+			want:   doctypeHTML4Strict,
+		},
+		"html4 default": {
+			format: formatHTML4,
+			want:   doctypeHTML4Transitional,
+		},
+		"html4 frameset": {
+			format: formatHTML4,
+			value:  "Frameset",
+			want:   doctypeHTML4Frameset,
+		},
+		"xhtml default": {
+			format: formatXHTML,
+			want:   doctypeXHTMLTransitional,
+		},
+		"xhtml strict": {
+			format: formatXHTML,
+			value:  "Strict",
+			want:   doctypeXHTMLStrict,
+		},
+		"xhtml 5": {
+			format: formatXHTML,
+			value:  "5",
+			want:   doctypeHTML5,
+		},
+		"versioned in any format": {
+			format: formatHTML5,
+			value:  "1.1",
+			want:   doctypeXHTML11,
+		},
+		"xml default": {
+			format: formatXML,
+			want:   `<?xml version='1.0' encoding='utf-8' ?>`,
+		},
+		"xml prolog in any format": {
+			format: formatHTML5,
+			value:  "XML",
+			want:   `<?xml version='1.0' encoding='utf-8' ?>`,
+		},
+		"xml prolog with encoding": {
+			format: formatXHTML,
+			value:  "XML iso-8859-1",
+			want:   `<?xml version='1.0' encoding='iso-8859-1' ?>`,
+		},
+		"unknown value": {
+			format: formatXHTML,
+			value:  "foo",
+			want:   doctypeXHTMLTransitional,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.format.doctype(tt.value); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,573 @@
+package compiler
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// templateMarker marks the place of a template in the Go code that is given to
+// gofmt; the template is replaced by a function with its signature and the
+// marker as its body.
+const templateMarker = "//goht:template "
+
+// Format returns the template file in its canonical layout.
+//
+// The Go code of the file is formatted with gofmt, which also sorts the
+// imports, and the signatures of the templates are formatted like the
+// signatures of Go functions. In Haml and Slim templates the attribute lists
+// are written with single spaces and double-quoted values, and whitespace that
+// is not part of the template content is removed from the ends of the lines.
+// EGO templates are kept as they are.
+//
+// A file that has errors is not formatted, and the errors are returned.
+func Format(src []byte) ([]byte, error) {
+	if _, err := parseBytes(src); err != nil {
+		return nil, err
+	}
+
+	return newFormatter(src).format()
+}
+
+// formatter edits the source of a file in place, rather than printing its
+// syntax tree, so that the layout, the blank lines and the template comments
+// that the tree doesn't have are kept.
+type formatter struct {
+	src string
+	// lineStarts are the offsets of the start of each line
+	lineStarts []int
+	templates  []formatterTemplate
+}
+
+type formatterTemplate struct {
+	keyword   string
+	signature string
+	// startLine and endLine are the lines of the template start and the
+	// closing brace
+	startLine int
+	endLine   int
+	tokens    []token
+}
+
+// formatterEdit replaces the source from start to end with text.
+type formatterEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+func newFormatter(src []byte) *formatter {
+	f := &formatter{
+		src:        string(src),
+		lineStarts: []int{0},
+	}
+	for i, c := range src {
+		if c == '\n' {
+			f.lineStarts = append(f.lineStarts, i+1)
+		}
+	}
+
+	l := newLexer(src)
+	var current *formatterTemplate
+	for t := l.nextToken(); t.Type() != tEOF; t = l.nextToken() {
+		switch {
+		case t.Type() == tTemplateStart:
+			current = &formatterTemplate{
+				keyword:   strings.Fields(f.line(t.line))[0],
+				signature: t.lit,
+				startLine: t.line,
+			}
+		case current == nil:
+		case t.Type() == tTemplateEnd:
+			current.endLine = t.line
+			f.templates = append(f.templates, *current)
+			current = nil
+		default:
+			current.tokens = append(current.tokens, t)
+		}
+	}
+
+	return f
+}
+
+func (f *formatter) format() ([]byte, error) {
+	// replace each template with a function so that gofmt can format the Go
+	// code and the template signatures
+	var goSrc strings.Builder
+	line := 1
+	for i, t := range f.templates {
+		for ; line < t.startLine; line++ {
+			goSrc.WriteString(f.line(line))
+		}
+		fmt.Fprintf(&goSrc, "func %s {\n%s%d\n}\n", t.signature, templateMarker, i)
+		line = t.endLine + 1
+	}
+	for ; line <= len(f.lineStarts); line++ {
+		goSrc.WriteString(f.line(line))
+	}
+
+	formatted, err := format.Source([]byte(goSrc.String()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to format the Go code: %w", err)
+	}
+
+	var buf strings.Builder
+	goLines := strings.SplitAfter(string(formatted), "\n")
+	for i := 0; i < len(goLines); i++ {
+		index, ok := f.templateIndex(goLines, i)
+		if !ok {
+			buf.WriteString(goLines[i])
+			continue
+		}
+		t := f.templates[index]
+		signature := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(goLines[i]), "func "), " {")
+		i += 2
+		// a template that is closed on the line that it starts on is empty
+		if t.endLine == t.startLine {
+			fmt.Fprintf(&buf, "%s %s {}\n", t.keyword, signature)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s %s {\n", t.keyword, signature)
+		buf.WriteString(f.formatBody(t))
+		buf.WriteString("}\n")
+	}
+
+	return []byte(buf.String()), nil
+}
+
+// templateIndex returns the index of the template when the line is the
+// signature of a function that was written for a template.
+func (f *formatter) templateIndex(lines []string, i int) (int, bool) {
+	if !strings.HasPrefix(lines[i], "func ") || i+2 >= len(lines) {
+		return 0, false
+	}
+	marker, ok := strings.CutPrefix(strings.TrimSpace(lines[i+1]), templateMarker)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(marker)
+	if err != nil || index >= len(f.templates) {
+		return 0, false
+	}
+	return index, true
+}
+
+// formatBody returns the lines between the start and the end of the template.
+func (f *formatter) formatBody(t formatterTemplate) string {
+	start := f.lineStarts[t.startLine]
+	end := f.lineStarts[t.endLine-1]
+	if t.keyword == "@ego" {
+		return f.src[start:end]
+	}
+
+	edits := f.attributeEdits(t)
+	edits = append(edits, f.lineEndEdits(t, edits)...)
+	slices.SortFunc(edits, func(a, b formatterEdit) int {
+		return a.start - b.start
+	})
+
+	var buf strings.Builder
+	for _, edit := range edits {
+		buf.WriteString(f.src[start:edit.start])
+		buf.WriteString(edit.text)
+		start = edit.end
+	}
+	buf.WriteString(f.src[start:end])
+	return buf.String()
+}
+
+// attributeEdits returns the edits that write the attribute lists of the
+// tags of the template in their canonical form.
+func (f *formatter) attributeEdits(t formatterTemplate) []formatterEdit {
+	var edits []formatterEdit
+	for i := 0; i+1 < len(t.tokens); i++ {
+		tok, next := t.tokens[i], t.tokens[i+1]
+		if !isTagHeadToken(tok) || isTagHeadToken(next) {
+			continue
+		}
+		if next.Type() != tAttrName && next.Type() != tAttributesCommand {
+			continue
+		}
+		pos := f.offset(tok.endLine, tok.endCol)
+		if tok.Type() == tObjectRef && pos < len(f.src) && f.src[pos] == ']' {
+			pos++
+		}
+		line := f.line(tok.line)
+		indent := line[:len(line)-len(strings.TrimLeft(line, "\t"))]
+		s := &attributeScanner{src: f.src, pos: pos, indent: indent}
+		text, ok := s.groups(t.keyword == "@slim")
+		if ok && f.src[pos:s.pos] != text {
+			edits = append(edits, formatterEdit{start: pos, end: s.pos, text: text})
+		}
+	}
+	return edits
+}
+
+// lineEndEdits returns the edits that remove the whitespace from the ends of
+// the lines of the template. Whitespace that is a part of a token, such as the
+// text of a filter, is kept.
+func (f *formatter) lineEndEdits(t formatterTemplate, attributeEdits []formatterEdit) []formatterEdit {
+	// keep is the offset in each line that the content of the tokens reaches
+	keep := make(map[int]int)
+	for _, tok := range t.tokens {
+		if tok.Type() == tNewLine || tok.Type() == tIndent {
+			continue
+		}
+		end := f.offset(tok.endLine, tok.endCol)
+		for line := tok.line; line <= tok.endLine; line++ {
+			keep[line] = max(keep[line], end)
+		}
+	}
+
+	var edits []formatterEdit
+	for line := t.startLine + 1; line < t.endLine; line++ {
+		text := strings.TrimRight(f.line(line), "\r\n")
+		start := f.lineStarts[line-1]
+		trimmed := start + len(strings.TrimRight(text, " \t"))
+		lineEnd := start + len(text)
+		trimmed = max(trimmed, keep[line])
+		if trimmed >= lineEnd {
+			continue
+		}
+		overlaps := slices.ContainsFunc(attributeEdits, func(e formatterEdit) bool {
+			return e.start < lineEnd && trimmed < e.end
+		})
+		if !overlaps {
+			edits = append(edits, formatterEdit{start: trimmed, end: lineEnd})
+		}
+	}
+	return edits
+}
+
+// line returns the line of the source, with its line ending.
+func (f *formatter) line(line int) string {
+	if line < 1 || line > len(f.lineStarts) {
+		return ""
+	}
+	if line == len(f.lineStarts) {
+		return f.src[f.lineStarts[line-1]:]
+	}
+	return f.src[f.lineStarts[line-1]:f.lineStarts[line]]
+}
+
+// offset returns the offset in the source of a token position.
+func (f *formatter) offset(line, col int) int {
+	if line > len(f.lineStarts) {
+		return len(f.src)
+	}
+	offset := f.lineStarts[line-1]
+	for i := 1; i < col && offset < len(f.src); i++ {
+		_, size := utf8.DecodeRuneInString(f.src[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func isTagHeadToken(t token) bool {
+	switch t.Type() {
+	case tTag, tId, tClass, tObjectRef:
+		return true
+	}
+	return false
+}
+
+// attributeScanner reads the attribute lists that follow a tag and returns
+// them in their canonical form. The lists have already been lexed without
+// errors, so the scanner only has to follow the same rules as the lexer.
+type attributeScanner struct {
+	src string
+	pos int
+	// indent is the indent of the line of the tag
+	indent string
+}
+
+// groups reads the attribute lists at the current position. Ruby style lists
+// are written as `{name: "value", other: #{value}}` and HTML style lists as
+// `(name="value" other=#{value})`. Lists that were written across several
+// lines are written with one attribute on each line.
+func (s *attributeScanner) groups(slim bool) (string, bool) {
+	var b strings.Builder
+	for {
+		var text string
+		var ok bool
+		switch s.peek() {
+		case '{':
+			text, ok = s.hash(s.indent)
+		case '(':
+			text, ok = s.html(')')
+		case '[':
+			if !slim {
+				return b.String(), true
+			}
+			text, ok = s.html(']')
+		default:
+			if !slim {
+				return b.String(), true
+			}
+			text, ok = s.bare()
+			if text == "" {
+				return b.String(), ok
+			}
+		}
+		if !ok {
+			return "", false
+		}
+		b.WriteString(text)
+	}
+}
+
+func (s *attributeScanner) hash(indent string) (string, bool) {
+	start := s.pos
+	s.pos++ // skip opening brace
+	var items []string
+	for {
+		s.skip(", \t\n\r")
+		var item string
+		var ok bool
+		switch s.peek() {
+		case '}':
+			s.pos++
+			if strings.Contains(s.src[start:s.pos], "\n") {
+				return "{\n" + joinLines(items, indent+"\t", ",") + indent + "}", true
+			}
+			return "{" + strings.Join(items, ", ") + "}", true
+		case 0:
+			return "", false
+		case '@':
+			item, ok = s.command()
+		default:
+			item, ok = s.hashAttribute(indent + "\t")
+		}
+		if !ok {
+			return "", false
+		}
+		items = append(items, item)
+	}
+}
+
+func (s *attributeScanner) hashAttribute(indent string) (string, bool) {
+	name, ok := s.name("?:,}{\" \t\n\r")
+	if !ok {
+		return "", false
+	}
+	s.skip(" \t\n\r")
+	operator := s.peek()
+	if operator != '?' && operator != ':' {
+		return name, true
+	}
+	s.pos++
+	s.skip(" \t\n\r")
+	value, ok := s.value(indent)
+	return name + string(operator) + " " + value, ok
+}
+
+func (s *attributeScanner) command() (string, bool) {
+	s.pos++ // skip @
+	name, ok := s.name(": \t\n\r")
+	if !ok {
+		return "", false
+	}
+	for s.peek() != '{' {
+		if s.peek() == 0 {
+			return "", false
+		}
+		s.pos++
+	}
+	expr, ok := s.braces()
+	return "@" + name + ": #{" + strings.TrimSpace(expr) + "}", ok
+}
+
+func (s *attributeScanner) html(end byte) (string, bool) {
+	start := s.pos
+	s.pos++ // skip opening wrapper
+	var items []string
+	for {
+		s.skip(" \t\n\r")
+		switch s.peek() {
+		case end:
+			s.pos++
+			open := string(s.src[start])
+			if strings.Contains(s.src[start:s.pos], "\n") {
+				return open + "\n" + joinLines(items, s.indent+"\t", "") + s.indent + string(end), true
+			}
+			return open + strings.Join(items, " ") + string(end), true
+		case 0:
+			return "", false
+		}
+		item, ok := s.htmlAttribute(string(end))
+		if !ok {
+			return "", false
+		}
+		items = append(items, item)
+	}
+}
+
+// bare reads the Slim attributes that follow a tag without a wrapper, such as
+// `a href="/"`.
+func (s *attributeScanner) bare() (string, bool) {
+	var items []string
+	for {
+		rest := s.src[s.pos:]
+		trimmed := strings.TrimLeft(rest, " \t")
+		if len(trimmed) == len(rest) || !isSlimBareAttribute(trimmed) {
+			break
+		}
+		s.pos += len(rest) - len(trimmed)
+		item, ok := s.htmlAttribute("")
+		if !ok {
+			return "", false
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return "", true
+	}
+	return " " + strings.Join(items, " "), true
+}
+
+func (s *attributeScanner) htmlAttribute(stopRunes string) (string, bool) {
+	name, ok := s.name("=\"` \t\n\r" + stopRunes)
+	if !ok {
+		return "", false
+	}
+	s.skip(" \t")
+	if s.peek() != '=' {
+		return name, true
+	}
+	s.pos++
+	s.skip(" \t")
+	value, ok := s.value("")
+	return name + "=" + value, ok
+}
+
+func (s *attributeScanner) name(stopRunes string) (string, bool) {
+	if c := s.peek(); c == '"' || c == '`' {
+		return s.quoted()
+	}
+	start := s.pos
+	for s.pos < len(s.src) && !strings.ContainsRune(stopRunes, rune(s.src[s.pos])) {
+		s.pos++
+	}
+	return s.src[start:s.pos], s.pos > start
+}
+
+// value reads an attribute value. Nested hashes are only allowed in Ruby style
+// lists, which give the indent of the nested hash.
+func (s *attributeScanner) value(hashIndent string) (string, bool) {
+	switch s.peek() {
+	case '"', '`':
+		lit, ok := s.quoted()
+		return formatQuotedValue(lit), ok
+	case '#':
+		s.pos++
+		if s.peek() != '{' {
+			return "", false
+		}
+		expr, ok := s.braces()
+		return "#{" + strings.TrimSpace(expr) + "}", ok
+	case '{':
+		if hashIndent != "" {
+			return s.hash(hashIndent)
+		}
+	}
+	if hashIndent != "" && (s.peek() == '-' || s.peek() >= '0' && s.peek() <= '9') {
+		// a number literal of a Ruby style list
+		start := s.pos
+		s.pos++
+		for s.pos < len(s.src) && strings.IndexByte("0123456789.", s.src[s.pos]) >= 0 {
+			s.pos++
+		}
+		return s.src[start:s.pos], true
+	}
+	return "", false
+}
+
+// quoted reads a quoted string, and returns it with its quotes.
+func (s *attributeScanner) quoted() (string, bool) {
+	start := s.pos
+	quote := s.src[s.pos]
+	s.pos++
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		s.pos++
+		switch {
+		case c == '\\' && quote != '`':
+			s.pos++
+		case c == quote:
+			return s.src[start:s.pos], true
+		}
+	}
+	return "", false
+}
+
+// braces reads the Go code between a pair of braces, and returns it without
+// the braces.
+func (s *attributeScanner) braces() (string, bool) {
+	s.pos++ // skip opening brace
+	start := s.pos
+	depth := 1
+	quote := byte(0)
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		s.pos++
+		if quote != 0 {
+			switch {
+			case c == '\\' && quote != '`':
+				s.pos++
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return s.src[start : s.pos-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *attributeScanner) peek() byte {
+	if s.pos >= len(s.src) {
+		return 0
+	}
+	return s.src[s.pos]
+}
+
+func (s *attributeScanner) skip(runes string) {
+	for s.pos < len(s.src) && strings.ContainsRune(runes, rune(s.src[s.pos])) {
+		s.pos++
+	}
+}
+
+// joinLines returns the items with each on its own line.
+func joinLines(items []string, indent, separator string) string {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString(indent + item + separator + "\n")
+	}
+	return b.String()
+}
+
+// formatQuotedValue returns the static attribute value with double quotes,
+// unless it is a raw string with content that would have to be escaped.
+func formatQuotedValue(lit string) string {
+	if !strings.HasPrefix(lit, "`") {
+		return lit
+	}
+	value := lit[1 : len(lit)-1]
+	if strings.ContainsAny(value, "\"\\\n\r") {
+		return lit
+	}
+	return `"` + value + `"`
+}
@@ -0,0 +1,100 @@
+package compiler
+
+import (
+	"testing"
+)
+
+func Test_Format(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"go code and imports": {
+			input: "package test\n\nimport (\n\"strings\"\n  \"fmt\"\n)\n\nvar x   =  1\n@goht Test( a int,b string ) {\n\t%p hello\n}\n",
+			want:  "package test\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\nvar x = 1\n\n@goht Test(a int, b string) {\n\t%p hello\n}\n",
+		},
+		"method templates": {
+			input: "package test\n\n@haml (u  *User)  Test() {\n\t%p= u.Name\n}\n",
+			want:  "package test\n\n@haml (u *User) Test() {\n\t%p= u.Name\n}\n",
+		},
+		"haml attributes": {
+			input: "@haml Test() {\n\t%p.a{id:\"a\",class :#{ b }, checked? #{c},disabled} text\n}\n",
+			want:  "@haml Test() {\n\t%p.a{id: \"a\", class: #{b}, checked? #{c}, disabled} text\n}\n",
+		},
+		"raw string values": {
+			input: "@haml Test() {\n\t%a{href: `/home`, title: `say \"hi\"`}\n}\n",
+			want:  "@haml Test() {\n\t%a{href: \"/home\", title: `say \"hi\"`}\n}\n",
+		},
+		"nested attributes": {
+			input: "@haml Test() {\n\t%div{data:{user_id:#{id},role:\"admin\"}}\n}\n",
+			want:  "@haml Test() {\n\t%div{data: {user_id: #{id}, role: \"admin\"}}\n}\n",
+		},
+		"nested number attributes": {
+			input: "@haml Test() {\n\t%div{data:{user_id:1,ratio:-0.5}}\n}\n",
+			want:  "@haml Test() {\n\t%div{data: {user_id: 1, ratio: -0.5}}\n}\n",
+		},
+		"attributes command": {
+			input: "@haml Test() {\n\t%input{@attributes:#{ attrs },type:\"text\"}\n}\n",
+			want:  "@haml Test() {\n\t%input{@attributes: #{attrs}, type: \"text\"}\n}\n",
+		},
+		"multiline attributes": {
+			input: "@haml Test() {\n\t%p{ id: \"a\",\n\t\tclass: #{b}} text\n\t%div\n\t\t%a(href=\"/\"\n\t\t\ttitle=#{t}) link\n}\n",
+			want:  "@haml Test() {\n\t%p{\n\t\tid: \"a\",\n\t\tclass: #{b},\n\t} text\n\t%div\n\t\t%a(\n\t\t\thref=\"/\"\n\t\t\ttitle=#{t}\n\t\t) link\n}\n",
+		},
+		"object reference": {
+			input: "@haml Test() {\n\t%p[obj]{id:\"a\"}\n}\n",
+			want:  "@haml Test() {\n\t%p[obj]{id: \"a\"}\n}\n",
+		},
+		"html attributes": {
+			input: "@haml Test() {\n\t%a( href = \"/\"   title=#{t} disabled ) Home\n}\n",
+			want:  "@haml Test() {\n\t%a(href=\"/\" title=#{t} disabled) Home\n}\n",
+		},
+		"slim attributes": {
+			input: "@slim Test() {\n\tp{id:\"a\"} text\n\ta[href=`/`  title=#{t}] Home\n\ta  href=\"/\"   title=#{t} Home\n\tli: a(href=\"/\"  ) Home\n}\n",
+			want:  "@slim Test() {\n\tp{id: \"a\"} text\n\ta[href=\"/\" title=#{t}] Home\n\ta href=\"/\" title=#{t} Home\n\tli: a(href=\"/\") Home\n}\n",
+		},
+		"trailing whitespace": {
+			input: "@haml Test() {\n\t%div   \n\t\t%p{id: \"a\"}\t\n\t\n\t%p text  \n}\n",
+			want:  "@haml Test() {\n\t%div\n\t\t%p{id: \"a\"}\n\n\t%p text  \n}\n",
+		},
+		"filter content": {
+			input: "@haml Test() {\n\t:plain\n\t\tfoo  \n\n\t\tbar\n\t%p{id:\"a\"}\n}\n",
+			want:  "@haml Test() {\n\t:plain\n\t\tfoo  \n\n\t\tbar\n\t%p{id: \"a\"}\n}\n",
+		},
+		"ego templates": {
+			input: "@ego Test( a int ) {\n\t<p class=\"a\"  >  <%= a %>  </p>  \n}\n",
+			want:  "@ego Test(a int) {\n\t<p class=\"a\"  >  <%= a %>  </p>  \n}\n",
+		},
+		"empty templates": {
+			input: "@goht Empty( a int ) {}\n@slim Other() {}\n\n@ego Last() {}\n",
+			want:  "@goht Empty(a int) {}\n@slim Other() {}\n\n@ego Last() {}\n",
+		},
+		"parse errors": {
+			input:   "@haml Test() {\n\t%p{id: bar}\n}\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Format([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(ErrorList); !ok {
+					t.Errorf("Format() error = %T, want an ErrorList", err)
+				}
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			again, err := Format(got)
+			if err != nil || string(again) != string(got) {
+				t.Errorf("Format() is not stable: %q, %v", again, err)
+			}
+		})
+	}
+}
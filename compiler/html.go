@@ -0,0 +1,55 @@
+package compiler
+
+import (
+	"regexp"
+)
+
+// htmlState is the state of the HTML of a template between its text and its
+// Go code.
+type htmlState int
+
+const (
+	htmlText htmlState = iota
+	htmlTagName
+	htmlInTag
+	htmlAttrName
+	htmlBeforeValue
+	htmlAttrValue
+	htmlEndTag
+	htmlComment
+	htmlDecl
+	htmlRawText
+)
+
+type (
+	htmlAttr struct {
+		name     string
+		value    []htmlPart
+		hasValue bool
+		// cond is the condition of a boolean attribute
+		cond string
+	}
+	// htmlPart is text, or a Go expression when code is set; the format
+	// formats the value of an expression that is not a string.
+	htmlPart struct {
+		text   string
+		code   string
+		format string
+	}
+)
+
+// htmlRawTextTags are the elements with content that is not HTML.
+var htmlRawTextTags = []string{"script", "style", "textarea", "title"}
+
+// reHTMLAttrName matches the attribute names that can be written in Haml and
+// Slim attribute lists.
+var reHTMLAttrName = regexp.MustCompile(`^[a-zA-Z_:@][a-zA-Z0-9_:.@-]*$`)
+
+// reHTMLIdentifier matches the ids and classes that can be written with the
+// `#` and `.` shortcuts of Haml and Slim.
+var reHTMLIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// isHTMLLetter reports whether c can start the name of a tag.
+func isHTMLLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
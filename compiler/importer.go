@@ -0,0 +1,1553 @@
+package compiler
+
+import (
+	"fmt"
+	gotoken "go/token"
+	"html"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/stackus/goht"
+)
+
+// ImportOptions are the options of Import.
+type ImportOptions struct {
+	// Package is the package of the Goht file.
+	Package string
+	// Name is the name of the main template, which is usually the name of the
+	// file without its extension.
+	Name string
+	// Dialect is the dialect of the templates: "haml", "slim" or "ego".
+	Dialect string
+}
+
+// Import returns a Goht file with the templates of an HTML or html/template
+// file written in the dialect of the options.
+//
+// The main template is named after the options, and each template that is
+// defined with {{define}} or {{block}} becomes a template of its own. A
+// template that uses its data is given a data parameter of the type any,
+// which should be replaced with the real type.
+//
+// The actions of the templates become Go code: {{if}}, {{range}} and {{with}}
+// become silent scripts, {{template}} becomes an @render command, and the
+// output of pipelines becomes interpolations. The HTML is rewritten as the
+// elements of Haml and Slim, and is kept as it is in EGO templates. Anything
+// that can't be imported is left in the template as a TODO comment.
+func Import(src []byte, opts ImportOptions) ([]byte, error) {
+	if _, ok := dialectKeywords[opts.Dialect]; !ok {
+		return nil, fmt.Errorf("unknown dialect: %q, expected haml, slim or ego", opts.Dialect)
+	}
+
+	t := parse.New(opts.Name)
+	t.Mode = parse.ParseComments | parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := t.Parse(string(src), "", "", trees); err != nil {
+		return nil, err
+	}
+
+	// the main template comes first and the defined templates follow in the
+	// order of the source; a file of only definitions has no main template
+	var ordered []*parse.Tree
+	for name, tree := range trees {
+		if name == opts.Name && parse.IsEmptyTree(tree.Root) {
+			continue
+		}
+		ordered = append(ordered, tree)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if (ordered[i].Name == opts.Name) != (ordered[j].Name == opts.Name) {
+			return ordered[i].Name == opts.Name
+		}
+		return ordered[i].Root.Pos < ordered[j].Root.Pos
+	})
+
+	names := make(map[string]string, len(trees))
+	for name := range trees {
+		names[name] = importName(name)
+	}
+
+	// the @render calls pass data to the templates that use their data
+	params := make(map[string]bool, len(ordered))
+	for _, tree := range ordered {
+		im := newImporter(opts.Dialect, names, nil)
+		im.template(tree)
+		params[tree.Name] = im.usesData
+	}
+
+	var body strings.Builder
+	usesFmt := false
+	for _, tree := range ordered {
+		im := newImporter(opts.Dialect, names, params)
+		code := im.template(tree)
+		usesFmt = usesFmt || im.usesFmt
+
+		body.WriteString("\n")
+		params := ""
+		if im.usesData {
+			params = "data any"
+			fmt.Fprintf(&body, "// TODO: replace the type of data with the type of the data of the %q template.\n", tree.Name)
+		}
+		fmt.Fprintf(&body, "%s %s(%s) {\n%s}\n", dialectKeywords[opts.Dialect], names[tree.Name], params, code)
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "package %s\n", opts.Package)
+	if usesFmt {
+		buf.WriteString("\nimport \"fmt\"\n")
+	}
+	buf.WriteString(body.String())
+
+	return Format([]byte(buf.String()))
+}
+
+// importName returns the name of the Go function of a template.
+func importName(name string) string {
+	var b strings.Builder
+	for _, field := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(field[:1]) + field[1:])
+	}
+	s := b.String()
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "T" + s
+	}
+	return s
+}
+
+// importScope holds the Go expression of the dot and the Go names of the
+// variables of a block of a template.
+type importScope struct {
+	parent *importScope
+	dot    string
+	vars   map[string]string
+}
+
+func (s *importScope) lookup(name string) (string, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// importNode is a node of the HTML of a Haml or Slim template.
+type importNode interface{}
+
+type (
+	importElement struct {
+		tag      string
+		attrs    []*htmlAttr
+		children []importNode
+		// raw is set for the elements with raw text, such as script and style
+		raw bool
+		// content is the raw text while the element is open
+		content []htmlPart
+	}
+	importText struct {
+		parts []htmlPart
+	}
+	importCode struct {
+		code     string
+		children []importNode
+	}
+	importRender struct {
+		call string
+	}
+	importComment struct {
+		text string
+		// silent comments are not rendered
+		silent bool
+	}
+	importDoctype struct{}
+	// importRaw is HTML that is written as text, such as the tags of an
+	// element that is opened and closed in different blocks.
+	importRaw struct {
+		parts []htmlPart
+	}
+)
+
+// importFrame holds the nodes of a block and its open elements.
+type importFrame struct {
+	nodes *[]importNode
+	open  []*importElement
+}
+
+func (f *importFrame) container() *[]importNode {
+	if n := len(f.open); n > 0 {
+		return &f.open[n-1].children
+	}
+	return f.nodes
+}
+
+// importClosedBy are the elements that are closed when one of the given
+// elements is opened inside of them.
+var importClosedBy = map[string][]string{
+	"li":     {"li"},
+	"dt":     {"dt", "dd"},
+	"dd":     {"dt", "dd"},
+	"tr":     {"tr"},
+	"td":     {"td", "th", "tr"},
+	"th":     {"td", "th", "tr"},
+	"option": {"option", "optgroup"},
+	"p": {
+		"address", "article", "aside", "blockquote", "details", "div", "dl",
+		"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2",
+		"h3", "h4", "h5", "h6", "header", "hr", "main", "nav", "ol", "p",
+		"pre", "section", "table", "ul",
+	},
+}
+
+type importer struct {
+	dialect string
+	// names are the Go names of the templates of the file
+	names map[string]string
+	// params are whether the templates of the file have a data parameter
+	params map[string]bool
+	scope  *importScope
+	taken  map[string]bool
+	// ints are the variables that hold integers
+	ints map[string]bool
+	// used are the variables that are used by the template
+	used     map[string]bool
+	usesData bool
+	usesFmt  bool
+	// todos are the notes about the pipeline that is being imported
+	todos []string
+
+	// ego is the body of an EGO template
+	ego strings.Builder
+
+	// the HTML of a Haml or Slim template
+	frames []*importFrame
+	state  htmlState
+	name   strings.Builder
+	tag    *importElement
+	attr   *htmlAttr
+	quote  byte
+	rawEl  *importElement
+}
+
+func newImporter(dialect string, names map[string]string, params map[string]bool) *importer {
+	return &importer{
+		dialect: dialect,
+		names:   names,
+		params:  params,
+		scope:   &importScope{dot: "data", vars: map[string]string{"$": "data"}},
+		taken:   map[string]bool{"data": true},
+		ints:    map[string]bool{},
+		used:    map[string]bool{},
+	}
+}
+
+// template returns the lines of the body of the template.
+func (im *importer) template(tree *parse.Tree) string {
+	if im.dialect == "ego" {
+		im.egoList(tree.Root)
+		var b strings.Builder
+		for _, line := range strings.Split(strings.Trim(im.ego.String(), " \t\n\r"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				b.WriteString("\n")
+				continue
+			}
+			b.WriteString("\t" + line + "\n")
+		}
+		return b.String()
+	}
+
+	var nodes []importNode
+	im.block(&nodes, tree.Root)
+	w := &importWriter{dialect: im.dialect}
+	w.nodes(nodes, 1)
+	return w.String()
+}
+
+// todo records a note about the pipeline that is being imported.
+func (im *importer) todo(format string, args ...any) {
+	im.todos = append(im.todos, fmt.Sprintf(format, args...))
+}
+
+// takeTodos returns the recorded notes as TODO comments.
+func (im *importer) takeTodos() []string {
+	todos := im.todos
+	im.todos = nil
+	return todos
+}
+
+func (im *importer) push(dot string) {
+	im.scope = &importScope{parent: im.scope, dot: dot, vars: map[string]string{}}
+}
+
+func (im *importer) pop() {
+	im.scope = im.scope.parent
+}
+
+// declare returns a Go name for the template variable or the value of the
+// expression, which is not used by any other variable.
+func (im *importer) declare(variable, expr string) string {
+	name := strings.TrimPrefix(variable, "$")
+	if name == "" {
+		name = importVarName(expr)
+	}
+	if gotoken.IsKeyword(name) {
+		name += "_"
+	}
+	base := name
+	for i := 2; im.taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	im.taken[name] = true
+	if variable != "" {
+		im.scope.vars[variable] = name
+	}
+	return name
+}
+
+// importVarName returns the name of a variable for the value of the Go
+// expression, which is the singular of the last field of the expression.
+func importVarName(expr string) string {
+	if i := strings.LastIndexAny(expr, ".()[] "); i >= 0 {
+		expr = expr[i+1:]
+	}
+	if expr == "" || !gotoken.IsIdentifier(expr) {
+		return "item"
+	}
+	name := strings.ToLower(expr[:1]) + expr[1:]
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ss"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+// dot returns the Go expression of the dot.
+func (im *importer) dot() string {
+	if im.scope.dot == "data" {
+		im.usesData = true
+	}
+	im.used[im.scope.dot] = true
+	return im.scope.dot
+}
+
+// pipe returns the Go expression of the value of the pipeline; the value of
+// each command is the last argument of the next command.
+func (im *importer) pipe(p *parse.PipeNode) string {
+	var result string
+	for i, cmd := range p.Cmds {
+		var extra []string
+		if i > 0 {
+			extra = []string{result}
+		}
+		result = im.command(cmd, extra)
+	}
+	return result
+}
+
+func (im *importer) command(cmd *parse.CommandNode, extra []string) string {
+	args := make([]string, 0, len(cmd.Args)-1+len(extra))
+	for _, arg := range cmd.Args[1:] {
+		args = append(args, im.operand(arg))
+	}
+	args = append(args, extra...)
+
+	switch first := cmd.Args[0].(type) {
+	case *parse.IdentifierNode:
+		return im.call(first.Ident, args)
+	case *parse.FieldNode, *parse.ChainNode, *parse.VariableNode:
+		if len(args) > 0 {
+			// a method that is called with arguments
+			return im.operand(first) + "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return im.operand(cmd.Args[0])
+}
+
+func (im *importer) operand(n parse.Node) string {
+	switch n := n.(type) {
+	case *parse.DotNode:
+		return im.dot()
+	case *parse.FieldNode:
+		return im.dot() + "." + strings.Join(n.Ident, ".")
+	case *parse.VariableNode:
+		v, ok := im.scope.lookup(n.Ident[0])
+		if !ok {
+			v = strings.TrimPrefix(n.Ident[0], "$")
+		}
+		if v == "data" {
+			im.usesData = true
+		}
+		im.used[v] = true
+		return strings.Join(append([]string{v}, n.Ident[1:]...), ".")
+	case *parse.ChainNode:
+		return importParen(im.operand(n.Node)) + "." + strings.Join(n.Field, ".")
+	case *parse.PipeNode:
+		return importParen(im.pipe(n))
+	case *parse.IdentifierNode:
+		return im.call(n.Ident, nil)
+	case *parse.StringNode:
+		return n.Quoted
+	case *parse.NumberNode:
+		return n.Text
+	case *parse.BoolNode:
+		return strconv.FormatBool(n.True)
+	case *parse.NilNode:
+		return "nil"
+	}
+	im.todo("%s could not be imported", n)
+	return "nil"
+}
+
+// importOperators are the functions of the templates that are Go operators.
+var importOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"eq":  "==",
+	"ne":  "!=",
+	"lt":  "<",
+	"le":  "<=",
+	"gt":  ">",
+	"ge":  ">=",
+}
+
+// call returns the Go expression of a call to a function of a template.
+func (im *importer) call(name string, args []string) string {
+	if op, ok := importOperators[name]; ok && len(args) >= 2 {
+		for i, arg := range args {
+			args[i] = importParen(arg)
+		}
+		switch name {
+		case "and", "or":
+			return strings.Join(args, " "+op+" ")
+		case "eq":
+			// eq is true when the first argument equals any of the others
+			var ors []string
+			for _, arg := range args[1:] {
+				ors = append(ors, args[0]+" == "+arg)
+			}
+			return strings.Join(ors, " || ")
+		default:
+			return args[0] + " " + op + " " + args[1]
+		}
+	}
+
+	switch {
+	case name == "not" && len(args) == 1:
+		return "!" + importParen(args[0])
+	case name == "len" && len(args) == 1:
+		return "len(" + args[0] + ")"
+	case name == "index" && len(args) >= 1:
+		s := importParen(args[0])
+		for _, arg := range args[1:] {
+			s += "[" + arg + "]"
+		}
+		return s
+	case name == "slice" && len(args) >= 1 && len(args) <= 4:
+		return importParen(args[0]) + "[" + strings.Join(append(args[1:], make([]string, max(0, 2-len(args)))...), ":") + "]"
+	case name == "print" || name == "printf" || name == "println":
+		im.usesFmt = true
+		return "fmt.S" + name + "(" + strings.Join(args, ", ") + ")"
+	case name == "call" && len(args) >= 1:
+		return importParen(args[0]) + "(" + strings.Join(args[1:], ", ") + ")"
+	case (name == "html" || name == "js" || name == "urlquery") && len(args) == 1:
+		// Goht escapes the output for the context it is written in
+		return args[0]
+	}
+
+	im.todo("the %s function of the template is called as a Go function", name)
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// output returns the part of the output of the pipeline; integers are
+// formatted, as the output of Goht must be a string.
+func (im *importer) output(p *parse.PipeNode) htmlPart {
+	code := im.pipe(p)
+	if im.isInt(code) {
+		return htmlPart{code: code, format: "%d"}
+	}
+	return htmlPart{code: code}
+}
+
+// isInt returns true for a Go expression that is known to be an integer.
+func (im *importer) isInt(code string) bool {
+	return im.ints[code] || strings.HasPrefix(code, "len(") && importParen(code) == code
+}
+
+// importParen wraps a Go expression with parentheses when it is not a
+// single operand.
+func importParen(expr string) string {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && c == ' ':
+			return "(" + expr + ")"
+		}
+	}
+	return expr
+}
+
+// assignment returns the Go statement of a pipeline that declares or assigns
+// variables.
+func (im *importer) assignment(p *parse.PipeNode) string {
+	expr := im.pipe(p)
+	var names []string
+	for _, v := range p.Decl {
+		if p.IsAssign {
+			name, _ := im.scope.lookup(v.Ident[0])
+			names = append(names, name)
+			continue
+		}
+		names = append(names, im.declare(v.Ident[0], expr))
+	}
+	op := " := "
+	if p.IsAssign {
+		op = " = "
+	}
+	return strings.Join(names, ", ") + op + expr
+}
+
+// importLoop is the for loop of a range.
+type importLoop struct {
+	key, value, expr string
+}
+
+// rangeClause declares the variables of the loop of a range in a new scope.
+func (im *importer) rangeClause(p *parse.PipeNode) *importLoop {
+	expr := im.pipe(p)
+	im.push("")
+	loop := &importLoop{key: "_", expr: expr}
+	switch len(p.Decl) {
+	case 0:
+		loop.value = im.declare("", expr)
+	case 1:
+		loop.value = im.declare(p.Decl[0].Ident[0], expr)
+	default:
+		loop.key, loop.value = im.declare(p.Decl[0].Ident[0], expr), im.declare(p.Decl[1].Ident[0], expr)
+		im.ints[loop.key] = true
+	}
+	im.scope.dot = loop.value
+	return loop
+}
+
+// code returns the Go code of the for loop; the variables that the content
+// of the loop doesn't use are left out, as Go doesn't allow unused variables.
+func (l *importLoop) code(used map[string]bool) string {
+	key, value := l.key, l.value
+	if used != nil && !used[key] {
+		key = "_"
+	}
+	if used != nil && !used[value] {
+		value = "_"
+	}
+	switch {
+	case key == "_" && value == "_":
+		return "for range " + l.expr
+	case value == "_":
+		return "for " + key + " := range " + l.expr
+	}
+	return "for " + key + ", " + value + " := range " + l.expr
+}
+
+// withClause returns the Go code of the condition of a with and declares the
+// variable of its value in a new scope.
+func (im *importer) withClause(p *parse.PipeNode) string {
+	expr := im.pipe(p)
+	variable := ""
+	if len(p.Decl) > 0 {
+		variable = p.Decl[0].Ident[0]
+	}
+	im.push("")
+	name := im.declare(variable, expr)
+	im.scope.dot = name
+	im.todo("check the condition of {{with %s}}; the template tests whether the value is empty", p)
+	return "if " + name + " := " + expr + "; " + name + " != nil"
+}
+
+// condition returns the Go condition of an if. The templates test whether
+// the value is empty, so integers are compared with zero, and other values
+// that are not known to be booleans are left with a note.
+func (im *importer) condition(p *parse.PipeNode) string {
+	if len(p.Decl) > 0 {
+		im.todo("the variables of {{if %s}} are not declared", p)
+	}
+	code := im.pipe(p)
+	switch {
+	case im.isInt(code):
+		return importParen(code) + " != 0"
+	case !importIsBool(p):
+		im.todo("check the condition of {{if %s}}; the template tests whether the value is empty", p)
+	}
+	return code
+}
+
+// importBoolFuncs are the functions of the templates that are imported as Go
+// operators with a boolean result.
+var importBoolFuncs = map[string]bool{
+	"and": true, "or": true, "not": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+}
+
+// importIsBool returns true when the value of the pipeline is a boolean.
+func importIsBool(p *parse.PipeNode) bool {
+	if len(p.Cmds) == 0 {
+		return false
+	}
+	switch first := p.Cmds[len(p.Cmds)-1].Args[0].(type) {
+	case *parse.IdentifierNode:
+		return importBoolFuncs[first.Ident]
+	case *parse.BoolNode:
+		return true
+	case *parse.PipeNode:
+		return importIsBool(first)
+	}
+	return false
+}
+
+// render returns the @render call of a {{template}} action.
+func (im *importer) render(n *parse.TemplateNode) string {
+	name, ok := im.names[n.Name]
+	if !ok {
+		name = importName(n.Name)
+		im.todo("the %q template is not defined in this file", n.Name)
+	}
+	arg := ""
+	if n.Pipe != nil {
+		arg = im.pipe(n.Pipe)
+	}
+	if usesData, ok := im.params[n.Name]; ok {
+		switch {
+		case !usesData:
+			arg = ""
+		case arg == "":
+			arg = "nil"
+		}
+	}
+	return "@render " + name + "(" + arg + ")"
+}
+
+// egoList writes the nodes of a list to the body of an EGO template.
+func (im *importer) egoList(list *parse.ListNode) {
+	if list == nil {
+		return
+	}
+	for _, n := range list.Nodes {
+		im.egoNode(n)
+	}
+}
+
+func (im *importer) egoNode(n parse.Node) {
+	switch n := n.(type) {
+	case *parse.TextNode:
+		im.ego.WriteString(strings.ReplaceAll(string(n.Text), "<%", "<%%"))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			im.egoTag("<% " + im.assignment(n.Pipe) + " %>")
+			return
+		}
+		part := im.output(n.Pipe)
+		im.egoTag("<%= " + importFormat(part) + part.code + " %>")
+	case *parse.CommentNode:
+		im.ego.WriteString("<%# " + importCommentText(n.Text) + " %>")
+	case *parse.IfNode:
+		im.egoTag("<% if " + im.condition(n.Pipe) + " { %>")
+		im.egoBranch(n.List, n.ElseList)
+	case *parse.RangeNode:
+		im.egoRange(n)
+	case *parse.WithNode:
+		im.egoTag("<% " + im.withClause(n.Pipe) + " { %>")
+		im.egoList(n.List)
+		im.pop()
+		im.egoElse(n.ElseList)
+	case *parse.TemplateNode:
+		im.egoTag("<%" + im.render(n) + " %>")
+	case *parse.BreakNode:
+		im.ego.WriteString("<% break %>")
+	case *parse.ContinueNode:
+		im.ego.WriteString("<% continue %>")
+	default:
+		im.ego.WriteString("<%# TODO: " + importCommentText(n.String()) + " could not be imported %>")
+	}
+}
+
+// egoTag writes a tag after the TODO comments of its pipeline.
+func (im *importer) egoTag(tag string) {
+	for _, todo := range im.takeTodos() {
+		im.ego.WriteString("<%# TODO: " + todo + " %>")
+	}
+	im.ego.WriteString(tag)
+}
+
+// egoElseTag writes the tag of an else before the TODO comments of its
+// pipeline.
+func (im *importer) egoElseTag(tag string) {
+	im.ego.WriteString(tag)
+	for _, todo := range im.takeTodos() {
+		im.ego.WriteString("<%# TODO: " + todo + " %>")
+	}
+}
+
+func (im *importer) egoBranch(list, elseList *parse.ListNode) {
+	im.egoList(list)
+	im.egoElse(elseList)
+}
+
+// egoElse writes the else of a block and closes the block; an else that
+// holds only an if or a with is an {{else if}} or an {{else with}}.
+func (im *importer) egoElse(elseList *parse.ListNode) {
+	if elseList == nil {
+		im.ego.WriteString("<% } %>")
+		return
+	}
+	if len(elseList.Nodes) == 1 {
+		switch n := elseList.Nodes[0].(type) {
+		case *parse.IfNode:
+			im.egoElseTag("<% } else if " + im.condition(n.Pipe) + " { %>")
+			im.egoBranch(n.List, n.ElseList)
+			return
+		case *parse.WithNode:
+			im.egoElseTag("<% } else " + im.withClause(n.Pipe) + " { %>")
+			im.egoList(n.List)
+			im.pop()
+			im.egoElse(n.ElseList)
+			return
+		}
+	}
+	im.ego.WriteString("<% } else { %>")
+	im.egoList(elseList)
+	im.ego.WriteString("<% } %>")
+}
+
+func (im *importer) egoRange(n *parse.RangeNode) {
+	if n.ElseList != nil {
+		im.egoTag("<% if len(" + im.pipe(n.Pipe) + ") > 0 { %>")
+	}
+	loop := im.rangeClause(n.Pipe)
+	start := im.ego.Len()
+	im.egoTag("<% " + loop.code(nil) + " { %>")
+	im.egoList(n.List)
+	im.pop()
+	if code := loop.code(im.used); code != loop.code(nil) {
+		s := im.ego.String()
+		i := start + strings.Index(s[start:], loop.code(nil))
+		im.ego.Reset()
+		im.ego.WriteString(s[:i] + code + s[i+len(loop.code(nil)):])
+	}
+	im.ego.WriteString("<% } %>")
+	if n.ElseList != nil {
+		im.ego.WriteString("<% } else { %>")
+		im.egoList(n.ElseList)
+		im.ego.WriteString("<% } %>")
+	}
+}
+
+// importCommentText returns the text of a template comment without its
+// delimiters, on a single line.
+func importCommentText(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "/*"), "*/")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// block imports the list of a block of a Haml or Slim template into the
+// nodes.
+func (im *importer) block(nodes *[]importNode, list *parse.ListNode) {
+	f := &importFrame{nodes: nodes}
+	im.frames = append(im.frames, f)
+	if list != nil {
+		for _, n := range list.Nodes {
+			im.node(n)
+		}
+	}
+	im.addTodos()
+	im.frames = im.frames[:len(im.frames)-1]
+
+	// the elements that are still open belong to the HTML around the block,
+	// so their tags are written as text, unless their end tag is optional
+	if len(im.frames) == 0 {
+		return
+	}
+	for i := len(f.open) - 1; i >= 0; i-- {
+		if _, ok := importClosedBy[f.open[i].tag]; ok {
+			continue
+		}
+		parent := f.nodes
+		if i > 0 {
+			parent = &f.open[i-1].children
+		}
+		el := f.open[i]
+		idx := slices.IndexFunc(*parent, func(n importNode) bool { return n == importNode(el) })
+		unwrapped := append([]importNode{importStartTag(el)}, el.children...)
+		*parent = slices.Replace(*parent, idx, idx+1, unwrapped...)
+	}
+}
+
+func (im *importer) frame() *importFrame {
+	return im.frames[len(im.frames)-1]
+}
+
+// add adds the node to the open element of the block, after the TODO
+// comments of its pipeline.
+func (im *importer) add(n importNode) {
+	im.addTodos()
+	c := im.frame().container()
+	*c = append(*c, n)
+}
+
+// addElse adds the else of a block; the TODO comments of its pipeline are
+// added to the else, as nothing may come between a block and its else.
+func (im *importer) addElse(code *importCode) {
+	c := im.frame().container()
+	*c = append(*c, code)
+}
+
+func (im *importer) addTodos() {
+	for _, todo := range im.takeTodos() {
+		c := im.frame().container()
+		*c = append(*c, &importComment{text: "TODO: " + todo, silent: true})
+	}
+}
+
+// addPart adds text or an expression to the text at the end of the open
+// element of the block.
+func (im *importer) addPart(p htmlPart) {
+	c := im.frame().container()
+	if len(im.todos) == 0 && len(*c) > 0 {
+		if t, ok := (*c)[len(*c)-1].(*importText); ok {
+			t.parts = append(t.parts, p)
+			return
+		}
+	}
+	im.add(&importText{parts: []htmlPart{p}})
+}
+
+func (im *importer) node(n parse.Node) {
+	if im.state != htmlText {
+		im.nodeInTag(n)
+		return
+	}
+
+	switch n := n.(type) {
+	case *parse.TextNode:
+		im.html(string(n.Text))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			im.add(&importCode{code: im.assignment(n.Pipe)})
+			return
+		}
+		im.addPart(im.output(n.Pipe))
+	case *parse.CommentNode:
+		im.add(&importComment{text: importCommentText(n.Text), silent: true})
+	case *parse.IfNode:
+		code := &importCode{code: "if " + im.condition(n.Pipe)}
+		im.add(code)
+		im.block(&code.children, n.List)
+		im.elseBlock(n.ElseList)
+	case *parse.RangeNode:
+		im.rangeBlock(n)
+	case *parse.WithNode:
+		code := &importCode{code: im.withClause(n.Pipe)}
+		im.add(code)
+		im.block(&code.children, n.List)
+		im.pop()
+		im.elseBlock(n.ElseList)
+	case *parse.TemplateNode:
+		call := im.render(n)
+		im.add(&importRender{call: call})
+	case *parse.BreakNode:
+		im.add(&importCode{code: "break"})
+	case *parse.ContinueNode:
+		im.add(&importCode{code: "continue"})
+	default:
+		im.todo("%s could not be imported", importCommentText(n.String()))
+	}
+}
+
+// elseBlock imports the else of a block; an else that holds only an if or a
+// with is an {{else if}} or an {{else with}}.
+func (im *importer) elseBlock(elseList *parse.ListNode) {
+	if elseList == nil {
+		return
+	}
+	if len(elseList.Nodes) == 1 {
+		switch n := elseList.Nodes[0].(type) {
+		case *parse.IfNode:
+			code := &importCode{code: "else if " + im.condition(n.Pipe)}
+			im.addElse(code)
+			im.block(&code.children, n.List)
+			im.elseBlock(n.ElseList)
+			return
+		case *parse.WithNode:
+			code := &importCode{code: "else " + im.withClause(n.Pipe)}
+			im.addElse(code)
+			im.block(&code.children, n.List)
+			im.pop()
+			im.elseBlock(n.ElseList)
+			return
+		}
+	}
+	code := &importCode{code: "else"}
+	im.add(code)
+	im.block(&code.children, elseList)
+}
+
+func (im *importer) rangeBlock(n *parse.RangeNode) {
+	var outer *importCode
+	if n.ElseList != nil {
+		outer = &importCode{code: "if len(" + im.pipe(n.Pipe) + ") > 0"}
+		im.add(outer)
+		im.frames = append(im.frames, &importFrame{nodes: &outer.children})
+	}
+	clause := im.rangeClause(n.Pipe)
+	loop := &importCode{}
+	im.add(loop)
+	im.block(&loop.children, n.List)
+	im.pop()
+	loop.code = clause.code(im.used)
+	if outer != nil {
+		im.frames = im.frames[:len(im.frames)-1]
+		code := &importCode{code: "else"}
+		im.add(code)
+		im.block(&code.children, n.ElseList)
+	}
+}
+
+// nodeInTag imports a node of the template that is inside of a tag, a
+// comment or an element with raw text.
+func (im *importer) nodeInTag(n parse.Node) {
+	switch n := n.(type) {
+	case *parse.TextNode:
+		im.html(string(n.Text))
+		return
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			break
+		}
+		switch im.state {
+		case htmlBeforeValue:
+			im.state, im.quote = htmlAttrValue, 0
+			fallthrough
+		case htmlAttrValue:
+			if goht.AttributeContext(im.attr.name) == goht.JSContext {
+				if _, c := importJSContexts(im.attr.value); c.err != "" {
+					break
+				}
+			}
+			part := im.output(n.Pipe)
+			if part.format != "" {
+				part = htmlPart{code: "goht.FormatString(" + strconv.Quote(part.format) + ", " + part.code + ")"}
+			}
+			im.attr.value = append(im.attr.value, part)
+			return
+		case htmlRawText:
+			im.rawEl.content = append(im.rawEl.content, im.output(n.Pipe))
+			return
+		}
+	case *parse.IfNode:
+		// {{if .Checked}}checked{{end}} is a boolean attribute
+		if im.state == htmlInTag && n.ElseList == nil && len(n.List.Nodes) == 1 {
+			if t, ok := n.List.Nodes[0].(*parse.TextNode); ok {
+				if name := strings.TrimSpace(string(t.Text)); reHTMLAttrName.MatchString(name) {
+					cond := im.condition(n.Pipe)
+					im.tag.attrs = append(im.tag.attrs, &htmlAttr{name: name, cond: cond})
+					return
+				}
+			}
+		}
+	case *parse.CommentNode:
+		return
+	}
+
+	where := "a tag"
+	switch {
+	case im.state == htmlComment:
+		where = "an HTML comment"
+	case im.state == htmlRawText:
+		where = "the <" + im.rawEl.tag + "> element"
+	case im.tag != nil:
+		where = "the <" + im.tag.tag + "> tag"
+	}
+	im.todo("%s could not be imported inside of %s", importCommentText(n.String()), where)
+}
+
+// html imports the HTML of a text node of a Haml or Slim template, which
+// continues the HTML of the text nodes before it.
+func (im *importer) html(s string) {
+	for len(s) > 0 {
+		switch im.state {
+		case htmlText:
+			i := strings.IndexByte(s, '<')
+			if i < 0 {
+				im.text(s)
+				return
+			}
+			im.text(s[:i])
+			s = s[i:]
+			switch {
+			case strings.HasPrefix(s, "<!--"):
+				im.state = htmlComment
+				im.name.Reset()
+				s = s[4:]
+			case strings.HasPrefix(s, "</") && len(s) > 2 && isHTMLLetter(s[2]):
+				im.state = htmlEndTag
+				im.name.Reset()
+				s = s[2:]
+			case strings.HasPrefix(s, "<!"):
+				im.state = htmlDecl
+				im.name.Reset()
+				s = s[2:]
+			case len(s) > 1 && isHTMLLetter(s[1]):
+				im.state = htmlTagName
+				im.name.Reset()
+				im.tag = &importElement{}
+				s = s[1:]
+			default:
+				im.text("<")
+				s = s[1:]
+			}
+		case htmlTagName:
+			i := strings.IndexAny(s, " \t\n\r/>")
+			if i < 0 {
+				im.name.WriteString(s)
+				return
+			}
+			im.name.WriteString(s[:i])
+			im.tag.tag = strings.ToLower(im.name.String())
+			im.state = htmlInTag
+			s = s[i:]
+		case htmlInTag:
+			switch c := s[0]; {
+			case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '/':
+				s = s[1:]
+			case c == '>':
+				s = s[1:]
+				im.startTag()
+			case c == '=' && im.attr != nil && !im.attr.hasValue:
+				im.attr.hasValue = true
+				im.state = htmlBeforeValue
+				s = s[1:]
+			default:
+				im.attr = &htmlAttr{}
+				im.tag.attrs = append(im.tag.attrs, im.attr)
+				im.name.Reset()
+				im.state = htmlAttrName
+			}
+		case htmlAttrName:
+			i := strings.IndexAny(s, " \t\n\r=/>")
+			if i < 0 {
+				im.name.WriteString(s)
+				return
+			}
+			im.name.WriteString(s[:i])
+			im.attr.name = im.name.String()
+			im.state = htmlInTag
+			s = s[i:]
+		case htmlBeforeValue:
+			switch c := s[0]; c {
+			case ' ', '\t', '\n', '\r':
+				s = s[1:]
+			case '"', '\'':
+				im.state, im.quote = htmlAttrValue, c
+				s = s[1:]
+			case '>':
+				im.state = htmlInTag
+			default:
+				im.state, im.quote = htmlAttrValue, 0
+			}
+		case htmlAttrValue:
+			var i int
+			if im.quote != 0 {
+				i = strings.IndexByte(s, im.quote)
+			} else {
+				i = strings.IndexAny(s, " \t\n\r>")
+			}
+			if i < 0 {
+				im.attr.value = append(im.attr.value, htmlPart{text: s})
+				return
+			}
+			if i > 0 {
+				im.attr.value = append(im.attr.value, htmlPart{text: s[:i]})
+			}
+			if im.quote != 0 {
+				i++
+			}
+			im.state = htmlInTag
+			s = s[i:]
+		case htmlEndTag:
+			i := strings.IndexByte(s, '>')
+			if i < 0 {
+				im.name.WriteString(s)
+				return
+			}
+			im.name.WriteString(s[:i])
+			im.state = htmlText
+			im.endTag(strings.ToLower(strings.TrimSpace(im.name.String())))
+			s = s[i+1:]
+		case htmlComment:
+			i := strings.Index(s, "-->")
+			if i < 0 {
+				im.name.WriteString(s)
+				return
+			}
+			im.name.WriteString(s[:i])
+			im.state = htmlText
+			im.comment(im.name.String())
+			s = s[i+3:]
+		case htmlDecl:
+			i := strings.IndexByte(s, '>')
+			if i < 0 {
+				im.name.WriteString(s)
+				return
+			}
+			im.name.WriteString(s[:i])
+			im.state = htmlText
+			if decl := strings.Join(strings.Fields(im.name.String()), " "); strings.EqualFold(decl, "doctype html") {
+				im.add(&importDoctype{})
+			} else {
+				im.add(&importRaw{parts: []htmlPart{{text: "<!" + decl + ">"}}})
+			}
+			s = s[i+1:]
+		case htmlRawText:
+			i := strings.Index(strings.ToLower(s), "</"+im.rawEl.tag)
+			if i < 0 {
+				im.rawEl.content = append(im.rawEl.content, htmlPart{text: s})
+				return
+			}
+			if i > 0 {
+				im.rawEl.content = append(im.rawEl.content, htmlPart{text: s[:i]})
+			}
+			s = s[i:]
+			if j := strings.IndexByte(s, '>'); j >= 0 {
+				s = s[j+1:]
+			} else {
+				s = ""
+			}
+			if len(im.rawEl.content) > 0 {
+				im.rawEl.children = []importNode{&importText{parts: im.rawEl.content}}
+			}
+			im.rawEl = nil
+			im.state = htmlText
+		}
+	}
+}
+
+func (im *importer) text(s string) {
+	if s != "" {
+		im.addPart(htmlPart{text: s})
+	}
+}
+
+func (im *importer) startTag() {
+	el := im.tag
+	im.tag, im.attr = nil, nil
+	im.state = htmlText
+
+	f := im.frame()
+	if n := len(f.open); n > 0 && slices.Contains(importClosedBy[f.open[n-1].tag], el.tag) {
+		f.open = f.open[:n-1]
+	}
+	im.add(el)
+	switch {
+	case slices.Contains(selfClosedTags, el.tag):
+	case slices.Contains(htmlRawTextTags, el.tag):
+		el.raw = true
+		im.rawEl = el
+		im.state = htmlRawText
+	default:
+		f.open = append(f.open, el)
+	}
+}
+
+func (im *importer) endTag(name string) {
+	f := im.frame()
+	for i := len(f.open) - 1; i >= 0; i-- {
+		if f.open[i].tag == name {
+			f.open = f.open[:i]
+			return
+		}
+	}
+	if slices.Contains(selfClosedTags, name) {
+		return
+	}
+	// the element was opened outside of the block
+	im.add(&importRaw{parts: []htmlPart{{text: "</" + name + ">"}}})
+}
+
+func (im *importer) comment(s string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return
+	}
+	if strings.HasPrefix(s, "[") {
+		// conditional comments are written as they are
+		im.add(&importRaw{parts: []htmlPart{{text: "<!--" + s + "-->"}}})
+		return
+	}
+	im.add(&importComment{text: strings.Join(strings.Fields(s), " ")})
+}
+
+// importStartTag returns the start tag of the element as HTML.
+func importStartTag(el *importElement) *importRaw {
+	parts := []htmlPart{{text: "<" + el.tag}}
+	for _, a := range el.attrs {
+		switch {
+		case a.cond != "":
+			parts = append(parts, htmlPart{text: " "}, htmlPart{code: "goht.If(" + a.cond + ", " + strconv.Quote(a.name) + ", \"\")"})
+		case !a.hasValue:
+			parts = append(parts, htmlPart{text: " " + a.name})
+		default:
+			parts = append(parts, htmlPart{text: " " + a.name + "=\""})
+			parts = append(parts, a.value...)
+			parts = append(parts, htmlPart{text: "\""})
+		}
+	}
+	parts = append(parts, htmlPart{text: ">"})
+	return &importRaw{parts: parts}
+}
+
+// importWriter writes the nodes of a Haml or Slim template.
+type importWriter struct {
+	strings.Builder
+	dialect string
+}
+
+func (w *importWriter) line(depth int, s string) {
+	w.WriteString(strings.Repeat("\t", depth) + s + "\n")
+}
+
+func (w *importWriter) nodes(nodes []importNode, depth int) {
+	for i, n := range nodes {
+		switch n := n.(type) {
+		case *importElement:
+			w.element(n, depth)
+		case *importText:
+			w.text(n.parts, depth, i > 0, i < len(nodes)-1)
+		case *importRaw:
+			w.text(n.parts, depth, i > 0, i < len(nodes)-1)
+		case *importCode:
+			w.line(depth, "- "+n.code)
+			w.nodes(n.children, depth+1)
+		case *importRender:
+			w.line(depth, "= "+n.call)
+		case *importComment:
+			switch {
+			case n.silent && w.dialect == "haml":
+				w.line(depth, "-# "+n.text)
+			case n.silent:
+				w.line(depth, "/ "+n.text)
+			case w.dialect == "haml":
+				w.line(depth, "/ "+n.text)
+			default:
+				w.line(depth, "/! "+n.text)
+			}
+		case *importDoctype:
+			if w.dialect == "haml" {
+				w.line(depth, "!!!")
+			} else {
+				w.line(depth, "doctype")
+			}
+		}
+	}
+}
+
+// text writes the lines of text; the text of Slim templates has no newlines,
+// so the whitespace around the text is kept in the lines.
+func (w *importWriter) text(parts []htmlPart, depth int, before, after bool) {
+	var lines [][]htmlPart
+	var line []htmlPart
+	for _, p := range parts {
+		if p.code != "" {
+			line = append(line, p)
+			continue
+		}
+		for i, s := range strings.Split(p.text, "\n") {
+			if i > 0 {
+				lines = append(lines, line)
+				line = nil
+			}
+			if s != "" {
+				line = append(line, htmlPart{text: s})
+			}
+		}
+	}
+	lines = append(lines, line)
+
+	var kept [][]htmlPart
+	for _, line := range lines {
+		if s := importLine(line); strings.TrimSpace(s) != "" {
+			kept = append(kept, line)
+		}
+	}
+	for i, line := range kept {
+		s := strings.TrimSpace(importLine(line))
+		if len(line) == 1 && line[0].code != "" {
+			w.line(depth, "= "+importFormat(line[0])+line[0].code)
+			continue
+		}
+		if w.dialect == "haml" {
+			if strings.ContainsRune("%#.\\!-=/:{&~[", rune(s[0])) {
+				s = "\\" + s
+			}
+			w.line(depth, s)
+			continue
+		}
+		if i == 0 && before && strings.TrimLeft(importLine(line), " \t\r") != importLine(line) {
+			s = " " + s
+		}
+		if i < len(kept)-1 || after && strings.TrimRight(importLine(line), " \t\r") != importLine(line) {
+			s += " "
+		}
+		w.line(depth, "| "+s)
+	}
+}
+
+// importFormat returns the format of the output of the part, followed by a
+// space.
+func importFormat(p htmlPart) string {
+	if p.format == "" {
+		return ""
+	}
+	return p.format + " "
+}
+
+// importLine returns the parts as the text of a line with interpolations.
+func importLine(parts []htmlPart) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if p.code != "" {
+			b.WriteString("#{" + importFormat(p) + p.code + "}")
+			continue
+		}
+		b.WriteString(strings.ReplaceAll(p.text, "#{", `#{"#{"}`))
+	}
+	return b.String()
+}
+
+func (w *importWriter) element(el *importElement, depth int) {
+	var head strings.Builder
+	var attrs []string
+	var id string
+	var classes []string
+	for _, a := range el.attrs {
+		static, isStatic := importStatic(a.value)
+		switch {
+		case a.name == "id" && isStatic && reHTMLIdentifier.MatchString(static) && id == "":
+			id = static
+			continue
+		case a.name == "class" && isStatic && a.hasValue:
+			var dynamic []string
+			for _, c := range strings.Fields(static) {
+				if reHTMLIdentifier.MatchString(c) {
+					classes = append(classes, c)
+				} else {
+					dynamic = append(dynamic, c)
+				}
+			}
+			if len(dynamic) == 0 {
+				continue
+			}
+			attrs = append(attrs, "class: "+strconv.Quote(strings.Join(dynamic, " ")))
+			continue
+		}
+		attrs = append(attrs, importAttribute(a))
+	}
+
+	if el.tag != "div" || id == "" && len(classes) == 0 {
+		if w.dialect == "haml" {
+			head.WriteString("%")
+		}
+		head.WriteString(el.tag)
+	}
+	if id != "" {
+		head.WriteString("#" + id)
+	}
+	for _, c := range classes {
+		head.WriteString("." + c)
+	}
+	if len(attrs) > 0 {
+		head.WriteString("{" + strings.Join(attrs, ", ") + "}")
+	}
+
+	var text []htmlPart
+	if len(el.children) == 1 {
+		if t, ok := el.children[0].(*importText); ok {
+			text = t.parts
+		}
+	}
+
+	// scripts and styles without attributes are filters
+	if el.raw && (el.tag == "script" || el.tag == "style") && len(el.attrs) == 0 && text != nil {
+		filter := ":javascript"
+		if el.tag == "style" {
+			filter = ":css"
+		}
+		w.line(depth, filter)
+		w.filter(text, depth+1)
+		return
+	}
+
+	line := importLine(text)
+	switch {
+	case len(el.children) == 0:
+		w.line(depth, head.String())
+	case text != nil && strings.TrimSpace(line) == "":
+		w.line(depth, head.String())
+	case text != nil && !strings.Contains(strings.TrimSpace(line), "\n"):
+		trimmed := importTrim(text)
+		if len(trimmed) == 1 && trimmed[0].code != "" {
+			w.line(depth, head.String()+"= "+importFormat(trimmed[0])+trimmed[0].code)
+			return
+		}
+		s := strings.TrimSpace(line)
+		if w.dialect == "slim" && strings.ContainsRune("=-/|:<>'", rune(s[0])) {
+			w.line(depth, head.String())
+			w.line(depth+1, "| "+s)
+			return
+		}
+		w.line(depth, head.String()+" "+s)
+	default:
+		w.line(depth, head.String())
+		w.nodes(el.children, depth+1)
+	}
+}
+
+// importTrim returns the parts without the whitespace parts around them.
+func importTrim(parts []htmlPart) []htmlPart {
+	for len(parts) > 0 && parts[0].code == "" && strings.TrimSpace(parts[0].text) == "" {
+		parts = parts[1:]
+	}
+	for len(parts) > 0 && parts[len(parts)-1].code == "" && strings.TrimSpace(parts[len(parts)-1].text) == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return parts
+}
+
+// filter writes the content of a filter without its common indentation.
+func (w *importWriter) filter(parts []htmlPart, depth int) {
+	lines := strings.Split(importLine(parts), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			w.WriteString("\n")
+			continue
+		}
+		w.line(depth, line[indent:])
+	}
+}
+
+// importStatic returns the value of an attribute without expressions.
+func importStatic(value []htmlPart) (string, bool) {
+	var b strings.Builder
+	for _, p := range value {
+		if p.code != "" {
+			return "", false
+		}
+		b.WriteString(p.text)
+	}
+	return html.UnescapeString(b.String()), true
+}
+
+// importAttribute returns an attribute of a Haml or Slim attribute list.
+func importAttribute(a *htmlAttr) string {
+	name := a.name
+	if !reHTMLIdentifier.MatchString(name) {
+		name = strconv.Quote(name)
+	}
+	switch {
+	case a.cond != "":
+		return name + "? #{" + a.cond + "}"
+	case !a.hasValue:
+		return name
+	}
+	if static, ok := importStatic(a.value); ok {
+		return name + ": " + strconv.Quote(static)
+	}
+	if goht.AttributeContext(a.name) == goht.JSContext {
+		return name + ": #{" + importJSAttribute(a.value) + "}"
+	}
+	var exprs []string
+	for _, p := range a.value {
+		if p.code != "" {
+			exprs = append(exprs, importParen(p.code))
+			continue
+		}
+		exprs = append(exprs, strconv.Quote(html.UnescapeString(p.text)))
+	}
+	return name + ": #{" + strings.Join(exprs, " + ") + "}"
+}
+
+// importJSAttribute returns the value of an event handler attribute as
+// trusted JavaScript. A dynamic value would be written as one JavaScript
+// string, so only the expressions are escaped, for where they are in the code.
+func importJSAttribute(value []htmlPart) string {
+	contexts, _ := importJSContexts(value)
+	var exprs []string
+	for i, p := range value {
+		switch {
+		case p.code == "":
+			exprs = append(exprs, strconv.Quote(html.UnescapeString(p.text)))
+		case contexts[i].inString:
+			exprs = append(exprs, "goht.EscapeJSString("+p.code+")")
+		default:
+			exprs = append(exprs, "goht.EscapeJS("+p.code+")")
+		}
+	}
+	return "goht.JS(" + strings.Join(exprs, " + ") + ")"
+}
+
+// importJSContexts returns the context of each expression of an event handler
+// attribute value, and the context at the end of the value.
+func importJSContexts(value []htmlPart) ([]escapeContext, escapeContext) {
+	s := &contextScanner{state: stateScript}
+	contexts := make([]escapeContext, len(value))
+	for i, p := range value {
+		if p.code != "" {
+			contexts[i] = s.context()
+			s.jsValue()
+			continue
+		}
+		text := html.UnescapeString(p.text)
+		for j := 0; j < len(text); j++ {
+			j += s.js(text, j)
+		}
+	}
+	return contexts, s.context()
+}
@@ -0,0 +1,138 @@
+package compiler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Importer(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		dialect string
+		want    string
+		wantErr bool
+	}{
+		"html to haml": {
+			input:   "<!DOCTYPE html>\n<html>\n<body>\n  <!-- nav -->\n  <div id=\"main\" class=\"page wide\">\n    <p>Hello <b>world</b>!</p>\n    <img src=\"/logo.png\" alt=\"Tom &amp; Jerry\">\n    <p>\n      - a list\n      #1\n    </p>\n  </div>\n</body>\n</html>\n",
+			dialect: "haml",
+			want:    "package pages\n\n@haml Page() {\n\t!!!\n\t%html\n\t\t%body\n\t\t\t/ nav\n\t\t\t#main.page.wide\n\t\t\t\t%p\n\t\t\t\t\tHello\n\t\t\t\t\t%b world\n\t\t\t\t\t\\!\n\t\t\t\t%img{src: \"/logo.png\", alt: \"Tom & Jerry\"}\n\t\t\t\t%p\n\t\t\t\t\t\\- a list\n\t\t\t\t\t\\#1\n}\n",
+		},
+		"html to slim": {
+			input:   "<div class=\"a\"><p>Hello <b>world</b> !</p><p>= not code</p></div>\n",
+			dialect: "slim",
+			want:    "package pages\n\n@slim Page() {\n\t.a\n\t\tp\n\t\t\t| Hello \n\t\t\tb world\n\t\t\t|  !\n\t\tp\n\t\t\t| = not code\n}\n",
+		},
+		"actions to haml": {
+			input:   "<ul class=\"list {{.Class}}\">\n{{range $i, $item := .Items}}\n  <li><a href=\"/items/{{$item.ID}}\">{{$item.Name}}</a> {{$i}}</li>\n{{else}}\n  <li>None</li>\n{{end}}\n</ul>\n{{if and .A (not .B)}}<p>a</p>{{else if eq .C 1 2}}<p>c</p>{{else}}{{/* nothing */}}{{end}}\n{{$n := len .Items}}<p>{{printf \"%d items\" $n}}</p>\n",
+			dialect: "haml",
+			want:    "package pages\n\nimport \"fmt\"\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t%ul{class: #{\"list \" + data.Class}}\n\t\t- if len(data.Items) > 0\n\t\t\t- for i, item := range data.Items\n\t\t\t\t%li\n\t\t\t\t\t%a{href: #{\"/items/\" + item.ID}}= item.Name\n\t\t\t\t\t\\#{%d i}\n\t\t- else\n\t\t\t%li None\n\t- if data.A && !data.B\n\t\t%p a\n\t- else if data.C == 1 || data.C == 2\n\t\t%p c\n\t- else\n\t\t-# nothing\n\t- n := len(data.Items)\n\t%p= fmt.Sprintf(\"%d items\", n)\n}\n",
+		},
+		"actions to slim": {
+			input:   "{{range .Users}}<p class=\"user\">{{.Name}}</p>{{end}}\n<input type=\"checkbox\" {{if .On}}checked{{end}}>\n",
+			dialect: "slim",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@slim Page(data any) {\n\t- for _, user := range data.Users\n\t\tp.user= user.Name\n\t/ TODO: check the condition of {{if .On}}; the template tests whether the value is empty\n\tinput{type: \"checkbox\", checked? #{data.On}}\n}\n",
+		},
+		"actions to ego": {
+			input:   "<ul>\n{{range .Users}}\n  <li {{if .Admin}}class=\"admin\"{{end}}>{{.Name}}</li>\n{{end}}\n</ul>\n{{with .Footer}}<p>{{.}}</p>{{end}}\n<p>{{len .Users}} <% x %></p>\n",
+			dialect: "ego",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@ego Page(data any) {\n\t<ul>\n\t<% for _, user := range data.Users { %>\n\t  <li <%# TODO: check the condition of {{if .Admin}}; the template tests whether the value is empty %><% if user.Admin { %>class=\"admin\"<% } %>><%= user.Name %></li>\n\t<% } %>\n\t</ul>\n\t<%# TODO: check the condition of {{with .Footer}}; the template tests whether the value is empty %><% if footer := data.Footer; footer != nil { %><p><%= footer %></p><% } %>\n\t<p><%= %d len(data.Users) %> <%% x %></p>\n}\n",
+		},
+		"unused variables and conditions": {
+			input:   "{{range $i, $x := .Items}}<br>{{end}}\n{{range .Users}}<p>{{$.Title}}</p>{{end}}\n{{range $i, $u := .Users}}<p>{{$i}}</p>{{end}}\n{{if len .Items}}<p>some</p>{{end}}\n{{if .Name}}<p>{{.Name}}</p>{{end}}\n",
+			dialect: "haml",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t- for range data.Items\n\t\t%br\n\t- for range data.Users\n\t\t%p= data.Title\n\t- for i2 := range data.Users\n\t\t%p= %d i2\n\t- if len(data.Items) != 0\n\t\t%p some\n\t-# TODO: check the condition of {{if .Name}}; the template tests whether the value is empty\n\t- if data.Name\n\t\t%p= data.Name\n}\n",
+		},
+		"unused variables to ego": {
+			input:   "{{range $i, $x := .Items}}<br>{{end}}\n{{range .Users}}<p>{{.Name}}</p>{{end}}\n",
+			dialect: "ego",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@ego Page(data any) {\n\t<% for range data.Items { %><br><% } %>\n\t<% for _, user := range data.Users { %><p><%= user.Name %></p><% } %>\n}\n",
+		},
+		"defined templates": {
+			input:   "{{define \"site-header\"}}<h1>{{.}}</h1>{{end}}{{template \"site-header\" .Title}}\n{{block \"main\" .}}<p>default</p>{{end}}\n",
+			dialect: "haml",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t= @render SiteHeader(data.Title)\n\t= @render Main()\n}\n\n// TODO: replace the type of data with the type of the data of the \"site-header\" template.\n@haml SiteHeader(data any) {\n\t%h1= data\n}\n\n@haml Main() {\n\t%p default\n}\n",
+		},
+		"only definitions": {
+			input:   "{{define \"a\"}}<p>a</p>{{end}}\n{{define \"b\"}}<p>b</p>{{end}}\n",
+			dialect: "slim",
+			want:    "package pages\n\n@slim A() {\n\tp a\n}\n\n@slim B() {\n\tp b\n}\n",
+		},
+		"todo comments": {
+			input:   "<p class=\"{{if .A}}a{{end}}\" {{.Attrs}}>{{upper .Name}}</p>\n{{template \"missing\"}}\n<!-- {{.X}} -->\n",
+			dialect: "haml",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t-# TODO: {{if .A}}a{{end}} could not be imported inside of the <p> tag\n\t-# TODO: {{.Attrs}} could not be imported inside of the <p> tag\n\t%p\n\t\t-# TODO: the upper function of the template is called as a Go function\n\t\t= upper(data.Name)\n\t-# TODO: the \"missing\" template is not defined in this file\n\t= @render Missing()\n\t-# TODO: {{.X}} could not be imported inside of an HTML comment\n}\n",
+		},
+		"tags across blocks": {
+			input:   "{{if .Wrap}}<div class=\"wrap\">{{end}}<span>x</span>{{if .Wrap}}</div>{{end}}\n",
+			dialect: "haml",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t-# TODO: check the condition of {{if .Wrap}}; the template tests whether the value is empty\n\t- if data.Wrap\n\t\t<div class=\"wrap\">\n\t%span x\n\t-# TODO: check the condition of {{if .Wrap}}; the template tests whether the value is empty\n\t- if data.Wrap\n\t\t</div>\n}\n",
+		},
+		"scripts and styles": {
+			input:   "<style>\n  p { color: red; }\n</style>\n<script src=\"/app.js\"></script>\n<script>\n  var name = \"{{.Name}}\";\n</script>\n",
+			dialect: "slim",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@slim Page(data any) {\n\t:css\n\t\tp { color: red; }\n\tscript{src: \"/app.js\"}\n\t:javascript\n\t\tvar name = \"#{data.Name}\";\n}\n",
+		},
+		"event handlers": {
+			input:   "<button onclick=\"go({{.ID}}, '{{.Name}}')\" onload=\"check(/{{.Name}}/)\">Go</button>\n",
+			dialect: "haml",
+			want:    "package pages\n\n// TODO: replace the type of data with the type of the data of the \"page\" template.\n@haml Page(data any) {\n\t-# TODO: {{.Name}} could not be imported inside of the <button> tag\n\t%button{onclick: #{goht.JS(\"go(\" + goht.EscapeJS(data.ID) + \", '\" + goht.EscapeJSString(data.Name) + \"')\")}, onload: \"check(//)\"} Go\n}\n",
+		},
+		"template errors": {
+			input:   "{{if .A}}<p>a</p>\n",
+			dialect: "haml",
+			wantErr: true,
+		},
+		"unknown dialect": {
+			input:   "<p>a</p>\n",
+			dialect: "pug",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Import([]byte(tt.input), ImportOptions{Package: "pages", Name: "page", Dialect: tt.dialect})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Import() = %q, want %q", got, tt.want)
+			}
+			if _, err := parseBytes(got); err != nil {
+				t.Errorf("imported file does not parse: %v", err)
+			}
+		})
+	}
+}
+
+// Test_ImportTestdata imports testdata/imported/page.html.tmpl to each
+// dialect, with the type of its data set to a string; the imported templates
+// are rendered by TestRender.
+func Test_ImportTestdata(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "imported", "page.html.tmpl"))
+	if err != nil {
+		t.Fatalf("error reading the template: %v", err)
+	}
+	for _, dialect := range []string{"haml", "slim", "ego"} {
+		t.Run(dialect, func(t *testing.T) {
+			imported, err := Import(input, ImportOptions{Package: "imported", Name: dialect + "_page", Dialect: dialect})
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			got := bytes.Replace(imported, []byte("(data any)"), []byte("(data string)"), 1)
+			goldenFileName := filepath.Join("testdata", "imported", dialect+".goht")
+			want, err := goldenFile(t, goldenFileName, got, *update)
+			if err != nil {
+				t.Fatalf("error reading golden file: %v", err)
+			}
+			if !bytes.Equal(want, got) {
+				t.Errorf("Import() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -18,14 +18,28 @@ type lexer struct {
 	width  int
 	pos    []int
 	indent int
+	// hashDepth is the number of nested attribute hashes that are open
+	hashDepth int
+	// recovery continues lexing after an error at the next line or template
+	// boundary of the language being lexed
+	recovery lexFn
+	// startLine and startCol hold the position of the captured string once
+	// runes have been removed from it; the position can no longer be counted
+	// back from the position of the lexer
+	startLine, startCol int
+	// openLine and openCol hold the position of the opening brace of the
+	// attributes being lexed, where attributes that are not closed by the end
+	// of the template are reported
+	openLine, openCol int
 }
 
 func newLexer(input []byte) *lexer {
 	return &lexer{
-		reader: bytes.NewReader(input),
-		lex:    lexGoLineStart,
-		tokens: make(chan token, 64),
-		pos:    []int{0},
+		reader:   bytes.NewReader(input),
+		lex:      lexGoLineStart,
+		tokens:   make(chan token, 64),
+		pos:      []int{0},
+		recovery: lexGoRecover,
 	}
 }
 
@@ -100,9 +114,30 @@ func (l *lexer) peekAhead(length int) string {
 	return s
 }
 
+// peekUntil returns the runes up to the next rune in the stopRunes list
+// without consuming them.
+func (l *lexer) peekUntil(stopRunes string) string {
+	width := 0
+	s := ""
+	for {
+		ch, size, err := l.reader.ReadRune()
+		if err != nil {
+			break
+		}
+		width += size
+		if strings.ContainsRune(stopRunes, ch) {
+			break
+		}
+		s += string(ch)
+	}
+	_, _ = l.reader.Seek(int64(-width), io.SeekCurrent)
+	return s
+}
+
 // ignore discards the current captured string.
 func (l *lexer) ignore() {
 	l.s = ""
+	l.startLine, l.startCol = 0, 0
 }
 
 // accept consumes the next rune if it's contained in the acceptRunes list.
@@ -137,6 +172,7 @@ func (l *lexer) acceptAhead(length int) {
 
 // skip discards the next rune.
 func (l *lexer) skip() rune {
+	l.keepStart()
 	r := l.next()
 	l.s = l.s[:len(l.s)-1]
 	return r
@@ -144,6 +180,7 @@ func (l *lexer) skip() rune {
 
 // skipRun discards a contiguous run of runes from the skipRunes list.
 func (l *lexer) skipRun(skipRunes string) {
+	l.keepStart()
 	for strings.ContainsRune(skipRunes, l.next()) {
 		l.s = l.s[:len(l.s)-1]
 	}
@@ -152,6 +189,7 @@ func (l *lexer) skipRun(skipRunes string) {
 
 // skipUntil discards runes until it encounters a rune in the stopRunes list.
 func (l *lexer) skipUntil(stopRunes string) {
+	l.keepStart()
 	for r := l.next(); !strings.ContainsRune(stopRunes, r) && r != scanner.EOF; r = l.next() {
 		l.s = l.s[:len(l.s)-1]
 	}
@@ -160,12 +198,44 @@ func (l *lexer) skipUntil(stopRunes string) {
 
 // skipAhead consumes the next length runes and discards them.
 func (l *lexer) skipAhead(length int) {
+	l.keepStart()
 	for i := 0; i < length; i++ {
 		l.next()
 	}
 	l.s = l.s[:len(l.s)-length]
 }
 
+// trimSpace removes the whitespace from both ends of the captured string.
+func (l *lexer) trimSpace() {
+	l.trimRight(" \t\n\r")
+	trimmed := strings.TrimLeft(l.s, " \t\n\r")
+	if lead := l.s[:len(l.s)-len(trimmed)]; lead != "" {
+		line, col := l.position()
+		if i := strings.LastIndexByte(lead, '\n'); i >= 0 {
+			line, col = line+strings.Count(lead, "\n"), len(lead)-i
+		} else {
+			col += len(lead)
+		}
+		l.startLine, l.startCol = line, col
+	}
+	l.s = trimmed
+}
+
+// trimRight removes the runes in the cutset from the end of the captured
+// string.
+func (l *lexer) trimRight(cutset string) {
+	l.keepStart()
+	l.s = strings.TrimRight(l.s, cutset)
+}
+
+// keepStart holds the position of the captured string before runes are
+// removed from it.
+func (l *lexer) keepStart() {
+	if l.s != "" && l.startLine == 0 {
+		l.startLine, l.startCol = l.position()
+	}
+}
+
 // current returns the current captured string being built by the lexer.
 func (l *lexer) current() string {
 	return l.s
@@ -174,19 +244,84 @@ func (l *lexer) current() string {
 // emit creates a new token with the current string and sends it to the tokens channel.
 func (l *lexer) emit(t tokenType) {
 	line, col := l.position()
-	l.tokens <- token{typ: t, lit: l.s, line: line, col: col}
-	l.s = ""
+	endLine, endCol := l.endPosition()
+	l.tokens <- token{typ: t, lit: l.s, line: line, col: col, endLine: endLine, endCol: endCol}
+	l.ignore()
 }
 
 // errorf creates a new error token with the formatted message and sends it to the tokens channel.
-func (l *lexer) errorf(format string, args ...any) lexFn {
+//
+// The error covers the current captured string, or the next rune when nothing
+// has been captured, which is usually the one that caused the error. Lexing
+// continues at the next line or template boundary.
+func (l *lexer) errorf(code ErrorCode, format string, args ...any) lexFn {
 	line, col := l.position()
-	l.tokens <- token{typ: tError, lit: fmt.Sprintf(format, args...), line: line, col: col}
-	return func(l *lexer) lexFn { return nil }
+	endLine, endCol := l.endPosition()
+	if r := l.peek(); l.s == "" && r != scanner.EOF && r != '\n' && r != '\r' {
+		endCol++
+	}
+	l.tokens <- token{typ: tError, lit: fmt.Sprintf(format, args...), line: line, col: col, endLine: endLine, endCol: endCol, code: code}
+	return l.recovery
+}
+
+// markOpen holds the position of the next rune as the opening brace of the
+// attributes being lexed.
+func (l *lexer) markOpen() {
+	l.openLine, l.openCol = len(l.pos), l.pos[len(l.pos)-1]+1
+}
+
+// notClosed reports a value that isn't closed. A value that runs into a line
+// that ends the template or declares the next one is reported at the opening
+// brace of its attributes, and lexing continues at that line; otherwise the
+// input ended before the value was closed.
+func (l *lexer) notClosed(what string) lexFn {
+	if !l.atTemplateBoundary() {
+		return l.errorf(ErrNotClosed, "%s not closed: eof", what)
+	}
+	l.ignore()
+	l.tokens <- token{typ: tError, lit: "attributes not closed", line: l.openLine, col: l.openCol, endLine: l.openLine, endCol: l.openCol + 1, code: ErrNotClosed}
+	l.hashDepth = 0
+	return lexTemplateBoundary
+}
+
+// atTemplateBoundary reports whether the lexer is at the start of a line that
+// ends the template, or that declares the next template; neither can be part
+// of a value that spans lines.
+func (l *lexer) atTemplateBoundary() bool {
+	if len(l.pos) == 1 || l.pos[len(l.pos)-1] != 0 {
+		return false
+	}
+	if l.peek() == '}' {
+		return true
+	}
+	ahead := l.peekAhead(6)
+	for _, keyword := range []string{"@goht ", "@haml ", "@slim ", "@ego "} {
+		if strings.HasPrefix(ahead, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipLines discards a run of the skipRunes, which may span lines, like
+// skipRun; it stops at a template boundary and reports false when it does.
+func (l *lexer) skipLines(skipRunes string) bool {
+	l.keepStart()
+	for !l.atTemplateBoundary() {
+		if !strings.ContainsRune(skipRunes, l.next()) {
+			l.backup()
+			return true
+		}
+		l.s = l.s[:len(l.s)-1]
+	}
+	return false
 }
 
 // position returns the current line and column of the content being lexed.
 func (l *lexer) position() (int, int) {
+	if l.startLine != 0 {
+		return l.startLine, l.startCol
+	}
 	newLinesInString := strings.Count(l.s, "\n")
 	parts := strings.SplitAfter(l.s, "\n")
 	line := len(l.pos) - newLinesInString
@@ -194,6 +329,12 @@ func (l *lexer) position() (int, int) {
 	return line, column
 }
 
+// endPosition returns the line and column just after the content that has
+// been lexed.
+func (l *lexer) endPosition() (int, int) {
+	return len(l.pos), l.pos[len(l.pos)-1] + 1
+}
+
 func (l *lexer) validateIndent(indent string) lexFn {
 	if indent == "" {
 		return nil
@@ -208,11 +349,14 @@ func (l *lexer) validateIndent(indent string) lexFn {
 
 	// require tabs for indenting; report the use of spaces as an error
 	if strings.Contains(indent, " ") {
-		return l.errorf("the line was indented using spaces, templates must be indented using tabs")
+		return l.errorf(ErrIndentSpaces, "the line was indented using spaces, templates must be indented using tabs")
 	}
 
 	if currentLen > l.indent+1 {
-		return l.errorf("the line was indented %d levels deeper than the previous line", currentLen-l.indent)
+		levels := currentLen - l.indent
+		// accept the indent so that the lines that follow are not reported as well
+		l.indent = currentLen
+		return l.errorf(ErrIndentTooDeep, "the line was indented %d levels deeper than the previous line", levels)
 	}
 
 	return nil
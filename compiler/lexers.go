@@ -6,6 +6,7 @@ import (
 )
 
 func lexGoLineStart(l *lexer) lexFn {
+	l.recovery = lexGoRecover
 	switch l.peek() {
 	case 'p':
 		if l.current() != "" {
@@ -39,10 +40,27 @@ func lexGoLineEnd(l *lexer) lexFn {
 		l.emit(tEOF)
 		return nil
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
+// lexTemplateBoundary continues lexing at a line that ends the template or
+// declares the next one, after a value that was not closed ran into it.
+func lexTemplateBoundary(l *lexer) lexFn {
+	if l.peek() == '}' {
+		l.emit(tTemplateEnd)
+		l.skip()
+	}
+	return lexGoLineStart
+}
+
+// lexGoRecover continues lexing the Go code at the next line after an error.
+func lexGoRecover(l *lexer) lexFn {
+	l.ignore()
+	l.skipUntil("\n\r")
+	return lexGoLineEnd
+}
+
 func lexPackage(l *lexer) lexFn {
 	l.acceptUntil(" (\r\n")
 	if l.current() != "package" {
@@ -53,7 +71,7 @@ func lexPackage(l *lexer) lexFn {
 
 	l.acceptUntil("\r\n")
 	if l.current() == "" {
-		return l.errorf("package name expected")
+		return l.errorf(ErrExpected, "package name expected")
 	}
 	l.emit(tPackage)
 	return lexGoLineEnd
@@ -88,11 +106,11 @@ func lexImports(l *lexer) lexFn {
 			l.skipRun(")\r\n")
 			return lexGoLineStart
 		case scanner.EOF:
-			return l.errorf("import expected")
+			return l.errorf(ErrExpected, "import expected")
 		default:
 			l.acceptUntil("\r\n")
 			if l.current() == "" {
-				return l.errorf("import expected")
+				return l.errorf(ErrExpected, "import expected")
 			}
 			l.emit(tImport)
 		}
@@ -111,13 +129,16 @@ func lexTemplate(l *lexer) lexFn {
 	l.acceptUntil(" ")
 	switch l.current() {
 	case "@goht", "@haml":
+		l.recovery = lexHamlRecover
 		return lexTemplateStart(l, true, lexHamlLineStart)
 	case "@slim":
+		l.recovery = lexSlimRecover
 		return lexTemplateStart(l, false, lexSlimLineStart)
 	case "@ego":
+		l.recovery = lexEgoRecover
 		return lexTemplateStart(l, false, lexEgoStart)
 	}
-	return l.errorf("unknown template type: %q", l.current())
+	return l.errorf(ErrUnknownTemplate, "unknown template type: %q", l.current())
 }
 
 func lexTemplateStart(l *lexer, keepNewlines bool, next lexFn) lexFn {
@@ -153,6 +174,113 @@ func lexTemplateStart(l *lexer, keepNewlines bool, next lexFn) lexFn {
 	return next
 }
 
+// lexHTMLAttributesStart lexes the HTML style attributes that are wrapped in
+// parentheses or brackets, such as `(href="/" title=#{title} disabled)`, into
+// the same tokens as the Ruby style attributes. The lexer continues with next
+// after the closing end rune.
+func lexHTMLAttributesStart(end rune, next lexFn) lexFn {
+	return func(l *lexer) lexFn {
+		l.markOpen()
+		l.skip() // skip opening wrapper
+		return lexHTMLAttributes(end, next)
+	}
+}
+
+func lexHTMLAttributes(end rune, next lexFn) lexFn {
+	return func(l *lexer) lexFn {
+		if !l.skipLines(" \t\n\r") {
+			return l.notClosed("attributes")
+		}
+		switch l.peek() {
+		case end:
+			l.skip() // skip closing wrapper
+			return next
+		case scanner.EOF:
+			return l.notClosed("attributes")
+		}
+		return lexHTMLAttribute(string(end), lexHTMLAttributes(end, next))
+	}
+}
+
+// lexHTMLAttribute lexes a single HTML style attribute: `name`,
+// `name="value"`, `name='value'` or `name=#{value}`.
+func lexHTMLAttribute(stopRunes string, next lexFn) lexFn {
+	return func(l *lexer) lexFn {
+		if l.peek() == '"' || l.peek() == '`' {
+			r := continueToMatchingQuote(l, tAttrName, false)
+			if r == scanner.EOF {
+				return l.notClosed("attribute name")
+			}
+		} else {
+			l.acceptUntil("=\"` \t\n\r" + stopRunes)
+			if l.current() == "" {
+				return l.errorf(ErrExpected, "attribute name expected")
+			}
+			l.emit(tAttrName)
+		}
+
+		l.skipRun(" \t")
+		if l.peek() != '=' {
+			// an attribute without a value
+			return next
+		}
+		l.next()
+		l.emit(tAttrOperator)
+		l.skipRun(" \t")
+
+		switch l.peek() {
+		case '"', '`':
+			if r := continueToMatchingQuote(l, tAttrEscapedValue, true); r == scanner.EOF {
+				return l.notClosed("attribute value")
+			}
+			return next
+		case '\'':
+			if r := continueToMatchingSingleQuote(l, tAttrEscapedValue); r == scanner.EOF {
+				return l.notClosed("attribute value")
+			}
+			return next
+		case '#':
+			l.skip() // skip hash
+			if l.peek() != '{' {
+				return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
+			}
+			l.skip() // skip opening brace
+			if r := continueToMatchingBrace(l, '}', false); r == scanner.EOF {
+				return l.notClosed("attribute value")
+			}
+			l.backup()
+			l.emit(tAttrDynamicValue)
+			l.skip() // skip closing brace
+			return next
+		}
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
+	}
+}
+
+// isNumberStart returns true when the rune starts a number literal.
+func isNumberStart(r rune) bool {
+	return r == '-' || r >= '0' && r <= '9'
+}
+
+// acceptNumber accepts a decimal number literal, such as `1`, `-2` or `0.5`,
+// as the value of an attribute; it returns false when there is no digit.
+func acceptNumber(l *lexer) bool {
+	l.accept("-")
+	digits := "0123456789"
+	if !strings.ContainsRune(digits, l.peek()) {
+		return false
+	}
+	l.acceptRun(digits)
+	if l.peek() == '.' {
+		l.next()
+		if !strings.ContainsRune(digits, l.peek()) {
+			return false
+		}
+		l.acceptRun(digits)
+	}
+	return true
+}
+
 func continueToMatchingQuote(l *lexer, typ tokenType, captureQuotes bool) rune {
 	quote := l.peek()
 	if quote != '`' && quote != '"' {
@@ -166,7 +294,7 @@ func continueToMatchingQuote(l *lexer, typ tokenType, captureQuotes bool) rune {
 	escaping := false
 	for {
 		r := l.next()
-		if r == scanner.EOF {
+		if r == scanner.EOF || r == '\n' && l.atTemplateBoundary() {
 			return scanner.EOF
 		}
 		if escaping {
@@ -191,6 +319,39 @@ func continueToMatchingQuote(l *lexer, typ tokenType, captureQuotes bool) rune {
 	return quote
 }
 
+// continueToMatchingSingleQuote captures a single-quoted value and emits it
+// as a double-quoted string, so that it's unquoted the same as the values in
+// double quotes.
+func continueToMatchingSingleQuote(l *lexer, typ tokenType) rune {
+	l.next() // opening quote
+	var sb strings.Builder
+	escaping := false
+	for {
+		r := l.next()
+		if r == scanner.EOF || r == '\n' && l.atTemplateBoundary() {
+			return scanner.EOF
+		}
+		switch {
+		case escaping:
+			escaping = false
+			if r != '\'' {
+				sb.WriteRune('\\')
+			}
+		case r == '\\':
+			escaping = true
+			continue
+		case r == '\'':
+			l.keepStart()
+			l.s = `"` + sb.String() + `"`
+			l.emit(typ)
+			return r
+		case r == '"':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+}
+
 func continueToMatchingBrace(l *lexer, endBrace rune, allowNewlines bool) rune {
 	startBrace := matchingStartBrace(endBrace)
 	depth := 1
@@ -199,7 +360,7 @@ func continueToMatchingBrace(l *lexer, endBrace rune, allowNewlines bool) rune {
 
 	for {
 		r := l.next()
-		if r == scanner.EOF {
+		if r == scanner.EOF || r == '\n' && l.atTemplateBoundary() {
 			return scanner.EOF
 		}
 
@@ -1,7 +1,6 @@
 package compiler
 
 import (
-	"fmt"
 	"strings"
 	"text/scanner"
 )
@@ -12,7 +11,7 @@ func lexEgoStart(_ *lexer) lexFn {
 
 func increaseEgoIndent(l *lexer) lexFn {
 	if l.current() != "" {
-		return l.errorf("uncommitted content during block start: %q", l.current())
+		return l.errorf(ErrUnexpectedToken, "uncommitted content during block start: %q", l.current())
 	}
 	l.indent++
 	l.s = strings.Repeat("\t", l.indent)
@@ -22,7 +21,7 @@ func increaseEgoIndent(l *lexer) lexFn {
 
 func decreaseEgoIndent(l *lexer) lexFn {
 	if l.current() != "" {
-		return l.errorf("uncommitted content during block end: %q", l.current())
+		return l.errorf(ErrUnexpectedToken, "uncommitted content during block end: %q", l.current())
 	}
 	l.indent--
 	l.s = strings.Repeat("\t", l.indent)
@@ -30,6 +29,32 @@ func decreaseEgoIndent(l *lexer) lexFn {
 	return nil
 }
 
+// endEgoCommand ends a command that is not followed by a block, so that the
+// content after it is not nested in the command.
+func endEgoCommand(l *lexer) {
+	l.s = strings.Repeat("\t", l.indent)
+	l.emit(tIndent)
+}
+
+// lexEgoRecover continues lexing after an error at the end of the template;
+// the blocks and tags of an EGO template can span many lines, so there is no
+// line boundary to recover at.
+func lexEgoRecover(l *lexer) lexFn {
+	l.ignore()
+	for {
+		l.skipUntil("\n\r")
+		l.skipRun("\n\r")
+		switch l.peek() {
+		case '}':
+			l.indent = 0
+			return lexEgoLineStart(lexEgoText)
+		case scanner.EOF:
+			l.emit(tEOF)
+			return nil
+		}
+	}
+}
+
 func lexEgoLineStart(next lexFn) lexFn {
 	return func(l *lexer) lexFn {
 		switch l.peek() {
@@ -45,19 +70,17 @@ func lexEgoLineStart(next lexFn) lexFn {
 			if l.current() != "" {
 				// if the indent is not at 1, then we know that we're ending early and should report an error
 				if l.indent != 0 {
-					fmt.Println("indent", l.indent)
-					fmt.Printf("current %q\n", l.current())
-					return l.errorf("unexpected closing brace: %q", l.current())
+					return l.errorf(ErrUnexpectedCharacter, "unexpected closing brace: %q", l.current())
 				}
 				// assumption: if there is anything in the buffer, then it is text, AND we can trim it
-				l.s = strings.TrimRight(l.s, " \t\n\r")
+				l.trimRight(" \t\n\r")
 				l.emit(tRawText)
 			}
 			l.emit(tTemplateEnd)
 			l.skip()
 			return lexGoLineStart
 		default:
-			return l.errorf("unexpected character: %q", l.peek())
+			return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 		}
 	}
 }
@@ -122,12 +145,12 @@ func lexEgoTagStart(l *lexer) lexFn {
 		l.skip() // consume the '%'
 		return lexEgoText
 	case scanner.EOF:
-		return l.errorf("unexpected EOF in tag")
+		return l.errorf(ErrNotClosed, "unexpected EOF in tag")
 	default:
 		// script
 		if r == '-' {
 			// strip the whitespace on current()
-			l.s = strings.TrimRight(l.s, " \t\n\r")
+			l.trimRight(" \t\n\r")
 			l.emit(tRawText)
 			l.skip() // consume the '-'
 			return lexEgoScriptStart
@@ -144,14 +167,14 @@ func findClosingTag(l *lexer, next lexFn) lexFn {
 		l.acceptRun(" \t")
 		l.acceptUntil("-$%\n\r")
 		if l.peek() == scanner.EOF {
-			return l.errorf("unexpected EOF in tag")
+			return l.errorf(ErrNotClosed, "unexpected EOF in tag")
 		}
 		switch l.peek() {
 		case '\n', '\r':
 			// enforce indents even inside of multiline tags
 			l.acceptRun("\n\r") // skip newlines
 			if l.peek() != '\t' {
-				return l.errorf("unexpected character at start of line: %q", l.peek())
+				return l.errorf(ErrUnexpectedCharacter, "unexpected character at start of line: %q", l.peek())
 			}
 			l.skip() // skip the tab
 			continue
@@ -203,7 +226,7 @@ func lexEgoClosingTag(l *lexer) lexFn {
 		return lexStripWhitespace
 	default:
 		// unexpected character
-		return l.errorf("unexpected character in closing tag: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character in closing tag: %q", l.peek())
 	}
 }
 
@@ -220,7 +243,7 @@ func lexEgoOutputStart(l *lexer) lexFn {
 	l.skipRun(" \t\n\r") // skip whitespace
 
 	return findClosingTag(l, func(l *lexer) lexFn {
-		l.s = strings.TrimSpace(l.s)
+		l.trimSpace()
 		l.emit(tScript)
 		return nil
 	})
@@ -231,7 +254,7 @@ func lexEgoUnescapedOutputStart(l *lexer) lexFn {
 
 	l.emit(tUnescaped)
 	return findClosingTag(l, func(l *lexer) lexFn {
-		l.s = strings.TrimSpace(l.s)
+		l.trimSpace()
 		l.emit(tScript)
 		return nil
 	})
@@ -243,7 +266,7 @@ func lexEgoCommandStart(l *lexer) lexFn {
 	// TODO look for the command text
 	l.acceptUntil(" \t%")
 	if l.current() == "" {
-		return l.errorf("command name expected")
+		return l.errorf(ErrExpected, "command name expected")
 	}
 	switch l.current() {
 	case "render":
@@ -252,8 +275,22 @@ func lexEgoCommandStart(l *lexer) lexFn {
 		return lexEgoChildrenStart
 	case "slot":
 		return lexEgoSlotStart
+	case "flush":
+		return lexEgoFlushStart
+	case "fragment":
+		return lexEgoFragmentStart
+	case "extends":
+		return lexEgoExtendsStart
+	case "block":
+		return lexEgoBlockStart
+	case "push":
+		return lexEgoPushStart
+	case "stack":
+		return lexEgoStackStart
+	case "json":
+		return lexEgoJsonStart
 	default:
-		return l.errorf("unknown command: %q", l.current())
+		return l.errorf(ErrUnknownCommand, "unknown command: %q", l.current())
 	}
 }
 
@@ -262,7 +299,7 @@ func lexEgoRenderStart(l *lexer) lexFn {
 	l.ignore()       // ignore the command keyword and the whitespace
 
 	return findClosingTag(l, func(l *lexer) lexFn {
-		l.s = strings.TrimSpace(l.s)
+		l.trimSpace()
 		s := l.current()
 		l.emit(tRenderCommand)
 		// if the content ends with a '{' then increase the indent (after emitting)
@@ -270,6 +307,8 @@ func lexEgoRenderStart(l *lexer) lexFn {
 			if err := increaseEgoIndent(l); err != nil {
 				return err
 			}
+		} else {
+			endEgoCommand(l)
 		}
 		return nil
 	})
@@ -281,7 +320,7 @@ func lexEgoChildrenStart(l *lexer) lexFn {
 
 	return findClosingTag(l, func(l *lexer) lexFn {
 		if strings.TrimSpace(l.s) != "" {
-			return l.errorf("unexpected content in children command: %q", l.s)
+			return l.errorf(ErrCommandArguments, "unexpected content in children command: %q", l.s)
 		}
 		l.ignore()
 		l.emit(tChildrenCommand)
@@ -289,16 +328,140 @@ func lexEgoChildrenStart(l *lexer) lexFn {
 	})
 }
 
+func lexEgoFlushStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		if strings.TrimSpace(l.s) != "" {
+			return l.errorf(ErrCommandArguments, "unexpected content in flush command: %q", l.s)
+		}
+		l.ignore()
+		l.emit(tFlushCommand)
+		return nil
+	})
+}
+
+func lexEgoFragmentStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		s := l.current()
+		l.trimRight(" \t{") // remove trailing whitespace and '{'
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "fragment name expected")
+		}
+		l.emit(tFragmentCommand)
+		// if the content originally ends with a '{' then increase the indent (after emitting)
+		if strings.HasSuffix(s, "{") {
+			if err := increaseEgoIndent(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func lexEgoExtendsStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "extends template expected")
+		}
+		l.emit(tExtendsCommand)
+		return nil
+	})
+}
+
+func lexEgoBlockStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		s := l.current()
+		l.trimRight(" \t{") // remove trailing whitespace and '{'
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "block name expected")
+		}
+		l.emit(tBlockCommand)
+		// if the content originally ends with a '{' then increase the indent (after emitting)
+		if strings.HasSuffix(s, "{") {
+			if err := increaseEgoIndent(l); err != nil {
+				return err
+			}
+		} else {
+			endEgoCommand(l)
+		}
+		return nil
+	})
+}
+
+func lexEgoPushStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		s := l.current()
+		l.trimRight(" \t{") // remove trailing whitespace and '{'
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "push stack name expected")
+		}
+		l.emit(tPushCommand)
+		// if the content originally ends with a '{' then increase the indent (after emitting)
+		if strings.HasSuffix(s, "{") {
+			if err := increaseEgoIndent(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func lexEgoStackStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "stack name expected")
+		}
+		l.emit(tStackCommand)
+		return nil
+	})
+}
+
+func lexEgoJsonStart(l *lexer) lexFn {
+	l.skipRun(" \t") // skip whitespace
+	l.ignore()       // ignore the command keyword and the whitespace
+
+	return findClosingTag(l, func(l *lexer) lexFn {
+		l.trimSpace()
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "json value expected")
+		}
+		l.emit(tJsonCommand)
+		return nil
+	})
+}
+
 func lexEgoSlotStart(l *lexer) lexFn {
 	l.skipRun(" \t") // skip whitespace
 	l.ignore()       // ignore the command keyword and the whitespace
 
 	return findClosingTag(l, func(l *lexer) lexFn {
-		l.s = strings.TrimSpace(l.s)
+		l.trimSpace()
 		s := l.current()
-		l.s = strings.TrimRight(l.s, " \t{") // remove trailing whitespace and '{'
+		l.trimRight(" \t{") // remove trailing whitespace and '{'
 		if l.current() == "" {
-			return l.errorf("slot name expected")
+			return l.errorf(ErrExpected, "slot name expected")
 		}
 		l.emit(tSlotCommand)
 		// if the content originally ends with a '{' then increase the indent (after emitting)
@@ -306,6 +469,8 @@ func lexEgoSlotStart(l *lexer) lexFn {
 			if err := increaseEgoIndent(l); err != nil {
 				return err
 			}
+		} else {
+			endEgoCommand(l)
 		}
 		return nil
 	})
@@ -322,7 +487,7 @@ func lexEgoScriptStart(l *lexer) lexFn {
 	}
 
 	return findClosingTag(l, func(l *lexer) lexFn {
-		l.s = strings.TrimSpace(l.s)
+		l.trimSpace()
 		s := l.current()
 		l.emit(tSilentScript)
 		// if the content ends with a '{' then increase the indent (after emitting)
@@ -344,7 +509,7 @@ func lexStripWhitespace(l *lexer) lexFn {
 			// enforce indents even when we are stripping whitespace
 			l.skipRun("\n\r") // skip newlines
 			if l.peek() != '\t' {
-				return l.errorf("unexpected character at start of line: %q", l.peek())
+				return l.errorf(ErrUnexpectedCharacter, "unexpected character at start of line: %q", l.peek())
 			}
 			l.skip() // skip the tab
 			continue
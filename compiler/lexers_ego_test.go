@@ -436,6 +436,7 @@ func Test_EgoRenderCommand(t *testing.T) {
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tRenderCommand, lit: "foo()"},
+				{typ: tIndent, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
@@ -444,6 +445,7 @@ func Test_EgoRenderCommand(t *testing.T) {
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tRenderCommand, lit: "foo()"},
+				{typ: tIndent, lit: ""},
 				{typ: tRawText, lit: "\nbaz"},
 				{typ: tEOF, lit: ""},
 			},
@@ -453,6 +455,7 @@ func Test_EgoRenderCommand(t *testing.T) {
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tRenderCommand, lit: "foo()"},
+				{typ: tIndent, lit: ""},
 				{typ: tRawText, lit: "\nbaz"},
 				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
@@ -463,6 +466,7 @@ func Test_EgoRenderCommand(t *testing.T) {
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tRenderCommand, lit: "foo()"},
+				{typ: tIndent, lit: ""},
 				{typ: tRawText, lit: "\n<div>baz</div>"},
 				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
@@ -474,6 +478,7 @@ func Test_EgoRenderCommand(t *testing.T) {
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tRawText, lit: "fizz"},
 				{typ: tRenderCommand, lit: "foo()"},
+				{typ: tIndent, lit: ""},
 				{typ: tRawText, lit: "\n<div>baz</div>"},
 				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
@@ -601,6 +606,23 @@ func Test_EgoChildrenCommand(t *testing.T) {
 				{typ: tError, lit: "unexpected content in children command: \"foobar \""},
 			},
 		},
+		"flush": {
+			input: "@ego test() {\n\t<%@ children %>\n\t<%@ flush %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tChildrenCommand, lit: ""},
+				{typ: tRawText, lit: "\n"},
+				{typ: tFlushCommand, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"flush with extra text": {
+			input: "@ego test() {\n\t<%@ flush now %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "unexpected content in flush command: \"now \""},
+			},
+		},
 		"unknown command": {
 			input: "@ego test() {\n\t<%@ unknown %>",
 			want: []token{
@@ -639,6 +661,16 @@ func Test_EgoSlotCommand(t *testing.T) {
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tSlotCommand, lit: "testing"},
+				{typ: tIndent, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with a value": {
+			input: "@ego test() {\n\t<%@slot row(item) %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tSlotCommand, lit: "row(item)"},
+				{typ: tIndent, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
@@ -678,6 +710,240 @@ func Test_EgoSlotCommand(t *testing.T) {
 	}
 }
 
+func Test_EgoFragmentCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with content": {
+			input: "@ego test() {\n\t<%@ fragment cart-items { %>\n\t\t<p>bar</p>\n\t<% } %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tFragmentCommand, lit: "cart-items"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tRawText, lit: "\n\t<p>bar</p>\n"},
+				{typ: tIndent, lit: ""},
+				{typ: tSilentScript, lit: "}"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing name": {
+			input: "@ego test() {\n\t<%@ fragment %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "fragment name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_EgoExtendsCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with template": {
+			input: "@ego test() {\n\t<%@extends Base(\"title\") %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tExtendsCommand, lit: "Base(\"title\")"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing template": {
+			input: "@ego test() {\n\t<%@extends %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "extends template expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_EgoBlockCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with content": {
+			input: "@ego test() {\n\t<%@block content { %>\n\t\t<p>bar</p>\n\t<% } %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tBlockCommand, lit: "content"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tRawText, lit: "\n\t<p>bar</p>\n"},
+				{typ: tIndent, lit: ""},
+				{typ: tSilentScript, lit: "}"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing name": {
+			input: "@ego test() {\n\t<%@block %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "block name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_EgoPushCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with content": {
+			input: "@ego test() {\n\t<%@push scripts \"app\" { %>\n\t\t<script></script>\n\t<% } %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tPushCommand, lit: "scripts \"app\""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tRawText, lit: "\n\t<script></script>\n"},
+				{typ: tIndent, lit: ""},
+				{typ: tSilentScript, lit: "}"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing name": {
+			input: "@ego test() {\n\t<%@push %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "push stack name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_EgoStackCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with name": {
+			input: "@ego test() {\n\t<%@stack scripts %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tStackCommand, lit: "scripts"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing name": {
+			input: "@ego test() {\n\t<%@stack %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "stack name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_EgoJsonCommand(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  []token
+	}{
+		"with value": {
+			input: "@ego test() {\n\t<%@json map[string]int{\"a\": 1} %>\n}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tJsonCommand, lit: "map[string]int{\"a\": 1}"},
+				{typ: tRawText, lit: ""},
+				{typ: tTemplateEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"missing value": {
+			input: "@ego test() {\n\t<%@json %>",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tError, lit: "json value expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newLexer([]byte(tt.input))
+			for _, want := range tt.want {
+				got := l.nextToken()
+				if got.typ != want.typ || got.lit != want.lit {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
 func Test_EgoTrimWhitespace(t *testing.T) {
 	tests := map[string]struct {
 		input string
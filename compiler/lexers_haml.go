@@ -1,7 +1,6 @@
 package compiler
 
 import (
-	"slices"
 	"strings"
 	"text/scanner"
 )
@@ -30,7 +29,7 @@ func lexHamlIndent(l *lexer) lexFn {
 	// there has not been any indentation yet
 	if l.indent == 0 && len(indent) == 0 {
 		// return an error that indents are required
-		return l.errorf("haml templates must be indented")
+		return l.errorf(ErrIndentMissing, "haml templates must be indented")
 	}
 
 	// validate the indent against the sequence and char
@@ -43,6 +42,15 @@ func lexHamlIndent(l *lexer) lexFn {
 	return lexHamlContentStart
 }
 
+// lexHamlRecover continues lexing the template at the next line after an
+// error.
+func lexHamlRecover(l *lexer) lexFn {
+	l.ignore()
+	l.hashDepth = 0
+	l.skipUntil("\n\r")
+	return lexHamlLineEnd
+}
+
 func lexHamlContentStart(l *lexer) lexFn {
 	switch l.peek() {
 	case '%':
@@ -87,6 +95,8 @@ func lexHamlContent(l *lexer) lexFn {
 		return lexHamlObjectReference
 	case '{':
 		return lexHamlAttributesStart
+	case '(':
+		return lexHTMLAttributesStart(')', lexHamlContent)
 	case '!':
 		return lexHamlUnescaped
 	case '=':
@@ -127,7 +137,7 @@ func lexHamlLineEnd(l *lexer) lexFn {
 		l.emit(tEOF)
 		return nil
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
@@ -141,11 +151,11 @@ func hamlIdentifier(typ tokenType, l *lexer) lexFn {
 	l.skip() // eat symbol
 
 	// these characters may follow an identifier
-	const mayFollowIdentifier = "%#.[{=!/<> \t\n\r"
+	const mayFollowIdentifier = "%#.[{(=!/<> \t\n\r"
 
 	l.acceptUntil(mayFollowIdentifier)
 	if l.current() == "" {
-		return l.errorf("%s identifier expected", typ)
+		return l.errorf(ErrExpected, "%s identifier expected", typ)
 	}
 	l.emit(typ)
 	return lexHamlContent
@@ -167,7 +177,7 @@ func lexHamlObjectReference(l *lexer) lexFn {
 	l.skip() // eat opening bracket
 	r := continueToMatchingBrace(l, ']', false)
 	if r == scanner.EOF {
-		return l.errorf("object reference not closed: eof")
+		return l.errorf(ErrNotClosed, "object reference not closed: eof")
 	}
 	l.backup()
 	l.emit(tObjectRef)
@@ -176,11 +186,15 @@ func lexHamlObjectReference(l *lexer) lexFn {
 }
 
 func lexHamlAttributesStart(l *lexer) lexFn {
+	l.markOpen()
 	l.skip()
 	return lexHamlAttribute
 }
 
 func lexHamlAttributesEnd(l *lexer) lexFn {
+	if l.hashDepth > 0 {
+		return lexHamlAttributeHashEnd
+	}
 	l.skip()
 	return lexHamlContent
 }
@@ -190,9 +204,12 @@ func lexHamlAttribute(l *lexer) lexFn {
 	// key
 	// key:value
 	// key?value
+	// key:{key:value, ...} (data and aria only)
 	// @attributes: []any (string, map[string]string, map[string]bool)
 
-	l.skipRun(", \t\n\r")
+	if !l.skipLines(", \t\n\r") {
+		return l.notClosed("attributes")
+	}
 
 	switch l.peek() {
 	case '}':
@@ -208,58 +225,79 @@ func lexHamlAttributeName(l *lexer) lexFn {
 	if l.peek() == '"' || l.peek() == '`' {
 		r := continueToMatchingQuote(l, tAttrName, false)
 		if r == scanner.EOF {
-			return l.errorf("attribute name not closed: eof")
+			return l.notClosed("attribute name")
 		} else if r != '"' && r != '`' {
-			return l.errorf("unexpected character: %q", r)
+			return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", r)
 		}
 	} else {
 		l.acceptUntil("?:,}{\" \t\n\r")
 		if l.current() == "" {
-			return l.errorf("attribute name expected")
+			return l.errorf(ErrExpected, "attribute name expected")
 		}
 		l.emit(tAttrName)
 	}
 
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case '?', ':':
 		return lexHamlAttributeOperator
 	case ',', '}':
 		return lexHamlAttributeEnd
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
 func lexHamlAttributeOperator(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case '?', ':':
 		l.next()
 		l.emit(tAttrOperator)
 		return lexHamlAttributeValue
 	}
-	return l.errorf("unexpected character: %q", l.peek())
+	return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 }
 
 func lexHamlAttributeValue(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 
 	switch l.peek() {
 	case '"', '`':
 		return lexHamlAttributeStaticValue
 	case '#':
 		return lexHamlAttributeDynamicValue
+	case '{':
+		return lexHamlAttributeHashStart
+	}
+	if isNumberStart(l.peek()) {
+		return lexHamlAttributeNumberValue
+	}
+	return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
+}
+
+// lexHamlAttributeNumberValue lexes a number literal, which is written as a
+// static value: `{data: {user_id: 1}}` renders `data-user-id="1"`.
+func lexHamlAttributeNumberValue(l *lexer) lexFn {
+	if !acceptNumber(l) {
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
-	return l.errorf("unexpected character: %q", l.peek())
+	l.emit(tAttrEscapedValue)
+	return lexHamlAttributeEnd
 }
 
 func lexHamlAttributeStaticValue(l *lexer) lexFn {
 	r := continueToMatchingQuote(l, tAttrEscapedValue, true)
 	if r == scanner.EOF {
-		return l.errorf("attribute value not closed: eof")
+		return l.notClosed("attribute value")
 	} else if r != '"' && r != '`' {
-		return l.errorf("unexpected character: %q", r)
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", r)
 	}
 	return lexHamlAttributeEnd
 }
@@ -267,12 +305,12 @@ func lexHamlAttributeStaticValue(l *lexer) lexFn {
 func lexHamlAttributeDynamicValue(l *lexer) lexFn {
 	l.skip() // skip hash
 	if l.peek() != '{' {
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 	l.skip() // skip opening brace
 	r := continueToMatchingBrace(l, '}', false)
 	if r == scanner.EOF {
-		return l.errorf("attribute value not closed: eof")
+		return l.notClosed("attribute value")
 	}
 	l.backup()
 	l.emit(tAttrDynamicValue)
@@ -280,17 +318,31 @@ func lexHamlAttributeDynamicValue(l *lexer) lexFn {
 	return lexHamlAttributeEnd
 }
 
+func lexHamlAttributeHashStart(l *lexer) lexFn {
+	l.skip() // skip opening brace
+	l.emit(tAttrHashStart)
+	l.hashDepth++
+	return lexHamlAttribute
+}
+
+func lexHamlAttributeHashEnd(l *lexer) lexFn {
+	l.skip() // skip closing brace
+	l.emit(tAttrHashEnd)
+	l.hashDepth--
+	return lexHamlAttributeEnd
+}
+
 func lexHamlAttributeCommandStart(l *lexer) lexFn {
 	l.skipRun("@")
 	l.acceptUntil(": \t\n\r")
 	if l.current() == "" {
-		return l.errorf("command code expected")
+		return l.errorf(ErrExpected, "command code expected")
 	}
 	switch l.current() {
 	case "attributes":
 		return lexHamlAttributeCommand(tAttributesCommand)
 	default:
-		return l.errorf("unknown attribute command: %s", l.current())
+		return l.errorf(ErrUnknownCommand, "unknown attribute command: %s", l.current())
 	}
 }
 
@@ -302,7 +354,7 @@ func lexHamlAttributeCommand(command tokenType) lexFn {
 		l.skip() // skip opening brace
 		r := continueToMatchingBrace(l, '}', true)
 		if r == scanner.EOF {
-			return l.errorf("attribute value not closed: eof")
+			return l.notClosed("attribute value")
 		}
 		l.backup()
 		l.emit(command)
@@ -313,7 +365,9 @@ func lexHamlAttributeCommand(command tokenType) lexFn {
 }
 
 func lexHamlAttributeEnd(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case ',':
 		l.skip()
@@ -321,7 +375,7 @@ func lexHamlAttributeEnd(l *lexer) lexFn {
 	case '}':
 		return lexHamlAttributesEnd
 	default:
-		return l.errorf("unexpected character: %c", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %c", l.peek())
 	}
 }
 
@@ -333,7 +387,7 @@ func lexHamlWhitespaceRemoval(l *lexer) lexFn {
 	case '<':
 		l.emit(tNukeInnerWhitespace)
 	default:
-		return l.errorf("unexpected character: %q", direction)
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", direction)
 	}
 	return lexHamlContentEnd
 }
@@ -379,7 +433,7 @@ func lexHamlDynamicText(l *lexer) lexFn {
 	l.skipRun("#{")
 	r := continueToMatchingBrace(l, '}', false)
 	if r == scanner.EOF {
-		return l.errorf("dynamic text value was not closed: eof")
+		return l.errorf(ErrNotClosed, "dynamic text value was not closed: eof")
 	}
 	l.backup()
 	l.emit(tDynamicText)
@@ -457,7 +511,7 @@ func lexHamlCodeBlockIndent(indent int, textType tokenType) lexFn {
 		indents := l.peekAhead(indent)
 
 		if len(strings.Trim(indents, "\t")) != 0 {
-			return l.errorf("expected continuation of code")
+			return l.errorf(ErrExpected, "expected continuation of code")
 		}
 
 		l.skipAhead(indent)
@@ -495,7 +549,7 @@ func lexHamlVoidTag(l *lexer) lexFn {
 	l.acceptUntil("\n\r")
 	if l.current() != "" {
 		l.ignore()
-		return l.errorf("self-closing tags can't have content")
+		return l.errorf(ErrIllegalNesting, "self-closing tags can't have content")
 	}
 	l.emit(tVoidTag)
 	return lexHamlLineEnd
@@ -505,7 +559,7 @@ func lexHamlCommandCode(l *lexer) lexFn {
 	l.skipRun("@")
 	l.acceptUntil("() \t\n\r")
 	if l.current() == "" {
-		return l.errorf("command code expected")
+		return l.errorf(ErrExpected, "command code expected")
 	}
 	switch l.current() {
 	case "render":
@@ -513,7 +567,7 @@ func lexHamlCommandCode(l *lexer) lexFn {
 		l.ignore()
 		l.acceptUntil("\\\n\r")
 		if l.current() == "" {
-			return l.errorf("render argument expected")
+			return l.errorf(ErrExpected, "render argument expected")
 		}
 		if n := l.peek(); n == '\\' || strings.HasSuffix(l.current(), ",") {
 			if n == '\\' {
@@ -528,34 +582,98 @@ func lexHamlCommandCode(l *lexer) lexFn {
 		l.ignore()
 		l.acceptUntil("\n\r")
 		if l.current() != "" {
-			return l.errorf("children command does not accept arguments")
+			return l.errorf(ErrCommandArguments, "children command does not accept arguments")
 		}
 		l.emit(tChildrenCommand)
+	case "flush":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() != "" {
+			return l.errorf(ErrCommandArguments, "flush command does not accept arguments")
+		}
+		l.emit(tFlushCommand)
 	case "slot":
 		l.acceptRun("() \t")
 		l.ignore()
 		l.acceptUntil("\n\r")
 		if l.current() == "" {
-			return l.errorf("slot name expected")
+			return l.errorf(ErrExpected, "slot name expected")
 		}
 		l.emit(tSlotCommand)
+	case "fragment":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "fragment name expected")
+		}
+		l.emit(tFragmentCommand)
+	case "extends":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "extends template expected")
+		}
+		l.emit(tExtendsCommand)
+	case "block":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "block name expected")
+		}
+		l.emit(tBlockCommand)
+	case "push":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "push stack name expected")
+		}
+		l.emit(tPushCommand)
+	case "stack":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "stack name expected")
+		}
+		l.emit(tStackCommand)
+	case "json":
+		l.acceptRun(" \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "json value expected")
+		}
+		l.emit(tJsonCommand)
+	case "format":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "format expected")
+		}
+		l.emit(tFormatCommand)
 	default:
-		return l.errorf("unknown command: %s", l.current())
+		return l.errorf(ErrUnknownCommand, "unknown command: %s", l.current())
 	}
 	l.skipRun("\n\r")
 	return lexHamlLineStart
 }
 
-var hamlFilters = []string{"javascript", "css", "plain", "escaped", "preserve"}
+var hamlFilters = []string{"javascript", "css", "plain", "escaped", "preserve", "markdown"}
 
 func lexHamlFilterStart(l *lexer) lexFn {
 	l.skipRun(": \t")
 	l.acceptUntil(" \t\n\r")
 	if l.current() == "" {
-		return l.errorf("filter name expected")
+		return l.errorf(ErrExpected, "filter name expected")
 	}
-	if !slices.Contains(hamlFilters, l.current()) {
-		return l.errorf("unknown filter: %s", l.current())
+	if !isFilter(hamlFilters, l.current()) {
+		return l.errorf(ErrUnknownFilter, "unknown filter: %s", l.current())
 	}
 	filter := l.current()
 	l.emit(tFilterStart)
@@ -563,14 +681,12 @@ func lexHamlFilterStart(l *lexer) lexFn {
 	l.skipRun("\n\r")   // split so we don't consume the indent on the next line
 
 	switch filter {
-	case "javascript", "css", "plain":
-		return lexHamlFilterLineStart(l.indent+1, tPlainText)
 	case "escaped":
 		return lexHamlFilterLineStart(l.indent+1, tEscapedText)
 	case "preserve":
 		return lexHamlFilterLineStart(l.indent+1, tPreserveText)
 	default:
-		return l.errorf("unsupported filter: %s", filter)
+		return lexHamlFilterLineStart(l.indent+1, tPlainText)
 	}
 }
 
@@ -634,7 +750,7 @@ func lexHamlFilterDynamicText(textType tokenType, next lexFn) lexFn {
 		l.skipAhead(2) // skip the hash and opening brace
 		r := continueToMatchingBrace(l, '}', false)
 		if r == scanner.EOF {
-			return l.errorf("dynamic text value was not closed: eof")
+			return l.errorf(ErrNotClosed, "dynamic text value was not closed: eof")
 		}
 		l.backup()
 		l.emit(tDynamicText)
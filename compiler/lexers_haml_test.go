@@ -805,7 +805,7 @@ func Test_HamlAttributes(t *testing.T) {
 			},
 		},
 		"several on multiple lines": {
-			input: "@goht test() {\n\t%foo{\n\tid:\"bar\",\n\tclass: `baz` ,\n\ttitle : \"qux\"\n}",
+			input: "@goht test() {\n\t%foo{\n\tid:\"bar\",\n\tclass: `baz` ,\n\ttitle : \"qux\"\n\t}",
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tKeepNewlines, lit: ""},
@@ -933,6 +933,132 @@ func Test_HamlAttributes(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"html style attributes": {
+			input: "@goht test() {\n\t%a(href=\"/\" title = #{title}\n\t\tdisabled)#bar text",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "href"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"/\""},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrDynamicValue, lit: "title"},
+				{typ: tAttrName, lit: "disabled"},
+				{typ: tId, lit: "bar"},
+				{typ: tPlainText, lit: "text"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"html style attributes with single quotes": {
+			input: "@goht test() {\n\t%a(title='t' alt='say \"hi\", it\\'s \\n')",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"t\""},
+				{typ: tAttrName, lit: "alt"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"say \\\"hi\\\", it's \\n\""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"html style single quotes not closed": {
+			input: "@goht test() {\n\t%a(title='t)",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tError, lit: "attribute value not closed: eof"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"html style attributes not closed": {
+			input: "@goht test() {\n\t%a(href=\"/\"",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "href"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"/\""},
+				{typ: tError, lit: "attributes not closed: eof"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"nested attributes": {
+			input: "@goht test() {\n\t%foo{data: {user_id: #{id}, role: \"admin\"}, id: \"bar\"}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "foo"},
+				{typ: tAttrName, lit: "data"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "user_id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrDynamicValue, lit: "id"},
+				{typ: tAttrName, lit: "role"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "\"admin\""},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tAttrName, lit: "id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "\"bar\""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"nested number attributes": {
+			input: "@goht test() {\n\t%foo{data: {user_id: 1, ratio: -0.5}}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "foo"},
+				{typ: tAttrName, lit: "data"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "user_id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "1"},
+				{typ: tAttrName, lit: "ratio"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "-0.5"},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"deeply nested attributes": {
+			input: "@goht test() {\n\t%foo{aria: {a: {b: \"c\",},}}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "foo"},
+				{typ: tAttrName, lit: "aria"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "a"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "b"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "\"c\""},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
 		"attributes command": {
 			input: "@goht test() {\n\t%foo{@attributes:#{listA, \"}}\", listB}}",
 			want: []token{
@@ -1508,6 +1634,182 @@ func Test_HamlOutputCode(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"with flush command": {
+			input: "@goht test() {\n\t= @flush",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFlushCommand, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with flush command and parens": {
+			input: "@goht test() {\n\t= @flush()",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFlushCommand, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without any flush arguments": {
+			input: "@goht test() {\n\t= @flush() asdfasdf",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "flush command does not accept arguments"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with fragment command": {
+			input: "@goht test() {\n\t= @fragment cart-items\n\t\t%p",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFragmentCommand, lit: "cart-items"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "p"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without fragment name": {
+			input: "@goht test() {\n\t= @fragment",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "fragment name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with extends command": {
+			input: "@goht test() {\n\t= @extends Base(\"title\")",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tExtendsCommand, lit: "Base(\"title\")"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without extends template": {
+			input: "@goht test() {\n\t= @extends",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "extends template expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with block command": {
+			input: "@goht test() {\n\t= @block content\n\t\t%p",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tBlockCommand, lit: "content"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "p"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without block name": {
+			input: "@goht test() {\n\t= @block",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "block name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with push command": {
+			input: "@goht test() {\n\t= @push scripts \"app\"\n\t\t%script",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tPushCommand, lit: "scripts \"app\""},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "script"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without push stack name": {
+			input: "@goht test() {\n\t= @push",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "push stack name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with stack command": {
+			input: "@goht test() {\n\t= @stack scripts",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tStackCommand, lit: "scripts"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with json command": {
+			input: "@goht test() {\n\t= @json(data)",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tJsonCommand, lit: "(data)"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without json value": {
+			input: "@goht test() {\n\t= @json",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "json value expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with format command": {
+			input: "@goht test() {\n\t= @format xhtml",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFormatCommand, lit: "xhtml"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without format": {
+			input: "@goht test() {\n\t= @format",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "format expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without stack name": {
+			input: "@goht test() {\n\t= @stack",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "stack name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1547,6 +1849,16 @@ func Test_HamlSlotCommand(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"with a value": {
+			input: "@goht test() {\n\t= @slot row(item)",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tKeepNewlines, lit: ""},
+				{typ: tIndent, lit: "\t"},
+				{typ: tSlotCommand, lit: "row(item)"},
+				{typ: tEOF, lit: ""},
+			},
+		},
 		"with default content": {
 			input: "@goht test() {\n\t= @slot testing\n\t\t%p bar",
 			want: []token{
@@ -1843,6 +2155,8 @@ func Test_HamlIndent(t *testing.T) {
 				{typ: tTag, lit: "p1"},
 				{typ: tNewLine, lit: "\n"},
 				{typ: tError, lit: "the line was indented using spaces, templates must be indented using tabs"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
@@ -1862,6 +2176,8 @@ func Test_HamlIndent(t *testing.T) {
 				{typ: tTag, lit: "p2"},
 				{typ: tNewLine, lit: "\n"},
 				{typ: tError, lit: "the line was indented 2 levels deeper than the previous line"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
@@ -1944,6 +2260,11 @@ func Test_HamlFilters(t *testing.T) {
 				{typ: tKeepNewlines, lit: ""},
 				{typ: tIndent, lit: "\t"},
 				{typ: tError, lit: "filter name expected"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tPlainText, lit: "foo"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
@@ -1954,6 +2275,11 @@ func Test_HamlFilters(t *testing.T) {
 				{typ: tKeepNewlines, lit: ""},
 				{typ: tIndent, lit: "\t"},
 				{typ: tError, lit: "unknown filter: unknown"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tPlainText, lit: "foo"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tTemplateEnd, lit: ""},
 				{typ: tEOF, lit: ""},
 			},
 		},
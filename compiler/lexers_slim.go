@@ -1,7 +1,7 @@
 package compiler
 
 import (
-	"slices"
+	"regexp"
 	"strings"
 	"text/scanner"
 )
@@ -30,7 +30,7 @@ func lexSlimIndent(l *lexer) lexFn {
 	// there has not been any indentation yet
 	if l.indent == 0 && len(indent) == 0 {
 		// return an error that indents are required
-		return l.errorf("slim templates must be indented")
+		return l.errorf(ErrIndentMissing, "slim templates must be indented")
 	}
 
 	// validate the indent against the sequence and char
@@ -43,6 +43,15 @@ func lexSlimIndent(l *lexer) lexFn {
 	return lexSlimContentStart
 }
 
+// lexSlimRecover continues lexing the template at the next line after an
+// error.
+func lexSlimRecover(l *lexer) lexFn {
+	l.ignore()
+	l.hashDepth = 0
+	l.skipUntil("\n\r")
+	return lexSlimLineEnd
+}
+
 func lexSlimContentStart(l *lexer) lexFn {
 	switch p := l.peek(); p {
 	case '#':
@@ -57,7 +66,7 @@ func lexSlimContentStart(l *lexer) lexFn {
 		return lexSlimComment
 	case ':':
 		return lexSlimFilterStart
-	case '|':
+	case '|', '\'':
 		return lexSlimTextBlock
 	case '{':
 		return lexSlimAttributesStart
@@ -68,7 +77,7 @@ func lexSlimContentStart(l *lexer) lexFn {
 		if isLetter(p) {
 			return lexSlimTag
 		}
-		return l.errorf("unexpected character: %q", p)
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", p)
 	}
 }
 
@@ -84,6 +93,10 @@ func lexSlimContent(l *lexer) lexFn {
 		return lexSlimClass
 	case '{':
 		return lexSlimAttributesStart
+	case '(':
+		return lexHTMLAttributesStart(')', lexSlimContent)
+	case '[':
+		return lexHTMLAttributesStart(']', lexSlimContent)
 	case '=':
 		return lexSlimOutputCode
 	case '/':
@@ -94,14 +107,26 @@ func lexSlimContent(l *lexer) lexFn {
 		return lexSlimInlineTag
 	case ' ', '\t':
 		l.skipRun(" \t")
-		return lexSlimTextBlockContent(l.indent+1, 0, tPlainText)
+		if isSlimBareAttribute(l.peekUntil("\n\r")) {
+			return lexHTMLAttribute("", lexSlimContent)
+		}
+		return lexSlimTextBlockContent(l.indent+1, 0, tPlainText, "")
 	case scanner.EOF, '\n', '\r':
 		return lexSlimLineEnd
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
+// reSlimBareAttribute matches the start of an attribute that follows a tag
+// without a wrapper, such as `a href="/" Home`. The value must be quoted or
+// interpolated so that the attribute cannot be mistaken for text.
+var reSlimBareAttribute = regexp.MustCompile("^[A-Za-z@:_][^\\s=\"'`#(){}\\[\\]]*=(\"|'|`|#\\{)")
+
+func isSlimBareAttribute(s string) bool {
+	return reSlimBareAttribute.MatchString(s)
+}
+
 func lexSlimLineEnd(l *lexer) lexFn {
 	l.skipRun(" \t")
 
@@ -112,7 +137,7 @@ func lexSlimLineEnd(l *lexer) lexFn {
 		l.emit(tEOF)
 		return nil
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
@@ -128,11 +153,11 @@ func slimIdentifier(typ tokenType, l *lexer) lexFn {
 	}
 
 	// these characters may follow an identifier
-	const mayFollowIdentifier = "#.{=!/<>: \t\n\r"
+	const mayFollowIdentifier = "#.{([=!/<>: \t\n\r"
 
 	l.acceptUntil(mayFollowIdentifier)
 	if l.current() == "" {
-		return l.errorf("%s identifier expected", typ)
+		return l.errorf(ErrExpected, "%s identifier expected", typ)
 	}
 	if l.current() == "doctype" {
 		return lexSlimDoctype
@@ -220,36 +245,52 @@ func lexSlimComment(l *lexer) lexFn {
 
 	l.skip() // eat bang
 	l.skipRun(" \t")
-	return lexSlimTextBlockContent(l.indent+1, 0, tComment)
+	return lexSlimTextBlockContent(l.indent+1, 0, tComment, "")
 }
 
+// lexSlimTextBlock lexes a block of text that starts with a pipe, or with a
+// single quote that adds a space to the end of the text.
 func lexSlimTextBlock(l *lexer) lexFn {
-	l.skip() // eat pipe
+	end := ""
+	if l.skip() == '\'' {
+		end = " "
+	}
 	// test for a space after the pipe
 	if n := l.peek(); n == ' ' || n == '\t' {
-		return lexSlimTextBlockContent(l.indent+1, 1, tPlainText)
+		return lexSlimTextBlockContent(l.indent+1, 1, tPlainText, end)
 	}
-	return lexSlimTextBlockContent(l.indent+1, 0, tPlainText)
+	return lexSlimTextBlockContent(l.indent+1, 0, tPlainText, end)
 }
 
-func lexSlimTextBlockLineStart(indent int, spaces int, textType tokenType) lexFn {
+// endSlimTextBlock emits the text that is added to the end of a text block,
+// which has no source of its own.
+func endSlimTextBlock(l *lexer, textType tokenType, end string) {
+	if end != "" {
+		line, col := l.position()
+		l.tokens <- token{typ: textType, lit: end, line: line, col: col, endLine: line, endCol: col}
+	}
+}
+
+func lexSlimTextBlockLineStart(indent int, spaces int, textType tokenType, end string) lexFn {
 	return func(l *lexer) lexFn {
 		switch l.peek() {
 		case ' ', '\t':
-			return lexSlimTextBlockIndent(indent, spaces, textType)
+			return lexSlimTextBlockIndent(indent, spaces, textType, end)
 		case scanner.EOF:
+			endSlimTextBlock(l, textType, end)
 			l.emit(tEOF)
 			return nil
 		default:
 			if l.current() != "" {
 				l.emit(textType)
 			}
+			endSlimTextBlock(l, textType, end)
 			return lexSlimLineStart
 		}
 	}
 }
 
-func lexSlimTextBlockIndent(indent int, spaces int, textType tokenType) lexFn {
+func lexSlimTextBlockIndent(indent int, spaces int, textType tokenType, end string) lexFn {
 	return func(l *lexer) lexFn {
 		// only accept the whitespace that belongs to the indent
 
@@ -260,16 +301,17 @@ func lexSlimTextBlockIndent(indent int, spaces int, textType tokenType) lexFn {
 			if l.current() != "" {
 				l.emit(textType)
 			}
+			endSlimTextBlock(l, textType, end)
 			return lexSlimLineStart
 		}
 
 		l.skipAhead(indent)
 
-		return lexSlimTextBlockContent(indent, spaces, textType)
+		return lexSlimTextBlockContent(indent, spaces, textType, end)
 	}
 }
 
-func lexSlimTextBlockContent(indent int, spaces int, textType tokenType) lexFn {
+func lexSlimTextBlockContent(indent int, spaces int, textType tokenType, end string) lexFn {
 	return func(l *lexer) lexFn {
 		if spaces != 0 {
 			if n := l.peek(); n == ' ' || n == '\t' {
@@ -283,13 +325,13 @@ func lexSlimTextBlockContent(indent int, spaces int, textType tokenType) lexFn {
 
 		if l.peek() == '#' && !strings.HasSuffix(l.current(), "\\") {
 			// l.emit(textType)
-			return lexSlimFilterDynamicText(textType, lexSlimTextBlockContent(indent, spaces, textType))
+			return lexSlimFilterDynamicText(textType, lexSlimTextBlockContent(indent, spaces, textType, end))
 		}
 		l.acceptRun("\n\r")
 		if l.current() != "" {
 			l.emit(tNewLine)
 		}
-		return lexSlimTextBlockLineStart(indent, spaces, textType)
+		return lexSlimTextBlockLineStart(indent, spaces, textType, end)
 	}
 }
 
@@ -301,7 +343,7 @@ func lexSlimCodeBlockIndent(indent int, textType tokenType) lexFn {
 		indents := l.peekAhead(indent)
 
 		if len(strings.Trim(indents, "\t")) != 0 {
-			return l.errorf("expected continuation of code")
+			return l.errorf(ErrExpected, "expected continuation of code")
 		}
 
 		l.skipAhead(indent)
@@ -332,7 +374,7 @@ func lexSlimCloseTag(l *lexer) lexFn {
 	l.skipRun(" \t")
 	l.acceptUntil("\n\r")
 	if l.current() != "" {
-		return l.errorf("self-closing tags can't have content")
+		return l.errorf(ErrIllegalNesting, "self-closing tags can't have content")
 	}
 	l.emit(tVoidTag)
 	return lexSlimLineEnd
@@ -342,7 +384,7 @@ func lexSlimCommandCode(l *lexer) lexFn {
 	l.skipRun("@")
 	l.acceptUntil("() \t\n\r")
 	if l.current() == "" {
-		return l.errorf("command code expected")
+		return l.errorf(ErrExpected, "command code expected")
 	}
 	switch l.current() {
 	case "render":
@@ -350,7 +392,7 @@ func lexSlimCommandCode(l *lexer) lexFn {
 		l.ignore()
 		l.acceptUntil("\\\n\r")
 		if l.current() == "" {
-			return l.errorf("render argument expected")
+			return l.errorf(ErrExpected, "render argument expected")
 		}
 		if n := l.peek(); n == '\\' || strings.HasSuffix(l.current(), ",") {
 			if n == '\\' {
@@ -365,43 +407,102 @@ func lexSlimCommandCode(l *lexer) lexFn {
 		l.ignore()
 		l.acceptUntil("\n\r")
 		if l.current() != "" {
-			return l.errorf("children command does not accept arguments")
+			return l.errorf(ErrCommandArguments, "children command does not accept arguments")
 		}
 		l.emit(tChildrenCommand)
+	case "flush":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() != "" {
+			return l.errorf(ErrCommandArguments, "flush command does not accept arguments")
+		}
+		l.emit(tFlushCommand)
 	case "slot":
 		l.acceptRun("() \t")
 		l.ignore()
 		l.acceptUntil("\n\r")
 		if l.current() == "" {
-			return l.errorf("slot name expected")
+			return l.errorf(ErrExpected, "slot name expected")
 		}
 		l.emit(tSlotCommand)
+	case "fragment":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "fragment name expected")
+		}
+		l.emit(tFragmentCommand)
+	case "extends":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "extends template expected")
+		}
+		l.emit(tExtendsCommand)
+	case "block":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "block name expected")
+		}
+		l.emit(tBlockCommand)
+	case "push":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "push stack name expected")
+		}
+		l.emit(tPushCommand)
+	case "stack":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "stack name expected")
+		}
+		l.emit(tStackCommand)
+	case "json":
+		l.acceptRun(" \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "json value expected")
+		}
+		l.emit(tJsonCommand)
+	case "format":
+		l.acceptRun("() \t")
+		l.ignore()
+		l.acceptUntil("\n\r")
+		if l.current() == "" {
+			return l.errorf(ErrExpected, "format expected")
+		}
+		l.emit(tFormatCommand)
 	}
 	l.skipRun("\n\r")
 	return lexSlimLineStart
 }
 
-var slimFilters = []string{"javascript", "css"}
+var slimFilters = []string{"javascript", "css", "markdown"}
 
 func lexSlimFilterStart(l *lexer) lexFn {
 	l.skipRun(": \t")
 	l.acceptUntil(" \t\n\r")
 	if l.current() == "" {
-		return l.errorf("filter name expected")
+		return l.errorf(ErrExpected, "filter name expected")
 	}
-	if !slices.Contains(slimFilters, l.current()) {
-		return l.errorf("unknown filter: %s", l.current())
+	if !isFilter(slimFilters, l.current()) {
+		return l.errorf(ErrUnknownFilter, "unknown filter: %s", l.current())
 	}
-	filter := l.current()
 	l.emit(tFilterStart)
 	l.skipUntil("\n\r") // ignore the rest of the current line
 	l.skipRun("\n\r")   // split so we don't consume the indent on the next line
 
-	switch filter {
-	case "javascript", "css":
-		return lexSlimFilterLineStart(l.indent+1, tPlainText)
-	}
-	return lexSlimLineEnd
+	return lexSlimFilterLineStart(l.indent+1, tPlainText)
 }
 
 func lexSlimFilterLineStart(indent int, textType tokenType) lexFn {
@@ -466,7 +567,7 @@ func lexSlimFilterDynamicText(textType tokenType, next lexFn) lexFn {
 		l.skipRun("#{")
 		r := continueToMatchingBrace(l, '}', false)
 		if r == scanner.EOF {
-			return l.errorf("dynamic text value was not closed: eof")
+			return l.errorf(ErrNotClosed, "dynamic text value was not closed: eof")
 		}
 		l.backup()
 		l.emit(tDynamicText)
@@ -478,11 +579,15 @@ func lexSlimFilterDynamicText(textType tokenType, next lexFn) lexFn {
 // Parsing the Slim attributes the same as the Haml attributes
 
 func lexSlimAttributesStart(l *lexer) lexFn {
+	l.markOpen()
 	l.skip()
 	return lexSlimAttribute
 }
 
 func lexSlimAttributesEnd(l *lexer) lexFn {
+	if l.hashDepth > 0 {
+		return lexSlimAttributeHashEnd
+	}
 	l.skip()
 	return lexSlimContent
 }
@@ -492,9 +597,12 @@ func lexSlimAttribute(l *lexer) lexFn {
 	// key
 	// key:value
 	// key?value
+	// key:{key:value, ...} (data and aria only)
 	// @attributes: []any (string, map[string]string, map[string]bool)
 
-	l.skipRun(", \t\n\r")
+	if !l.skipLines(", \t\n\r") {
+		return l.notClosed("attributes")
+	}
 
 	switch l.peek() {
 	case '}':
@@ -510,58 +618,79 @@ func lexSlimAttributeName(l *lexer) lexFn {
 	if l.peek() == '"' || l.peek() == '`' {
 		r := continueToMatchingQuote(l, tAttrName, false)
 		if r == scanner.EOF {
-			return l.errorf("attribute name not closed: eof")
+			return l.notClosed("attribute name")
 		} else if r != '"' && r != '`' {
-			return l.errorf("unexpected character: %q", r)
+			return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", r)
 		}
 	} else {
 		l.acceptUntil("?:,}{\" \t\n\r")
 		if l.current() == "" {
-			return l.errorf("attribute name expected")
+			return l.errorf(ErrExpected, "attribute name expected")
 		}
 		l.emit(tAttrName)
 	}
 
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case '?', ':':
 		return lexSlimAttributeOperator
 	case ',', '}':
 		return lexSlimAttributeEnd
 	default:
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 }
 
 func lexSlimAttributeOperator(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case '?', ':':
 		l.next()
 		l.emit(tAttrOperator)
 		return lexSlimAttributeValue
 	}
-	return l.errorf("unexpected character: %q", l.peek())
+	return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 }
 
 func lexSlimAttributeValue(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 
 	switch l.peek() {
 	case '"', '`':
 		return lexSlimAttributeStaticValue
 	case '#':
 		return lexSlimAttributeDynamicValue
+	case '{':
+		return lexSlimAttributeHashStart
+	}
+	if isNumberStart(l.peek()) {
+		return lexSlimAttributeNumberValue
 	}
-	return l.errorf("unexpected character: %q", l.peek())
+	return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
+}
+
+// lexSlimAttributeNumberValue lexes a number literal, which is written as a
+// static value: `{data: {user_id: 1}}` renders `data-user-id="1"`.
+func lexSlimAttributeNumberValue(l *lexer) lexFn {
+	if !acceptNumber(l) {
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
+	}
+	l.emit(tAttrEscapedValue)
+	return lexSlimAttributeEnd
 }
 
 func lexSlimAttributeStaticValue(l *lexer) lexFn {
 	r := continueToMatchingQuote(l, tAttrEscapedValue, true)
 	if r == scanner.EOF {
-		return l.errorf("attribute value not closed: eof")
+		return l.notClosed("attribute value")
 	} else if r != '"' && r != '`' {
-		return l.errorf("unexpected character: %q", r)
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", r)
 	}
 	return lexSlimAttributeEnd
 }
@@ -569,12 +698,12 @@ func lexSlimAttributeStaticValue(l *lexer) lexFn {
 func lexSlimAttributeDynamicValue(l *lexer) lexFn {
 	l.skip() // skip hash
 	if l.peek() != '{' {
-		return l.errorf("unexpected character: %q", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %q", l.peek())
 	}
 	l.skip() // skip opening brace
 	r := continueToMatchingBrace(l, '}', false)
 	if r == scanner.EOF {
-		return l.errorf("attribute value not closed: eof")
+		return l.notClosed("attribute value")
 	}
 	l.backup()
 	l.emit(tAttrDynamicValue)
@@ -582,17 +711,31 @@ func lexSlimAttributeDynamicValue(l *lexer) lexFn {
 	return lexSlimAttributeEnd
 }
 
+func lexSlimAttributeHashStart(l *lexer) lexFn {
+	l.skip() // skip opening brace
+	l.emit(tAttrHashStart)
+	l.hashDepth++
+	return lexSlimAttribute
+}
+
+func lexSlimAttributeHashEnd(l *lexer) lexFn {
+	l.skip() // skip closing brace
+	l.emit(tAttrHashEnd)
+	l.hashDepth--
+	return lexSlimAttributeEnd
+}
+
 func lexSlimAttributeCommandStart(l *lexer) lexFn {
 	l.skipRun("@")
 	l.acceptUntil(": \t\n\r")
 	if l.current() == "" {
-		return l.errorf("command code expected")
+		return l.errorf(ErrExpected, "command code expected")
 	}
 	switch l.current() {
 	case "attributes":
 		return lexSlimAttributeCommand(tAttributesCommand)
 	default:
-		return l.errorf("unknown attribute command: %s", l.current())
+		return l.errorf(ErrUnknownCommand, "unknown attribute command: %s", l.current())
 	}
 }
 
@@ -604,7 +747,7 @@ func lexSlimAttributeCommand(command tokenType) lexFn {
 		l.skip() // skip opening brace
 		r := continueToMatchingBrace(l, '}', true)
 		if r == scanner.EOF {
-			return l.errorf("attribute value not closed: eof")
+			return l.notClosed("attribute value")
 		}
 		l.backup()
 		l.emit(command)
@@ -615,7 +758,9 @@ func lexSlimAttributeCommand(command tokenType) lexFn {
 }
 
 func lexSlimAttributeEnd(l *lexer) lexFn {
-	l.skipRun(" \t\n\r")
+	if !l.skipLines(" \t\n\r") {
+		return l.notClosed("attributes")
+	}
 	switch l.peek() {
 	case ',':
 		l.skip()
@@ -623,7 +768,7 @@ func lexSlimAttributeEnd(l *lexer) lexFn {
 	case '}':
 		return lexSlimAttributesEnd
 	default:
-		return l.errorf("unexpected character: %c", l.peek())
+		return l.errorf(ErrUnexpectedCharacter, "unexpected character: %c", l.peek())
 	}
 }
 
@@ -54,6 +54,28 @@ func Test_SlimText(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"quote": {
+			input: "@slim test() {\n\t' foobar\n\tp",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tPlainText, lit: "foobar"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tPlainText, lit: " "},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "p"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"quote without text": {
+			input: "@slim test() {\n\t'",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tPlainText, lit: " "},
+				{typ: tEOF, lit: ""},
+			},
+		},
 		"multiple lines": {
 			input: "@slim test() {\n\t|foobar\n\t|baz",
 			want: []token{
@@ -666,7 +688,7 @@ func Test_SlimAttributes(t *testing.T) {
 			},
 		},
 		"several on multiple lines": {
-			input: "@slim test() {\n\tfoo{\n\tid:\"bar\",\n\tclass: `baz` ,\n\ttitle : \"qux\"\n}",
+			input: "@slim test() {\n\tfoo{\n\tid:\"bar\",\n\tclass: `baz` ,\n\ttitle : \"qux\"\n\t}",
 			want: []token{
 				{typ: tTemplateStart, lit: "test()"},
 				{typ: tIndent, lit: "\t"},
@@ -762,6 +784,113 @@ func Test_SlimAttributes(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"parentheses wrapped attributes": {
+			input: "@slim test() {\n\ta(href=\"/\" disabled) text",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "href"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"/\""},
+				{typ: tAttrName, lit: "disabled"},
+				{typ: tPlainText, lit: "text"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"single quoted attributes": {
+			input: "@slim test() {\n\ta(title='t') text\n\ta[title='t']\n\ta href='/' Home",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"t\""},
+				{typ: tPlainText, lit: "text"},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"t\""},
+				{typ: tNewLine, lit: "\n"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "href"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"/\""},
+				{typ: tPlainText, lit: "Home"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"bracket wrapped attributes": {
+			input: "@slim test() {\n\ta[title=#{title}]",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tAttrName, lit: "title"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrDynamicValue, lit: "title"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"bare attributes": {
+			input: "@slim test() {\n\ta.btn href=\"/\" data-id=#{id} x=1 Home",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "a"},
+				{typ: tClass, lit: "btn"},
+				{typ: tAttrName, lit: "href"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrEscapedValue, lit: "\"/\""},
+				{typ: tAttrName, lit: "data-id"},
+				{typ: tAttrOperator, lit: "="},
+				{typ: tAttrDynamicValue, lit: "id"},
+				{typ: tPlainText, lit: "x=1 Home"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"nested attributes": {
+			input: "@slim test() {\n\tfoo{data: {user_id: #{id}}, id: \"bar\"}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "foo"},
+				{typ: tAttrName, lit: "data"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "user_id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrDynamicValue, lit: "id"},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tAttrName, lit: "id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "\"bar\""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"nested number attributes": {
+			input: "@slim test() {\n\tfoo{data: {user_id: 1, role: \"admin\"}}",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tTag, lit: "foo"},
+				{typ: tAttrName, lit: "data"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrHashStart, lit: ""},
+				{typ: tAttrName, lit: "user_id"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "1"},
+				{typ: tAttrName, lit: "role"},
+				{typ: tAttrOperator, lit: ":"},
+				{typ: tAttrEscapedValue, lit: "\"admin\""},
+				{typ: tAttrHashEnd, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
 		"attributes command": {
 			input: "@slim test() {\n\tfoo{@attributes:#{listA, \"}}\", listB}}",
 			want: []token{
@@ -1219,6 +1348,147 @@ func Test_SlimOutputCode(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"with flush command": {
+			input: "@slim test() {\n\t= @flush",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFlushCommand, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with flush command and parens": {
+			input: "@slim test() {\n\t= @flush()",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFlushCommand, lit: ""},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without any flush arguments": {
+			input: "@slim test() {\n\t= @flush() asdfasdf",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "flush command does not accept arguments"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with fragment command": {
+			input: "@slim test() {\n\t= @fragment cart-items\n\t\tp",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFragmentCommand, lit: "cart-items"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "p"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without fragment name": {
+			input: "@slim test() {\n\t= @fragment",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "fragment name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with extends command": {
+			input: "@slim test() {\n\t= @extends Base(\"title\")",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tExtendsCommand, lit: "Base(\"title\")"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without extends template": {
+			input: "@slim test() {\n\t= @extends",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "extends template expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with block command": {
+			input: "@slim test() {\n\t= @block content\n\t\tp",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tBlockCommand, lit: "content"},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "p"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without block name": {
+			input: "@slim test() {\n\t= @block",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "block name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with push command": {
+			input: "@slim test() {\n\t= @push scripts \"app\"\n\t\tscript",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tPushCommand, lit: "scripts \"app\""},
+				{typ: tIndent, lit: "\t\t"},
+				{typ: tTag, lit: "script"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with stack command": {
+			input: "@slim test() {\n\t= @stack scripts",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tStackCommand, lit: "scripts"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with json command": {
+			input: "@slim test() {\n\t= @json user.Settings",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tJsonCommand, lit: "user.Settings"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"with format command": {
+			input: "@slim test() {\n\t= @format xml",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tFormatCommand, lit: "xml"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without format": {
+			input: "@slim test() {\n\t= @format",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "format expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
+		"without stack name": {
+			input: "@slim test() {\n\t= @stack",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tError, lit: "stack name expected"},
+				{typ: tEOF, lit: ""},
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1256,6 +1526,15 @@ func Test_SlimSlotCommand(t *testing.T) {
 				{typ: tEOF, lit: ""},
 			},
 		},
+		"with a value": {
+			input: "@slim test() {\n\t= @slot row(item)",
+			want: []token{
+				{typ: tTemplateStart, lit: "test()"},
+				{typ: tIndent, lit: "\t"},
+				{typ: tSlotCommand, lit: "row(item)"},
+				{typ: tEOF, lit: ""},
+			},
+		},
 		"with default content": {
 			input: "@slim test() {\n\t= @slot testing\n\t\tp bar",
 			want: []token{
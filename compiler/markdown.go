@@ -0,0 +1,769 @@
+package compiler
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// markdown converts the body of a :markdown filter into HTML when the template
+// is generated.
+//
+// The blocks and inlines of CommonMark that are used in prose are supported:
+// headings, paragraphs, block quotes, lists, code blocks, thematic breaks and
+// HTML blocks; and emphasis, strikethrough, code spans, links, images,
+// autolinks, inline HTML and hard line breaks. Links may also be written with
+// reference definitions.
+func markdown(src string) (string, error) {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(src, "\r\n", "\n"), "\n"), "\n")
+	md := &mdConverter{refs: make(map[string]mdLink)}
+	lines = md.references(lines)
+
+	var b strings.Builder
+	for _, block := range md.blocks(lines) {
+		b.WriteString(block.html)
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+type mdConverter struct {
+	refs map[string]mdLink
+}
+
+type mdLink struct {
+	href  string
+	title string
+}
+
+// mdBlock is a converted block; the text of a paragraph is kept so that it can
+// be written without its tags in a tight list.
+type mdBlock struct {
+	html   string
+	text   string
+	isPara bool
+}
+
+var (
+	reMdHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+|$)(.*)$`)
+	reMdClosing   = regexp.MustCompile(`(?:^|[ \t]+)#+[ \t]*$`)
+	reMdBreak     = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	reMdSetext    = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	reMdFence     = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^ \t`]*)[^`]*$")
+	reMdQuote     = regexp.MustCompile(`^ {0,3}> ?`)
+	reMdListItem  = regexp.MustCompile(`^( {0,3})([*+-]|\d{1,9}[.)])([ \t]+|$)`)
+	reMdHTMLBlock = regexp.MustCompile(`^ {0,3}<(?:/?[A-Za-z][A-Za-z0-9-]*(?:[ \t/>]|$)|!--|![A-Z]|\?)`)
+	reMdRef       = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^ \t>]+)>?(?:[ \t]+(?:"([^"]*)"|'([^']*)'|\(([^)]*)\)))?[ \t]*$`)
+	reMdAutolink  = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^ \t\n<>]*)>`)
+	reMdEmail     = regexp.MustCompile(`^<([^ \t\n@<>]+@[^ \t\n@<>]+)>`)
+	reMdTag       = regexp.MustCompile(`^(?:<[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>|</[A-Za-z][A-Za-z0-9-]*\s*>|<!--[\s\S]*?-->)`)
+	reMdEntity    = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+// references removes the link reference definitions from the lines and keeps
+// them for the links that use them.
+func (md *mdConverter) references(lines []string) []string {
+	var out []string
+	var fence string
+	for _, line := range lines {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimLeft(line, " "), fence) {
+				fence = ""
+			}
+			out = append(out, line)
+			continue
+		}
+		if m := reMdFence.FindStringSubmatch(line); m != nil {
+			fence = m[2][:3]
+			out = append(out, line)
+			continue
+		}
+		if m := reMdRef.FindStringSubmatch(line); m != nil {
+			label := mdLabel(m[1])
+			if _, ok := md.refs[label]; !ok {
+				md.refs[label] = mdLink{href: m[2], title: m[3] + m[4] + m[5]}
+			}
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// blocks converts the lines into blocks.
+func (md *mdConverter) blocks(lines []string) []mdBlock {
+	var blocks []mdBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isMdBlank(line):
+			i++
+		case mdIndent(line) >= 4:
+			i = md.indentedCode(&blocks, lines, i)
+		case reMdFence.MatchString(line):
+			i = md.fencedCode(&blocks, lines, i)
+		case reMdHeading.MatchString(line):
+			m := reMdHeading.FindStringSubmatch(line)
+			text := strings.TrimSpace(reMdClosing.ReplaceAllString(m[2], ""))
+			level := strconv.Itoa(len(m[1]))
+			blocks = append(blocks, mdBlock{html: "<h" + level + ">" + md.inline(text) + "</h" + level + ">"})
+			i++
+		case reMdBreak.MatchString(line):
+			blocks = append(blocks, mdBlock{html: "<hr />"})
+			i++
+		case reMdQuote.MatchString(line):
+			i = md.blockQuote(&blocks, lines, i)
+		case reMdListItem.MatchString(line):
+			i = md.list(&blocks, lines, i)
+		case reMdHTMLBlock.MatchString(line):
+			start := i
+			for i < len(lines) && !isMdBlank(lines[i]) {
+				i++
+			}
+			blocks = append(blocks, mdBlock{html: strings.Join(lines[start:i], "\n")})
+		default:
+			i = md.paragraph(&blocks, lines, i)
+		}
+	}
+	return blocks
+}
+
+func (md *mdConverter) indentedCode(blocks *[]mdBlock, lines []string, i int) int {
+	var code []string
+	for ; i < len(lines); i++ {
+		if !isMdBlank(lines[i]) && mdIndent(lines[i]) < 4 {
+			break
+		}
+		code = append(code, mdOutdent(lines[i], 4))
+	}
+	// the blank lines after the code belong to the blocks that follow
+	for len(code) > 0 && isMdBlank(code[len(code)-1]) {
+		code = code[:len(code)-1]
+	}
+	*blocks = append(*blocks, mdBlock{html: "<pre><code>" + mdEscape(strings.Join(code, "\n")) + "\n</code></pre>"})
+	return i
+}
+
+func (md *mdConverter) fencedCode(blocks *[]mdBlock, lines []string, i int) int {
+	m := reMdFence.FindStringSubmatch(lines[i])
+	indent, fence, info := len(m[1]), m[2], m[3]
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]+" \t") == "" {
+			i++
+			break
+		}
+		code = append(code, mdOutdent(lines[i], indent))
+	}
+	open := "<pre><code>"
+	if info != "" {
+		open = `<pre><code class="language-` + mdAttr(mdUnescape(info)) + `">`
+	}
+	content := mdEscape(strings.Join(code, "\n"))
+	if len(code) > 0 {
+		content += "\n"
+	}
+	*blocks = append(*blocks, mdBlock{html: open + content + "</code></pre>"})
+	return i
+}
+
+func (md *mdConverter) blockQuote(blocks *[]mdBlock, lines []string, i int) int {
+	var quoted []string
+	for ; i < len(lines) && !isMdBlank(lines[i]); i++ {
+		line := lines[i]
+		if loc := reMdQuote.FindStringIndex(line); loc != nil {
+			quoted = append(quoted, line[loc[1]:])
+			continue
+		}
+		// a lazy continuation of a paragraph in the quote
+		if len(quoted) == 0 || md.startsBlock(line) {
+			break
+		}
+		quoted = append(quoted, line)
+	}
+	var b strings.Builder
+	b.WriteString("<blockquote>\n")
+	for _, block := range md.blocks(quoted) {
+		b.WriteString(block.html)
+		b.WriteString("\n")
+	}
+	b.WriteString("</blockquote>")
+	*blocks = append(*blocks, mdBlock{html: b.String()})
+	return i
+}
+
+func (md *mdConverter) list(blocks *[]mdBlock, lines []string, i int) int {
+	first := reMdListItem.FindStringSubmatch(lines[i])
+	ordered := !strings.ContainsAny(first[2], "*+-")
+	marker := first[2][len(first[2])-1:]
+
+	// listItem returns the match of the line when it is an item of the list
+	listItem := func(line string) []string {
+		m := reMdListItem.FindStringSubmatch(line)
+		if m == nil || m[2][len(m[2])-1:] != marker || !strings.ContainsAny(m[2], "*+-") != ordered {
+			return nil
+		}
+		return m
+	}
+
+	var items [][]string
+	loose := false
+	for i < len(lines) {
+		m := listItem(lines[i])
+		if m == nil {
+			break
+		}
+		// the content of the item is indented to the text after the marker
+		width := len(m[1]) + len(m[2]) + 1
+		if m[3] != "" && !strings.Contains(m[3], "\t") && len(m[3]) <= 4 {
+			width = len(m[0])
+		}
+		item := []string{strings.TrimLeft(lines[i][len(m[0]):], " \t")}
+		if m[3] != "" && (strings.Contains(m[3], "\t") || len(m[3]) > 4) {
+			item[0] = mdOutdent(lines[i][len(m[1])+len(m[2]):], 1)
+		}
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isMdBlank(line) {
+				item = append(item, "")
+				continue
+			}
+			if mdIndent(line) >= width {
+				item = append(item, mdOutdent(line, width))
+				continue
+			}
+			// a lazy continuation of the paragraph of the item
+			if !isMdBlank(item[len(item)-1]) && !md.startsBlock(line) && !reMdListItem.MatchString(line) {
+				item = append(item, line)
+				continue
+			}
+			break
+		}
+		// a blank line between the items makes the list loose
+		end := len(item)
+		for end > 0 && isMdBlank(item[end-1]) {
+			end--
+		}
+		if end < len(item) && i < len(lines) && listItem(lines[i]) != nil {
+			loose = true
+		}
+		item = item[:end]
+		// as does a blank line between the blocks of an item
+		if slices.ContainsFunc(item, isMdBlank) && len(md.blocks(item)) > 1 {
+			loose = true
+		}
+		items = append(items, item)
+	}
+
+	var b strings.Builder
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if start, _ := strconv.Atoi(strings.TrimRight(first[2], ".)")); start != 1 {
+			b.WriteString(`<ol start="` + strconv.Itoa(start) + `">` + "\n")
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+	for _, item := range items {
+		b.WriteString("<li>")
+		for _, block := range md.blocks(item) {
+			if !loose && block.isPara {
+				b.WriteString(block.text)
+				continue
+			}
+			if !strings.HasSuffix(b.String(), "\n") {
+				b.WriteString("\n")
+			}
+			b.WriteString(block.html)
+			b.WriteString("\n")
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</" + tag + ">")
+	*blocks = append(*blocks, mdBlock{html: b.String()})
+	return i
+}
+
+func (md *mdConverter) paragraph(blocks *[]mdBlock, lines []string, i int) int {
+	text := []string{strings.TrimLeft(lines[i], " \t")}
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if m := reMdSetext.FindStringSubmatch(line); m != nil {
+			tag := "h2"
+			if m[1][0] == '=' {
+				tag = "h1"
+			}
+			content := md.inline(strings.TrimRight(strings.Join(text, "\n"), " \t"))
+			*blocks = append(*blocks, mdBlock{html: "<" + tag + ">" + content + "</" + tag + ">"})
+			return i + 1
+		}
+		if isMdBlank(line) || md.startsBlock(line) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " \t"))
+	}
+	content := md.inline(strings.TrimRight(strings.Join(text, "\n"), " \t"))
+	*blocks = append(*blocks, mdBlock{html: "<p>" + content + "</p>", text: content, isPara: true})
+	return i
+}
+
+// startsBlock returns true when the line starts a block that interrupts a
+// paragraph.
+func (md *mdConverter) startsBlock(line string) bool {
+	if mdIndent(line) >= 4 {
+		return false
+	}
+	if reMdHeading.MatchString(line) || reMdBreak.MatchString(line) || reMdFence.MatchString(line) || reMdQuote.MatchString(line) {
+		return true
+	}
+	// only a list item with text, and an ordered list that starts at 1
+	m := reMdListItem.FindStringSubmatch(line)
+	if m == nil || isMdBlank(line[len(m[0]):]) {
+		return false
+	}
+	return strings.ContainsAny(m[2], "*+-") || strings.TrimLeft(m[2], "0") == "1"+m[2][len(m[2])-1:]
+}
+
+// inline converts the inlines of the text of a block.
+func (md *mdConverter) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				b.WriteString("<br />\n")
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && isMdPunct(s[i+1]) {
+				b.WriteString(mdEscape(s[i+1 : i+2]))
+				i += 2
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case '`':
+			n := mdRun(s, i)
+			if end := mdCodeEnd(s, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(s[i+n:end], "\n", " ")
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+					code = code[1 : len(code)-1]
+				}
+				b.WriteString("<code>" + mdEscape(code) + "</code>")
+				i = end + n
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n
+		case '<':
+			if m := reMdAutolink.FindStringSubmatch(s[i:]); m != nil {
+				b.WriteString(`<a href="` + mdAttr(m[1]) + `">` + mdEscape(m[1]) + "</a>")
+				i += len(m[0])
+				continue
+			}
+			if m := reMdEmail.FindStringSubmatch(s[i:]); m != nil {
+				b.WriteString(`<a href="mailto:` + mdAttr(m[1]) + `">` + mdEscape(m[1]) + "</a>")
+				i += len(m[0])
+				continue
+			}
+			if tag := reMdTag.FindString(s[i:]); tag != "" {
+				b.WriteString(tag)
+				i += len(tag)
+				continue
+			}
+			b.WriteString("&lt;")
+			i++
+		case '!', '[':
+			if c == '!' && (i+1 >= len(s) || s[i+1] != '[') {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			start := i
+			if c == '!' {
+				start++
+			}
+			if link, end, ok := md.link(s, start); ok {
+				if c == '!' {
+					b.WriteString(`<img src="` + mdAttr(link.href) + `" alt="` + mdAttr(mdPlain(link.text)) + `"`)
+					if link.title != "" {
+						b.WriteString(` title="` + mdAttr(link.title) + `"`)
+					}
+					b.WriteString(" />")
+				} else {
+					b.WriteString(`<a href="` + mdAttr(link.href) + `"`)
+					if link.title != "" {
+						b.WriteString(` title="` + mdAttr(link.title) + `"`)
+					}
+					b.WriteString(">" + md.inline(link.text) + "</a>")
+				}
+				i = end
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case '*', '_', '~':
+			n := mdRun(s, i)
+			if inner, end, ok := mdEmphasis(s, i, n); ok {
+				content := md.inline(inner)
+				switch {
+				case c == '~':
+					content = "<del>" + content + "</del>"
+				case n == 1:
+					content = "<em>" + content + "</em>"
+				case n == 2:
+					content = "<strong>" + content + "</strong>"
+				default:
+					content = "<em><strong>" + content + "</strong></em>"
+				}
+				b.WriteString(content)
+				i = end
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n
+		case '&':
+			if entity := reMdEntity.FindString(s[i:]); entity != "" {
+				b.WriteString(entity)
+				i += len(entity)
+				continue
+			}
+			b.WriteString("&amp;")
+			i++
+		case '>':
+			b.WriteString("&gt;")
+			i++
+		case '"':
+			b.WriteString("&quot;")
+			i++
+		case '\n':
+			out := b.String()
+			trimmed := strings.TrimRight(out, " ")
+			b.Reset()
+			b.WriteString(trimmed)
+			if len(out)-len(trimmed) >= 2 {
+				b.WriteString("<br />")
+			}
+			b.WriteByte('\n')
+			i++
+			for i < len(s) && s[i] == ' ' {
+				i++
+			}
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+type mdInlineLink struct {
+	text  string
+	href  string
+	title string
+}
+
+// link parses the link that starts with the bracket at i, and returns the
+// position after it.
+func (md *mdConverter) link(s string, i int) (mdInlineLink, int, bool) {
+	end := mdBracketEnd(s, i)
+	if end < 0 {
+		return mdInlineLink{}, 0, false
+	}
+	link := mdInlineLink{text: s[i+1 : end]}
+	i = end + 1
+
+	// an inline link
+	if i < len(s) && s[i] == '(' {
+		if href, title, next, ok := mdDestination(s, i+1); ok {
+			link.href, link.title = href, title
+			return link, next, true
+		}
+	}
+
+	// a reference link
+	label := link.text
+	if i+1 < len(s) && s[i] == '[' {
+		if refEnd := strings.IndexByte(s[i:], ']'); refEnd > 0 {
+			if l := s[i+1 : i+refEnd]; l != "" {
+				label = l
+			}
+			i += refEnd + 1
+		}
+	}
+	ref, ok := md.refs[mdLabel(label)]
+	if !ok {
+		return mdInlineLink{}, 0, false
+	}
+	link.href, link.title = ref.href, ref.title
+	return link, i, true
+}
+
+// mdDestination parses the destination and title of an inline link that
+// starts at i, and returns the position after the closing parenthesis.
+func mdDestination(s string, i int) (href, title string, next int, ok bool) {
+	i = mdSkipSpace(s, i)
+	if i < len(s) && s[i] == '<' {
+		end := strings.IndexAny(s[i:], ">\n")
+		if end < 0 || s[i+end] != '>' {
+			return "", "", 0, false
+		}
+		href = s[i+1 : i+end]
+		i += end + 1
+	} else {
+		start, depth := i, 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				continue
+			}
+			if c == ' ' || c == '\t' || c == '\n' || c == ')' && depth == 0 {
+				break
+			}
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			}
+		}
+		href = s[start:i]
+	}
+
+	j := mdSkipSpace(s, i)
+	if j < len(s) && j > i && strings.IndexByte(`"'(`, s[j]) >= 0 {
+		closer := s[j]
+		if closer == '(' {
+			closer = ')'
+		}
+		end := strings.IndexByte(s[j+1:], closer)
+		if end < 0 {
+			return "", "", 0, false
+		}
+		title = s[j+1 : j+1+end]
+		j = mdSkipSpace(s, j+end+2)
+	}
+	if j >= len(s) || s[j] != ')' {
+		return "", "", 0, false
+	}
+	return mdUnescape(href), mdUnescape(title), j + 1, true
+}
+
+// mdEmphasis finds the closing run of the emphasis that is opened by the run
+// of n delimiters at i, and returns the text between them and the position
+// after the closing run.
+func mdEmphasis(s string, i, n int) (string, int, bool) {
+	c := s[i]
+	if c == '~' && n != 2 || n > 3 {
+		return "", 0, false
+	}
+	start := i + n
+	// the opening run must be followed by text, and an underscore may not be
+	// within a word
+	if start >= len(s) || isMdSpace(s[start]) || c == '_' && i > 0 && isMdWord(s[i-1]) {
+		return "", 0, false
+	}
+	for j := start; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			m := mdRun(s, j)
+			if end := mdCodeEnd(s, j+m, m); end >= 0 {
+				j = end + m
+				continue
+			}
+			j += m
+			continue
+		case c:
+			m := mdRun(s, j)
+			if m == n && !isMdSpace(s[j-1]) && !(c == '_' && j+m < len(s) && isMdWord(s[j+m])) {
+				return s[start:j], j + m, true
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return "", 0, false
+}
+
+// mdBracketEnd returns the position of the bracket that closes the one at i.
+func mdBracketEnd(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			m := mdRun(s, j)
+			if end := mdCodeEnd(s, j+m, m); end >= 0 {
+				j = end + m - 1
+			} else {
+				j += m - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// mdCodeEnd returns the position of the run of n backticks that closes a code
+// span, or -1 when the code span is not closed.
+func mdCodeEnd(s string, i, n int) int {
+	for j := i; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := mdRun(s, j)
+		if m == n {
+			return j
+		}
+		j += m
+	}
+	return -1
+}
+
+// mdRun returns the length of the run of the character at i.
+func mdRun(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func mdSkipSpace(s string, i int) int {
+	for i < len(s) && isMdSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// mdIndent returns the width of the indent of the line; tabs are stops of
+// four columns.
+func mdIndent(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// mdOutdent removes up to width columns of indent from the line.
+func mdOutdent(line string, width int) string {
+	col := 0
+	for i, c := range line {
+		if col >= width {
+			return line[i:]
+		}
+		switch c {
+		case ' ':
+			col++
+		case '\t':
+			next := col + 4 - col%4
+			if next > width {
+				// the rest of the tab is kept as spaces
+				return strings.Repeat(" ", next-width) + line[i+1:]
+			}
+			col = next
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+// mdLabel normalizes the label of a link reference.
+func mdLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// mdUnescape removes the backslashes of escaped punctuation.
+func mdUnescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isMdPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// mdPlain returns the text of the inlines without their markup, for the alt
+// text of images.
+func mdPlain(s string) string {
+	return strings.NewReplacer("*", "", "_", "", "`", "", "~", "", "[", "", "]", "").Replace(mdUnescape(s))
+}
+
+// mdEscape escapes the special characters of HTML in text.
+func mdEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+// mdAttr escapes a value for a quoted attribute; entities that are already in
+// the value are kept.
+func mdAttr(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '&':
+			if entity := reMdEntity.FindString(s[i:]); entity != "" {
+				b.WriteString(entity)
+				i += len(entity) - 1
+				continue
+			}
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&quot;")
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func isMdBlank(line string) bool {
+	return strings.Trim(line, " \t") == ""
+}
+
+func isMdSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isMdWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+func isMdPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,64 @@
+package compiler
+
+import (
+	"testing"
+)
+
+func Test_markdown(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"headings": {
+			src:  "# Title #\n\n### Third\n\nSetext\n===\n\nSecond\n---\n",
+			want: "<h1>Title</h1>\n<h3>Third</h3>\n<h1>Setext</h1>\n<h2>Second</h2>\n",
+		},
+		"paragraphs": {
+			src:  "First line\nsecond line  \nbreak \"q\" & it's &copy; 2 < 3\n\nNext \\*paragraph\\*\n",
+			want: "<p>First line\nsecond line<br />\nbreak &quot;q&quot; &amp; it's &copy; 2 &lt; 3</p>\n<p>Next *paragraph*</p>\n",
+		},
+		"emphasis": {
+			src:  "*em*, **strong**, ***both***, _em_, __strong__, ~~del~~ and snake_case_name * alone",
+			want: "<p><em>em</em>, <strong>strong</strong>, <em><strong>both</strong></em>, <em>em</em>, <strong>strong</strong>, <del>del</del> and snake_case_name * alone</p>\n",
+		},
+		"code": {
+			src:  "Some `code <x>` and `` a ` b ``\n\n```go\nfmt.Println(\"<hi>\")\n```\n\n    indented\n    code\n",
+			want: "<p>Some <code>code &lt;x&gt;</code> and <code>a ` b</code></p>\n<pre><code class=\"language-go\">fmt.Println(&quot;&lt;hi&gt;&quot;)\n</code></pre>\n<pre><code>indented\ncode\n</code></pre>\n",
+		},
+		"tight lists": {
+			src:  "- one\n- two\n\t- nested\n- three\n\n3) x\n4) y\n",
+			want: "<ul>\n<li>one</li>\n<li>two\n<ul>\n<li>nested</li>\n</ul>\n</li>\n<li>three</li>\n</ul>\n<ol start=\"3\">\n<li>x</li>\n<li>y</li>\n</ol>\n",
+		},
+		"loose lists": {
+			src:  "1. a\n\n2. b\n\n- c\n\n  d\n",
+			want: "<ol>\n<li>\n<p>a</p>\n</li>\n<li>\n<p>b</p>\n</li>\n</ol>\n<ul>\n<li>\n<p>c</p>\n<p>d</p>\n</li>\n</ul>\n",
+		},
+		"block quotes": {
+			src:  "> quote\nlazy\n> # heading\n",
+			want: "<blockquote>\n<p>quote\nlazy</p>\n<h1>heading</h1>\n</blockquote>\n",
+		},
+		"links": {
+			src:  "[link](http://x.com/?a=1&b=2 \"T\") ![img *a*](/i.png) <https://auto.link> <a@b.com>\n\n[ref] and [text][ref]\n\n[ref]: /r 'Ref'\n",
+			want: "<p><a href=\"http://x.com/?a=1&amp;b=2\" title=\"T\">link</a> <img src=\"/i.png\" alt=\"img a\" /> <a href=\"https://auto.link\">https://auto.link</a> <a href=\"mailto:a@b.com\">a@b.com</a></p>\n<p><a href=\"/r\" title=\"Ref\">ref</a> and <a href=\"/r\" title=\"Ref\">text</a></p>\n",
+		},
+		"html": {
+			src:  "***\n\n<div class=\"x\">\nraw *html*\n</div>\n\nSome <span>inline</span> html\n",
+			want: "<hr />\n<div class=\"x\">\nraw *html*\n</div>\n<p>Some <span>inline</span> html</p>\n",
+		},
+		"placeholders": {
+			src:  "# " + filterPlaceholder(0) + "\n\n[x](" + filterPlaceholder(1) + ") *" + filterPlaceholder(2) + "*\n",
+			want: "<h1>" + filterPlaceholder(0) + "</h1>\n<p><a href=\"" + filterPlaceholder(1) + "\">x</a> <em>" + filterPlaceholder(2) + "</em></p>\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := markdown(tt.src)
+			if err != nil {
+				t.Fatalf("markdown() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("markdown() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
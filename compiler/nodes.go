@@ -32,6 +32,14 @@ const (
 	nRenderCommand
 	nChildrenCommand
 	nSlotCommand
+	nFlushCommand
+	nFragmentCommand
+	nExtendsCommand
+	nBlockCommand
+	nPushCommand
+	nStackCommand
+	nJsonCommand
+	nFormatCommand
 	nFilter
 )
 
@@ -71,6 +79,22 @@ func (n nodeType) String() string {
 		return "ChildrenCommand"
 	case nSlotCommand:
 		return "SlotCommand"
+	case nFlushCommand:
+		return "FlushCommand"
+	case nFragmentCommand:
+		return "FragmentCommand"
+	case nExtendsCommand:
+		return "ExtendsCommand"
+	case nBlockCommand:
+		return "BlockCommand"
+	case nPushCommand:
+		return "PushCommand"
+	case nStackCommand:
+		return "StackCommand"
+	case nJsonCommand:
+		return "JsonCommand"
+	case nFormatCommand:
+		return "FormatCommand"
 	case nFilter:
 		return "Filter"
 	default:
@@ -155,11 +179,19 @@ func (n *node) Tree(buf *bytes.Buffer, indent int) string {
 	return buf.String()
 }
 
-func (n *node) errorf(format string, args ...interface{}) error {
+func (n *node) errorf(code ErrorCode, format string, args ...interface{}) error {
+	return errorAt(n.origin, code, format, args...)
+}
+
+// errorAt returns an error that covers the source of the token.
+func errorAt(t token, code ErrorCode, format string, args ...interface{}) error {
 	return PositionalError{
-		Line:   n.origin.line,
-		Column: n.origin.col,
-		Err:    fmt.Errorf(format, args...),
+		Line:      t.line,
+		Column:    t.col,
+		EndLine:   t.endLine,
+		EndColumn: t.endCol,
+		Code:      code,
+		Err:       fmt.Errorf(format, args...),
 	}
 }
 
@@ -208,6 +240,32 @@ func (n *node) handleNode(p *parser, indent int) error {
 		p.addChild(NewChildrenCommandNode(p.next()))
 	case tSlotCommand:
 		p.addNode(NewSlotCommandNode(p.next(), indent, n.keepNewlines))
+	case tFlushCommand:
+		p.addChild(NewFlushCommandNode(p.next()))
+	case tFragmentCommand:
+		p.addNode(NewFragmentCommandNode(p.next(), indent, n.keepNewlines))
+	case tExtendsCommand:
+		p.addChild(NewExtendsCommandNode(p.next()))
+	case tBlockCommand:
+		p.addNode(NewBlockCommandNode(p.next(), indent, n.keepNewlines))
+	case tPushCommand:
+		p.addNode(NewPushCommandNode(p.next(), indent, n.keepNewlines))
+	case tStackCommand:
+		p.addChild(NewStackCommandNode(p.next()))
+	case tJsonCommand:
+		p.addChild(NewJsonCommandNode(p.next()))
+	case tFormatCommand:
+		f := NewFormatCommandNode(p.next())
+		if n.typ != nTemplate || len(n.children) > 0 {
+			return f.errorf(ErrFormat, "the format must be set at the start of the template")
+		}
+		format, ok := outputFormats[strings.ToLower(f.name)]
+		if !ok {
+			return f.errorf(ErrFormat, "unknown format: %q", f.name)
+		}
+		f.format = format
+		p.format = format
+		p.addChild(f)
 	case tFilterStart:
 		t := p.next()
 		switch t.lit {
@@ -218,20 +276,23 @@ func (n *node) handleNode(p *parser, indent int) error {
 		case "plain", "escaped", "preserve":
 			p.addNode(NewTextFilterNode(t, indent))
 		default:
-			return n.errorf("unknown filter: %s", t)
+			filter, ok := lookupFilter(t.lit)
+			if !ok {
+				return errorAt(t, ErrUnknownFilter, "unknown filter: %s", t.lit)
+			}
+			if filter.Import != "" {
+				p.template.Root.(*RootNode).addFilterImport(filter.Import)
+			}
+			p.addNode(NewCustomFilterNode(t, indent, filter))
 		}
 	case tTemplateEnd:
 		return p.backToType(nTemplate)
 	case tEOF:
-		return n.errorf("template is incomplete: %s", p.peek())
+		return n.errorf(ErrIncomplete, "template is incomplete: reached %s", p.peek().describe())
 	case tError:
-		return PositionalError{
-			Line:   t.line,
-			Column: t.col,
-			Err:    fmt.Errorf("%s", t.lit),
-		}
+		return errorAt(t, t.code, "%s", t.lit)
 	default:
-		return n.errorf("unexpected: %s", p.peek())
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
 	}
 	return nil
 }
@@ -302,7 +363,7 @@ func (n *RootNode) Source(tw *templateWriter) error {
 	}
 
 	for _, c := range n.children {
-		if err := c.Source(tw); err != nil {
+		if err := tw.source(c); err != nil {
 			return err
 		}
 	}
@@ -321,6 +382,21 @@ func (n *RootNode) addImport(t token) {
 	n.userImports = append(n.userImports, t)
 }
 
+// addFilterImport adds the import of the package of a custom filter unless
+// the template file already imports it.
+func (n *RootNode) addFilterImport(path string) {
+	pkg := strconv.Quote(path)
+	if slices.Contains(n.imports, pkg) {
+		return
+	}
+	for _, i := range n.userImports {
+		if strings.HasSuffix(i.lit, pkg) {
+			return
+		}
+	}
+	n.imports = append(n.imports, pkg)
+}
+
 func (n *RootNode) parse(p *parser) error {
 	switch p.peek().Type() {
 	case tPackage:
@@ -331,12 +407,13 @@ func (n *RootNode) parse(p *parser) error {
 	case tGoCode, tNewLine:
 		p.addNode(NewCodeNode(p.next()))
 	case tTemplateStart:
+		p.format = formatHTML5
 		p.addNode(NewTemplateNode(p.next()))
 	case tEOF:
 		p.next()
 		return nil
 	default:
-		return n.errorf("unexpected: %s", p.peek())
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
 	}
 
 	return nil
@@ -360,11 +437,20 @@ func NewCodeNode(t token) *CodeNode {
 
 func (n *CodeNode) Source(tw *templateWriter) error {
 	for _, t := range n.tokens {
-		if r, err := tw.Write(t.lit); err != nil {
+		// the newlines before the code are not given a directive
+		if tw.lines != nil && tw.lines.line != 0 && t.typ != tNewLine {
+			tw.lines.line, tw.lines.col = t.line, t.col
+		}
+		if r, err := tw.WriteCode(t, t.lit); err != nil {
 			return err
 		} else if t.typ != tNewLine {
 			tw.Add(t, r)
 		}
+		// the Go code follows the lines of the template; only the first line
+		// needs a directive, and one could break up a multiline string
+		if tw.lines != nil && t.typ != tNewLine {
+			tw.lines.line = 0
+		}
 	}
 	return nil
 }
@@ -382,7 +468,7 @@ func (n *CodeNode) parse(p *parser) error {
 	case tPackage, tImport, tTemplateStart, tEOF:
 		return p.backToType(nRoot)
 	default:
-		return n.errorf("unexpected: %s", p.peek())
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
 	}
 }
 
@@ -401,9 +487,10 @@ func NewTemplateNode(t token) *TemplateNode {
 func (n *TemplateNode) Source(tw *templateWriter) error {
 	entry := ` goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, ` + strconv.Quote(n.name()) + `, &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -420,7 +507,7 @@ func (n *TemplateNode) Source(tw *templateWriter) error {
 	if _, err := tw.Write("func "); err != nil {
 		return err
 	}
-	if r, err := tw.Write(n.decl); err != nil {
+	if r, err := tw.WriteCode(n.origin, n.decl); err != nil {
 		return err
 	} else {
 		tw.Add(n.origin, r)
@@ -429,14 +516,38 @@ func (n *TemplateNode) Source(tw *templateWriter) error {
 		return err
 	}
 
+	tw.format = n.format()
+	scanContexts(n.children)
+
+	extends, err := n.extends()
+	if err != nil {
+		return err
+	}
+
 	itw := tw.Indent(2)
 	for _, c := range n.children {
-		if err := c.Source(itw); err != nil {
+		if extends != nil {
+			switch c := c.(type) {
+			case *BlockCommandNode:
+				c.override = true
+			case *PushCommandNode, *SilentScriptNode:
+			default:
+				// only the blocks, pushes and Go code of a template that
+				// extends another template are used; extends has checked
+				// that the rest is whitespace
+				continue
+			}
+		}
+		if err := itw.source(c); err != nil {
 			return err
 		}
 	}
-	// ensure the template ends with a newline
-	if !n.keepNewlines {
+	if extends != nil {
+		if err := itw.source(extends); err != nil {
+			return err
+		}
+	} else if !n.keepNewlines {
+		// ensure the template ends with a newline
 		if _, err := itw.WriteStringLiteral("\\n"); err != nil {
 			return err
 		}
@@ -445,10 +556,98 @@ func (n *TemplateNode) Source(tw *templateWriter) error {
 		return err
 	}
 
-	_, err := tw.Write(exit)
+	_, err = tw.Write(exit)
 	return err
 }
 
+// format returns the format that the template is written in.
+func (n *TemplateNode) format() outputFormat {
+	for _, c := range n.children {
+		if f, ok := c.(*FormatCommandNode); ok {
+			return f.format
+		}
+	}
+	return formatHTML5
+}
+
+// extends returns the command of the template that this template extends, or
+// nil when it does not extend another template. The command must be at the
+// top level of the template, and the rest of a template that extends another
+// template must be blocks, pushes, Go code or whitespace.
+func (n *TemplateNode) extends() (*ExtendsCommandNode, error) {
+	var extends *ExtendsCommandNode
+	for _, c := range n.children {
+		if e, ok := c.(*ExtendsCommandNode); ok {
+			if extends != nil {
+				return nil, e.errorf(ErrExtends, "a template can only extend one template")
+			}
+			extends = e
+			continue
+		}
+		if e := nestedExtends(c.Children()); e != nil {
+			return nil, e.errorf(ErrExtends, "the @extends command can't be nested in other content")
+		}
+	}
+	if extends == nil {
+		return nil, nil
+	}
+	for _, c := range n.children {
+		content := true
+		switch c := c.(type) {
+		case *ExtendsCommandNode, *BlockCommandNode, *PushCommandNode, *SilentScriptNode, *FormatCommandNode, *NewLineNode:
+			content = false
+		case *TextNode:
+			content = c.isDynamic || strings.TrimSpace(c.text) != ""
+		case *RawTextNode:
+			content = strings.TrimSpace(c.text) != ""
+		}
+		if content {
+			return nil, errorAt(c.Origin(), ErrExtends, "a template that extends another template can only have blocks, pushes and Go code outside of its blocks")
+		}
+	}
+	return extends, nil
+}
+
+// nestedExtends returns the first @extends command in the nodes or in their
+// children, or nil.
+func nestedExtends(nodes []nodeBase) *ExtendsCommandNode {
+	for _, c := range nodes {
+		if e, ok := c.(*ExtendsCommandNode); ok {
+			return e
+		}
+		if e := nestedExtends(c.Children()); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// name returns the name of the template from its declaration.
+//
+// (e.g. "Details" or "User.Details" when it has a receiver)
+func (n *TemplateNode) name() string {
+	decl := strings.TrimSpace(n.decl)
+	receiver := ""
+	if strings.HasPrefix(decl, "(") {
+		end := strings.IndexByte(decl, ')')
+		if end < 0 {
+			return decl
+		}
+		if fields := strings.Fields(decl[1:end]); len(fields) > 0 {
+			receiver = strings.TrimLeft(fields[len(fields)-1], "*")
+			if i := strings.IndexByte(receiver, '['); i >= 0 {
+				receiver = receiver[:i]
+			}
+			receiver += "."
+		}
+		decl = strings.TrimSpace(decl[end+1:])
+	}
+	if i := strings.IndexAny(decl, "(["); i >= 0 {
+		decl = strings.TrimSpace(decl[:i])
+	}
+	return receiver + decl
+}
+
 func (n *TemplateNode) parse(p *parser) error {
 	switch p.peek().Type() {
 	case tTemplateEnd:
@@ -472,7 +671,7 @@ func NewDoctypeNode(t token) *DoctypeNode {
 }
 
 func (n *DoctypeNode) Source(tw *templateWriter) error {
-	_, err := tw.WriteStringLiteral(`<!DOCTYPE html>`)
+	_, err := tw.WriteStringLiteral(tw.format.doctype(n.doctype))
 	return err
 }
 
@@ -480,10 +679,17 @@ type attribute struct {
 	name      string
 	isBoolean bool
 	isDynamic bool
-	value     string
-	origin    token
+	// isHash is set for a dynamic data or aria attribute, which is expanded
+	// into prefixed attributes when its value is a map
+	isHash bool
+	value  string
+	origin token
 }
 
+// hashAttributes are the attributes that can be given a hash of values that
+// is expanded into prefixed attributes, such as "data-user-id".
+var hashAttributes = []string{"data", "aria"}
+
 type ElementNode struct {
 	node
 	tag                 string
@@ -526,7 +732,7 @@ func NewElementNode(t token, indent int, keepNewlines bool) *ElementNode {
 
 func (n *ElementNode) Source(tw *templateWriter) error {
 	if n.nukeOuterWhitespace {
-		if _, err := tw.WriteStringLiteral(goht.NukeBefore); err != nil {
+		if err := tw.TrimSpaceBefore(); err != nil {
 			return err
 		}
 	}
@@ -545,8 +751,13 @@ func (n *ElementNode) Source(tw *templateWriter) error {
 	}
 	// close the tag
 	if n.isSelfClosing {
-		// add a "/" to the end of the tag as long as it's not in the list of tags that shouldn't get it
-		if !slices.Contains(selfClosedTags, strings.ToLower(n.tag)) {
+		switch {
+		case tw.format == formatXHTML:
+			if _, err := tw.WriteStringLiteral(" /"); err != nil {
+				return err
+			}
+		case tw.format == formatXML || !slices.Contains(selfClosedTags, strings.ToLower(n.tag)):
+			// add a "/" to the end of the tag as long as it's not in the list of tags that shouldn't get it
 			if _, err := tw.WriteStringLiteral("/"); err != nil {
 				return err
 			}
@@ -560,22 +771,20 @@ func (n *ElementNode) Source(tw *templateWriter) error {
 	}
 
 	if n.nukeInnerWhitespace {
-		if _, err := tw.WriteStringLiteral(goht.NukeAfter); err != nil {
-			return err
-		}
+		tw.TrimSpaceAfter()
 	}
 
 	// ignore children if there is only a newline
 	if !(len(n.children) == 1 && n.children[0].Type() == nNewLine) {
 		for _, c := range n.children {
-			if err := c.Source(tw); err != nil {
+			if err := tw.source(c); err != nil {
 				return err
 			}
 		}
 	}
 
 	if n.nukeInnerWhitespace {
-		if _, err := tw.WriteStringLiteral(goht.NukeBefore); err != nil {
+		if err := tw.TrimSpaceBefore(); err != nil {
 			return err
 		}
 	}
@@ -584,9 +793,7 @@ func (n *ElementNode) Source(tw *templateWriter) error {
 		return err
 	}
 	if n.nukeOuterWhitespace {
-		if _, err := tw.WriteStringLiteral(goht.NukeAfter); err != nil {
-			return err
-		}
+		tw.TrimSpaceAfter()
 	} else if n.keepNewlines {
 		if _, err := tw.WriteStringLiteral("\\n"); err != nil {
 			return err
@@ -607,7 +814,7 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 		if _, err := tw.WriteIndent(`if ` + vName + ` := goht.ObjectID(`); err != nil {
 			return err
 		}
-		if r, err := tw.Write(n.objectRef.lit); err != nil {
+		if r, err := tw.WriteCode(*n.objectRef, n.objectRef.lit); err != nil {
 			return err
 		} else {
 			tw.Add(*n.objectRef, r)
@@ -615,7 +822,7 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 		if _, err := tw.Write("); " + vName + " != \"\" {\n"); err != nil {
 			return err
 		}
-		if _, err := tw.WriteIndent("\t" + `if _, __err = __buf.WriteString(" id=\""+` + vName + `+"\""` + "); __err != nil { return }\n"); err != nil {
+		if _, err := tw.WriteIndent("\t" + `if _, __err = __buf.WriteString(" id=\""+goht.EscapeString(` + vName + `)+"\""` + "); __err != nil { return }\n"); err != nil {
 			return err
 		}
 		if _, err := tw.WriteIndent("}\n"); err != nil {
@@ -633,7 +840,7 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 	for _, key := range n.attributes.keys {
 		attr := n.attributes.values[key]
 		if attr.value == "" {
-			if _, err := tw.WriteStringLiteral(` ` + attr.name); err != nil {
+			if _, err := tw.WriteStringLiteral(tw.booleanAttribute(attr.name)); err != nil {
 				return err
 			}
 			continue
@@ -642,7 +849,7 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 			if _, err := tw.WriteIndent("if "); err != nil {
 				return err
 			}
-			if r, err := tw.Write(attr.value); err != nil {
+			if r, err := tw.WriteCode(attr.origin, attr.value); err != nil {
 				return err
 			} else {
 				tw.Add(attr.origin, r)
@@ -650,14 +857,28 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 			if _, err := tw.Write(" {\n"); err != nil {
 				return err
 			}
-			itw := tw.Indent(1)
-			if _, err := itw.WriteStringLiteral(" " + attr.name); err != nil {
+			if goht.IsARIAAttribute(attr.name) {
+				// an aria attribute is written with its boolean value, as a
+				// bare aria attribute would be empty rather than true
+				if err := writeBranch(tw, ` `+attr.name+`=\"true\"`); err != nil {
+					return err
+				}
+				if _, err := tw.WriteIndent("} else {\n"); err != nil {
+					return err
+				}
+				if err := writeBranch(tw, ` `+attr.name+`=\"false\"`); err != nil {
+					return err
+				}
+			} else if err := writeBranch(tw, tw.booleanAttribute(attr.name)); err != nil {
 				return err
 			}
-			if _, err := itw.Close(); err != nil {
+			if _, err := tw.WriteIndent("}\n"); err != nil {
 				return err
 			}
-			if _, err := tw.WriteIndent("}\n"); err != nil {
+			continue
+		}
+		if attr.isHash {
+			if err := n.renderHashAttribute(tw, attr); err != nil {
 				return err
 			}
 			continue
@@ -666,13 +887,17 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 			return err
 		}
 		if attr.isDynamic {
-			if _, err := tw.WriteIndent(`if _, __err = __buf.WriteString(goht.EscapeString(`); err != nil {
+			escapers := attributeContext(attr.name).escapers()
+			if _, err := tw.WriteIndent(`if _, __err = __buf.WriteString(` + strings.Join(escapers, "(")); err != nil {
+				return err
+			}
+			if _, err := tw.Write("("); err != nil {
 				return err
 			}
 			if err := writeFormattedText(tw, attr.origin); err != nil {
 				return err
 			}
-			if _, err := tw.Write(")+" + `"\""` + "); __err != nil { return }\n"); err != nil {
+			if _, err := tw.Write(strings.Repeat(")", len(escapers)) + "+" + `"\""` + "); __err != nil { return }\n"); err != nil {
 				return err
 			}
 			continue
@@ -686,7 +911,11 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 		if _, err := tw.WriteIndent(`var ` + vName + " string\n"); err != nil {
 			return err
 		}
-		if _, err := tw.WriteIndent(vName + `, __err = goht.BuildAttributeList(` + n.attributesCmd + ")\n"); err != nil {
+		buildAttributeList := "goht.BuildAttributeList"
+		if tw.format.isXML() {
+			buildAttributeList = "goht.BuildXHTMLAttributeList"
+		}
+		if _, err := tw.WriteIndent(vName + `, __err = ` + buildAttributeList + `(` + n.attributesCmd + ")\n"); err != nil {
 			return err
 		}
 		if _, err := tw.WriteErrorHandler(); err != nil {
@@ -699,6 +928,45 @@ func (n *ElementNode) renderAttributes(tw *templateWriter) error {
 	return nil
 }
 
+// writeBranch writes the string literal in a block of an if statement.
+func writeBranch(tw *templateWriter, s string) error {
+	itw := tw.Indent(1)
+	if _, err := itw.WriteStringLiteral(s); err != nil {
+		return err
+	}
+	_, err := itw.Close()
+	return err
+}
+
+// renderHashAttribute writes a dynamic data or aria attribute, which is
+// expanded into prefixed attributes when its value is a map.
+func (n *ElementNode) renderHashAttribute(tw *templateWriter, attr attribute) error {
+	vName := tw.GetVarName()
+	if _, err := tw.WriteIndent(`var ` + vName + " string\n"); err != nil {
+		return err
+	}
+	buildHashAttributeList := "goht.BuildHashAttributeList"
+	if tw.format.isXML() {
+		buildHashAttributeList = "goht.BuildXHTMLHashAttributeList"
+	}
+	if _, err := tw.WriteIndent(vName + `, __err = ` + buildHashAttributeList + `("` + attr.name + `", `); err != nil {
+		return err
+	}
+	if r, err := tw.WriteCode(attr.origin, attr.value); err != nil {
+		return err
+	} else {
+		tw.Add(attr.origin, r)
+	}
+	if _, err := tw.Write(")\n"); err != nil {
+		return err
+	}
+	if _, err := tw.WriteErrorHandler(); err != nil {
+		return err
+	}
+	_, err := tw.WriteStringIndent(vName)
+	return err
+}
+
 func (n *ElementNode) renderClass(tw *templateWriter) error {
 	if n.objectRef != nil {
 		n.classes = append(n.classes, *n.objectRef)
@@ -759,7 +1027,7 @@ func (n *ElementNode) renderClass(tw *templateWriter) error {
 				return err
 			}
 		case tAttrDynamicValue:
-			if r, err := tw.Write(class.lit); err != nil {
+			if r, err := tw.WriteCode(class, class.lit); err != nil {
 				return err
 			} else {
 				tw.Add(class, r)
@@ -785,7 +1053,7 @@ func (n *ElementNode) renderClass(tw *templateWriter) error {
 	if _, err := tw.WriteErrorHandler(); err != nil {
 		return err
 	}
-	if _, err := tw.WriteStringIndent(`" class=\""+` + vName + `+"\""`); err != nil {
+	if _, err := tw.WriteStringIndent(`" class=\""+goht.EscapeString(` + vName + `)+"\""`); err != nil {
 		return err
 	}
 
@@ -811,9 +1079,9 @@ func (n *ElementNode) parse(p *parser) error {
 			}
 			if nextIndent > n.indent && (n.disallowChildren || n.isSelfClosing) {
 				if n.isSelfClosing {
-					return n.errorf("illegal nesting: self-closing tags can't have content %s", p.peek())
+					return n.errorf(ErrIllegalNesting, "illegal nesting: self-closing tags can't have content, found %s", p.peek().describe())
 				}
-				return n.errorf("illegal nesting: content can't be both given on the same line and nested %s", p.peek())
+				return n.errorf(ErrIllegalNesting, "illegal nesting: content can't be both given on the same line and nested, found %s", p.peek().describe())
 			}
 		default:
 			return n.handleNode(p, n.indent+1)
@@ -823,7 +1091,7 @@ func (n *ElementNode) parse(p *parser) error {
 	case tNewLine:
 		p.next()
 		n.isComplete = true
-		if slices.Contains(selfClosedTags, n.tag) {
+		if slices.Contains(selfClosedTags, n.tag) && p.format != formatXML {
 			n.isSelfClosing = true
 		}
 		if n.isSelfClosing || len(n.children) > 0 {
@@ -865,6 +1133,13 @@ func (n *ElementNode) parse(p *parser) error {
 }
 
 func (n *ElementNode) parseAttributes(p *parser) error {
+	return n.parseAttributeHash(p, "")
+}
+
+// parseAttributeHash parses a list of attributes. The names of the attributes
+// in a nested hash are given the prefix of the hash, with any underscores
+// replaced by hyphens.
+func (n *ElementNode) parseAttributeHash(p *parser, prefix string) error {
 	for {
 		if p.peek().Type() != tAttrName {
 			break
@@ -875,6 +1150,9 @@ func (n *ElementNode) parseAttributes(p *parser) error {
 		var origin token
 
 		name := p.next().lit
+		if prefix != "" {
+			name = prefix + "-" + strings.ReplaceAll(name, "_", "-")
+		}
 		switch p.peek().Type() {
 		case tAttrOperator:
 			op := p.next().lit
@@ -882,8 +1160,24 @@ func (n *ElementNode) parseAttributes(p *parser) error {
 			case "?":
 				isBoolean = true
 				if p.peek().Type() != tAttrDynamicValue {
-					return n.errorf("expected dynamic value: %s", p.peek())
+					return errorAt(p.peek(), ErrAttribute, "expected dynamic value, found %s", p.peek().describe())
+				}
+			case ":":
+				if p.peek().Type() != tAttrHashStart {
+					break
 				}
+				if prefix == "" && !slices.Contains(hashAttributes, name) {
+					return n.errorf(ErrAttribute, "nested attributes are only supported for data and aria: %s", name)
+				}
+				p.next()
+				if err := n.parseAttributeHash(p, name); err != nil {
+					return err
+				}
+				if p.peek().Type() != tAttrHashEnd {
+					return errorAt(p.peek(), ErrAttribute, "expected end of nested attributes, found %s", p.peek().describe())
+				}
+				p.next()
+				continue
 			}
 		default:
 			n.attributes.Set(name, attribute{
@@ -892,20 +1186,24 @@ func (n *ElementNode) parseAttributes(p *parser) error {
 			continue
 		}
 		if p.peek().Type() != tAttrDynamicValue && p.peek().Type() != tAttrEscapedValue {
-			return n.errorf("expected attribute value: %s", p.peek())
+			return errorAt(p.peek(), ErrAttribute, "expected attribute value, found %s", p.peek().describe())
 		}
 		origin = p.next()
 		if origin.typ == tAttrDynamicValue {
 			isDynamic = true
 			value = origin.lit
+		} else if unquoted, err := strconv.Unquote(origin.lit); err == nil {
+			value = unquoted
 		} else {
-			value, _ = strconv.Unquote(origin.lit)
+			// a number literal is not quoted
+			value = origin.lit
 		}
 
 		n.attributes.Set(name, attribute{
 			name:      name,
 			isBoolean: isBoolean,
 			isDynamic: isDynamic,
+			isHash:    isDynamic && !isBoolean && prefix == "" && slices.Contains(hashAttributes, name),
 			value:     value,
 			origin:    origin,
 		})
@@ -1003,7 +1301,7 @@ func (n *CommentNode) Source(tw *templateWriter) error {
 	}
 
 	for _, c := range n.children {
-		if err := c.Source(tw); err != nil {
+		if err := tw.source(c); err != nil {
 			return err
 		}
 	}
@@ -1027,7 +1325,7 @@ func (n *CommentNode) parse(p *parser) error {
 			return p.backToIndent(nextIndent - 1)
 		}
 		if nextIndent > n.indent && n.text != "" {
-			return n.errorf("illegal nesting: content can't be both given on the same line and nested %s", p.peek())
+			return n.errorf(ErrIllegalNesting, "illegal nesting: content can't be both given on the same line and nested, found %s", p.peek().describe())
 		}
 	}
 	return n.handleNode(p, n.indent+1)
@@ -1039,6 +1337,7 @@ type TextNode struct {
 	isDynamic  bool
 	isPlain    bool
 	isPreserve bool
+	context    escapeContext
 }
 
 func NewTextNode(t token) *TextNode {
@@ -1072,15 +1371,15 @@ func writeFormattedText(tw *templateWriter, t token) error {
 		if _, err := tw.Write(`", `); err != nil {
 			return err
 		}
-		if r, err := tw.Write(matches[2]); err != nil {
+		strT := token{
+			typ:  tDynamicText,
+			line: t.line,
+			col:  t.col + len(t.lit) - len(matches[2]),
+			lit:  matches[2],
+		}
+		if r, err := tw.WriteCode(strT, matches[2]); err != nil {
 			return err
 		} else {
-			strT := token{
-				typ:  tDynamicText,
-				line: t.line,
-				col:  t.col + len(t.lit) - len(matches[2]),
-				lit:  matches[2],
-			}
 			tw.Add(strT, r)
 		}
 		if _, err := tw.Write(")"); err != nil {
@@ -1088,7 +1387,7 @@ func writeFormattedText(tw *templateWriter, t token) error {
 		}
 		return nil
 	}
-	if r, err := tw.Write(strings.TrimSpace(strings.Replace(t.lit, "\n", " ", -1))); err != nil {
+	if r, err := tw.WriteCode(t, strings.TrimSpace(strings.Replace(t.lit, "\n", " ", -1))); err != nil {
 		return err
 	} else {
 		tw.Add(t, r)
@@ -1098,28 +1397,8 @@ func writeFormattedText(tw *templateWriter, t token) error {
 
 func (n *TextNode) Source(tw *templateWriter) error {
 	if n.isDynamic {
-		vName := tw.GetVarName()
-		if _, err := tw.WriteIndent(`var ` + vName + " string\n"); err != nil {
-			return err
-		}
-
-		if _, err := tw.WriteIndent(`if ` + vName + `, __err = goht.CaptureErrors(`); err != nil {
-			return err
-		}
-		if !tw.isUnescaped {
-			if _, err := tw.Write(`goht.EscapeString(`); err != nil {
-				return err
-			}
-		}
-		if err := writeFormattedText(tw, n.origin); err != nil {
-			return err
-		}
-		if !tw.isUnescaped {
-			if _, err := tw.Write(")"); err != nil {
-				return err
-			}
-		}
-		if _, err := tw.Write("); __err != nil { return }\n"); err != nil {
+		vName, err := n.capture(tw)
+		if err != nil {
 			return err
 		}
 
@@ -1148,6 +1427,26 @@ func (n *TextNode) Source(tw *templateWriter) error {
 	return err
 }
 
+// capture writes the escaped value of the dynamic text into a new variable,
+// and returns the name of the variable.
+func (n *TextNode) capture(tw *templateWriter) (string, error) {
+	vName := tw.GetVarName()
+	if _, err := tw.WriteIndent(`var ` + vName + " string\n"); err != nil {
+		return "", err
+	}
+
+	if _, err := tw.WriteIndent(`if ` + vName + `, __err = goht.CaptureErrors(`); err != nil {
+		return "", err
+	}
+	if err := writeEscaped(tw, n.context, n.origin); err != nil {
+		return "", err
+	}
+	if _, err := tw.Write("); __err != nil { return }\n"); err != nil {
+		return "", err
+	}
+	return vName, nil
+}
+
 func (n *TextNode) Tree(buf *bytes.Buffer, indent int) string {
 	lead := strings.Repeat("\t", indent)
 	typ := "(S)"
@@ -1209,7 +1508,7 @@ func NewUnescapeNode(t token, indent int) *UnescapeNode {
 func (n *UnescapeNode) Source(tw *templateWriter) error {
 	tw.isUnescaped = true
 	for _, child := range n.children {
-		err := child.Source(tw)
+		err := tw.source(child)
 		if err != nil {
 			return err
 		}
@@ -1219,8 +1518,10 @@ func (n *UnescapeNode) Source(tw *templateWriter) error {
 }
 
 func (n *UnescapeNode) parse(p *parser) error {
-	t := p.peek()
-	_ = t
+	// an unescaped output ends with its code; EGO has no newline after it
+	if len(n.children) > 0 && n.children[len(n.children)-1].Type() == nScriptNode {
+		return p.backToParent()
+	}
 	switch p.peek().Type() {
 	case tNewLine:
 		return p.backToParent()
@@ -1301,7 +1602,7 @@ func (n *SilentScriptNode) Source(tw *templateWriter) error {
 	if _, err := tw.WriteIndent(start); err != nil {
 		return err
 	}
-	if r, err := tw.Write(code); err != nil {
+	if r, err := tw.WriteCode(n.origin, code); err != nil {
 		return err
 	} else {
 		tw.Add(n.origin, r)
@@ -1316,7 +1617,7 @@ func (n *SilentScriptNode) Source(tw *templateWriter) error {
 
 	itw := tw.Indent(1)
 	for _, c := range n.children {
-		if err := c.Source(itw); err != nil {
+		if err := itw.source(c); err != nil {
 			return err
 		}
 	}
@@ -1362,7 +1663,8 @@ func (n *SilentScriptNode) parse(p *parser) error {
 
 type ScriptNode struct {
 	node
-	code string
+	code    string
+	context escapeContext
 }
 
 func NewScriptNode(t token, keepNewlines bool) *ScriptNode {
@@ -1386,19 +1688,9 @@ func (n *ScriptNode) Source(tw *templateWriter) error {
 	if _, err := tw.WriteIndent(`if ` + vName + `, __err = goht.CaptureErrors(`); err != nil {
 		return err
 	}
-	if !tw.isUnescaped {
-		if _, err := tw.Write(`goht.EscapeString(`); err != nil {
-			return err
-		}
-	}
-	if err := writeFormattedText(tw, n.origin); err != nil {
+	if err := writeEscaped(tw, n.context, n.origin); err != nil {
 		return err
 	}
-	if !tw.isUnescaped {
-		if _, err := tw.Write(")"); err != nil {
-			return err
-		}
-	}
 	if _, err := tw.Write("); __err != nil { return }\n"); err != nil {
 		return err
 	}
@@ -1431,7 +1723,7 @@ func (n *RenderCommandNode) Source(tw *templateWriter) error {
 		if _, err := tw.WriteIndent("if __err = "); err != nil {
 			return err
 		}
-		if r, err := tw.Write(strings.TrimSpace(strings.Replace(n.command, "\n", " ", -1))); err != nil {
+		if r, err := tw.WriteCode(n.origin, strings.TrimSpace(strings.Replace(n.command, "\n", " ", -1))); err != nil {
 			return err
 		} else {
 			tw.Add(n.origin, r)
@@ -1455,7 +1747,7 @@ func (n *RenderCommandNode) Source(tw *templateWriter) error {
 	lines := []string{
 		"__buf, __isBuf := __w.(goht.Buffer)\n",
 		"if !__isBuf {\n",
-		"	__buf = goht.GetBuffer()\n",
+		"	__buf = goht.AcquireBuffer(ctx, __w)\n",
 		"	defer goht.ReleaseBuffer(__buf)\n",
 		"}\n",
 	}
@@ -1465,7 +1757,7 @@ func (n *RenderCommandNode) Source(tw *templateWriter) error {
 		}
 	}
 	for _, c := range n.children {
-		if err := c.Source(itw); err != nil {
+		if err := itw.source(c); err != nil {
 			return err
 		}
 	}
@@ -1489,7 +1781,7 @@ func (n *RenderCommandNode) Source(tw *templateWriter) error {
 	if _, err := tw.WriteIndent("if __err = "); err != nil {
 		return err
 	}
-	if r, err := tw.Write(strings.TrimRight(n.command, " \t{")); err != nil {
+	if r, err := tw.WriteCode(n.origin, strings.TrimRight(n.command, " \t{")); err != nil {
 		return err
 	} else {
 		tw.Add(n.origin, r)
@@ -1532,83 +1824,71 @@ func (n *ChildrenCommandNode) Source(tw *templateWriter) error {
 	return err
 }
 
-type SlotCommandNode struct {
+type FlushCommandNode struct {
 	node
-	slot string
 }
 
-func NewSlotCommandNode(t token, indent int, keepNewlines bool) *SlotCommandNode {
-	n := &SlotCommandNode{
-		node: newNode(nSlotCommand, indent, t),
-		slot: t.lit,
+func NewFlushCommandNode(t token) *FlushCommandNode {
+	return &FlushCommandNode{
+		node: newNode(nFlushCommand, 0, t),
 	}
+}
 
-	if keepNewlines {
-		n.keepNewlines = true
-	}
+func (n *FlushCommandNode) Source(tw *templateWriter) error {
+	_, err := tw.WriteIndent("if __err = __buf.Flush(); __err != nil { return }\n")
+	return err
+}
 
-	return n
+type FragmentCommandNode struct {
+	node
+	fragment string
 }
 
-func (n *SlotCommandNode) Source(tw *templateWriter) error {
-	if _, err := tw.WriteIndent("if __st := goht.GetSlottedTemplate(__sts, " + strconv.Quote(n.slot) + "); __st != nil {\n"); err != nil {
-		return err
+func NewFragmentCommandNode(t token, indent int, keepNewlines bool) *FragmentCommandNode {
+	n := &FragmentCommandNode{
+		node:     newNode(nFragmentCommand, indent, t),
+		fragment: t.lit,
 	}
 
-	itw := tw.Indent(1)
-
-	// lines := []string{
-	// 			"__sts := append(__st.SlottedTemplates(), __sts...)\n",
-	// 			"_ = __sts\n",
-	// }
-	// for _, line := range lines {
-	// 	if _, err := itw.WriteIndent(line); err != nil {
-	// 		return err
-	// 	}
-	// }
-
-	if _, err := itw.WriteIndent("if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil { return }\n"); err != nil {
-		return err
+	if keepNewlines {
+		n.keepNewlines = true
 	}
 
-	if _, err := itw.Close(); err != nil {
-		return err
-	}
+	return n
+}
 
-	if len(n.children) == 0 {
-		_, err := tw.WriteIndent("}\n")
+func (n *FragmentCommandNode) Source(tw *templateWriter) error {
+	if _, err := tw.WriteIndent("__buf.BeginFragment(" + strconv.Quote(n.fragment) + ")\n"); err != nil {
 		return err
 	}
-
-	if _, err := tw.WriteIndent("} else {\n"); err != nil {
+	if _, err := tw.WriteIndent("{\n"); err != nil {
 		return err
 	}
 
-	itw = tw.Indent(1)
+	itw := tw.Indent(1)
 	for _, c := range n.children {
-		if err := c.Source(itw); err != nil {
+		if err := itw.source(c); err != nil {
 			return err
 		}
 	}
-
 	if _, err := itw.Close(); err != nil {
 		return err
 	}
+	if _, err := itw.WriteIndent("__buf.EndFragment()\n"); err != nil {
+		return err
+	}
 
 	if next, ok := n.nextSibling.(*SilentScriptNode); ok {
 		if strings.TrimSpace(next.code) == "}" {
 			return nil
 		}
 	}
-	// either there's no next SilentScript, or it's not an else-type, so close now
-	if _, err := tw.WriteIndent("}\n"); err != nil {
-		return err
-	}
-
-	return nil
+	// either there's no next SilentScript, or it's not a closing brace, so close now
+	_, err := tw.WriteIndent("}\n")
+	return err
 }
 
-func (n *SlotCommandNode) parse(p *parser) error {
+func (n *FragmentCommandNode) parse(p *parser) error {
 	switch p.peek().Type() {
 	case tNewLine:
 		p.next()
@@ -1618,64 +1898,576 @@ func (n *SlotCommandNode) parse(p *parser) error {
 	}
 }
 
-type JavaScriptFilterNode struct {
+type ExtendsCommandNode struct {
 	node
+	template string
 }
 
-func NewJavaScriptFilterNode(t token, indent int) *JavaScriptFilterNode {
-	return &JavaScriptFilterNode{
-		node: newNode(nFilter, indent, t),
+func NewExtendsCommandNode(t token) *ExtendsCommandNode {
+	return &ExtendsCommandNode{
+		node:     newNode(nExtendsCommand, 0, t),
+		template: t.lit,
 	}
 }
 
-func (n *JavaScriptFilterNode) Source(tw *templateWriter) error {
-	if _, err := tw.WriteStringLiteral("<script>\\n"); err != nil {
+func (n *ExtendsCommandNode) Source(tw *templateWriter) error {
+	if _, err := tw.WriteIndent("if __err = goht.Extend(ctx, __buf, "); err != nil {
 		return err
 	}
-	for _, c := range n.children {
-		if err := c.Source(tw); err != nil {
-			return err
-		}
+	if r, err := tw.WriteCode(n.origin, strings.TrimSpace(n.template)); err != nil {
+		return err
+	} else {
+		tw.Add(n.origin, r)
 	}
-	_, err := tw.WriteStringLiteral("</script>")
+	_, err := tw.Write(", __sts...); __err != nil { return }\n")
 	return err
 }
 
-func (n *JavaScriptFilterNode) parse(p *parser) error {
-	switch p.peek().Type() {
-	case tPlainText, tDynamicText:
-		n.AddChild(NewTextNode(p.next()))
-	case tFilterEnd:
-		p.next()
-		return p.backToParent()
-	case tEOF:
-		return n.errorf("javascript filter is incomplete: %s", p.peek())
-	default:
-		return n.errorf("unexpected token: %s", p.peek())
-	}
-	return nil
-}
-
-type CssFilterNode struct {
+type BlockCommandNode struct {
 	node
+	block string
+	// override is set when the block is in a template that extends another
+	// template and replaces the content of the block in that template
+	override bool
 }
 
-func NewCssFilterNode(t token, indent int) *CssFilterNode {
-	return &CssFilterNode{
-		node: newNode(nFilter, indent, t),
+func NewBlockCommandNode(t token, indent int, keepNewlines bool) *BlockCommandNode {
+	n := &BlockCommandNode{
+		node:  newNode(nBlockCommand, indent, t),
+		block: t.lit,
+	}
+
+	if keepNewlines {
+		n.keepNewlines = true
 	}
+
+	return n
 }
 
-func (n *CssFilterNode) Source(tw *templateWriter) error {
-	if _, err := tw.WriteStringLiteral("<style>\\n"); err != nil {
+func (n *BlockCommandNode) Source(tw *templateWriter) error {
+	closingBrace := false
+	if next, ok := n.nextSibling.(*SilentScriptNode); ok {
+		closingBrace = strings.TrimSpace(next.code) == "}"
+	}
+
+	if n.override {
+		return n.sourceOverride(tw, closingBrace)
+	}
+
+	if _, err := tw.WriteIndent("if __bt := goht.GetBlock(ctx, " + strconv.Quote(n.block) + "); __bt != nil {\n"); err != nil {
+		return err
+	}
+	if _, err := tw.Indent(1).WriteIndent("if __err = __bt.Render(ctx, __buf, __sts...); __err != nil { return }\n"); err != nil {
+		return err
+	}
+
+	if len(n.children) == 0 && !closingBrace {
+		_, err := tw.WriteIndent("}\n")
+		return err
+	}
+
+	if _, err := tw.WriteIndent("} else {\n"); err != nil {
 		return err
 	}
+
+	itw := tw.Indent(1)
 	for _, c := range n.children {
-		if err := c.Source(tw); err != nil {
+		if err := itw.source(c); err != nil {
 			return err
 		}
 	}
-	_, err := tw.WriteStringLiteral("</style>")
+	if _, err := itw.Close(); err != nil {
+		return err
+	}
+
+	if closingBrace {
+		return nil
+	}
+	// either there's no next SilentScript, or it's not a closing brace, so close now
+	_, err := tw.WriteIndent("}\n")
+	return err
+}
+
+// sourceOverride writes the content of the block as a template that is
+// defined for the template being extended.
+func (n *BlockCommandNode) sourceOverride(tw *templateWriter, closingBrace bool) error {
+	fnLine := "goht.DefineBlock(ctx, " + strconv.Quote(n.block) + ", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {\n"
+	if _, err := tw.WriteIndent(fnLine); err != nil {
+		return err
+	}
+
+	itw := tw.Indent(1)
+
+	lines := []string{
+		"__buf, __isBuf := __w.(goht.Buffer)\n",
+		"if !__isBuf {\n",
+		"	__buf = goht.AcquireBuffer(ctx, __w)\n",
+		"	defer goht.ReleaseBuffer(__buf)\n",
+		"}\n",
+	}
+	for _, line := range lines {
+		if _, err := itw.WriteIndent(line); err != nil {
+			return err
+		}
+	}
+	for _, c := range n.children {
+		if err := itw.source(c); err != nil {
+			return err
+		}
+	}
+	if _, err := itw.Close(); err != nil {
+		return err
+	}
+
+	lines = []string{
+		"	if !__isBuf {\n",
+		"		_, __err = io.Copy(__w, __buf)\n",
+		"	}\n",
+		"	return\n",
+		"}))\n",
+	}
+	for _, line := range lines {
+		if _, err := tw.WriteIndent(line); err != nil {
+			return err
+		}
+	}
+
+	if closingBrace {
+		// skip the closing brace; the block has already been closed
+		n.nextSibling.(*SilentScriptNode).forRender = true
+	}
+	return nil
+}
+
+func (n *BlockCommandNode) parse(p *parser) error {
+	switch p.peek().Type() {
+	case tNewLine:
+		p.next()
+		return nil
+	default:
+		return n.handleNode(p, n.indent+1)
+	}
+}
+
+type PushCommandNode struct {
+	node
+	stack string
+	// key is the Go expression that de-duplicates the pushed content; empty
+	// when all of the pushes are kept
+	key string
+}
+
+func NewPushCommandNode(t token, indent int, keepNewlines bool) *PushCommandNode {
+	stack, key := parseStackName(t.lit)
+	n := &PushCommandNode{
+		node:  newNode(nPushCommand, indent, t),
+		stack: stack,
+		key:   key,
+	}
+
+	if keepNewlines {
+		n.keepNewlines = true
+	}
+
+	return n
+}
+
+func (n *PushCommandNode) Source(tw *templateWriter) error {
+	key := n.key
+	if key == "" {
+		key = `""`
+	}
+	fnLine := "if __err = __buf.Push(ctx, " + strconv.Quote(n.stack) + ", " + key + ", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {\n"
+	if _, err := tw.WriteIndent(fnLine); err != nil {
+		return err
+	}
+
+	itw := tw.Indent(1)
+
+	lines := []string{
+		"__buf, __isBuf := __w.(goht.Buffer)\n",
+		"if !__isBuf {\n",
+		"	__buf = goht.AcquireBuffer(ctx, __w)\n",
+		"	defer goht.ReleaseBuffer(__buf)\n",
+		"}\n",
+	}
+	for _, line := range lines {
+		if _, err := itw.WriteIndent(line); err != nil {
+			return err
+		}
+	}
+	for _, c := range n.children {
+		if err := itw.source(c); err != nil {
+			return err
+		}
+	}
+	if _, err := itw.Close(); err != nil {
+		return err
+	}
+
+	lines = []string{
+		"	if !__isBuf {\n",
+		"		_, __err = io.Copy(__w, __buf)\n",
+		"	}\n",
+		"	return\n",
+		"})); __err != nil { return }\n",
+	}
+	for _, line := range lines {
+		if _, err := tw.WriteIndent(line); err != nil {
+			return err
+		}
+	}
+
+	if next, ok := n.nextSibling.(*SilentScriptNode); ok && strings.TrimSpace(next.code) == "}" {
+		// skip the closing brace; the push has already been closed
+		next.forRender = true
+	}
+	return nil
+}
+
+func (n *PushCommandNode) parse(p *parser) error {
+	switch p.peek().Type() {
+	case tNewLine:
+		p.next()
+		return nil
+	default:
+		return n.handleNode(p, n.indent+1)
+	}
+}
+
+type FormatCommandNode struct {
+	node
+	name   string
+	format outputFormat
+}
+
+func NewFormatCommandNode(t token) *FormatCommandNode {
+	return &FormatCommandNode{
+		node: newNode(nFormatCommand, 0, t),
+		name: strings.TrimSpace(t.lit),
+	}
+}
+
+// Source writes nothing; the format is used by the other nodes of the
+// template.
+func (n *FormatCommandNode) Source(*templateWriter) error {
+	return nil
+}
+
+type StackCommandNode struct {
+	node
+	stack string
+}
+
+func NewStackCommandNode(t token) *StackCommandNode {
+	stack, _ := parseStackName(t.lit)
+	return &StackCommandNode{
+		node:  newNode(nStackCommand, 0, t),
+		stack: stack,
+	}
+}
+
+func (n *StackCommandNode) Source(tw *templateWriter) error {
+	_, err := tw.WriteIndent("if _, __err = __buf.WriteStack(" + strconv.Quote(n.stack) + "); __err != nil { return }\n")
+	return err
+}
+
+// parseStackName splits the arguments of a push or stack command into the
+// name of the stack, which may be quoted, and the Go expression that follows.
+func parseStackName(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if q, err := strconv.QuotedPrefix(s); err == nil {
+		name, _ := strconv.Unquote(q)
+		return name, strings.TrimSpace(s[len(q):])
+	}
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+type JsonCommandNode struct {
+	node
+	value   string
+	context escapeContext
+}
+
+func NewJsonCommandNode(t token) *JsonCommandNode {
+	return &JsonCommandNode{
+		node:  newNode(nJsonCommand, 0, t),
+		value: jsonValue(t.lit),
+	}
+}
+
+// Source writes the value as JSON in a script element, or only the JSON when
+// the command is already within a script element. The JSON is not escaped for
+// any other context, so the command is an error anywhere else.
+func (n *JsonCommandNode) Source(tw *templateWriter) error {
+	if n.context.err != "" {
+		return n.errorf(ErrContext, "%s", n.context.err)
+	}
+	if n.context.inAttr || n.context.inTagName || n.context.inAttrName || n.context.inString || (n.context.kind != goht.HTMLContext && n.context.kind != goht.JSContext) {
+		return n.errorf(ErrContext, "@json can only be used in element content or a script element")
+	}
+	inScript := n.context.kind == goht.JSContext
+	if !inScript {
+		if _, err := tw.WriteStringLiteral(`<script type=\"application/json\">`); err != nil {
+			return err
+		}
+	}
+
+	vName := tw.GetVarName()
+	if _, err := tw.WriteIndent(`var ` + vName + " string\n"); err != nil {
+		return err
+	}
+	if _, err := tw.WriteIndent(`if ` + vName + `, __err = goht.CaptureErrors(goht.JSON(`); err != nil {
+		return err
+	}
+	if r, err := tw.WriteCode(n.origin, n.value); err != nil {
+		return err
+	} else {
+		tw.Add(n.origin, r)
+	}
+	if _, err := tw.Write(")); __err != nil { return }\n"); err != nil {
+		return err
+	}
+	if _, err := tw.WriteStringIndent(vName); err != nil {
+		return err
+	}
+
+	if !inScript {
+		if _, err := tw.WriteStringLiteral("</script>"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonValue returns the Go expression of a json command without the
+// parentheses that it may be wrapped in (e.g. "@json(value)").
+func jsonValue(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return s
+	}
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\'', '`':
+			q, err := strconv.QuotedPrefix(s[i:])
+			if err != nil {
+				return s
+			}
+			i += len(q) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(s)-1 {
+				// the parentheses do not wrap the whole value (e.g. "(a).b()")
+				return s
+			}
+		}
+	}
+	return strings.TrimSpace(s[1 : len(s)-1])
+}
+
+type SlotCommandNode struct {
+	node
+	slot string
+	// value is the Go expression passed to a scoped slot (e.g. "row(item)")
+	value    string
+	valueCol int
+	// valueType is the type of the value when it is named (e.g. "row[Item](item)")
+	valueType string
+}
+
+func NewSlotCommandNode(t token, indent int, keepNewlines bool) *SlotCommandNode {
+	n := &SlotCommandNode{
+		node: newNode(nSlotCommand, indent, t),
+		slot: t.lit,
+	}
+
+	if i := strings.IndexByte(t.lit, '('); i >= 0 && strings.HasSuffix(t.lit, ")") {
+		n.slot = strings.TrimSpace(t.lit[:i])
+		n.value = strings.TrimSpace(t.lit[i+1 : len(t.lit)-1])
+		n.valueCol = t.col + i + 1 + len(t.lit[i+1:]) - len(strings.TrimLeft(t.lit[i+1:], " \t"))
+		if j := strings.IndexByte(n.slot, '['); j >= 0 && strings.HasSuffix(n.slot, "]") {
+			n.valueType = strings.TrimSpace(n.slot[j+1 : len(n.slot)-1])
+			n.slot = strings.TrimSpace(n.slot[:j])
+		}
+	}
+
+	if keepNewlines {
+		n.keepNewlines = true
+	}
+
+	return n
+}
+
+func (n *SlotCommandNode) Source(tw *templateWriter) error {
+	if _, err := tw.WriteIndent("if __st := goht.GetSlottedTemplate(__sts, " + strconv.Quote(n.slot) + "); __st != nil {\n"); err != nil {
+		return err
+	}
+
+	itw := tw.Indent(1)
+
+	// lines := []string{
+	// 			"__sts := append(__st.SlottedTemplates(), __sts...)\n",
+	// 			"_ = __sts\n",
+	// }
+	// for _, line := range lines {
+	// 	if _, err := itw.WriteIndent(line); err != nil {
+	// 		return err
+	// 	}
+	// }
+
+	if n.value != "" {
+		call := "goht.RenderScopedSlot"
+		if n.valueType != "" {
+			call += "[" + n.valueType + "]"
+		}
+		if _, err := itw.WriteIndent("if __err = " + call + "(ctx, __buf, __st, "); err != nil {
+			return err
+		}
+		value := token{typ: tSlotCommand, line: n.origin.line, col: n.valueCol, lit: n.value}
+		if r, err := itw.WriteCode(value, n.value); err != nil {
+			return err
+		} else {
+			itw.Add(value, r)
+		}
+		if _, err := itw.Write(", append(__st.SlottedTemplates(), __sts...)...); __err != nil { return }\n"); err != nil {
+			return err
+		}
+	} else if _, err := itw.WriteIndent("if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil { return }\n"); err != nil {
+		return err
+	}
+
+	if _, err := itw.Close(); err != nil {
+		return err
+	}
+
+	if len(n.children) == 0 {
+		_, err := tw.WriteIndent("}\n")
+		return err
+	}
+
+	if _, err := tw.WriteIndent("} else {\n"); err != nil {
+		return err
+	}
+
+	itw = tw.Indent(1)
+	for _, c := range n.children {
+		if err := itw.source(c); err != nil {
+			return err
+		}
+	}
+
+	if _, err := itw.Close(); err != nil {
+		return err
+	}
+
+	if next, ok := n.nextSibling.(*SilentScriptNode); ok {
+		if strings.TrimSpace(next.code) == "}" {
+			return nil
+		}
+	}
+	// either there's no next SilentScript, or it's not an else-type, so close now
+	if _, err := tw.WriteIndent("}\n"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *SlotCommandNode) parse(p *parser) error {
+	switch p.peek().Type() {
+	case tNewLine:
+		p.next()
+		return nil
+	default:
+		return n.handleNode(p, n.indent+1)
+	}
+}
+
+type JavaScriptFilterNode struct {
+	node
+}
+
+func NewJavaScriptFilterNode(t token, indent int) *JavaScriptFilterNode {
+	return &JavaScriptFilterNode{
+		node: newNode(nFilter, indent, t),
+	}
+}
+
+func (n *JavaScriptFilterNode) Source(tw *templateWriter) error {
+	if _, err := tw.WriteStringLiteral("<script>\\n"); err != nil {
+		return err
+	}
+	if tw.format.isXML() {
+		if _, err := tw.WriteStringLiteral("//<![CDATA[\\n"); err != nil {
+			return err
+		}
+	}
+	for _, c := range n.children {
+		if err := tw.source(c); err != nil {
+			return err
+		}
+	}
+	if tw.format.isXML() {
+		if _, err := tw.WriteStringLiteral("//]]>\\n"); err != nil {
+			return err
+		}
+	}
+	_, err := tw.WriteStringLiteral("</script>")
+	return err
+}
+
+func (n *JavaScriptFilterNode) parse(p *parser) error {
+	switch p.peek().Type() {
+	case tPlainText, tDynamicText:
+		n.AddChild(NewTextNode(p.next()))
+	case tFilterEnd:
+		p.next()
+		return p.backToParent()
+	case tEOF:
+		return n.errorf(ErrIncomplete, "javascript filter is incomplete: reached %s", p.peek().describe())
+	default:
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
+	}
+	return nil
+}
+
+type CssFilterNode struct {
+	node
+}
+
+func NewCssFilterNode(t token, indent int) *CssFilterNode {
+	return &CssFilterNode{
+		node: newNode(nFilter, indent, t),
+	}
+}
+
+func (n *CssFilterNode) Source(tw *templateWriter) error {
+	if _, err := tw.WriteStringLiteral("<style>\\n"); err != nil {
+		return err
+	}
+	if tw.format.isXML() {
+		if _, err := tw.WriteStringLiteral("/*<![CDATA[*/\\n"); err != nil {
+			return err
+		}
+	}
+	for _, c := range n.children {
+		if err := tw.source(c); err != nil {
+			return err
+		}
+	}
+	if tw.format.isXML() {
+		if _, err := tw.WriteStringLiteral("/*]]>*/\\n"); err != nil {
+			return err
+		}
+	}
+	_, err := tw.WriteStringLiteral("</style>")
 	return err
 }
 
@@ -1687,9 +2479,9 @@ func (n *CssFilterNode) parse(p *parser) error {
 		p.next()
 		return p.backToParent()
 	case tEOF:
-		return n.errorf("css filter is incomplete: %s", p.peek())
+		return n.errorf(ErrIncomplete, "css filter is incomplete: reached %s", p.peek().describe())
 	default:
-		return n.errorf("unexpected token: %s", p.peek())
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
 	}
 	return nil
 }
@@ -1714,7 +2506,7 @@ func (n *TextFilterNode) Source(tw *templateWriter) error {
 		}()
 	}
 	for _, c := range n.children {
-		if err := c.Source(tw); err != nil {
+		if err := tw.source(c); err != nil {
 			return err
 		}
 	}
@@ -1734,9 +2526,141 @@ func (n *TextFilterNode) parse(p *parser) error {
 		p.next()
 		return p.backToParent()
 	case tEOF:
-		return n.errorf("text filter is incomplete: %s", p.peek())
+		return n.errorf(ErrIncomplete, "text filter is incomplete: reached %s", p.peek().describe())
 	default:
-		return n.errorf("unexpected token: %s", p.peek())
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
+	}
+	return nil
+}
+
+// CustomFilterNode is a filter that has been registered with RegisterFilter.
+type CustomFilterNode struct {
+	node
+	filter Filter
+	// parts are the static output of the Transform of the filter and the
+	// interpolated values that are written between them
+	parts []nodeBase
+}
+
+func NewCustomFilterNode(t token, indent int, filter Filter) *CustomFilterNode {
+	return &CustomFilterNode{
+		node:   newNode(nFilter, indent, t),
+		filter: filter,
+	}
+}
+
+func (n *CustomFilterNode) Source(tw *templateWriter) error {
+	if n.filter.Transform != nil {
+		for _, c := range n.parts {
+			if err := tw.source(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// the body, with its interpolated values, is converted when the template is rendered
+	var body []string
+	for _, c := range n.children {
+		t, ok := c.(*TextNode)
+		if !ok {
+			continue
+		}
+		if !t.isDynamic {
+			body = append(body, strconv.Quote(t.text))
+			continue
+		}
+		vName, err := t.capture(tw)
+		if err != nil {
+			return err
+		}
+		body = append(body, vName)
+	}
+	if len(body) == 0 {
+		body = append(body, `""`)
+	}
+
+	vName := tw.GetVarName()
+	if _, err := tw.WriteVar(vName); err != nil {
+		return err
+	}
+	if _, err := tw.WriteIndent(`if ` + vName + `, __err = goht.CaptureErrors(` + n.filter.Func + `(` + strings.Join(body, " + ") + `)); __err != nil { return }` + "\n"); err != nil {
+		return err
+	}
+	_, err := tw.WriteStringIndent(vName)
+	return err
+}
+
+func (n *CustomFilterNode) parse(p *parser) error {
+	switch p.peek().Type() {
+	case tPlainText, tDynamicText:
+		n.AddChild(NewTextNode(p.next()))
+	case tFilterEnd:
+		p.next()
+		if err := n.transform(); err != nil {
+			p.report(err.(PositionalError))
+		}
+		return p.backToParent()
+	case tEOF:
+		return n.errorf(ErrIncomplete, "%s filter is incomplete: reached %s", n.origin.lit, p.peek().describe())
+	default:
+		return errorAt(p.peek(), ErrUnexpectedToken, "unexpected %s", p.peek().describe())
+	}
+	return nil
+}
+
+// transform converts the body of the filter with the Transform of the filter,
+// and splits the output around the placeholders of the interpolated values.
+func (n *CustomFilterNode) transform() error {
+	if n.filter.Transform == nil {
+		return nil
+	}
+
+	var body strings.Builder
+	var values []nodeBase
+	for _, c := range n.children {
+		t, ok := c.(*TextNode)
+		if !ok {
+			continue
+		}
+		if t.isDynamic {
+			body.WriteString(filterPlaceholder(len(values)))
+			values = append(values, t)
+			continue
+		}
+		body.WriteString(t.text)
+	}
+
+	out, err := n.filter.Transform(body.String())
+	if err != nil {
+		return errorAt(n.origin, ErrFilter, "the %s filter failed: %v", n.origin.lit, err)
+	}
+
+	written := make([]bool, len(values))
+	var text strings.Builder
+	addText := func() {
+		if text.Len() > 0 {
+			n.parts = append(n.parts, NewTextNode(token{typ: tPlainText, lit: text.String(), line: n.origin.line, col: n.origin.col}))
+			text.Reset()
+		}
+	}
+	for out != "" {
+		if i, length, ok := filterValue(out); ok && i >= 0 && i < len(values) {
+			addText()
+			n.parts = append(n.parts, values[i])
+			written[i] = true
+			out = out[length:]
+			continue
+		}
+		text.WriteByte(out[0])
+		out = out[1:]
+	}
+	addText()
+
+	for i, ok := range written {
+		if !ok {
+			return errorAt(values[i].Origin(), ErrFilter, "the output of the %s filter is missing the interpolated value: %s", n.origin.lit, values[i].Origin().lit)
+		}
 	}
 	return nil
 }
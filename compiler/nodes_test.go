@@ -2,6 +2,8 @@ package compiler
 
 import (
 	"bytes"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -159,6 +161,62 @@ var foo = "bar"
 	}
 }
 
+func Test_ParseErrors(t *testing.T) {
+	input := `@haml test() {
+	%p{id: bar}
+	%p ok
+	:unknown
+		text
+}
+
+@slim other() {
+	p
+			span deep
+}
+
+@ego last() {
+	<%@unknown %>
+}
+
+@slim unclosed() {
+	p{ x
+}
+
+@ego valid() {
+	<p>ok</p>
+}
+
+@haml after() {
+	%p
+`
+	p := newParser([]byte(input))
+	err := p.parse()
+	errList, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("parse() error = %v, want an ErrorList", err)
+	}
+	want := []PositionalError{
+		{Line: 2, Column: 9, EndLine: 2, EndColumn: 10, Code: ErrUnexpectedCharacter},
+		{Line: 4, Column: 3, EndLine: 4, EndColumn: 10, Code: ErrUnknownFilter},
+		{Line: 10, Column: 1, EndLine: 10, EndColumn: 4, Code: ErrIndentTooDeep},
+		{Line: 14, Column: 5, EndLine: 14, EndColumn: 12, Code: ErrUnknownCommand},
+		{Line: 18, Column: 3, EndLine: 18, EndColumn: 4, Code: ErrNotClosed},
+		{Line: 26, Column: 3, EndLine: 26, EndColumn: 4, Code: ErrIncomplete},
+	}
+	if len(errList) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errList), len(want), errList)
+	}
+	for i, e := range errList {
+		got := PositionalError{Line: e.Line, Column: e.Column, EndLine: e.EndLine, EndColumn: e.EndColumn, Code: e.Code}
+		if got != want[i] {
+			t.Errorf("error %d (%v) = %s %d:%d-%d:%d, want %s %d:%d-%d:%d", i, e, got.Code, got.Line, got.Column, got.EndLine, got.EndColumn, want[i].Code, want[i].Line, want[i].Column, want[i].EndLine, want[i].EndColumn)
+		}
+	}
+	if tpl := p.template.Root.Children(); len(tpl) == 0 {
+		t.Errorf("want the templates to be parsed")
+	}
+}
+
 func Test_RootNode(t *testing.T) {
 	tests := map[string]struct {
 		input   string
@@ -327,6 +385,10 @@ func Test_UnescapeNode(t *testing.T) {
 				Text(S)
 `,
 		},
+		"ego output after an unescaped output": {
+			input: "@ego test() {\n\t<%! \"<b>\" %> <%= \"<i>\" %>\n}",
+			want:  "Root\n\tTemplate\n\t\tUnescape\n\t\t\tScript\n\t\tText\n\t\tScript\n\t\tText\n",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -475,6 +537,61 @@ func Test_ElementAttributes(t *testing.T) {
 	Template
 		Element p(foo={bar})
 			NewLine
+`,
+		},
+		"nested attributes": {
+			input: "@goht test() {\n\t%p{data: {user_id: #{id}, user: {role: \"admin\"}}, aria: {hidden? #{hide}}}\n}",
+			want: `Root
+	Template
+		Element p(data-user-id={id},data-user-role="admin",aria-hidden?={hide})
+			NewLine
+`,
+		},
+		"nested number attributes": {
+			input: "@goht test() {\n\t%div{data: {user_id: 1, role: \"admin\", ratio: -0.5}}\n}",
+			want: `Root
+	Template
+		Element div(data-user-id="1",data-role="admin",data-ratio="-0.5")
+			NewLine
+`,
+		},
+		"dynamic data attribute": {
+			input: "@slim test() {\n\tp{data: #{settings}}\n}",
+			want: `Root
+	Template
+		Element p(data={settings})
+`,
+		},
+		"nested attributes not data or aria": {
+			input: "@goht test() {\n\t%p{style: {color: \"red\"}}\n}",
+			want: `Root
+	Template
+		Element p()
+`,
+			wantErr: true,
+		},
+		"html style attributes": {
+			input: "@goht test() {\n\t%p(foo=\"bar\" fizz=#{buzz} baz)\n}",
+			want: `Root
+	Template
+		Element p(foo="bar",fizz={buzz},baz)
+			NewLine
+`,
+		},
+		"html style single quoted attributes": {
+			input: "@goht test() {\n\t%p(title='say \"hi\"' alt='it\\'s')\n}",
+			want: `Root
+	Template
+		Element p(title="say \"hi\"",alt="it's")
+			NewLine
+`,
+		},
+		"slim bare attributes": {
+			input: "@slim test() {\n\tp foo=\"bar\" fizz=#{buzz} text\n}",
+			want: `Root
+	Template
+		Element p(foo="bar",fizz={buzz})
+			Text(S)
 `,
 		},
 		"quoted attribute names": {
@@ -783,6 +900,25 @@ func Test_RenderNode(t *testing.T) {
 				Text(S)
 		Element p2()
 			Text(S)
+`,
+		},
+		"ego without children": {
+			input: "@ego test() {\n\t<p><%@render foo() %></p>\n}",
+			want: `Root
+	Template
+		Text
+		RenderCommand
+		Text
+`,
+		},
+		"ego with children": {
+			input: "@ego test() {\n\t<%@render foo() { %><p>bar</p><% } %>\n}",
+			want: `Root
+	Template
+		RenderCommand
+			Text
+		SilentScript
+		Text
 `,
 		},
 	}
@@ -846,3 +982,570 @@ func Test_ChildrenCommand(t *testing.T) {
 		})
 	}
 }
+
+func Test_FlushCommand(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"simple": {
+			input: "@goht test() {\n\t= @flush\n}",
+			want: `Root
+	Template
+		FlushCommand
+`,
+		},
+		"after head": {
+			input: "@goht test() {\n\t%html\n\t\t%head\n\t\t= @flush\n\t\t%body\n}",
+			want: `Root
+	Template
+		Element html()
+			NewLine
+			Element head()
+				NewLine
+			FlushCommand
+			Element body()
+				NewLine
+`,
+		},
+		"slim": {
+			input: "@slim test() {\n\thead\n\t= @flush\n\tbody\n}",
+			want: `Root
+	Template
+		Element head()
+		FlushCommand
+		Element body()
+`,
+		},
+		"ego": {
+			input: "@ego test() {\n\t<head></head><%@ flush %><body></body>\n}",
+			want: `Root
+	Template
+		Text
+		FlushCommand
+		Text
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newParser([]byte(test.input))
+			err := p.parse()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			buf := new(bytes.Buffer)
+			_ = p.template.Root.Tree(buf, 0)
+			got := buf.String()
+			if got != test.want {
+				t.Errorf("got \n%s----\nwant \n%s----", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_FragmentCommand(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"simple": {
+			input: "@goht test() {\n\t= @fragment items\n\t\t%ul\n}",
+			want: `Root
+	Template
+		FragmentCommand
+			Element ul()
+				NewLine
+`,
+		},
+		"slim": {
+			input: "@slim test() {\n\t= @fragment items\n\t\tul\n\tp\n}",
+			want: `Root
+	Template
+		FragmentCommand
+			Element ul()
+		Element p()
+`,
+		},
+		"ego": {
+			input: "@ego test() {\n\t<%@ fragment items { %><ul></ul><% } %>\n}",
+			want: `Root
+	Template
+		FragmentCommand
+			Text
+		SilentScript
+		Text
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newParser([]byte(test.input))
+			err := p.parse()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			buf := new(bytes.Buffer)
+			_ = p.template.Root.Tree(buf, 0)
+			got := buf.String()
+			if got != test.want {
+				t.Errorf("got \n%s----\nwant \n%s----", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_ExtendsCommand(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"haml": {
+			input: "@goht test() {\n\t= @extends Base()\n\t= @block content\n\t\t%p\n}",
+			want: `Root
+	Template
+		ExtendsCommand
+		BlockCommand
+			Element p()
+				NewLine
+`,
+		},
+		"slim": {
+			input: "@slim test() {\n\t= @extends Base()\n\t= @block content\n\t\tp\n}",
+			want: `Root
+	Template
+		ExtendsCommand
+		BlockCommand
+			Element p()
+`,
+		},
+		"ego": {
+			input: "@ego test() {\n\t<%@extends Base() %>\n\t<%@block content { %><p></p><% } %>\n}",
+			want: `Root
+	Template
+		ExtendsCommand
+		Text
+		BlockCommand
+			Text
+		SilentScript
+		Text
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newParser([]byte(test.input))
+			err := p.parse()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			buf := new(bytes.Buffer)
+			_ = p.template.Root.Tree(buf, 0)
+			got := buf.String()
+			if got != test.want {
+				t.Errorf("got \n%s----\nwant \n%s----", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_ExtendsCommandMoreThanOnce(t *testing.T) {
+	tpl, err := ParseString("@goht test() {\n\t= @extends Base()\n\t= @extends Other()\n}")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	err = tpl.Generate(new(bytes.Buffer))
+	if err == nil || !strings.Contains(err.Error(), "a template can only extend one template") {
+		t.Errorf("want an error for extending more than one template, got %v", err)
+	}
+}
+
+func Test_ExtendsCommandErrors(t *testing.T) {
+	tests := map[string]string{
+		"content outside of the blocks": "@goht test() {\n\t= @extends Base()\n\t%p Lost\n\t= @block content\n\t\t%p\n}",
+		"text outside of the blocks":    "@ego test() {\n\t<%@extends Base() %>\n\tLost\n\t<%@block content { %><p></p><% } %>\n}",
+		"output outside of the blocks":  "@slim test(v string) {\n\t= @extends Base()\n\t= v\n}",
+		"nested in an element":          "@goht test() {\n\t%div\n\t\t= @extends Base()\n}",
+		"nested in a block":             "@goht test() {\n\t= @extends Base()\n\t= @block content\n\t\t= @extends Other()\n}",
+		"nested in go code":             "@goht test(ok bool) {\n\t- if ok\n\t\t= @extends Base()\n}",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString(input)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			err = tpl.Generate(new(bytes.Buffer))
+			var pErr PositionalError
+			if !errors.As(err, &pErr) || pErr.Code != ErrExtends {
+				t.Errorf("Generate() error = %v, want a %s error", err, ErrExtends)
+			}
+		})
+	}
+}
+
+func Test_PushCommand(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"haml": {
+			input: "@goht test() {\n\t= @stack scripts\n\t= @push scripts \"app\"\n\t\t%script\n}",
+			want: `Root
+	Template
+		StackCommand
+		PushCommand
+			Element script()
+				NewLine
+`,
+		},
+		"slim": {
+			input: "@slim test() {\n\t= @stack scripts\n\t= @push scripts \"app\"\n\t\tscript\n}",
+			want: `Root
+	Template
+		StackCommand
+		PushCommand
+			Element script()
+`,
+		},
+		"ego": {
+			input: "@ego test() {\n\t<%@stack scripts %>\n\t<%@push scripts \"app\" { %><script></script><% } %>\n}",
+			want: `Root
+	Template
+		StackCommand
+		Text
+		PushCommand
+			Text
+		SilentScript
+		Text
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newParser([]byte(test.input))
+			err := p.parse()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			buf := new(bytes.Buffer)
+			_ = p.template.Root.Tree(buf, 0)
+			got := buf.String()
+			if got != test.want {
+				t.Errorf("got \n%s----\nwant \n%s----", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_jsonValue(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"bare":           {input: "data", want: "data"},
+		"parenthesized":  {input: "(data)", want: "data"},
+		"spaces":         {input: " ( data ) ", want: "data"},
+		"call":           {input: "(settings(user))", want: "settings(user)"},
+		"not wrapping":   {input: "(a).b()", want: "(a).b()"},
+		"quoted paren":   {input: `(get(")"))`, want: `get(")")`},
+		"quoted in call": {input: `(strings.Split(s, ")"))`, want: `strings.Split(s, ")")`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := jsonValue(tt.input); got != tt.want {
+				t.Errorf("jsonValue(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_JsonCommandContext(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"element content": {
+			input: "@ego test(v any) {\n\t<div><%@json v %></div>\n}",
+			want:  `__buf.WriteString("<div><script type=\"application/json\">")`,
+		},
+		"script": {
+			input: "@ego test(v any) {\n\t<script>var v = <%@json v %>;</script>\n}",
+			want:  `__buf.WriteString("<script>var v = ")`,
+		},
+		"quoted attribute": {
+			input:   "@ego test(v any) {\n\t<div title=\"<%@json v %>\"></div>\n}",
+			wantErr: true,
+		},
+		"unquoted attribute": {
+			input:   "@ego test(v any) {\n\t<div title=<%@json v %>></div>\n}",
+			wantErr: true,
+		},
+		"event handler": {
+			input:   "@ego test(v any) {\n\t<button onclick=\"load(<%@json v %>)\"></button>\n}",
+			wantErr: true,
+		},
+		"javascript string": {
+			input:   "@ego test(v any) {\n\t<script>var v = '<%@json v %>';</script>\n}",
+			wantErr: true,
+		},
+		"style": {
+			input:   "@ego test(v any) {\n\t<style><%@json v %></style>\n}",
+			wantErr: true,
+		},
+		"attribute name": {
+			input:   "@ego test(v any) {\n\t<div <%@json v %>></div>\n}",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString(tt.input)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			var buf bytes.Buffer
+			err = tpl.Generate(&buf)
+			if tt.wantErr {
+				var pErr PositionalError
+				if !errors.As(err, &pErr) || pErr.Code != ErrContext {
+					t.Fatalf("Generate() error = %v, want a %s error", err, ErrContext)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Generate() is missing %s\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func Test_JavaScriptContexts(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"template literal": {
+			input: "@ego test(v string) {\n\t<script>var a = `hi <%= v %>`;</script>\n}",
+			want:  `goht.EscapeJSString(v)`,
+		},
+		"template literal substitution": {
+			input: "@ego test(v string) {\n\t<script>var a = `hi ${ {a: 1}.a + <%= v %> }`;</script>\n}",
+			want:  `goht.EscapeJS(v)`,
+		},
+		"after a template literal substitution": {
+			input: "@ego test(v string) {\n\t<script>var a = `${b} <%= v %>`;</script>\n}",
+			want:  `goht.EscapeJSString(v)`,
+		},
+		"after a regular expression": {
+			input: "@ego test(v string) {\n\t<script>var re = /'/; var b = <%= v %>;</script>\n}",
+			want:  `goht.EscapeJS(v)`,
+		},
+		"regular expression with a class": {
+			input: "@ego test(v string) {\n\t<script>var re = /[/']/; var b = <%= v %>;</script>\n}",
+			want:  `goht.EscapeJS(v)`,
+		},
+		"after a keyword": {
+			input: "@ego test(v string) {\n\t<script>if (typeof /'/ === x) { b = <%= v %>; }</script>\n}",
+			want:  `goht.EscapeJS(v)`,
+		},
+		"after a division": {
+			input: "@ego test(v string) {\n\t<script>var a = (b) / 2, c = d / 'e' + <%= v %>;</script>\n}",
+			want:  `goht.EscapeJS(v)`,
+		},
+		"event handler": {
+			input: "@ego test(v string) {\n\t<button onclick=\"check(/'/, <%= v %>)\"></button>\n}",
+			want:  `goht.EscapeAttr(goht.EscapeJS(v))`,
+		},
+		"unquoted url after another attribute": {
+			input: "@ego test(u string) {\n\t<a class=x href=<%= u %>>link</a>\n}",
+			want:  `goht.EscapeUnquotedAttr(goht.EscapeURL(u))`,
+		},
+		"unquoted url after a spaced attribute": {
+			input: "@ego test(u string) {\n\t<a class = x href = <%= u %>>link</a>\n}",
+			want:  `goht.EscapeUnquotedAttr(goht.EscapeURL(u))`,
+		},
+		"inside of a regular expression": {
+			input:   "@ego test(v string) {\n\t<script>var re = /<%= v %>/;</script>\n}",
+			wantErr: true,
+		},
+		"json inside of a regular expression": {
+			input:   "@ego test(v any) {\n\t<script>var re = /<%@json v %>/;</script>\n}",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString(tt.input)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			var buf bytes.Buffer
+			err = tpl.Generate(&buf)
+			if tt.wantErr {
+				var pErr PositionalError
+				if !errors.As(err, &pErr) || pErr.Code != ErrContext {
+					t.Fatalf("Generate() error = %v, want a %s error", err, ErrContext)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Generate() is missing %s\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func Test_DynamicNames(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"tag name": {
+			input: "@ego test(level string) {\n\t<h<%= level %>>Title</h<%= level %>>\n}",
+			want:  `goht.EscapeTagName("h", level)`,
+		},
+		"attribute": {
+			input: "@ego test(attr string) {\n\t<input <%= attr %>>\n}",
+			want:  `goht.EscapeAttrName("", attr)`,
+		},
+		"attribute name": {
+			input: "@ego test(key string) {\n\t<div data-<%= key %>=\"1\"></div>\n}",
+			want:  `goht.EscapeAttrName("data-", key)`,
+		},
+		"after an attribute name": {
+			input: "@ego test(attr string) {\n\t<input disabled <%= attr %>>\n}",
+			want:  `goht.EscapeAttrName("", attr)`,
+		},
+		"unescaped": {
+			input: "@ego test(attr string) {\n\t<input <%! attr %>>\n}",
+			want:  `goht.CaptureErrors(attr)`,
+		},
+		"text after a tag name": {
+			input:   "@ego test(v string) {\n\t<h<%= v %>x></h1>\n}",
+			wantErr: true,
+		},
+		"text after an attribute name": {
+			input:   "@ego test(v string) {\n\t<div <%= v %>-id=\"1\"></div>\n}",
+			wantErr: true,
+		},
+		"two values in an attribute name": {
+			input:   "@ego test(a, b string) {\n\t<div <%= a %><%= b %>></div>\n}",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString(tt.input)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			var buf bytes.Buffer
+			err = tpl.Generate(&buf)
+			if tt.wantErr {
+				var pErr PositionalError
+				if !errors.As(err, &pErr) || pErr.Code != ErrContext {
+					t.Fatalf("Generate() error = %v, want a %s error", err, ErrContext)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Generate() is missing %s\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func Test_FormatCommand(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"haml": {
+			input: "@goht test() {\n\t= @format xml\n\t%link/\n}",
+			want: `Root
+	Template
+		FormatCommand
+		Element link()
+			NewLine
+`,
+		},
+		"slim": {
+			input: "@slim test() {\n\t= @format xhtml\n\tbr\n}",
+			want: `Root
+	Template
+		FormatCommand
+		Element br()
+`,
+		},
+		"not at the start": {
+			input: "@goht test() {\n\t%p\n\t= @format xhtml\n}",
+			want: `Root
+	Template
+		Element p()
+			NewLine
+`,
+			wantErr: true,
+		},
+		"unknown format": {
+			input: "@goht test() {\n\t= @format svg\n}",
+			want: `Root
+	Template
+`,
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newParser([]byte(test.input))
+			err := p.parse()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, test.wantErr)
+			}
+			buf := new(bytes.Buffer)
+			_ = p.template.Root.Tree(buf, 0)
+			got := buf.String()
+			if got != test.want {
+				t.Errorf("got \n%s----\nwant \n%s----", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_parseStackName(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		stack string
+		key   string
+	}{
+		"name":           {input: "scripts", stack: "scripts"},
+		"quoted name":    {input: `"page scripts"`, stack: "page scripts"},
+		"name and key":   {input: `scripts "app"`, stack: "scripts", key: `"app"`},
+		"key expression": {input: `"scripts"  widget.ID`, stack: "scripts", key: "widget.ID"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stack, key := parseStackName(tt.input)
+			if stack != tt.stack || key != tt.key {
+				t.Errorf("parseStackName(%q) = %q, %q; want %q, %q", tt.input, stack, key, tt.stack, tt.key)
+			}
+		})
+	}
+}
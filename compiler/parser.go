@@ -1,8 +1,10 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 type parser struct {
@@ -12,6 +14,12 @@ type parser struct {
 	nodes    stack[parsingNode]
 	token    token
 	tokens   stack[token]
+	// format is the format of the template being parsed
+	format outputFormat
+	// errors are the errors that have been found so far
+	errors ErrorList
+	// source is the template file, which is used for the source lines of errors
+	source []byte
 }
 
 func ParseFile(fileName string) (*Template, error) {
@@ -20,7 +28,11 @@ func ParseFile(fileName string) (*Template, error) {
 		return nil, err
 	}
 
-	return parseBytes(contents)
+	t, err := parseBytes(contents)
+	if t != nil {
+		t.Filename = filepath.Base(fileName)
+	}
+	return t, err
 }
 
 func ParseString(contents string) (*Template, error) {
@@ -45,8 +57,9 @@ func newParser(contents []byte) *parser {
 		template: &Template{
 			Root: rootNode,
 		},
-		n:     rootNode,
-		nodes: nodes,
+		n:      rootNode,
+		nodes:  nodes,
+		source: contents,
 	}
 
 	return p
@@ -57,17 +70,71 @@ func (p *parser) nextToken() {
 	p.tokens.push(token)
 }
 
+// parse parses the template file. Parsing continues after an error at the
+// next line or template boundary, and an ErrorList of all the errors that
+// were found is returned.
 func (p *parser) parse() error {
 	p.nextToken()
 	for {
 		if err := p.n.parse(p); err != nil {
-			return err
+			p.recover(err)
 		}
 		if p.token.Type() == tEOF {
 			break
 		}
 	}
-	return nil
+	return p.errors.Err()
+}
+
+// recover records the error, and then skips the rest of the line or template
+// that it was found in so that parsing can continue.
+func (p *parser) recover(err error) {
+	if t := p.peek(); t.Type() == tError {
+		// the lexer error is the cause of the parser error
+		err = errorAt(t, t.code, "%s", t.lit)
+		p.next()
+	}
+	posErr, ok := err.(PositionalError)
+	if !ok {
+		posErr = errorAt(p.peek(), ErrUnexpectedToken, "%w", err).(PositionalError)
+	}
+	p.report(posErr)
+
+	// continue with the template, or at the root when outside a template
+	if p.backToType(nTemplate) != nil {
+		p.n = p.nodes.peek()
+	}
+	for {
+		switch p.peek().Type() {
+		case tNewLine:
+			p.next()
+			return
+		case tTemplateEnd:
+			if p.n.Type() == nTemplate {
+				return
+			}
+		case tEOF:
+			p.next()
+			return
+		}
+		p.next()
+	}
+}
+
+// report records an error without leaving the line or template that it was
+// found in.
+func (p *parser) report(err PositionalError) {
+	err.Source = p.sourceLine(err.Line)
+	p.errors = append(p.errors, err)
+}
+
+// sourceLine returns the line of the template file without its line ending.
+func (p *parser) sourceLine(line int) string {
+	lines := bytes.Split(p.source, []byte("\n"))
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return string(bytes.TrimSuffix(lines[line-1], []byte("\r")))
 }
 
 func (p *parser) peek() token {
@@ -10,6 +10,11 @@ import (
 
 	"github.com/stackus/goht"
 	"github.com/stackus/goht/compiler/testdata"
+	"github.com/stackus/goht/compiler/testdata/convert"
+	"github.com/stackus/goht/compiler/testdata/convert/ego"
+	"github.com/stackus/goht/compiler/testdata/convert/haml"
+	"github.com/stackus/goht/compiler/testdata/convert/slim"
+	"github.com/stackus/goht/compiler/testdata/imported"
 )
 
 func TestRender(t *testing.T) {
@@ -57,6 +62,14 @@ func TestRender(t *testing.T) {
 			template: testdata.FiltersTest(),
 			htmlFile: "filters",
 		},
+		"markdown filter": {
+			template: testdata.MarkdownFilterTest("Hello <World>", "javascript:alert(1)"),
+			htmlFile: "markdown_filter",
+		},
+		"slim markdown filter": {
+			template: testdata.SlimMarkdownFilterTest("Hello <World>"),
+			htmlFile: "slim_markdown_filter",
+		},
 		"object references": {
 			template: testdata.ObjectReferencesTest(),
 			htmlFile: "obj_references",
@@ -93,6 +106,38 @@ func TestRender(t *testing.T) {
 			template: testdata.EgoTemplate(),
 			htmlFile: "ego_template",
 		},
+		"escaping": {
+			template: testdata.EscapingTest(),
+			htmlFile: "escaping",
+		},
+		"ego escaping": {
+			template: testdata.EgoEscapingTest(),
+			htmlFile: "ego_escaping",
+		},
+		"json": {
+			template: testdata.JsonTest(map[string]string{"theme": "</script><!--dark"}),
+			htmlFile: "json",
+		},
+		"slim json": {
+			template: testdata.SlimJsonTest(map[string]string{"theme": "dark"}),
+			htmlFile: "slim_json",
+		},
+		"ego json": {
+			template: testdata.EgoJsonTest(map[string]string{"theme": "</script><!--dark"}),
+			htmlFile: "ego_json",
+		},
+		"imported haml": {
+			template: imported.HamlPage("<5>"),
+			htmlFile: "imported/haml",
+		},
+		"imported slim": {
+			template: imported.SlimPage("<5>"),
+			htmlFile: "imported/slim",
+		},
+		"imported ego": {
+			template: imported.EgoPage("<5>"),
+			htmlFile: "imported/ego",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -123,3 +168,77 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+// TestRenderConverted checks that the templates of testdata/convert render
+// the same HTML after they are converted to each dialect.
+func TestRenderConverted(t *testing.T) {
+	items := []string{"one", "<two>"}
+	tests := map[string][]goht.Template{
+		"haml page": {
+			convert.HamlPage("Title", items, true),
+			haml.HamlPage("Title", items, true),
+			slim.HamlPage("Title", items, true),
+			ego.HamlPage("Title", items, true),
+		},
+		"haml page without admin": {
+			convert.HamlPage("Title", nil, false),
+			haml.HamlPage("Title", nil, false),
+			slim.HamlPage("Title", nil, false),
+			ego.HamlPage("Title", nil, false),
+		},
+		"slim page": {
+			convert.SlimPage("Title", items, 1),
+			haml.SlimPage("Title", items, 1),
+			slim.SlimPage("Title", items, 1),
+			ego.SlimPage("Title", items, 1),
+		},
+		"slim page with the default case": {
+			convert.SlimPage("Title", items, 2),
+			haml.SlimPage("Title", items, 2),
+			slim.SlimPage("Title", items, 2),
+			ego.SlimPage("Title", items, 2),
+		},
+		"ego page": {
+			convert.EgoPage("Title", items, true),
+			haml.EgoPage("Title", items, true),
+			slim.EgoPage("Title", items, true),
+			ego.EgoPage("Title", items, true),
+		},
+		"ego page without admin": {
+			convert.EgoPage("Title", items, false),
+			haml.EgoPage("Title", items, false),
+			slim.EgoPage("Title", items, false),
+			ego.EgoPage("Title", items, false),
+		},
+		"haml nav": {
+			convert.HamlNav("Title", items),
+			haml.HamlNav("Title", items),
+			slim.HamlNav("Title", items),
+			ego.HamlNav("Title", items),
+		},
+		"ego list": {
+			convert.EgoList("Title", items),
+			haml.EgoList("Title", items),
+			slim.EgoList("Title", items),
+			ego.EgoList("Title", items),
+		},
+	}
+	dialects := []string{"haml", "slim", "ego"}
+	for name, templates := range tests {
+		t.Run(name, func(t *testing.T) {
+			var want bytes.Buffer
+			if err := templates[0].Render(context.Background(), &want); err != nil {
+				t.Fatalf("error rendering template: %v", err)
+			}
+			for i, tpl := range templates[1:] {
+				var got bytes.Buffer
+				if err := tpl.Render(context.Background(), &got); err != nil {
+					t.Fatalf("error rendering the %s template: %v", dialects[i], err)
+				}
+				if got.String() != want.String() {
+					t.Errorf("the %s template renders %q, want %q", dialects[i], got.String(), want.String())
+				}
+			}
+		})
+	}
+}
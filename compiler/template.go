@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
@@ -16,14 +17,59 @@ type templateWriter struct {
 	num          *int
 	pos          *Position
 	sm           *SourceMap
+	lines        *lineDirectives
+	static       *staticLiteral
+	format       outputFormat
 	indent       int
 	inStatic     bool
 	inErrHandler bool
 	isUnescaped  bool
 }
 
+// lineDirectives tracks the template line of the code being written so that
+// //line directives can be written into the generated code. The compiler,
+// panics, go vet and coverage will then report positions from the GoHT
+// template instead of the generated file.
+//
+// A directive is written before each line of generated code because gofmt
+// will split some of the generated lines after they have been written. The
+// column of a line directive is the column of the template node; gofmt can
+// change the indent of the line, so the Go code from the template is also
+// given its own /*line*/ directive with the exact column of the code.
+type lineDirectives struct {
+	fileName string
+	line     int
+	col      int
+	// indent is the indent of the line that has not been written yet
+	indent string
+	// inline is set while the /*line*/ directive of a token is written
+	inline bool
+	// comma is set when the code written last ends with a comma
+	comma bool
+}
+
+// staticLiteral holds the content of the open string literal until the
+// literal is closed. This lets the whitespace removal of the Haml `<` and `>`
+// operators be done to the static content of the template when it is
+// compiled instead of when it is rendered.
+type staticLiteral struct {
+	content strings.Builder
+	indent  int
+	line    int
+	col     int
+	// trimNext is set when the whitespace that follows is to be removed
+	trimNext bool
+}
+
+// Generate writes the Go source for the template.
+//
+// When the template has a Filename, //line directives are written so that the
+// positions of the generated code refer back to the template file.
 func (t *Template) Generate(w io.Writer) error {
 	tw := newTemplateWriter(w, nil)
+	if t.Filename != "" {
+		tw.lines = &lineDirectives{fileName: t.Filename}
+	}
 
 	err := t.Root.Source(tw)
 	return err
@@ -48,7 +94,8 @@ func newTemplateWriter(w io.Writer, sm *SourceMap) *templateWriter {
 			Line: 1,
 			Col:  1,
 		},
-		sm: sm,
+		sm:     sm,
+		static: &staticLiteral{},
 	}
 	return tw
 }
@@ -63,6 +110,16 @@ func (tw *templateWriter) Add(t token, r Range) {
 	tw.sm.Add(t, r)
 }
 
+// source writes the source of the node; setting the template line of the
+// code that is written for it.
+func (tw *templateWriter) source(n nodeBase) error {
+	if tw.lines != nil && n.Origin().line > 0 {
+		tw.lines.line = n.Origin().line
+		tw.lines.col = n.Origin().col
+	}
+	return n.Source(tw)
+}
+
 func (tw *templateWriter) Indent(indents uint) *templateWriter {
 	itw := *tw
 	itw.indent += int(indents)
@@ -100,6 +157,51 @@ func (tw *templateWriter) Write(s string) (r Range, err error) {
 			return
 		}
 	}
+	if tw.pos.Col == 1 {
+		if err = tw.writeTrimNext(); err != nil {
+			return
+		}
+	}
+	return tw.write(s)
+}
+
+// WriteCode writes the Go code of a token to the template.
+//
+// When line directives are written, the code follows a /*line*/ directive so
+// that the positions in the code are the positions of the token in the
+// template. The column of the directive is where gofmt leaves it: gofmt puts
+// a space between the directive and the code, moves a directive that follows
+// a comma in front of the comma, and one in front of an else after the else.
+//
+// (e.g. /*line file.goht:3:4*/ ... the code of the token ...)
+func (tw *templateWriter) WriteCode(t token, s string) (r Range, err error) {
+	if tw.lines == nil || t.line == 0 || strings.TrimSpace(s) == "" || s == "else" {
+		return tw.Write(s)
+	}
+	if code, ok := strings.CutPrefix(s, "else "); ok {
+		// gofmt moves a directive in front of an else to after the else
+		if r, err = tw.Write("else "); err != nil {
+			return
+		}
+		t.col += len(s) - len(code)
+		var cr Range
+		cr, err = tw.WriteCode(t, code)
+		r.To = cr.To
+		return
+	}
+	col := t.col - 1
+	if tw.lines.comma {
+		col--
+	}
+	if col < 1 {
+		return tw.Write(s)
+	}
+	tw.lines.inline = true
+	_, err = tw.Write(fmt.Sprintf("/*line %s:%d:%d*/ ", tw.lines.fileName, t.line, col))
+	tw.lines.inline = false
+	if err != nil {
+		return
+	}
 	return tw.write(s)
 }
 
@@ -113,6 +215,9 @@ func (tw *templateWriter) WriteIndent(s string) (r Range, err error) {
 			return
 		}
 	}
+	if err = tw.writeTrimNext(); err != nil {
+		return
+	}
 	if _, err = tw.write(strings.Repeat("\t", tw.indent)); err != nil {
 		return
 	}
@@ -124,18 +229,75 @@ func (tw *templateWriter) WriteIndent(s string) (r Range, err error) {
 //
 // (e.g. " ... whatever you give it ... ")
 func (tw *templateWriter) WriteStringLiteral(s string) (r Range, err error) {
-	if !tw.inStatic {
-		if _, err = tw.write(strings.Repeat("\t", tw.indent)); err != nil {
-			return
-		}
-		if _, err = tw.write(`if _, __err = __buf.WriteString("`); err != nil {
+	if tw.static.trimNext {
+		if s = trimLeadingSpace(s); s == "" {
 			return
 		}
+		tw.static.trimNext = false
+	}
+	if !tw.inStatic {
 		tw.inStatic = true
 		tw.inErrHandler = true
+		tw.static.indent = tw.indent
+		if tw.lines != nil {
+			tw.static.line = tw.lines.line
+			tw.static.col = tw.lines.col
+		}
 	}
 
-	return tw.write(s)
+	tw.static.content.WriteString(s)
+	return
+}
+
+// booleanAttribute returns the attribute, escaped for a string literal, that
+// is written for a boolean attribute that is true.
+//
+// (e.g. ` checked` or ` checked="checked"` for the xhtml and xml formats)
+func (tw *templateWriter) booleanAttribute(name string) string {
+	if tw.format.isXML() {
+		return ` ` + name + `=\"` + name + `\"`
+	}
+	return ` ` + name
+}
+
+// TrimSpaceBefore removes the whitespace that was written before this point.
+//
+// The whitespace is removed from the open string literal; if that leaves the
+// literal empty then the whitespace before it is removed when the template
+// is rendered.
+func (tw *templateWriter) TrimSpaceBefore() error {
+	if tw.inStatic {
+		s := trimTrailingSpace(tw.static.content.String())
+		tw.static.content.Reset()
+		if s != "" {
+			tw.static.content.WriteString(s)
+			return nil
+		}
+		tw.inStatic = false
+		tw.inErrHandler = false
+	}
+	_, err := tw.WriteIndent("__buf.TrimPrecedingSpace()\n")
+	return err
+}
+
+// TrimSpaceAfter removes the whitespace that is written after this point.
+//
+// The whitespace is removed from the string literals that follow; if code is
+// written before anything other than whitespace then the whitespace is
+// removed when the template is rendered.
+func (tw *templateWriter) TrimSpaceAfter() {
+	tw.static.trimNext = true
+}
+
+// writeTrimNext writes the code to remove the whitespace that follows when
+// it could not be removed from the string literals.
+func (tw *templateWriter) writeTrimNext() error {
+	if !tw.static.trimNext {
+		return nil
+	}
+	tw.static.trimNext = false
+	_, err := tw.write(strings.Repeat("\t", tw.indent) + "__buf.TrimFollowingSpace()\n")
+	return err
 }
 
 // WriteStringIndent writes a string literal to the template with the current indent.
@@ -149,6 +311,9 @@ func (tw *templateWriter) WriteStringIndent(s string) (r Range, err error) {
 		}
 	}
 
+	if err = tw.writeTrimNext(); err != nil {
+		return
+	}
 	if _, err = tw.write(strings.Repeat("\t", tw.indent)); err != nil {
 		return
 	}
@@ -179,6 +344,12 @@ func (tw *templateWriter) Close() (r Range, err error) {
 }
 
 func (tw *templateWriter) write(s string) (r Range, err error) {
+	out := s
+	if tw.lines != nil {
+		if out, err = tw.writeLineDirective(s); err != nil {
+			return
+		}
+	}
 	r.From = *tw.pos
 	nl := strings.Count(s, "\n")
 	tw.pos.Line += nl
@@ -188,19 +359,58 @@ func (tw *templateWriter) write(s string) (r Range, err error) {
 		tw.pos.Col += len(s)
 	}
 
-	_, err = io.WriteString(tw.w, s)
+	_, err = io.WriteString(tw.w, out)
 	r.To = *tw.pos
+	if tw.lines != nil && s != "" {
+		tw.lines.comma = strings.HasSuffix(s, ", ")
+	}
 	return
 }
 
-func (tw *templateWriter) closeStringLiteral() (Range, error) {
-	var nl = "\n"
-	if tw.inErrHandler {
-		nl = ""
+// writeLineDirective writes a //line directive when s starts a new line of
+// code that came from a line of the template, and returns what is left of the
+// line to write.
+//
+// The indent of a line is held until the code on the line is written. A line
+// that starts with the Go code of a token has a /*line*/ directive in front of
+// the code instead; gofmt would move that directive to a line of its own when
+// it follows a //line directive.
+func (tw *templateWriter) writeLineDirective(s string) (string, error) {
+	if s == "" || tw.pos.Col != 1 && tw.lines.indent == "" {
+		return s, nil
+	}
+	if strings.Trim(s, "\t") == "" {
+		tw.lines.indent += s
+		return "", nil
+	}
+	s, tw.lines.indent = tw.lines.indent+s, ""
+	if tw.lines.line == 0 || tw.lines.inline || strings.TrimLeft(s, "\t")[0] == '\n' {
+		return s, nil
 	}
-	s := `")` + nl + tw.addErrHandler()
+	directive := fmt.Sprintf("//line %s:%d\n", tw.lines.fileName, tw.lines.line)
+	if tw.lines.col > 0 {
+		directive = fmt.Sprintf("//line %s:%d:%d\n", tw.lines.fileName, tw.lines.line, tw.lines.col)
+	}
+	tw.pos.Line++
+	_, err := io.WriteString(tw.w, directive)
+	return s, err
+}
+
+func (tw *templateWriter) closeStringLiteral() (r Range, err error) {
 	tw.inStatic = false
-	return tw.write(s)
+	tw.inErrHandler = false
+	s := tw.static.content.String()
+	tw.static.content.Reset()
+	if s == "" {
+		// all of the content was whitespace that has been removed
+		return
+	}
+	if tw.lines != nil {
+		line, col := tw.lines.line, tw.lines.col
+		tw.lines.line, tw.lines.col = tw.static.line, tw.static.col
+		defer func() { tw.lines.line, tw.lines.col = line, col }()
+	}
+	return tw.write(strings.Repeat("\t", tw.static.indent) + `if _, __err = __buf.WriteString("` + s + `"); __err != nil { return }` + "\n")
 }
 
 func (tw *templateWriter) addErrHandler() string {
@@ -210,3 +420,40 @@ func (tw *templateWriter) addErrHandler() string {
 	}
 	return strings.Repeat("\t", tw.indent) + "if __err != nil { return }\n"
 }
+
+// trimLeadingSpace removes the whitespace from the start of the content of a
+// string literal; the whitespace is either a space or an escape sequence.
+func trimLeadingSpace(s string) string {
+	for {
+		switch {
+		case strings.HasPrefix(s, " "), strings.HasPrefix(s, "\t"):
+			s = s[1:]
+		case len(s) > 1 && s[0] == '\\' && strings.IndexByte("tnfr", s[1]) >= 0:
+			s = s[2:]
+		default:
+			return s
+		}
+	}
+}
+
+// trimTrailingSpace removes the whitespace from the end of the content of a
+// string literal; the whitespace is either a space or an escape sequence.
+func trimTrailingSpace(s string) string {
+	for {
+		switch {
+		case strings.HasSuffix(s, " "), strings.HasSuffix(s, "\t"):
+			s = s[:len(s)-1]
+		case len(s) > 1 && strings.IndexByte("tnfr", s[len(s)-1]) >= 0 && isEscape(s[:len(s)-1]):
+			s = s[:len(s)-2]
+		default:
+			return s
+		}
+	}
+}
+
+// isEscape returns true when s ends with a backslash that is not itself
+// escaped.
+func isEscape(s string) bool {
+	n := len(s) - len(strings.TrimRight(s, "\\"))
+	return n%2 == 1
+}
@@ -2,9 +2,17 @@ package compiler
 
 import (
 	"bytes"
+	"errors"
+	goast "go/ast"
 	"go/format"
+	goimporter "go/importer"
+	goparser "go/parser"
+	gotoken "go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -50,6 +58,18 @@ func TestTemplate_Generate(t *testing.T) {
 		"render": {
 			templateFile: "rendering",
 		},
+		"escaping": {
+			templateFile: "escaping",
+		},
+		"stacks": {
+			templateFile: "stacks",
+		},
+		"blocks": {
+			templateFile: "blocks",
+		},
+		"handler": {
+			templateFile: "handler",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -99,3 +119,222 @@ func TestTemplate_Generate(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplate_GenerateLineDirectives(t *testing.T) {
+	tpl, err := ParseFile(filepath.Join("testdata", "conditionals.goht"))
+	if err != nil {
+		t.Fatalf("error parsing template: %v", err)
+	}
+	if tpl.Filename != "conditionals.goht" {
+		t.Errorf("want filename conditionals.goht, got %q", tpl.Filename)
+	}
+
+	var gotW bytes.Buffer
+	if err = tpl.Generate(&gotW); err != nil {
+		t.Fatalf("error generating template: %v", err)
+	}
+	got, err := format.Source(gotW.Bytes())
+	if err != nil {
+		t.Fatalf("error formatting source: %v", err)
+	}
+
+	// the template position of each line of code is the position from the last
+	// directive; the Go code from the template has a directive of its own
+	reInline := regexp.MustCompile(`/\*line ([^*]+)\*/ `)
+	lines := make(map[string]string)
+	directive := ""
+	for _, line := range strings.Split(string(got), "\n") {
+		if strings.HasPrefix(line, "//line ") {
+			directive = strings.TrimPrefix(line, "//line ")
+			continue
+		}
+		if m := reInline.FindStringSubmatch(line); m != nil {
+			directive = m[1]
+			line = reInline.ReplaceAllString(line, "")
+		}
+		lines[strings.TrimSpace(line)] = directive
+	}
+
+	tests := map[string]string{
+		"func ConditionalsTest(v bool) goht.Template {":                      "conditionals.goht:3:6",
+		`if _, __err = __buf.WriteString("<p>before</p>\n"); __err != nil {`: "conditionals.goht:4:3",
+		"if v {": "conditionals.goht:5:3",
+		`if _, __err = __buf.WriteString("<p>after</p>\n"); __err != nil {`: "conditionals.goht:7:3",
+	}
+	for code, want := range tests {
+		if lines[code] != want {
+			t.Errorf("want %q to be from %s, got %q", code, want, lines[code])
+		}
+	}
+}
+
+func TestTemplate_GenerateErrorPositions(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"haml script": {
+			input: "@haml Test() {\n\t%p= missing\n}\n",
+			want:  "test.goht:4:6",
+		},
+		"haml silent script": {
+			input: "@haml Test() {\n\t- if missing\n\t\t%p\n}\n",
+			want:  "test.goht:4:7",
+		},
+		"haml else": {
+			input: "@haml Test() {\n\t- if true\n\t\t%p\n\t- else if missing\n\t\t%p\n}\n",
+			want:  "test.goht:6:12",
+		},
+		"haml attribute": {
+			input: "@haml Test() {\n\t%a{href: #{missing}}\n}\n",
+			want:  "test.goht:4:13",
+		},
+		"haml class": {
+			input: "@haml Test() {\n\t%p.a{class: #{missing}}\n}\n",
+			want:  "test.goht:4:16",
+		},
+		"haml interpolation": {
+			input: "@haml Test() {\n\t%p Hello #{missing}\n}\n",
+			want:  "test.goht:4:13",
+		},
+		"slim script": {
+			input: "@slim Test() {\n\tp= missing\n}\n",
+			want:  "test.goht:4:5",
+		},
+		"ego output": {
+			input: "@ego Test() {\n\t<p><%= missing %></p>\n}\n",
+			want:  "test.goht:4:9",
+		},
+		"ego script": {
+			input: "@ego Test() {\n\t<% if missing { %>ok<% } %>\n}\n",
+			want:  "test.goht:4:8",
+		},
+	}
+	fset := gotoken.NewFileSet()
+	conf := types.Config{Importer: goimporter.ForCompiler(fset, "source", nil)}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString("package testdata\n\n" + tt.input)
+			if err != nil {
+				t.Fatalf("error parsing template: %v", err)
+			}
+			tpl.Filename = "test.goht"
+			var gotW bytes.Buffer
+			if err = tpl.Generate(&gotW); err != nil {
+				t.Fatalf("error generating template: %v", err)
+			}
+			src, err := format.Source(gotW.Bytes())
+			if err != nil {
+				t.Fatalf("error formatting source: %v", err)
+			}
+			f, err := goparser.ParseFile(fset, "test.goht.go", src, 0)
+			if err != nil {
+				t.Fatalf("error parsing generated code: %v", err)
+			}
+			_, err = conf.Check("testdata", fset, []*goast.File{f}, nil)
+			var typeErr types.Error
+			if !errors.As(err, &typeErr) {
+				t.Fatalf("want a compile error, got %v", err)
+			}
+			if got := fset.Position(typeErr.Pos).String(); got != tt.want {
+				t.Errorf("want the error %q at %s, got %s", typeErr.Msg, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplate_GenerateWithoutFilename(t *testing.T) {
+	tpl, err := ParseString("package testdata\n\n@goht Test() {\n\t%p hello\n}\n")
+	if err != nil {
+		t.Fatalf("error parsing template: %v", err)
+	}
+
+	var gotW bytes.Buffer
+	if err = tpl.Generate(&gotW); err != nil {
+		t.Fatalf("error generating template: %v", err)
+	}
+	if strings.Contains(gotW.String(), "//line") {
+		t.Errorf("want no line directives without a filename:\n%s", gotW.String())
+	}
+}
+
+func TestTemplate_GenerateScopedSlotType(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr string
+	}{
+		"haml": {
+			input: "@haml Test(items []string) {\n\t- for _, item := range items\n\t\t=@slot row[string](item)\n}\n",
+		},
+		"slim": {
+			input: "@slim Test(items []string) {\n\t- for _, item := range items\n\t\t=@slot row[string](item)\n}\n",
+		},
+		"ego": {
+			input: "@ego Test(items []string) {\n\t<% for _, item := range items { %><%@slot row[string](item) %><% } %>\n}\n",
+		},
+		"inferred": {
+			input: "@haml Test(items []string) {\n\t- for _, item := range items\n\t\t=@slot row(item)\n}\n",
+		},
+		"mismatch": {
+			input:   "@haml Test(items []string) {\n\t- for _, item := range items\n\t\t=@slot row[int](item)\n}\n",
+			wantErr: "cannot use item (variable of type string) as int value in argument to goht.RenderScopedSlot[int]",
+		},
+	}
+	fset := gotoken.NewFileSet()
+	conf := types.Config{Importer: goimporter.ForCompiler(fset, "source", nil)}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpl, err := ParseString("package testdata\n\n" + tt.input)
+			if err != nil {
+				t.Fatalf("error parsing template: %v", err)
+			}
+			var gotW bytes.Buffer
+			if err = tpl.Generate(&gotW); err != nil {
+				t.Fatalf("error generating template: %v", err)
+			}
+			f, err := goparser.ParseFile(fset, name+".go", gotW.Bytes(), 0)
+			if err != nil {
+				t.Fatalf("error parsing generated code: %v", err)
+			}
+			_, err = conf.Check("testdata", fset, []*goast.File{f}, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("generated code does not compile: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("want compile error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	tests := map[string]struct {
+		literal  string
+		leading  string
+		trailing string
+	}{
+		"spaces":           {literal: "  a b  ", leading: "a b  ", trailing: "  a b"},
+		"escaped newlines": {literal: `\n\t a\n \r\n`, leading: `a\n \r\n`, trailing: `\n\t a`},
+		"escaped slash":    {literal: `\\n`, leading: `\\n`, trailing: `\\n`},
+		"escaped slash before newline": {
+			literal:  `a\\\n`,
+			leading:  `a\\\n`,
+			trailing: `a\\`,
+		},
+		"only whitespace": {literal: `\n  \f`, leading: "", trailing: ""},
+		"nbsp":            {literal: "&nbsp;", leading: "&nbsp;", trailing: "&nbsp;"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := trimLeadingSpace(tt.literal); got != tt.leading {
+				t.Errorf("trimLeadingSpace(%q) = %q, want %q", tt.literal, got, tt.leading)
+			}
+			if got := trimTrailingSpace(tt.literal); got != tt.trailing {
+				t.Errorf("trimTrailingSpace(%q) = %q, want %q", tt.literal, got, tt.trailing)
+			}
+		})
+	}
+}
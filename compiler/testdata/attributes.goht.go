@@ -9,9 +9,10 @@ import "github.com/stackus/goht"
 
 func AttributesTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "AttributesTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -51,7 +52,39 @@ func AttributesTest() goht.Template {
 		if _, __err = __buf.WriteString("<div class=\"multiline\" foo=\"bar\" fizz=\""); __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(goht.EscapeString(fizz) + "\""); __err != nil {
+		if _, __err = __buf.WriteString(goht.EscapeAttr(fizz) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("></div>\n<div class=\"nested\" data-user-id=\"1\" data-role=\"admin\"></div>\n<div class=\"aria\" aria-label=\"Close\""); __err != nil {
+			return
+		}
+		if condition {
+			if _, __err = __buf.WriteString(" aria-expanded=\"true\""); __err != nil {
+				return
+			}
+		} else {
+			if _, __err = __buf.WriteString(" aria-expanded=\"false\""); __err != nil {
+				return
+			}
+		}
+		if !condition {
+			if _, __err = __buf.WriteString(" aria-hidden=\"true\""); __err != nil {
+				return
+			}
+		} else {
+			if _, __err = __buf.WriteString(" aria-hidden=\"false\""); __err != nil {
+				return
+			}
+		}
+		if _, __err = __buf.WriteString("></div>\n<div class=\"aria\""); __err != nil {
+			return
+		}
+		var __var2 string
+		__var2, __err = goht.BuildHashAttributeList("aria", map[string]any{"expanded": true, "pressed": false})
+		if __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString("></div>\n"); __err != nil {
@@ -0,0 +1,217 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package testdata
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+func BlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "BlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<title>\n"); __err != nil {
+			return
+		}
+		if __bt := goht.GetBlock(ctx, "title"); __bt != nil {
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+		} else {
+			if _, __err = __buf.WriteString("Default"); __err != nil {
+				return
+			}
+		}
+		if _, __err = __buf.WriteString("</title>\n"); __err != nil {
+			return
+		}
+		if __err = NestedBlockTest().Render(ctx, __buf); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func NestedBlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "NestedBlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<h1>\n"); __err != nil {
+			return
+		}
+		if __bt := goht.GetBlock(ctx, "title"); __bt != nil {
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+		} else {
+			if _, __err = __buf.WriteString("Nested"); __err != nil {
+				return
+			}
+		}
+		if _, __err = __buf.WriteString("</h1>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func PageBlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PageBlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		goht.DefineBlock(ctx, "title", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("Page"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		}))
+		if __err = goht.Extend(ctx, __buf, BlockTest(), __sts...); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func OtherBlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "OtherBlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		goht.DefineBlock(ctx, "other", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("Other"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		}))
+		if __err = goht.Extend(ctx, __buf, BlockTest(), __sts...); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func ExtendedBlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ExtendedBlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		goht.DefineBlock(ctx, "title", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("Extended"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		}))
+		if __err = goht.Extend(ctx, __buf, PageBlockTest(), __sts...); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func InheritedBlockTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "InheritedBlockTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		goht.DefineBlock(ctx, "title", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("Inherited"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		}))
+		if __err = goht.Extend(ctx, __buf, OtherBlockTest(), __sts...); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -9,9 +9,10 @@ import "github.com/stackus/goht"
 
 func CommentsTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "CommentsTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
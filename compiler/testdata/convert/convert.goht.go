@@ -0,0 +1,640 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package convert
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line convert.goht:3:1
+// The templates are converted to each dialect by the tests, which check that
+// the converted templates render the same HTML.
+
+//line convert.goht:6:7
+func /*line convert.goht:6:6*/ HamlCard(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlCard", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString("<div class=\"card\" data-title=\""); __err != nil {
+			return
+		}
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line convert.goht:7:21*/ title) + "\""); __err != nil {
+			return
+		}
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString("><h2>"); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		var __var1 string
+//line convert.goht:8:8
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:8:7*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		if _, __err = __buf.WriteString("</h2>\n"); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		__buf.TrimPrecedingSpace()
+//line convert.goht:9:14
+		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:12:7
+func /*line convert.goht:12:6*/ HamlPage(title string, items []string, admin bool) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:13:5
+		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<title>"); __err != nil {
+			return
+		}
+//line convert.goht:16:12
+		var __var1 string
+//line convert.goht:16:12
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:16:11*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:16:12
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:16:12
+		if _, __err = __buf.WriteString("</title>\n<style>\n.card { color: red; }\n</style></head>\n<body id=\"page\""); __err != nil {
+			return
+		}
+//line convert.goht:19:4
+		var __var2 string
+//line convert.goht:19:4
+		__var2, __err = goht.BuildClassList("light" /*line convert.goht:19:27*/, title)
+//line convert.goht:19:4
+		if __err != nil {
+			return
+		}
+//line convert.goht:19:4
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
+			return
+		}
+//line convert.goht:19:4
+		if _, __err = __buf.WriteString(">\n<!--the list of items-->\n<ul class=\"items\">\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:23:6*/ for i, item := range items {
+//line convert.goht:24:7
+			if _, __err = __buf.WriteString("<li data-item=\""); __err != nil {
+				return
+			}
+//line convert.goht:24:7
+			if _, __err = __buf.WriteString(goht.EscapeAttr( /*line convert.goht:24:22*/ item) + "\""); __err != nil {
+				return
+			}
+//line convert.goht:24:7
+			if /*line convert.goht:24:41*/ i == 0 {
+//line convert.goht:24:7
+				if _, __err = __buf.WriteString(" selected"); __err != nil {
+					return
+				}
+//line convert.goht:24:7
+			}
+//line convert.goht:24:7
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+//line convert.goht:24:52
+			var __var3 string
+//line convert.goht:24:52
+			if __var3, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:24:51*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:24:52
+			if _, __err = __buf.WriteString(__var3); __err != nil {
+				return
+			}
+//line convert.goht:24:52
+			if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+				return
+			}
+//line convert.goht:24:52
+		}
+//line convert.goht:24:52
+		if _, __err = __buf.WriteString("</ul>\n<p>\nHello, "); __err != nil {
+			return
+		}
+//line convert.goht:26:14
+		var __var4 string
+//line convert.goht:26:14
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:26:13*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:26:14
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+//line convert.goht:26:20
+		if _, __err = __buf.WriteString("!<b>world</b>= not code\n</p>\n<p><a href=\"/about\">About</a>\n<span>and more</span></p>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:32:5*/ if admin {
+//line convert.goht:33:8
+			var __var5 string
+//line convert.goht:33:8
+			if __var5, __err = goht.CaptureErrors( /*line convert.goht:33:7*/ "<em>admin</em>"); __err != nil {
+				return
+			}
+//line convert.goht:33:8
+			if _, __err = __buf.WriteString(__var5); __err != nil {
+				return
+			}
+//line convert.goht:34:6
+		} else {
+//line convert.goht:35:6
+			if _, __err = __buf.WriteString("<p class=\"guest\">Guest</p>\n"); __err != nil {
+				return
+			}
+//line convert.goht:35:14
+		}
+//line convert.goht:36:14
+		__var6 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line convert.goht:36:14
+			__buf, __isBuf := __w.(goht.Buffer)
+//line convert.goht:36:14
+			if !__isBuf {
+//line convert.goht:36:14
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line convert.goht:36:14
+				defer goht.ReleaseBuffer(__buf)
+//line convert.goht:36:14
+			}
+//line convert.goht:37:6
+			if _, __err = __buf.WriteString("<p>"); __err != nil {
+				return
+			}
+//line convert.goht:37:10
+			__buf.TrimFollowingSpace()
+//line convert.goht:37:10
+			var __var7 string
+//line convert.goht:37:10
+			if __var7, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:37:9*/ "inside")); __err != nil {
+				return
+			}
+//line convert.goht:37:10
+			if _, __err = __buf.WriteString(__var7); __err != nil {
+				return
+			}
+//line convert.goht:37:10
+			__buf.TrimPrecedingSpace()
+//line convert.goht:37:10
+			if _, __err = __buf.WriteString("</p>\n"); __err != nil {
+				return
+			}
+//line convert.goht:37:10
+			if !__isBuf {
+//line convert.goht:37:10
+				_, __err = io.Copy(__w, __buf)
+//line convert.goht:37:10
+			}
+//line convert.goht:37:10
+			return
+//line convert.goht:37:10
+		})
+//line convert.goht:37:10
+		if __err = /*line convert.goht:36:13*/ HamlCard(title).Render(goht.PushChildren(ctx, __var6), __buf, __sts...); __err != nil {
+			return
+		}
+//line convert.goht:38:5
+		if _, __err = __buf.WriteString("<br><p>Some <em>markdown</em> for "); __err != nil {
+			return
+		}
+//line convert.goht:40:27
+		var __var8 string
+//line convert.goht:40:27
+		if __var8, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:40:26*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:40:27
+		if _, __err = __buf.WriteString(__var8); __err != nil {
+			return
+		}
+//line convert.goht:39:5
+		if _, __err = __buf.WriteString(".</p>\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line convert.goht:39:5
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:43:7
+func /*line convert.goht:43:6*/ HamlNav(title string, links []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlNav", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:44:3
+		if _, __err = __buf.WriteString("<nav class=\"menu\"><ul>"); __err != nil {
+			return
+		}
+//line convert.goht:46:6
+		__buf.TrimFollowingSpace()
+		/*line convert.goht:46:5*/ for _, link := range links {
+//line convert.goht:47:6
+			__buf.TrimPrecedingSpace()
+//line convert.goht:47:6
+			if _, __err = __buf.WriteString("<li><a href=\""); __err != nil {
+				return
+			}
+//line convert.goht:48:7
+			if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL( /*line convert.goht:48:16*/ link)) + "\""); __err != nil {
+				return
+			}
+//line convert.goht:48:7
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+//line convert.goht:48:25
+			var __var1 string
+//line convert.goht:48:25
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:48:24*/ link)); __err != nil {
+				return
+			}
+//line convert.goht:48:25
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line convert.goht:48:25
+			if _, __err = __buf.WriteString("</a></li>"); __err != nil {
+				return
+			}
+//line convert.goht:48:25
+			__buf.TrimFollowingSpace()
+//line convert.goht:48:25
+		}
+//line convert.goht:48:25
+		__buf.TrimPrecedingSpace()
+//line convert.goht:48:25
+		if _, __err = __buf.WriteString("</ul><p>Hello, "); __err != nil {
+			return
+		}
+//line convert.goht:49:16
+		var __var2 string
+//line convert.goht:49:16
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:49:15*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:49:16
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line convert.goht:49:22
+		if _, __err = __buf.WriteString("!</p></nav>\n"); __err != nil {
+			return
+		}
+//line convert.goht:49:22
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:52:7
+func /*line convert.goht:52:6*/ SlimPage(title string, items []string, kind int) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:53:2
+		if _, __err = __buf.WriteString("<html><body class=\"dark\"><ul><li><a href=\"/\">Home</a></li></ul><p>Hello, <b>world</b>! This issome text with "); __err != nil {
+			return
+		}
+//line convert.goht:62:19
+		var __var1 string
+//line convert.goht:62:19
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:62:18*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:62:19
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:62:25
+		if _, __err = __buf.WriteString(".</p>"); __err != nil {
+			return
+		}
+		/*line convert.goht:63:5*/ switch kind {
+		/*line convert.goht:64:6*/ case 1:
+//line convert.goht:65:6
+			if _, __err = __buf.WriteString("<span class=\"one\">one</span>"); __err != nil {
+				return
+			}
+			/*line convert.goht:66:6*/
+		default:
+//line convert.goht:67:6
+			if _, __err = __buf.WriteString("<span>other</span>"); __err != nil {
+				return
+			}
+//line convert.goht:67:11
+		}
+//line convert.goht:68:4
+		if _, __err = __buf.WriteString("<ol>"); __err != nil {
+			return
+		}
+		/*line convert.goht:69:6*/ for _, item := range items {
+//line convert.goht:70:6
+			if _, __err = __buf.WriteString("<li>"); __err != nil {
+				return
+			}
+//line convert.goht:70:10
+			var __var2 string
+//line convert.goht:70:10
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:70:9*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:70:10
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line convert.goht:70:10
+			if _, __err = __buf.WriteString("</li>"); __err != nil {
+				return
+			}
+//line convert.goht:70:10
+		}
+//line convert.goht:70:10
+		if _, __err = __buf.WriteString("</ol><p>"); __err != nil {
+			return
+		}
+//line convert.goht:71:8
+		var __var3 string
+//line convert.goht:71:8
+		if __var3, __err = goht.CaptureErrors( /*line convert.goht:71:7*/ "<i>raw</i>"); __err != nil {
+			return
+		}
+//line convert.goht:71:8
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line convert.goht:71:8
+		if _, __err = __buf.WriteString("</p><!--an html comment--><input type=\"text\" value=\""); __err != nil {
+			return
+		}
+//line convert.goht:73:4
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line convert.goht:73:29*/ title) + "\""); __err != nil {
+			return
+		}
+//line convert.goht:73:4
+		if _, __err = __buf.WriteString("><textarea>abc</textarea></body></html>\n"); __err != nil {
+			return
+		}
+//line convert.goht:75:7
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:78:6
+func /*line convert.goht:78:5*/ EgoPage(title string, items []string, admin bool) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:79:2
+		if _, __err = __buf.WriteString("<ul class=\"list\">\n\t"); __err != nil {
+			return
+		}
+		/*line convert.goht:80:5*/ for _, item := range items {
+//line convert.goht:80:37
+			if _, __err = __buf.WriteString("\n\t\t<li>"); __err != nil {
+				return
+			}
+//line convert.goht:81:12
+			var __var1 string
+//line convert.goht:81:12
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:81:11*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:81:12
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line convert.goht:81:19
+			if _, __err = __buf.WriteString("</li>\n\t"); __err != nil {
+				return
+			}
+			/*line convert.goht:82:5*/
+		}
+//line convert.goht:82:10
+		if _, __err = __buf.WriteString("\n</ul>\n\n<p>Hello "); __err != nil {
+			return
+		}
+//line convert.goht:85:15
+		var __var2 string
+//line convert.goht:85:15
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:85:14*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:85:15
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line convert.goht:85:23
+		if _, __err = __buf.WriteString(", <b>bold</b> and <i>italic</i>!</p>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:86:4*/ if admin {
+//line convert.goht:86:18
+			if _, __err = __buf.WriteString("\n\t<span class=\"admin\">yes</span>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:88:4*/
+		} else {
+//line convert.goht:88:16
+			if _, __err = __buf.WriteString("\n\t<span>no</span>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:90:4*/
+		}
+//line convert.goht:90:9
+		if _, __err = __buf.WriteString("\n<input type=\"checkbox\""); __err != nil {
+			return
+		}
+		/*line convert.goht:91:26*/ if admin {
+//line convert.goht:91:40
+			if _, __err = __buf.WriteString(" checked"); __err != nil {
+				return
+			}
+			/*line convert.goht:91:50*/
+		}
+//line convert.goht:91:55
+		if _, __err = __buf.WriteString(">\n<a href=\"/items?a=1&amp;b=2\" title=\""); __err != nil {
+			return
+		}
+//line convert.goht:92:42
+		var __var3 string
+//line convert.goht:92:42
+		if __var3, __err = goht.CaptureErrors(goht.EscapeAttr( /*line convert.goht:92:41*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:92:42
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line convert.goht:92:50
+		if _, __err = __buf.WriteString("\">link</a>\n<div><span>a</span><span>b</span> <em>c</em></div>\n<script>\n\tvar title = \""); __err != nil {
+			return
+		}
+//line convert.goht:95:20
+		var __var4 string
+//line convert.goht:95:20
+		if __var4, __err = goht.CaptureErrors(goht.EscapeJSString( /*line convert.goht:95:19*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:95:20
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+//line convert.goht:95:28
+		if _, __err = __buf.WriteString("\";\n</script>\n"); __err != nil {
+			return
+		}
+//line convert.goht:97:6
+		var __var5 string
+//line convert.goht:97:6
+		if __var5, __err = goht.CaptureErrors( /*line convert.goht:97:5*/ "<hr>"); __err != nil {
+			return
+		}
+//line convert.goht:97:6
+		if _, __err = __buf.WriteString(__var5); __err != nil {
+			return
+		}
+//line convert.goht:97:15
+		if _, __err = __buf.WriteString("\n"); __err != nil {
+			return
+		}
+//line convert.goht:97:15
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:100:6
+func /*line convert.goht:100:5*/ EgoList(title string, items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoList", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:101:2
+		if _, __err = __buf.WriteString("<p>Hello "); __err != nil {
+			return
+		}
+//line convert.goht:101:15
+		var __var1 string
+//line convert.goht:101:15
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:101:14*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:101:15
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:101:23
+		if _, __err = __buf.WriteString(", <b>bold</b> and <i>italic</i>!</p>\n<ul>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:103:4*/ for _, item := range items {
+//line convert.goht:104:2
+			if _, __err = __buf.WriteString("<li>"); __err != nil {
+				return
+			}
+//line convert.goht:104:10
+			var __var2 string
+//line convert.goht:104:10
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:104:9*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:104:10
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line convert.goht:104:17
+			if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:105:4*/
+		}
+//line convert.goht:106:2
+		if _, __err = __buf.WriteString("</ul>\n"); __err != nil {
+			return
+		}
+//line convert.goht:106:2
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
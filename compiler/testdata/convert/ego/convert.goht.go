@@ -0,0 +1,647 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package ego
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line convert.goht:3:1
+// The templates are converted to each dialect by the tests, which check that
+// the converted templates render the same HTML.
+
+//line convert.goht:6:7
+func /*line convert.goht:6:6*/ HamlCard(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlCard", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString("<div class=\"card\" data-title=\""); __err != nil {
+			return
+		}
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line convert.goht:7:21*/ title) + "\""); __err != nil {
+			return
+		}
+//line convert.goht:7:3
+		if _, __err = __buf.WriteString("><h2>"); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		var __var1 string
+//line convert.goht:8:8
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:8:7*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:8:8
+		if _, __err = __buf.WriteString("</h2>\n"); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		__buf.TrimPrecedingSpace()
+//line convert.goht:9:14
+		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
+			return
+		}
+//line convert.goht:9:14
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:12:6
+func /*line convert.goht:12:5*/ HamlPage(title string, items []string, admin bool) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:13:2
+		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<title>"); __err != nil {
+			return
+		}
+//line convert.goht:16:13
+		var __var1 string
+//line convert.goht:16:13
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:16:12*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:16:13
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:16:21
+		if _, __err = __buf.WriteString("</title>\n<style>\n.card { color: red; }\n</style></head>\n<body id=\"page\" class=\""); __err != nil {
+			return
+		}
+//line convert.goht:20:29
+		var __var2 string
+//line convert.goht:20:29
+		if __var2, __err = goht.CaptureErrors( /*line convert.goht:20:28*/ goht.CaptureErrors(goht.BuildClassList("light", title))); __err != nil {
+			return
+		}
+//line convert.goht:20:29
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line convert.goht:20:87
+		if _, __err = __buf.WriteString("\">\n<!--the list of items-->\n<ul class=\"items\">\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:24:4*/ for i, item := range items {
+//line convert.goht:25:2
+			if _, __err = __buf.WriteString("<li data-item=\""); __err != nil {
+				return
+			}
+//line convert.goht:25:21
+			var __var3 string
+//line convert.goht:25:21
+			if __var3, __err = goht.CaptureErrors(goht.EscapeAttr( /*line convert.goht:25:20*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:25:21
+			if _, __err = __buf.WriteString(__var3); __err != nil {
+				return
+			}
+//line convert.goht:25:28
+			if _, __err = __buf.WriteString("\""); __err != nil {
+				return
+			}
+			/*line convert.goht:25:31*/ if i == 0 {
+//line convert.goht:25:46
+				if _, __err = __buf.WriteString(" selected"); __err != nil {
+					return
+				}
+				/*line convert.goht:25:57*/
+			}
+//line convert.goht:25:62
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+//line convert.goht:25:67
+			var __var4 string
+//line convert.goht:25:67
+			if __var4, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:25:66*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:25:67
+			if _, __err = __buf.WriteString(__var4); __err != nil {
+				return
+			}
+//line convert.goht:25:74
+			if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:26:4*/
+		}
+//line convert.goht:27:2
+		if _, __err = __buf.WriteString("</ul>\n<p>\nHello, "); __err != nil {
+			return
+		}
+//line convert.goht:29:13
+		var __var5 string
+//line convert.goht:29:13
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:29:12*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:29:13
+		if _, __err = __buf.WriteString(__var5); __err != nil {
+			return
+		}
+//line convert.goht:29:21
+		if _, __err = __buf.WriteString("!<b>world</b>= not code\n</p>\n<p><a href=\"/about\">About</a>\n<span>and more</span></p>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:33:4*/ if admin {
+//line convert.goht:34:6
+			var __var6 string
+//line convert.goht:34:6
+			if __var6, __err = goht.CaptureErrors( /*line convert.goht:34:5*/ "<em>admin</em>"); __err != nil {
+				return
+			}
+//line convert.goht:34:6
+			if _, __err = __buf.WriteString(__var6); __err != nil {
+				return
+			}
+			/*line convert.goht:34:27*/
+		} else {
+//line convert.goht:34:39
+			if _, __err = __buf.WriteString("<p class=\"guest\">Guest</p>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:35:4*/
+		}
+//line convert.goht:36:12
+		__var7 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line convert.goht:36:12
+			__buf, __isBuf := __w.(goht.Buffer)
+//line convert.goht:36:12
+			if !__isBuf {
+//line convert.goht:36:12
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line convert.goht:36:12
+				defer goht.ReleaseBuffer(__buf)
+//line convert.goht:36:12
+			}
+//line convert.goht:36:32
+			if _, __err = __buf.WriteString("<p>"); __err != nil {
+				return
+			}
+//line convert.goht:36:39
+			var __var8 string
+//line convert.goht:36:39
+			if __var8, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:36:38*/ "inside")); __err != nil {
+				return
+			}
+//line convert.goht:36:39
+			if _, __err = __buf.WriteString(__var8); __err != nil {
+				return
+			}
+//line convert.goht:36:50
+			if _, __err = __buf.WriteString("</p>\n"); __err != nil {
+				return
+			}
+//line convert.goht:36:50
+			if !__isBuf {
+//line convert.goht:36:50
+				_, __err = io.Copy(__w, __buf)
+//line convert.goht:36:50
+			}
+//line convert.goht:36:50
+			return
+//line convert.goht:36:50
+		})
+//line convert.goht:36:50
+		if __err = /*line convert.goht:36:11*/ HamlCard(title).Render(goht.PushChildren(ctx, __var7), __buf, __sts...); __err != nil {
+			return
+		}
+//line convert.goht:38:2
+		if _, __err = __buf.WriteString("<br><p>Some <em>markdown</em> for "); __err != nil {
+			return
+		}
+//line convert.goht:38:40
+		var __var9 string
+//line convert.goht:38:40
+		if __var9, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:38:39*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:38:40
+		if _, __err = __buf.WriteString(__var9); __err != nil {
+			return
+		}
+//line convert.goht:38:48
+		if _, __err = __buf.WriteString(".</p>\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line convert.goht:38:48
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:43:6
+func /*line convert.goht:43:5*/ HamlNav(title string, links []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlNav", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:44:2
+		if _, __err = __buf.WriteString("<nav class=\"menu\"><ul>"); __err != nil {
+			return
+		}
+		/*line convert.goht:44:26*/ for _, link := range links {
+//line convert.goht:44:58
+			if _, __err = __buf.WriteString("<li><a href=\""); __err != nil {
+				return
+			}
+//line convert.goht:44:75
+			var __var1 string
+//line convert.goht:44:75
+			if __var1, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeURL( /*line convert.goht:44:74*/ link))); __err != nil {
+				return
+			}
+//line convert.goht:44:75
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line convert.goht:44:82
+			if _, __err = __buf.WriteString("\">"); __err != nil {
+				return
+			}
+//line convert.goht:44:88
+			var __var2 string
+//line convert.goht:44:88
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:44:87*/ link)); __err != nil {
+				return
+			}
+//line convert.goht:44:88
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line convert.goht:44:95
+			if _, __err = __buf.WriteString("</a></li>"); __err != nil {
+				return
+			}
+			/*line convert.goht:44:106*/
+		}
+//line convert.goht:44:111
+		if _, __err = __buf.WriteString("</ul><p>Hello, "); __err != nil {
+			return
+		}
+//line convert.goht:44:130
+		var __var3 string
+//line convert.goht:44:130
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:44:129*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:44:130
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line convert.goht:44:138
+		if _, __err = __buf.WriteString("!</p></nav>\n"); __err != nil {
+			return
+		}
+//line convert.goht:44:138
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:47:6
+func /*line convert.goht:47:5*/ SlimPage(title string, items []string, kind int) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:48:2
+		if _, __err = __buf.WriteString("<html><body class=\"dark\"><ul><li><a href=\"/\">Home</a></li></ul><p>Hello, <b>world</b>! This issome text with "); __err != nil {
+			return
+		}
+//line convert.goht:48:143
+		var __var1 string
+//line convert.goht:48:143
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:48:142*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:48:143
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:48:151
+		if _, __err = __buf.WriteString(".</p>"); __err != nil {
+			return
+		}
+		/*line convert.goht:48:158*/ switch kind {
+		/*line convert.goht:48:177*/ case 1:
+//line convert.goht:48:188
+			if _, __err = __buf.WriteString("<span class=\"one\">one</span>"); __err != nil {
+				return
+			}
+			/*line convert.goht:48:218*/
+		default:
+//line convert.goht:48:230
+			if _, __err = __buf.WriteString("<span>other</span>"); __err != nil {
+				return
+			}
+			/*line convert.goht:48:250*/
+		}
+//line convert.goht:48:255
+		if _, __err = __buf.WriteString("<ol>"); __err != nil {
+			return
+		}
+		/*line convert.goht:48:261*/ for _, item := range items {
+//line convert.goht:48:293
+			if _, __err = __buf.WriteString("<li>"); __err != nil {
+				return
+			}
+//line convert.goht:48:301
+			var __var2 string
+//line convert.goht:48:301
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:48:300*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:48:301
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line convert.goht:48:308
+			if _, __err = __buf.WriteString("</li>"); __err != nil {
+				return
+			}
+			/*line convert.goht:48:315*/
+		}
+//line convert.goht:48:320
+		if _, __err = __buf.WriteString("</ol><p>"); __err != nil {
+			return
+		}
+//line convert.goht:48:332
+		var __var3 string
+//line convert.goht:48:332
+		if __var3, __err = goht.CaptureErrors( /*line convert.goht:48:331*/ "<i>raw</i>"); __err != nil {
+			return
+		}
+//line convert.goht:48:332
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line convert.goht:48:347
+		if _, __err = __buf.WriteString("</p><!--an html comment--><input type=\"text\" value=\""); __err != nil {
+			return
+		}
+//line convert.goht:48:403
+		var __var4 string
+//line convert.goht:48:403
+		if __var4, __err = goht.CaptureErrors(goht.EscapeAttr( /*line convert.goht:48:402*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:48:403
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+//line convert.goht:48:411
+		if _, __err = __buf.WriteString("\"><textarea>abc</textarea></body></html>\n"); __err != nil {
+			return
+		}
+//line convert.goht:48:411
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:51:6
+func /*line convert.goht:51:5*/ EgoPage(title string, items []string, admin bool) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:52:2
+		if _, __err = __buf.WriteString("<ul class=\"list\">\n\t"); __err != nil {
+			return
+		}
+		/*line convert.goht:53:5*/ for _, item := range items {
+//line convert.goht:53:37
+			if _, __err = __buf.WriteString("\n\t\t<li>"); __err != nil {
+				return
+			}
+//line convert.goht:54:12
+			var __var1 string
+//line convert.goht:54:12
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:54:11*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:54:12
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line convert.goht:54:19
+			if _, __err = __buf.WriteString("</li>\n\t"); __err != nil {
+				return
+			}
+			/*line convert.goht:55:5*/
+		}
+//line convert.goht:55:10
+		if _, __err = __buf.WriteString("\n</ul>\n\n<p>Hello "); __err != nil {
+			return
+		}
+//line convert.goht:58:15
+		var __var2 string
+//line convert.goht:58:15
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:58:14*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:58:15
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line convert.goht:58:23
+		if _, __err = __buf.WriteString(", <b>bold</b> and <i>italic</i>!</p>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:59:4*/ if admin {
+//line convert.goht:59:18
+			if _, __err = __buf.WriteString("\n\t<span class=\"admin\">yes</span>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:61:4*/
+		} else {
+//line convert.goht:61:16
+			if _, __err = __buf.WriteString("\n\t<span>no</span>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:63:4*/
+		}
+//line convert.goht:63:9
+		if _, __err = __buf.WriteString("\n<input type=\"checkbox\""); __err != nil {
+			return
+		}
+		/*line convert.goht:64:26*/ if admin {
+//line convert.goht:64:40
+			if _, __err = __buf.WriteString(" checked"); __err != nil {
+				return
+			}
+			/*line convert.goht:64:50*/
+		}
+//line convert.goht:64:55
+		if _, __err = __buf.WriteString(">\n<a href=\"/items?a=1&amp;b=2\" title=\""); __err != nil {
+			return
+		}
+//line convert.goht:65:42
+		var __var3 string
+//line convert.goht:65:42
+		if __var3, __err = goht.CaptureErrors(goht.EscapeAttr( /*line convert.goht:65:41*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:65:42
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line convert.goht:65:50
+		if _, __err = __buf.WriteString("\">link</a>\n<div><span>a</span><span>b</span> <em>c</em></div>\n<script>\n\tvar title = \""); __err != nil {
+			return
+		}
+//line convert.goht:68:20
+		var __var4 string
+//line convert.goht:68:20
+		if __var4, __err = goht.CaptureErrors(goht.EscapeJSString( /*line convert.goht:68:19*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:68:20
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+//line convert.goht:68:28
+		if _, __err = __buf.WriteString("\";\n</script>\n"); __err != nil {
+			return
+		}
+//line convert.goht:70:6
+		var __var5 string
+//line convert.goht:70:6
+		if __var5, __err = goht.CaptureErrors( /*line convert.goht:70:5*/ "<hr>"); __err != nil {
+			return
+		}
+//line convert.goht:70:6
+		if _, __err = __buf.WriteString(__var5); __err != nil {
+			return
+		}
+//line convert.goht:70:15
+		if _, __err = __buf.WriteString("\n"); __err != nil {
+			return
+		}
+//line convert.goht:70:15
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line convert.goht:73:6
+func /*line convert.goht:73:5*/ EgoList(title string, items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoList", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line convert.goht:74:2
+		if _, __err = __buf.WriteString("<p>Hello "); __err != nil {
+			return
+		}
+//line convert.goht:74:15
+		var __var1 string
+//line convert.goht:74:15
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:74:14*/ title)); __err != nil {
+			return
+		}
+//line convert.goht:74:15
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line convert.goht:74:23
+		if _, __err = __buf.WriteString(", <b>bold</b> and <i>italic</i>!</p>\n<ul>\n"); __err != nil {
+			return
+		}
+		/*line convert.goht:76:4*/ for _, item := range items {
+//line convert.goht:77:2
+			if _, __err = __buf.WriteString("<li>"); __err != nil {
+				return
+			}
+//line convert.goht:77:10
+			var __var2 string
+//line convert.goht:77:10
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line convert.goht:77:9*/ item)); __err != nil {
+				return
+			}
+//line convert.goht:77:10
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line convert.goht:77:17
+			if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+				return
+			}
+			/*line convert.goht:78:4*/
+		}
+//line convert.goht:79:2
+		if _, __err = __buf.WriteString("</ul>\n"); __err != nil {
+			return
+		}
+//line convert.goht:79:2
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -0,0 +1,603 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package testdata
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+func EscapingTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EscapingTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		text := `<b>"bold" & 'quoted'</b>`
+		link := "javascript:alert(1)"
+		query := "a b&c=d"
+		color := "red; background: url(javascript:alert(1))"
+		if _, __err = __buf.WriteString("<p>"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</p>\n<a href=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL(link)) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Link</a>\n<a href=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL("https://example.com/?q="+query)) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Search</a>\n<img src=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL("/images/a b.png")) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("><button onclick=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeJS(text)) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Click</button>\n<div style=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeCSS(color)) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Styled</div>\n<div data-url=\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL(link)) + "\""); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Data</div>\n<script>\nvar text = \""); __err != nil {
+			return
+		}
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.EscapeJSString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\";\nvar value = "); __err != nil {
+			return
+		}
+		var __var3 string
+		if __var3, __err = goht.CaptureErrors(goht.EscapeJS(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n// don't change the context\nvar after = "); __err != nil {
+			return
+		}
+		var __var4 string
+		if __var4, __err = goht.CaptureErrors(goht.EscapeJS(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\nvar greeting = `hi "); __err != nil {
+			return
+		}
+		var __var5 string
+		if __var5, __err = goht.CaptureErrors(goht.EscapeJSString("${alert(1)}")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var5); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("`;\nvar nested = `a ${b + `c ${d}`} "); __err != nil {
+			return
+		}
+		var __var6 string
+		if __var6, __err = goht.CaptureErrors(goht.EscapeJSString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var6); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("`;\nvar re = /'/, after = "); __err != nil {
+			return
+		}
+		var __var7 string
+		if __var7, __err = goht.CaptureErrors(goht.EscapeJS("1;alert(2)")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var7); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(" / 2;\nvar kw = typeof /\"[/]/;\nvar bar = "); __err != nil {
+			return
+		}
+		var __var8 string
+		if __var8, __err = goht.CaptureErrors(goht.EscapeJS("1;alert(3)")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var8); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n</script><style>\n.color {\n\tcolor: "); __err != nil {
+			return
+		}
+		var __var9 string
+		if __var9, __err = goht.CaptureErrors(goht.EscapeCSS(color)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var9); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n}\n</style>"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func EgoEscapingTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoEscapingTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		text := `<b>"bold" & 'quoted'</b>`
+		link := "javascript:alert(1)"
+		if _, __err = __buf.WriteString("<a href=\""); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeURL(link))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\" title=\""); __err != nil {
+			return
+		}
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.EscapeAttr(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\">Link</a>\n<a href=\"/search?q="); __err != nil {
+			return
+		}
+		var __var3 string
+		if __var3, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeURLPart("a b&c=d"))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\">Search</a>\n"); __err != nil {
+			return
+		}
+		v := "x onmouseover=alert(1)"
+		if _, __err = __buf.WriteString("<a title="); __err != nil {
+			return
+		}
+		var __var4 string
+		if __var4, __err = goht.CaptureErrors(goht.EscapeUnquotedAttr(v)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Unquoted</a>\n<a href=/items/"); __err != nil {
+			return
+		}
+		var __var5 string
+		if __var5, __err = goht.CaptureErrors(goht.EscapeUnquotedAttr(goht.EscapeURLPart(v))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var5); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(" class="); __err != nil {
+			return
+		}
+		var __var6 string
+		if __var6, __err = goht.CaptureErrors(goht.EscapeUnquotedAttr("")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var6); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Unquoted</a>\n"); __err != nil {
+			return
+		}
+		name := "onmouseover=alert(1) x"
+		if _, __err = __buf.WriteString("<div "); __err != nil {
+			return
+		}
+		var __var7 string
+		if __var7, __err = goht.CaptureErrors(goht.EscapeAttrName("", name)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var7); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Attribute</div>\n<div data-"); __err != nil {
+			return
+		}
+		var __var8 string
+		if __var8, __err = goht.CaptureErrors(goht.EscapeAttrName("data-", name)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var8); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("=\"1\">Data</div>\n<"); __err != nil {
+			return
+		}
+		var __var9 string
+		if __var9, __err = goht.CaptureErrors(goht.EscapeTagName("", name)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var9); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">Tag</"); __err != nil {
+			return
+		}
+		var __var10 string
+		if __var10, __err = goht.CaptureErrors(goht.EscapeTagName("", name)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var10); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">\n<"); __err != nil {
+			return
+		}
+		var __var11 string
+		if __var11, __err = goht.CaptureErrors(goht.EscapeTagName("", "script")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var11); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">alert(1)</"); __err != nil {
+			return
+		}
+		var __var12 string
+		if __var12, __err = goht.CaptureErrors(goht.EscapeTagName("", "script")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var12); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">\n<div "); __err != nil {
+			return
+		}
+		var __var13 string
+		if __var13, __err = goht.CaptureErrors(goht.EscapeAttrName("", "onclick")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var13); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("=\"alert(1)\" data-"); __err != nil {
+			return
+		}
+		var __var14 string
+		if __var14, __err = goht.CaptureErrors(goht.EscapeAttrName("data-", "href")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var14); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("=\"/\">Changed</div>\n<h"); __err != nil {
+			return
+		}
+		var __var15 string
+		if __var15, __err = goht.CaptureErrors(goht.EscapeTagName("h", "2")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var15); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(" "); __err != nil {
+			return
+		}
+		var __var16 string
+		if __var16, __err = goht.CaptureErrors(goht.EscapeAttrName("", "hidden")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var16); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(" data-"); __err != nil {
+			return
+		}
+		var __var17 string
+		if __var17, __err = goht.CaptureErrors(goht.EscapeAttrName("data-", "id")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var17); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("=\"1\" on"); __err != nil {
+			return
+		}
+		var __var18 string
+		if __var18, __err = goht.CaptureErrors(goht.EscapeAttrName("on", "click")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var18); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("=\"handle("); __err != nil {
+			return
+		}
+		var __var19 string
+		if __var19, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeJS(text))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var19); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(")\">Allowed</h"); __err != nil {
+			return
+		}
+		var __var20 string
+		if __var20, __err = goht.CaptureErrors(goht.EscapeTagName("h", "2")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var20); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(">\n<button onclick=\"handle("); __err != nil {
+			return
+		}
+		var __var21 string
+		if __var21, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeJS(text))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var21); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(")\">Click</button>\n<button onclick=\"handle('"); __err != nil {
+			return
+		}
+		var __var22 string
+		if __var22, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeJSString(text))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var22); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("')\">Click</button>\n<div style=\"color: "); __err != nil {
+			return
+		}
+		var __var23 string
+		if __var23, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeCSS("red"))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var23); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\">Styled</div>\n<script>\n\tvar text = '"); __err != nil {
+			return
+		}
+		var __var24 string
+		if __var24, __err = goht.CaptureErrors(goht.EscapeJSString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var24); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("';\n\tvar value = "); __err != nil {
+			return
+		}
+		var __var25 string
+		if __var25, __err = goht.CaptureErrors(goht.EscapeJS(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var25); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n\tvar greeting = `hi "); __err != nil {
+			return
+		}
+		var __var26 string
+		if __var26, __err = goht.CaptureErrors(goht.EscapeJSString("${alert(1)}")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var26); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("`;\n\tvar re = /'/; var b = "); __err != nil {
+			return
+		}
+		var __var27 string
+		if __var27, __err = goht.CaptureErrors(goht.EscapeJS("1;alert(2)")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var27); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n\tvar ratio = b / 2, other = '"); __err != nil {
+			return
+		}
+		var __var28 string
+		if __var28, __err = goht.CaptureErrors(goht.EscapeJSString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var28); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("';\n</script>\n<style>\n\t.color { color: "); __err != nil {
+			return
+		}
+		var __var29 string
+		if __var29, __err = goht.CaptureErrors(goht.EscapeCSS("expression(alert(1))")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var29); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("; }\n</style>\n<p>"); __err != nil {
+			return
+		}
+		var __var30 string
+		if __var30, __err = goht.CaptureErrors(goht.EscapeString(text)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var30); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func JsonTest(data map[string]string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "JsonTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<script type=\"application/json\">"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.JSON(data)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</script><script type=\"application/json\" id=\"settings\">\n"); __err != nil {
+			return
+		}
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.JSON(data["theme"])); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</script>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func SlimJsonTest(data map[string]string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimJsonTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<script type=\"application/json\">"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.JSON(data)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</script>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func EgoJsonTest(data map[string]string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoJsonTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<script type=\"application/json\">"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.JSON(data)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</script>\n<script>\n\tvar theme = "); __err != nil {
+			return
+		}
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.JSON(data["theme"])); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(";\n</script>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
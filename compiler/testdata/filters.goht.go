@@ -9,9 +9,10 @@ import "github.com/stackus/goht"
 
 func FiltersTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "FiltersTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -62,7 +63,7 @@ func FiltersTest() goht.Template {
 			return
 		}
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(str)); __err != nil {
+		if __var5, __err = goht.CaptureErrors(goht.EscapeJSString(str)); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString(__var5); __err != nil {
@@ -72,7 +73,7 @@ func FiltersTest() goht.Template {
 			return
 		}
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(str)); __err != nil {
+		if __var6, __err = goht.CaptureErrors(goht.EscapeJSString(str)); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString(__var6); __err != nil {
@@ -86,7 +87,7 @@ func FiltersTest() goht.Template {
 			return
 		}
 		var __var7 string
-		if __var7, __err = goht.CaptureErrors(goht.EscapeString(color)); __err != nil {
+		if __var7, __err = goht.CaptureErrors(goht.EscapeCSS(color)); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString(__var7); __err != nil {
@@ -101,3 +102,75 @@ func FiltersTest() goht.Template {
 		return
 	})
 }
+
+func MarkdownFilterTest(title, url string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "MarkdownFilterTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<article>\n<h1>"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(title)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</h1>\n<p>Some <em>emphasis</em> and <strong>strong</strong> text with <code>code</code>.</p>\n<ul>\n<li><a href=\""); __err != nil {
+			return
+		}
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeURL(url))); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\">A link</a></li>\n<li>Item two</li>\n</ul>\n</article>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func SlimMarkdownFilterTest(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimMarkdownFilterTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<div><blockquote>\n<p>"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(title)); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</p>\n</blockquote>\n</div>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -0,0 +1,91 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package imported
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+// TODO: replace the type of data with the type of the data of the "ego_page" template.
+//
+//line ego.goht:3:1
+//line ego.goht:4:6
+func /*line ego.goht:4:5*/ EgoPage(data string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line ego.goht:5:2
+		if _, __err = __buf.WriteString("<button onclick=\"go("); __err != nil {
+			return
+		}
+//line ego.goht:5:26
+		var __var1 string
+//line ego.goht:5:26
+		if __var1, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeJS( /*line ego.goht:5:25*/ data))); __err != nil {
+			return
+		}
+//line ego.goht:5:26
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line ego.goht:5:33
+		if _, __err = __buf.WriteString(")\">Go</button>\n<a href=\"#\" onclick=\"say('"); __err != nil {
+			return
+		}
+//line ego.goht:6:32
+		var __var2 string
+//line ego.goht:6:32
+		if __var2, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeJSString( /*line ego.goht:6:31*/ data))); __err != nil {
+			return
+		}
+//line ego.goht:6:32
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line ego.goht:6:39
+		if _, __err = __buf.WriteString("', /[\\/']/); return false\" title=\""); __err != nil {
+			return
+		}
+//line ego.goht:6:77
+		var __var3 string
+//line ego.goht:6:77
+		if __var3, __err = goht.CaptureErrors(goht.EscapeAttr( /*line ego.goht:6:76*/ data)); __err != nil {
+			return
+		}
+//line ego.goht:6:77
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line ego.goht:6:84
+		if _, __err = __buf.WriteString("\">"); __err != nil {
+			return
+		}
+//line ego.goht:6:90
+		var __var4 string
+//line ego.goht:6:90
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString( /*line ego.goht:6:89*/ data)); __err != nil {
+			return
+		}
+//line ego.goht:6:90
+		if _, __err = __buf.WriteString(__var4); __err != nil {
+			return
+		}
+//line ego.goht:6:97
+		if _, __err = __buf.WriteString("</a>\n"); __err != nil {
+			return
+		}
+//line ego.goht:6:97
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -0,0 +1,73 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package imported
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+// TODO: replace the type of data with the type of the data of the "haml_page" template.
+//
+//line haml.goht:3:1
+//line haml.goht:4:7
+func /*line haml.goht:4:6*/ HamlPage(data string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line haml.goht:5:3
+		if _, __err = __buf.WriteString("<button onclick=\""); __err != nil {
+			return
+		}
+//line haml.goht:5:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeJS( /*line haml.goht:5:20*/ goht.JS("go("+goht.EscapeJS(data)+")"))) + "\""); __err != nil {
+			return
+		}
+//line haml.goht:5:3
+		if _, __err = __buf.WriteString(">Go</button>\n<a href=\"#\" onclick=\""); __err != nil {
+			return
+		}
+//line haml.goht:6:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeJS( /*line haml.goht:6:26*/ goht.JS("say('"+goht.EscapeJSString(data)+"', /[\\/']/); return false"))) + "\""); __err != nil {
+			return
+		}
+//line haml.goht:6:3
+		if _, __err = __buf.WriteString(" title=\""); __err != nil {
+			return
+		}
+//line haml.goht:6:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line haml.goht:6:113*/ data) + "\""); __err != nil {
+			return
+		}
+//line haml.goht:6:3
+		if _, __err = __buf.WriteString(">"); __err != nil {
+			return
+		}
+//line haml.goht:6:122
+		var __var1 string
+//line haml.goht:6:122
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line haml.goht:6:121*/ data)); __err != nil {
+			return
+		}
+//line haml.goht:6:122
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line haml.goht:6:122
+		if _, __err = __buf.WriteString("</a>\n"); __err != nil {
+			return
+		}
+//line haml.goht:6:122
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
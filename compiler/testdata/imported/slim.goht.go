@@ -0,0 +1,73 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package imported
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+// TODO: replace the type of data with the type of the data of the "slim_page" template.
+//
+//line slim.goht:3:1
+//line slim.goht:4:7
+func /*line slim.goht:4:6*/ SlimPage(data string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimPage", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line slim.goht:5:2
+		if _, __err = __buf.WriteString("<button onclick=\""); __err != nil {
+			return
+		}
+//line slim.goht:5:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeJS( /*line slim.goht:5:19*/ goht.JS("go("+goht.EscapeJS(data)+")"))) + "\""); __err != nil {
+			return
+		}
+//line slim.goht:5:2
+		if _, __err = __buf.WriteString(">Go</button><a href=\"#\" onclick=\""); __err != nil {
+			return
+		}
+//line slim.goht:6:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeJS( /*line slim.goht:6:25*/ goht.JS("say('"+goht.EscapeJSString(data)+"', /[\\/']/); return false"))) + "\""); __err != nil {
+			return
+		}
+//line slim.goht:6:2
+		if _, __err = __buf.WriteString(" title=\""); __err != nil {
+			return
+		}
+//line slim.goht:6:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line slim.goht:6:112*/ data) + "\""); __err != nil {
+			return
+		}
+//line slim.goht:6:2
+		if _, __err = __buf.WriteString(">"); __err != nil {
+			return
+		}
+//line slim.goht:6:121
+		var __var1 string
+//line slim.goht:6:121
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line slim.goht:6:120*/ data)); __err != nil {
+			return
+		}
+//line slim.goht:6:121
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line slim.goht:6:121
+		if _, __err = __buf.WriteString("</a>\n"); __err != nil {
+			return
+		}
+//line slim.goht:6:121
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
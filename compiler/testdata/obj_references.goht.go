@@ -19,9 +19,10 @@ func (ObjRef) ObjectClass() string {
 
 func ObjectReferencesTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ObjectReferencesTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -32,7 +33,7 @@ func ObjectReferencesTest() goht.Template {
 			return
 		}
 		if __var1 := goht.ObjectID(o); __var1 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var1 + "\""); __err != nil {
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 				return
 			}
 		}
@@ -41,14 +42,14 @@ func ObjectReferencesTest() goht.Template {
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString(">Unprefixed</p>\n<p"); __err != nil {
 			return
 		}
 		if __var3 := goht.ObjectID(o, "prefixed"); __var3 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var3 + "\""); __err != nil {
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 				return
 			}
 		}
@@ -57,7 +58,7 @@ func ObjectReferencesTest() goht.Template {
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
 		if _, __err = __buf.WriteString(">Prefixed</p>\n"); __err != nil {
@@ -9,9 +9,10 @@ import "github.com/stackus/goht"
 
 func ChildrenTest(v string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ChildrenTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -45,9 +46,10 @@ func ChildrenTest(v string) goht.Template {
 
 func RenderTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RenderTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -59,7 +61,7 @@ func RenderTest() goht.Template {
 		__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
 			__buf, __isBuf := __w.(goht.Buffer)
 			if !__isBuf {
-				__buf = goht.GetBuffer()
+				__buf = goht.AcquireBuffer(ctx, __w)
 				defer goht.ReleaseBuffer(__buf)
 			}
 			if _, __err = __buf.WriteString("<div class=\"child\">This is child content</div>\n"); __err != nil {
@@ -85,9 +87,10 @@ func RenderTest() goht.Template {
 
 func WrapperTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "WrapperTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -111,9 +114,10 @@ func WrapperTest() goht.Template {
 
 func WrappedTest(v string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "WrappedTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -141,9 +145,10 @@ func WrappedTest(v string) goht.Template {
 
 func NestedRenderTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "NestedRenderTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -152,7 +157,7 @@ func NestedRenderTest() goht.Template {
 		__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
 			__buf, __isBuf := __w.(goht.Buffer)
 			if !__isBuf {
-				__buf = goht.GetBuffer()
+				__buf = goht.AcquireBuffer(ctx, __w)
 				defer goht.ReleaseBuffer(__buf)
 			}
 			if __err = WrappedTest("first").Render(ctx, __buf); __err != nil {
@@ -178,9 +183,10 @@ func NestedRenderTest() goht.Template {
 
 func SlotTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlotTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -216,9 +222,10 @@ func SlotTest() goht.Template {
 
 func SlotWithDefaultTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlotWithDefaultTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
@@ -0,0 +1,166 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package testdata
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+func StackTest(pushes ...goht.Template) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "StackTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if _, __err = __buf.WriteString("<head>\n"); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteStack("styles"); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteStack("scripts"); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("</head>\n<body>\n"); __err != nil {
+			return
+		}
+		for _, push := range pushes {
+			if __err = push.Render(ctx, __buf); __err != nil {
+				return
+			}
+		}
+		if _, __err = __buf.WriteString("</body>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func PushScriptTest(key, src string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PushScriptTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if __err = __buf.Push(ctx, "scripts", key, goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("<script src=\""); __err != nil {
+				return
+			}
+			if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL(src)) + "\""); __err != nil {
+				return
+			}
+			if _, __err = __buf.WriteString("></script>\n"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		})); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func PushStyleTest(key, href string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PushStyleTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if __err = __buf.Push(ctx, "styles", key, goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("<link rel=\"stylesheet\" href=\""); __err != nil {
+				return
+			}
+			if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL(href)) + "\""); __err != nil {
+				return
+			}
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		})); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+func PushWidgetTest() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PushWidgetTest", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+		if __err = __buf.Push(ctx, "scripts", "widget", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+			__buf, __isBuf := __w.(goht.Buffer)
+			if !__isBuf {
+				__buf = goht.AcquireBuffer(ctx, __w)
+				defer goht.ReleaseBuffer(__buf)
+			}
+			if _, __err = __buf.WriteString("<script src=\"/widget.js\"></script>\n"); __err != nil {
+				return
+			}
+			if __err = PushStyleTest("widget", "/widget.css").Render(ctx, __buf); __err != nil {
+				return
+			}
+			if !__isBuf {
+				_, __err = io.Copy(__w, __buf)
+			}
+			return
+		})); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("<div class=\"widget\"></div>\n"); __err != nil {
+			return
+		}
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
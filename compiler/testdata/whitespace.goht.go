@@ -9,15 +9,50 @@ import "github.com/stackus/goht"
 
 func WhitespaceTest() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "WhitespaceTest", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		if _, __err = __buf.WriteString("<p>\n<span>Content</span>\n>☢~<span>\nOuter\n</span>~☢<<span>Content</span>\n</p>\n<p>\n<span>Content</span>\n<span>~☢<\nInner\n>☢~</span>\n<span>Content</span>\n</p>\n<p>\n<span>Content</span>\n>☢~<span>~☢<\nBoth\n>☢~</span>~☢<<span>Content</span>\n</p>\n"); __err != nil {
+		if _, __err = __buf.WriteString("<p>\n<span>Content</span><span>\nOuter\n</span><span>Content</span>\n</p>\n<p>\n<span>Content</span>\n<span>Inner</span>\n<span>Content</span>\n</p>\n<p>\n<span>Content</span><span>Both</span><span>Content</span>\n</p>\n<p>\n"); __err != nil {
+			return
+		}
+		var __var1 string
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString("Dynamic ")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+		__buf.TrimPrecedingSpace()
+		if _, __err = __buf.WriteString("<span>Outer</span>"); __err != nil {
+			return
+		}
+		__buf.TrimFollowingSpace()
+		var __var2 string
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(" Dynamic")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString("\n</p>\n<p>"); __err != nil {
+			return
+		}
+		__buf.TrimFollowingSpace()
+		var __var3 string
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(" Inner ")); __err != nil {
+			return
+		}
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+		__buf.TrimPrecedingSpace()
+		if _, __err = __buf.WriteString("</p>\n<p>~☢< The whitespace markers of older versions are kept >☢~</p>\n"); __err != nil {
 			return
 		}
 		if !__isBuf {
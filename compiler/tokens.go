@@ -2,6 +2,8 @@ package compiler
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 )
 
 type tokenType int
@@ -11,6 +13,11 @@ type token struct {
 	lit  string
 	line int
 	col  int
+	// endLine and endCol are the position just after the end of the token
+	endLine int
+	endCol  int
+	// code is the error code of an error token
+	code ErrorCode
 }
 
 const (
@@ -37,6 +44,8 @@ const (
 	tAttrOperator
 	tAttrEscapedValue
 	tAttrDynamicValue
+	tAttrHashStart
+	tAttrHashEnd
 	tIndent
 	tComment
 	tRubyComment
@@ -57,6 +66,14 @@ const (
 	tRenderCommand
 	tChildrenCommand
 	tSlotCommand
+	tFlushCommand
+	tFragmentCommand
+	tExtendsCommand
+	tBlockCommand
+	tPushCommand
+	tStackCommand
+	tJsonCommand
+	tFormatCommand
 	tAttributesCommand
 	tFilterStart
 	tFilterEnd
@@ -100,6 +117,10 @@ func (t tokenType) String() string {
 		return "AttrEscapedValue"
 	case tAttrDynamicValue:
 		return "AttrDynamicValue"
+	case tAttrHashStart:
+		return "AttrHashStart"
+	case tAttrHashEnd:
+		return "AttrHashEnd"
 	case tIndent:
 		return "Indent"
 	case tComment:
@@ -140,6 +161,22 @@ func (t tokenType) String() string {
 		return "ChildrenCommand"
 	case tSlotCommand:
 		return "SlotCommand"
+	case tFlushCommand:
+		return "FlushCommand"
+	case tFragmentCommand:
+		return "FragmentCommand"
+	case tExtendsCommand:
+		return "ExtendsCommand"
+	case tBlockCommand:
+		return "BlockCommand"
+	case tPushCommand:
+		return "PushCommand"
+	case tStackCommand:
+		return "StackCommand"
+	case tJsonCommand:
+		return "JsonCommand"
+	case tFormatCommand:
+		return "FormatCommand"
 	case tAttributesCommand:
 		return "AttributesCommand"
 	case tFilterStart:
@@ -167,6 +204,31 @@ func (t token) Col() int {
 	return t.col
 }
 
+// describe returns the token as it would be described to a template author,
+// such as `tag "p"` or "the end of the file".
+func (t token) describe() string {
+	switch t.typ {
+	case tEOF:
+		return "the end of the file"
+	case tNewLine:
+		return "the end of the line"
+	case tTemplateEnd:
+		return "the end of the template"
+	}
+	var words []string
+	for i, r := range t.typ.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, " ")
+		}
+		words = append(words, string(unicode.ToLower(r)))
+	}
+	lit := t.lit
+	if len([]rune(lit)) > 30 {
+		lit = string([]rune(lit)[:30]) + "..."
+	}
+	return fmt.Sprintf("%s %q", strings.Join(words, ""), lit)
+}
+
 func (t token) String() string {
 	if t.typ != tError && len(t.lit) > 30 {
 		return fmt.Sprintf("%s[%d:%d]: %q", t.typ, t.line, t.col, string([]rune(t.lit)[:30])+"...")
@@ -0,0 +1,364 @@
+package goht
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// EscapeContext is the part of a document that a dynamic value is written into.
+//
+// Each context requires its own escaping for the value to be written safely.
+type EscapeContext int
+
+const (
+	// HTMLContext is element text or the value of an ordinary attribute.
+	HTMLContext EscapeContext = iota
+	// URLContext is the value of an attribute that holds a URL, such as href or src.
+	URLContext
+	// JSContext is JavaScript, such as a script element or an event handler attribute.
+	JSContext
+	// CSSContext is CSS, such as a style element or the style attribute.
+	CSSContext
+)
+
+// urlAttributes are the attributes whose values are URLs.
+var urlAttributes = map[string]bool{
+	"action":     true,
+	"archive":    true,
+	"background": true,
+	"cite":       true,
+	"classid":    true,
+	"codebase":   true,
+	"data":       true,
+	"formaction": true,
+	"href":       true,
+	"icon":       true,
+	"longdesc":   true,
+	"manifest":   true,
+	"ping":       true,
+	"poster":     true,
+	"profile":    true,
+	"src":        true,
+	"usemap":     true,
+}
+
+// AttributeContext returns the context of the value of the named attribute.
+//
+// Event handler attributes (onclick, onload, ...) hold JavaScript, the style
+// attribute holds CSS, and attributes such as href and src hold URLs. Names
+// that include "src", "uri" or "url" are also treated as URLs.
+func AttributeContext(name string) EscapeContext {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "data-") {
+		name = name[5:]
+	} else if prefix, short, ok := strings.Cut(name, ":"); ok {
+		if prefix == "xmlns" {
+			return URLContext
+		}
+		name = short
+	}
+	switch {
+	case name == "style":
+		return CSSContext
+	case strings.HasPrefix(name, "on"):
+		return JSContext
+	case urlAttributes[name],
+		strings.Contains(name, "src"),
+		strings.Contains(name, "uri"),
+		strings.Contains(name, "url"):
+		return URLContext
+	}
+	return HTMLContext
+}
+
+// EscapeAttribute escapes the value of the named attribute for the context of
+// that attribute. The result can be written between the quotes of the value.
+func EscapeAttribute[T ~string](name string, value T) string {
+	switch AttributeContext(name) {
+	case URLContext:
+		return EscapeAttr(EscapeURL(value))
+	case JSContext:
+		return EscapeAttr(EscapeJS(value))
+	case CSSContext:
+		return EscapeAttr(EscapeCSS(value))
+	default:
+		return EscapeAttr(value)
+	}
+}
+
+// unsafeURL replaces a URL that uses a scheme that is not allowed.
+const unsafeURL = "about:invalid#zGohtz"
+
+// unsafeCSS replaces a CSS value that could be used to run code.
+const unsafeCSS = "zGohtz"
+
+// unsafeAttr replaces an empty value of an attribute that is not quoted.
+const unsafeAttr = "zGohtz"
+
+// safeSchemes are the URL schemes that may be used in a dynamic URL.
+var safeSchemes = []string{"http", "https", "mailto", "tel"}
+
+// EscapeURL filters and normalizes a URL.
+//
+// URLs with a scheme other than http, https, mailto or tel are replaced with
+// "about:invalid#zGohtz". Characters which are not allowed in a URL are
+// percent-encoded. The result still needs to be HTML escaped.
+//
+// A URL value is trusted and will not be filtered, only normalized.
+func EscapeURL[T ~string](value T) string {
+	s := string(value)
+	if _, ok := any(value).(URL); ok {
+		return normalizeURL(s)
+	}
+	if scheme, _, ok := strings.Cut(s, ":"); ok && !strings.ContainsAny(scheme, "/?#") {
+		safe := false
+		for _, allowed := range safeSchemes {
+			if strings.EqualFold(strings.TrimSpace(scheme), allowed) {
+				safe = true
+				break
+			}
+		}
+		if !safe {
+			return unsafeURL
+		}
+	}
+	return normalizeURL(s)
+}
+
+// EscapeURLPart percent-encodes a value that is written into a part of a URL,
+// such as a path segment or a query parameter.
+//
+// A URL value is trusted and will only be normalized.
+func EscapeURLPart[T ~string](value T) string {
+	s := string(value)
+	if _, ok := any(value).(URL); ok {
+		return normalizeURL(s)
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) {
+			b.WriteByte(c)
+			continue
+		}
+		writePercent(&b, c)
+	}
+	return b.String()
+}
+
+func normalizeURL(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) || strings.IndexByte("!#$&*+,/:;=?@[]%", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		writePercent(&b, c)
+	}
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func writePercent(b *strings.Builder, c byte) {
+	const hex = "0123456789ABCDEF"
+	b.WriteByte('%')
+	b.WriteByte(hex[c>>4])
+	b.WriteByte(hex[c&0xF])
+}
+
+// EscapeJS returns the value as a quoted JavaScript string.
+//
+// The result is safe to use as a value within a script element. The
+// characters that could end the script element are escaped as well.
+//
+// A JS value is trusted and is returned as it is.
+func EscapeJS[T ~string](value T) string {
+	if js, ok := any(value).(JS); ok {
+		return string(js)
+	}
+	return `"` + EscapeJSString(value) + `"`
+}
+
+// EscapeJSString escapes a value that is written inside of a quoted
+// JavaScript string or a template literal. The '$', '{' and '}' are escaped
+// so that the value can't start a substitution of a template literal.
+func EscapeJSString[T ~string](value T) string {
+	var b strings.Builder
+	for _, r := range string(value) {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '"', '\'', '`', '$', '{', '}', '<', '>', '&', '=', '\u2028', '\u2029':
+			writeUnicodeEscape(&b, r)
+		default:
+			if r < ' ' {
+				writeUnicodeEscape(&b, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func writeUnicodeEscape(b *strings.Builder, r rune) {
+	const hex = "0123456789ABCDEF"
+	b.WriteString(`\u`)
+	for shift := 12; shift >= 0; shift -= 4 {
+		b.WriteByte(hex[(r>>shift)&0xF])
+	}
+}
+
+// unsafeCSSValues are the keywords which are not allowed in a dynamic CSS value.
+var unsafeCSSValues = []string{
+	"expression", "javascript:", "vbscript:", "-moz-binding", "behavior",
+}
+
+// EscapeCSS filters a CSS value.
+//
+// Values which could break out of the declaration they are written into, or
+// could be used to run code, are replaced with "zGohtz". This includes values
+// with a ';', which would add declarations, and values with the '(', ')',
+// '@', '/', '[' or ']' of functions such as url(), at-rules and comments.
+// Use a CSS value to write more than one declaration.
+//
+// A CSS value is trusted and is returned as it is.
+func EscapeCSS[T ~string](value T) string {
+	s := string(value)
+	if _, ok := any(value).(CSS); ok {
+		return s
+	}
+	if strings.ContainsAny(s, "<>\"'`\\{};()@/[]") {
+		return unsafeCSS
+	}
+	for _, r := range s {
+		if r < ' ' && r != '\t' && r != '\n' || r == utf8.RuneError {
+			return unsafeCSS
+		}
+	}
+	lower := strings.ToLower(s)
+	for _, keyword := range unsafeCSSValues {
+		if strings.Contains(lower, keyword) {
+			return unsafeCSS
+		}
+	}
+	return s
+}
+
+// unsafeName replaces a dynamic part of a tag or attribute name that could
+// change the markup around it.
+const unsafeName = "zGohtz"
+
+// isName returns true when the name is made of ASCII letters, digits and
+// dashes, and starts with a letter.
+func isName(name string) bool {
+	if name == "" || !isASCIILetter(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if c := name[i]; !isASCIILetter(c) && !('0' <= c && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// EscapeTagName filters a value that is written at the end of a tag name,
+// such as `<h<%= level %>>`. The prefix is the static start of the name.
+//
+// Values that are empty, that have characters other than ASCII letters,
+// digits and '-', or that make the tag a script or style element are replaced
+// with "zGohtz".
+func EscapeTagName[T ~string](prefix string, value T) string {
+	s := string(value)
+	name := strings.ToLower(prefix + s)
+	if s == "" || !isName(name) || name == "script" || name == "style" {
+		return unsafeName
+	}
+	return s
+}
+
+// EscapeAttrName filters a value that is written at the end of an attribute
+// name, such as `<div data-<%= key %>="1">`. The prefix is the static start
+// of the name.
+//
+// Values that are empty, that have characters other than ASCII letters,
+// digits and '-', or that change the context of the attribute value, such as
+// turning the attribute into an event handler or a URL, are replaced with
+// "zGohtz".
+func EscapeAttrName[T ~string](prefix string, value T) string {
+	s := string(value)
+	if s == "" || !isName(prefix+s) || AttributeContext(prefix+s) != AttributeContext(prefix) {
+		return unsafeName
+	}
+	return s
+}
+
+// EscapeString escapes a value that is written into HTML.
+//
+// An HTML value is trusted and is returned as it is.
+func EscapeString[T ~string](value T) string {
+	if h, ok := any(value).(HTML); ok {
+		return string(h)
+	}
+	return escapeHTML(string(value))
+}
+
+// EscapeAttr escapes a value that is written into an attribute value.
+//
+// Unlike EscapeString, an HTML value is escaped as well; markup is not
+// allowed within an attribute.
+func EscapeAttr[T ~string](value T) string {
+	return escapeHTML(string(value))
+}
+
+// escapeHTML escapes s the same as html.EscapeString and also replaces NUL,
+// which is not allowed in HTML text and starts the stack placeholders.
+func escapeHTML(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\x00", "&#xfffd;")
+}
+
+// unquotedAttrReplacer escapes the characters that would end an attribute
+// value that is not quoted, or start another attribute after it.
+var unquotedAttrReplacer = strings.NewReplacer(
+	"\x00", "&#xfffd;",
+	"\t", "&#9;",
+	"\n", "&#10;",
+	"\v", "&#11;",
+	"\f", "&#12;",
+	"\r", "&#13;",
+	" ", "&#32;",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	"=", "&#61;",
+	">", "&gt;",
+	"`", "&#96;",
+)
+
+// EscapeUnquotedAttr escapes a value that is written into an attribute value
+// that is not quoted, such as `<a title=value>`.
+//
+// Whitespace and the characters that end or start an attribute are escaped as
+// well, so the value can't add attributes to the element. An empty value is
+// replaced with "zGohtz", as the attribute would take the text after it as its
+// value.
+func EscapeUnquotedAttr[T ~string](value T) string {
+	if value == "" {
+		return unsafeAttr
+	}
+	return unquotedAttrReplacer.Replace(string(value))
+}
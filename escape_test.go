@@ -0,0 +1,243 @@
+package goht
+
+import (
+	"testing"
+)
+
+func TestAttributeContext(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want EscapeContext
+	}{
+		"text":        {name: "title", want: HTMLContext},
+		"href":        {name: "href", want: URLContext},
+		"uppercase":   {name: "SRC", want: URLContext},
+		"contains":    {name: "imgsrc", want: URLContext},
+		"data prefix": {name: "data-url", want: URLContext},
+		"data text":   {name: "data-name", want: HTMLContext},
+		"xmlns":       {name: "xmlns:svg", want: URLContext},
+		"namespaced":  {name: "xlink:href", want: URLContext},
+		"handler":     {name: "onclick", want: JSContext},
+		"style":       {name: "style", want: CSSContext},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := AttributeContext(tt.name); got != tt.want {
+				t.Errorf("AttributeContext(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"relative":   {url: "/path/to/page?q=1#top", want: "/path/to/page?q=1#top"},
+		"https":      {url: "https://example.com/", want: "https://example.com/"},
+		"mailto":     {url: "mailto:someone@example.com", want: "mailto:someone@example.com"},
+		"javascript": {url: "javascript:alert(1)", want: "about:invalid#zGohtz"},
+		"mixed case": {url: " JavaScript:alert(1)", want: "about:invalid#zGohtz"},
+		"data":       {url: "data:text/html,hi", want: "about:invalid#zGohtz"},
+		"colon path": {url: "/a:b", want: "/a:b"},
+		"spaces":     {url: "/a b\"c", want: "/a%20b%22c"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeURL(tt.url); got != tt.want {
+				t.Errorf("EscapeURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeURLPart(t *testing.T) {
+	if got, want := EscapeURLPart("a b&c=d/é"), "a%20b%26c%3Dd%2F%C3%A9"; got != want {
+		t.Errorf("EscapeURLPart() = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeJS(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"plain":            {value: "hello", want: `"hello"`},
+		"quotes":           {value: `"a" 'b'`, want: `"\u0022a\u0022 \u0027b\u0027"`},
+		"script":           {value: "</script>", want: `"\u003C/script\u003E"`},
+		"escapes":          {value: "a\\b\nc", want: `"a\\b\nc"`},
+		"control":          {value: "\x00", want: `"\u0000"`},
+		"template literal": {value: "`${alert(1)}`", want: `"\u0060\u0024\u007Balert(1)\u007D\u0060"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeJS(tt.value); got != tt.want {
+				t.Errorf("EscapeJS(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeCSS(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"color":       {value: "red", want: "red"},
+		"length":      {value: "1.5em", want: "1.5em"},
+		"expression":  {value: "expression(alert(1))", want: "zGohtz"},
+		"url":         {value: "url(javascript:alert(1))", want: "zGohtz"},
+		"breakout":    {value: "red}body{color:blue", want: "zGohtz"},
+		"tag":         {value: "</style>", want: "zGohtz"},
+		"comment":     {value: "red/**/", want: "zGohtz"},
+		"font":        {value: "12px Arial, sans-serif", want: "12px Arial, sans-serif"},
+		"declaration": {value: "red;position:fixed", want: "zGohtz"},
+		"url path":    {value: "url(//example.com/a.png)", want: "zGohtz"},
+		"at-rule":     {value: "@import 'a.css'", want: "zGohtz"},
+		"selector":    {value: "red[x]", want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeCSS(tt.value); got != tt.want {
+				t.Errorf("EscapeCSS(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeString(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"text":   {value: `<b>"a" & b</b>`, want: "&lt;b&gt;&#34;a&#34; &amp; b&lt;/b&gt;"},
+		"nul":    {value: "a\x00b", want: "a&#xfffd;b"},
+		"stacks": {value: stackPlaceholder + "scripts\x00", want: "&#xfffd;goht-stack:scripts&#xfffd;"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeString(tt.value); got != tt.want {
+				t.Errorf("EscapeString(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+			if got := EscapeAttr(tt.value); got != tt.want {
+				t.Errorf("EscapeAttr(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeAttribute(t *testing.T) {
+	tests := map[string]struct {
+		name  string
+		value string
+		want  string
+	}{
+		"text":    {name: "title", value: `"a" & b`, want: "&#34;a&#34; &amp; b"},
+		"url":     {name: "href", value: "javascript:alert(1)", want: "about:invalid#zGohtz"},
+		"handler": {name: "onclick", value: "a", want: "&#34;a&#34;"},
+		"style":   {name: "style", value: "expression(1)", want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeAttribute(tt.name, tt.value); got != tt.want {
+				t.Errorf("EscapeAttribute(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeUnquotedAttr(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"plain":      {value: "title", want: "title"},
+		"attributes": {value: "x onmouseover=alert(1)", want: "x&#32;onmouseover&#61;alert(1)"},
+		"whitespace": {value: "a\tb\nc", want: "a&#9;b&#10;c"},
+		"markup":     {value: "<b>'a'+`b`</b>", want: "&lt;b&gt;&#39;a&#39;&#43;&#96;b&#96;&lt;/b&gt;"},
+		"empty":      {value: "", want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeUnquotedAttr(tt.value); got != tt.want {
+				t.Errorf("EscapeUnquotedAttr(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeTagName(t *testing.T) {
+	tests := map[string]struct {
+		prefix string
+		value  string
+		want   string
+	}{
+		"tag":        {value: "section", want: "section"},
+		"prefix":     {prefix: "h", value: "2", want: "2"},
+		"attributes": {value: "div onmouseover=alert(1)", want: "zGohtz"},
+		"digit":      {value: "1", want: "zGohtz"},
+		"script":     {value: "Script", want: "zGohtz"},
+		"style":      {prefix: "st", value: "yle", want: "zGohtz"},
+		"empty":      {prefix: "h", value: "", want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeTagName(tt.prefix, tt.value); got != tt.want {
+				t.Errorf("EscapeTagName(%q, %q) = %q, want %q", tt.prefix, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeAttrName(t *testing.T) {
+	tests := map[string]struct {
+		prefix string
+		value  string
+		want   string
+	}{
+		"attribute":  {value: "disabled", want: "disabled"},
+		"data":       {prefix: "data-", value: "user-id", want: "user-id"},
+		"handler":    {prefix: "on", value: "click", want: "click"},
+		"attributes": {value: "onmouseover=alert(1) x", want: "zGohtz"},
+		"space":      {prefix: "data-", value: "a b", want: "zGohtz"},
+		"to handler": {value: "onclick", want: "zGohtz"},
+		"to url":     {prefix: "data-", value: "href", want: "zGohtz"},
+		"to style":   {value: "style", want: "zGohtz"},
+		"empty":      {prefix: "data-", value: "", want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EscapeAttrName(tt.prefix, tt.value); got != tt.want {
+				t.Errorf("EscapeAttrName(%q, %q) = %q, want %q", tt.prefix, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrustedValues(t *testing.T) {
+	type label string
+	tests := map[string]struct {
+		got  string
+		want string
+	}{
+		"html":           {got: EscapeString(HTML("<b>a</b>")), want: "<b>a</b>"},
+		"html attribute": {got: EscapeAttr(HTML("<b>a</b>")), want: "&lt;b&gt;a&lt;/b&gt;"},
+		"url":            {got: EscapeURL(URL("sms:+1 555")), want: "sms:+1%20555"},
+		"url part":       {got: EscapeURLPart(URL("/a/b?c=d")), want: "/a/b?c=d"},
+		"js":             {got: EscapeJS(JS("alert(1)")), want: "alert(1)"},
+		"js string":      {got: EscapeJSString(JS("'")), want: `\u0027`},
+		"css":            {got: EscapeCSS(CSS("color: red; width: 1px")), want: "color: red; width: 1px"},
+		"named string":   {got: EscapeString(label("<html>")), want: "&lt;html&gt;"},
+		"attribute":      {got: EscapeAttribute("href", URL("sms:1")), want: "sms:1"},
+		"sanitized url":  {got: string(SanitizeURL("javascript:alert(1)")), want: "about:invalid#zGohtz"},
+		"sanitized css":  {got: string(SanitizeCSS("expression(1)")), want: "zGohtz"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
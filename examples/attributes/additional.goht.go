@@ -7,12 +7,23 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line additional.goht:3:1
 // Additional attributes may be added to an element using the `@attributes`
 // command. This command accepts a list of additional attributes in the
 // following formats:
 // - A map[string]bool - Any attribute with a true value is added as is.
 // - A map[string]string - Any attribute with a non-empty string value is
 //   added as is.
+// - A map[string]any - The values are written the same as the values of a
+//   goht.Attrs list.
+// - A goht.Attrs list - The attributes are added in the order of the list.
+//   The values may be strings, bools, numbers, fmt.Stringers, nil or the
+//   trusted types. A false or nil value leaves the attribute out.
+// - A goht.Attributer - Any type with an `Attributes() goht.Attrs` method,
+//   such as the props of a component.
+//
+// The attributes are added in the order that they are given, and the keys
+// of each map are sorted.
 
 var boolAttrs = map[string]bool{
 	"disabled": true,
@@ -23,30 +34,39 @@ var strAttrs = map[string]string{
 	"value": "foo",
 }
 
-func AttributesCmd() goht.Template {
+//line additional.goht:29:7
+func /*line additional.goht:29:6*/ AttributesCmd() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "AttributesCmd", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line additional.goht:30:3
 		if _, __err = __buf.WriteString("<input"); __err != nil {
 			return
 		}
+//line additional.goht:30:3
 		var __var1 string
+//line additional.goht:30:3
 		__var1, __err = goht.BuildAttributeList(boolAttrs, strAttrs)
+//line additional.goht:30:3
 		if __err != nil {
 			return
 		}
+//line additional.goht:30:3
 		if _, __err = __buf.WriteString(" " + __var1); __err != nil {
 			return
 		}
+//line additional.goht:30:3
 		if _, __err = __buf.WriteString(">"); __err != nil {
 			return
 		}
+//line additional.goht:30:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -54,30 +74,39 @@ func AttributesCmd() goht.Template {
 	})
 }
 
-func HamlAttributesCmd() goht.Template {
+//line additional.goht:35:7
+func /*line additional.goht:35:6*/ HamlAttributesCmd() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlAttributesCmd", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line additional.goht:36:3
 		if _, __err = __buf.WriteString("<input"); __err != nil {
 			return
 		}
+//line additional.goht:36:3
 		var __var1 string
+//line additional.goht:36:3
 		__var1, __err = goht.BuildAttributeList(boolAttrs, strAttrs)
+//line additional.goht:36:3
 		if __err != nil {
 			return
 		}
+//line additional.goht:36:3
 		if _, __err = __buf.WriteString(" " + __var1); __err != nil {
 			return
 		}
+//line additional.goht:36:3
 		if _, __err = __buf.WriteString(">"); __err != nil {
 			return
 		}
+//line additional.goht:36:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -85,30 +114,205 @@ func HamlAttributesCmd() goht.Template {
 	})
 }
 
-func SlimAttributesCmd() goht.Template {
+//line additional.goht:41:7
+func /*line additional.goht:41:6*/ SlimAttributesCmd() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimAttributesCmd", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line additional.goht:42:2
 		if _, __err = __buf.WriteString("<input"); __err != nil {
 			return
 		}
+//line additional.goht:42:2
 		var __var1 string
+//line additional.goht:42:2
 		__var1, __err = goht.BuildAttributeList(boolAttrs, strAttrs)
+//line additional.goht:42:2
 		if __err != nil {
 			return
 		}
+//line additional.goht:42:2
 		if _, __err = __buf.WriteString(" " + __var1); __err != nil {
 			return
 		}
+//line additional.goht:42:2
 		if _, __err = __buf.WriteString(">\n"); __err != nil {
 			return
 		}
+//line additional.goht:42:2
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line additional.goht:47:1
+var orderedAttrs = goht.Attrs{
+	{Key: "type", Value: "number"},
+	{Key: "name", Value: "quantity"},
+	{Key: "min", Value: 1},
+	{Key: "step", Value: 0.5},
+	{Key: "required", Value: true},
+	{Key: "readonly", Value: false},
+	{Key: "placeholder", Value: nil},
+}
+
+type ButtonProps struct {
+	Label    string
+	Disabled bool
+	Target   string
+}
+
+func (p ButtonProps) Attributes() goht.Attrs {
+	return goht.Attrs{
+		{Key: "type", Value: "button"},
+		{Key: "aria-label", Value: p.Label},
+		{Key: "disabled", Value: p.Disabled},
+		{Key: "data-target", Value: p.Target},
+	}
+}
+
+//line additional.goht:72:7
+func /*line additional.goht:72:6*/ OrderedAttributesCmd(props ButtonProps) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "OrderedAttributesCmd", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line additional.goht:73:3
+		if _, __err = __buf.WriteString("<input"); __err != nil {
+			return
+		}
+//line additional.goht:73:3
+		var __var1 string
+//line additional.goht:73:3
+		__var1, __err = goht.BuildAttributeList(orderedAttrs)
+//line additional.goht:73:3
+		if __err != nil {
+			return
+		}
+//line additional.goht:73:3
+		if _, __err = __buf.WriteString(" " + __var1); __err != nil {
+			return
+		}
+//line additional.goht:73:3
+		if _, __err = __buf.WriteString("><button"); __err != nil {
+			return
+		}
+//line additional.goht:74:3
+		var __var2 string
+//line additional.goht:74:3
+		__var2, __err = goht.BuildAttributeList(props)
+//line additional.goht:74:3
+		if __err != nil {
+			return
+		}
+//line additional.goht:74:3
+		if _, __err = __buf.WriteString(" " + __var2); __err != nil {
+			return
+		}
+//line additional.goht:74:3
+		if _, __err = __buf.WriteString(">"); __err != nil {
+			return
+		}
+//line additional.goht:74:34
+		var __var3 string
+//line additional.goht:74:34
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString( /*line additional.goht:74:33*/ props.Label)); __err != nil {
+			return
+		}
+//line additional.goht:74:34
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line additional.goht:74:34
+		if _, __err = __buf.WriteString("</button>\n"); __err != nil {
+			return
+		}
+//line additional.goht:74:34
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line additional.goht:77:7
+func /*line additional.goht:77:6*/ SlimOrderedAttributesCmd(props ButtonProps) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimOrderedAttributesCmd", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line additional.goht:78:2
+		if _, __err = __buf.WriteString("<input"); __err != nil {
+			return
+		}
+//line additional.goht:78:2
+		var __var1 string
+//line additional.goht:78:2
+		__var1, __err = goht.BuildAttributeList(orderedAttrs)
+//line additional.goht:78:2
+		if __err != nil {
+			return
+		}
+//line additional.goht:78:2
+		if _, __err = __buf.WriteString(" " + __var1); __err != nil {
+			return
+		}
+//line additional.goht:78:2
+		if _, __err = __buf.WriteString("><button"); __err != nil {
+			return
+		}
+//line additional.goht:79:2
+		var __var2 string
+//line additional.goht:79:2
+		__var2, __err = goht.BuildAttributeList(props)
+//line additional.goht:79:2
+		if __err != nil {
+			return
+		}
+//line additional.goht:79:2
+		if _, __err = __buf.WriteString(" " + __var2); __err != nil {
+			return
+		}
+//line additional.goht:79:2
+		if _, __err = __buf.WriteString(">"); __err != nil {
+			return
+		}
+//line additional.goht:79:33
+		var __var3 string
+//line additional.goht:79:33
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString( /*line additional.goht:79:32*/ props.Label)); __err != nil {
+			return
+		}
+//line additional.goht:79:33
+		if _, __err = __buf.WriteString(__var3); __err != nil {
+			return
+		}
+//line additional.goht:79:33
+		if _, __err = __buf.WriteString("</button>\n"); __err != nil {
+			return
+		}
+//line additional.goht:79:33
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line classes.goht:3:1
 // The class attribute is a bit special. You will often find yourself
 // working with not one, but several classes. This is why repeating
 // use of the class `.` operator is allowed. You may also run into
@@ -15,12 +16,18 @@ import "github.com/stackus/goht"
 // If you provide a dynamic value to the class attribute, it will be
 // interpreted as a parameter list.
 // The types of parameters that are allowed are:
-// - `string` - will be added as a class if it is not blank
+// - `string` - each class in the string will be added
 // - `[]string` - each non-blank string will be added as a class
-// - `map[string]bool` - each key with a true value will be added
+// - `map[string]bool` - each key with a true value will be added, in
+//   sorted order
+// - `goht.Classes` - an ordered list of classes that can be built up
+//   with `Add` and the conditional `If`
+// - `goht.ClassLister` - any type with a `ClassList() []string` method
+// - `fmt.Stringer` - the string will be added as a class
+// - `[]any` - a list of any of the above
 // If you have any dynamic sources for a class, from an object
 // reference, or from the class attribute, they will be merged and
-// deduplicated.
+// deduplicated. The classes keep the order that they are given in.
 // If you have all static values for your classes, then they are
 // rendered as-is avoiding any extra processing.
 
@@ -30,30 +37,39 @@ var myOptionalClasses = map[string]bool{
 	"qux": false,
 }
 
-func Classes() goht.Template {
+//line classes.goht:32:7
+func /*line classes.goht:32:6*/ Classes() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Classes", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line classes.goht:33:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line classes.goht:33:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList("fizz", "buzz", myClassList, myOptionalClasses)
+//line classes.goht:33:3
+		__var1, __err = goht.BuildClassList("fizz", "buzz" /*line classes.goht:33:22*/, myClassList, myOptionalClasses)
+//line classes.goht:33:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line classes.goht:33:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line classes.goht:33:3
 		if _, __err = __buf.WriteString("></p>\n"); __err != nil {
 			return
 		}
+//line classes.goht:33:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -61,30 +77,39 @@ func Classes() goht.Template {
 	})
 }
 
-func HamlClasses() goht.Template {
+//line classes.goht:36:7
+func /*line classes.goht:36:6*/ HamlClasses() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlClasses", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line classes.goht:37:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line classes.goht:37:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList("fizz", "buzz", myClassList, myOptionalClasses)
+//line classes.goht:37:3
+		__var1, __err = goht.BuildClassList("fizz", "buzz" /*line classes.goht:37:22*/, myClassList, myOptionalClasses)
+//line classes.goht:37:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line classes.goht:37:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line classes.goht:37:3
 		if _, __err = __buf.WriteString("></p>\n"); __err != nil {
 			return
 		}
+//line classes.goht:37:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -92,30 +117,160 @@ func HamlClasses() goht.Template {
 	})
 }
 
-func SlimClasses() goht.Template {
+//line classes.goht:40:7
+func /*line classes.goht:40:6*/ SlimClasses() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimClasses", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line classes.goht:41:2
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line classes.goht:41:2
 		var __var1 string
-		__var1, __err = goht.BuildClassList("fizz", "buzz", myClassList, myOptionalClasses)
+//line classes.goht:41:2
+		__var1, __err = goht.BuildClassList("fizz", "buzz" /*line classes.goht:41:21*/, myClassList, myOptionalClasses)
+//line classes.goht:41:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line classes.goht:41:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line classes.goht:41:2
 		if _, __err = __buf.WriteString("></p>\n"); __err != nil {
 			return
 		}
+//line classes.goht:41:2
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line classes.goht:44:1
+type CardProps struct {
+	Elevated bool
+}
+
+func (p CardProps) ClassList() []string {
+	return goht.Classes{"card", "rounded"}.If(p.Elevated, "shadow")
+}
+
+//line classes.goht:52:7
+func /*line classes.goht:52:6*/ ComposedClasses(active bool, props CardProps) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ComposedClasses", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line classes.goht:53:3
+		if _, __err = __buf.WriteString("<button"); __err != nil {
+			return
+		}
+//line classes.goht:53:3
+		var __var1 string
+//line classes.goht:53:3
+		__var1, __err = goht.BuildClassList("btn" /*line classes.goht:53:21*/, goht.Classes{"btn", "px-4"}.If(active, "bg-blue-500", "text-white").If(!active, "bg-gray-200"))
+//line classes.goht:53:3
+		if __err != nil {
+			return
+		}
+//line classes.goht:53:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
+			return
+		}
+//line classes.goht:53:3
+		if _, __err = __buf.WriteString(">Save</button>\n<div"); __err != nil {
+			return
+		}
+//line classes.goht:54:3
+		var __var2 string
+//line classes.goht:54:3
+		__var2, __err = goht.BuildClassList("rounded" /*line classes.goht:54:18*/, props, []any{"p-4", myClassList})
+//line classes.goht:54:3
+		if __err != nil {
+			return
+		}
+//line classes.goht:54:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
+			return
+		}
+//line classes.goht:54:3
+		if _, __err = __buf.WriteString(">Card</div>\n"); __err != nil {
+			return
+		}
+//line classes.goht:54:55
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line classes.goht:57:7
+func /*line classes.goht:57:6*/ SlimComposedClasses(active bool, props CardProps) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimComposedClasses", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line classes.goht:58:2
+		if _, __err = __buf.WriteString("<button"); __err != nil {
+			return
+		}
+//line classes.goht:58:2
+		var __var1 string
+//line classes.goht:58:2
+		__var1, __err = goht.BuildClassList("btn" /*line classes.goht:58:20*/, goht.Classes{"btn", "px-4"}.If(active, "bg-blue-500", "text-white").If(!active, "bg-gray-200"))
+//line classes.goht:58:2
+		if __err != nil {
+			return
+		}
+//line classes.goht:58:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
+			return
+		}
+//line classes.goht:58:2
+		if _, __err = __buf.WriteString(">Save</button><div"); __err != nil {
+			return
+		}
+//line classes.goht:59:3
+		var __var2 string
+//line classes.goht:59:3
+		__var2, __err = goht.BuildClassList("rounded" /*line classes.goht:59:18*/, props, []any{"p-4", myClassList})
+//line classes.goht:59:3
+		if __err != nil {
+			return
+		}
+//line classes.goht:59:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
+			return
+		}
+//line classes.goht:59:3
+		if _, __err = __buf.WriteString(">Card</div>\n"); __err != nil {
+			return
+		}
+//line classes.goht:59:55
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
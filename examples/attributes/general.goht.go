@@ -7,24 +7,29 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
-// Goht supports the Ruby 1.9 hash style of attributes. The other styles
-// such as HTML style, or Ruby rocket style are not supported. This should
-// not be a problem as the Ruby 1.9 style is very similar to the style used
-// by Go for maps.
+//line general.goht:3:1
+// Goht supports the Ruby 1.9 hash style of attributes, which is very
+// similar to the style used by Go for maps, and the HTML style of attributes
+// wrapped in parentheses; html.goht has more examples of the HTML style. The
+// Ruby rocket style is not supported.
 
-func StaticAttrs() goht.Template {
+//line general.goht:8:7
+func /*line general.goht:8:6*/ StaticAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "StaticAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:9:3
 		if _, __err = __buf.WriteString("<p class=\"foo\" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:9:30
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -32,19 +37,23 @@ func StaticAttrs() goht.Template {
 	})
 }
 
-func HamlStaticAttrs() goht.Template {
+//line general.goht:12:7
+func /*line general.goht:12:6*/ HamlStaticAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlStaticAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:13:3
 		if _, __err = __buf.WriteString("<p class=\"foo\" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:13:30
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -52,19 +61,23 @@ func HamlStaticAttrs() goht.Template {
 	})
 }
 
-func SlimStaticAttrs() goht.Template {
+//line general.goht:16:7
+func /*line general.goht:16:6*/ SlimStaticAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimStaticAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:17:2
 		if _, __err = __buf.WriteString("<p class=\"foo\" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:17:29
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -72,36 +85,94 @@ func SlimStaticAttrs() goht.Template {
 	})
 }
 
+//line general.goht:20:7
+func /*line general.goht:20:6*/ HamlHTMLStaticAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlHTMLStaticAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line general.goht:21:3
+		if _, __err = __buf.WriteString("<p class=\"foo\" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
+			return
+		}
+//line general.goht:21:27
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line general.goht:24:7
+func /*line general.goht:24:6*/ SlimHTMLStaticAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimHTMLStaticAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line general.goht:25:2
+		if _, __err = __buf.WriteString("<p class=\"foo\" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
+			return
+		}
+//line general.goht:25:26
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line general.goht:28:1
 // You can also use dynamic values for your attributes. Dynamic attribute
 // values share the same syntax as the interpolated values. A hash and a
 // pair of curly braces.
 
 var myDynamicValue = "foo"
 
-func DynamicAttrs() goht.Template {
+//line general.goht:34:7
+func /*line general.goht:34:6*/ DynamicAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "DynamicAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:35:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:35:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:35:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:35:13*/ myDynamicValue)
+//line general.goht:35:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:35:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:35:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:35:42
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -109,30 +180,39 @@ func DynamicAttrs() goht.Template {
 	})
 }
 
-func HamlDynamicAttrs() goht.Template {
+//line general.goht:38:7
+func /*line general.goht:38:6*/ HamlDynamicAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlDynamicAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:39:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:39:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:39:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:39:13*/ myDynamicValue)
+//line general.goht:39:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:39:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:39:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:39:42
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -140,30 +220,39 @@ func HamlDynamicAttrs() goht.Template {
 	})
 }
 
-func SlimDynamicAttrs() goht.Template {
+//line general.goht:42:7
+func /*line general.goht:42:6*/ SlimDynamicAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimDynamicAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:43:2
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:43:2
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:43:2
+		__var1, __err = goht.BuildClassList( /*line general.goht:43:12*/ myDynamicValue)
+//line general.goht:43:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:43:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:43:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:43:41
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -171,36 +260,46 @@ func SlimDynamicAttrs() goht.Template {
 	})
 }
 
+//line general.goht:46:1
 // There are times when you have a lot of attributes and you want to keep
 // your lines short. You can break up your attributes into multiple lines
 // without any additional syntax.
 // You may include a comma after the last attribute if you wish but it is
 // not required.
 
-func MultilineAttrs() goht.Template {
+//line general.goht:52:7
+func /*line general.goht:52:6*/ MultilineAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "MultilineAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:53:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:53:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:53:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:54:11*/ myDynamicValue)
+//line general.goht:53:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:53:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:53:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:56:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -208,30 +307,39 @@ func MultilineAttrs() goht.Template {
 	})
 }
 
-func HamlMultilineAttrs() goht.Template {
+//line general.goht:59:7
+func /*line general.goht:59:6*/ HamlMultilineAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlMultilineAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:60:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:60:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:60:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:61:11*/ myDynamicValue)
+//line general.goht:60:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:60:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:60:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:63:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -239,30 +347,39 @@ func HamlMultilineAttrs() goht.Template {
 	})
 }
 
-func SlimMultilineAttrs() goht.Template {
+//line general.goht:66:7
+func /*line general.goht:66:6*/ SlimMultilineAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimMultilineAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:67:2
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:67:2
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:67:2
+		__var1, __err = goht.BuildClassList( /*line general.goht:68:11*/ myDynamicValue)
+//line general.goht:67:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:67:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:67:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:70:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -270,66 +387,91 @@ func SlimMultilineAttrs() goht.Template {
 	})
 }
 
+//line general.goht:73:1
 // You may include as much whitespace as you wish between the attribute,
 // operator, value, and attribute separator. The following are all valid.
 
-func WhitespaceAttrs() goht.Template {
+//line general.goht:76:7
+func /*line general.goht:76:6*/ WhitespaceAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "WhitespaceAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:77:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:77:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:77:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:77:13*/ myDynamicValue)
+//line general.goht:77:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:77:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:77:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:78:3
 		var __var2 string
-		__var2, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:78:3
+		__var2, __err = goht.BuildClassList( /*line general.goht:78:12*/ myDynamicValue)
+//line general.goht:78:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line general.goht:78:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line general.goht:78:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:79:3
 		var __var3 string
-		__var3, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:79:3
+		__var3, __err = goht.BuildClassList( /*line general.goht:79:13*/ myDynamicValue)
+//line general.goht:79:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var3 + "\""); __err != nil {
+//line general.goht:79:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 			return
 		}
+//line general.goht:79:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:80:3
 		var __var4 string
-		__var4, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:80:3
+		__var4, __err = goht.BuildClassList( /*line general.goht:80:13*/ myDynamicValue)
+//line general.goht:80:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+//line general.goht:80:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
+//line general.goht:80:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:80:45
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -337,63 +479,87 @@ func WhitespaceAttrs() goht.Template {
 	})
 }
 
-func HamlWhitespaceAttrs() goht.Template {
+//line general.goht:83:7
+func /*line general.goht:83:6*/ HamlWhitespaceAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlWhitespaceAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:84:3
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:84:3
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:84:3
+		__var1, __err = goht.BuildClassList( /*line general.goht:84:13*/ myDynamicValue)
+//line general.goht:84:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:84:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:84:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:85:3
 		var __var2 string
-		__var2, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:85:3
+		__var2, __err = goht.BuildClassList( /*line general.goht:85:12*/ myDynamicValue)
+//line general.goht:85:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line general.goht:85:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line general.goht:85:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:86:3
 		var __var3 string
-		__var3, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:86:3
+		__var3, __err = goht.BuildClassList( /*line general.goht:86:13*/ myDynamicValue)
+//line general.goht:86:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var3 + "\""); __err != nil {
+//line general.goht:86:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 			return
 		}
+//line general.goht:86:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n<p"); __err != nil {
 			return
 		}
+//line general.goht:87:3
 		var __var4 string
-		__var4, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:87:3
+		__var4, __err = goht.BuildClassList( /*line general.goht:87:13*/ myDynamicValue)
+//line general.goht:87:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+//line general.goht:87:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
+//line general.goht:87:3
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:87:45
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -401,63 +567,87 @@ func HamlWhitespaceAttrs() goht.Template {
 	})
 }
 
-func SlimWhitespaceAttrs() goht.Template {
+//line general.goht:90:7
+func /*line general.goht:90:6*/ SlimWhitespaceAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimWhitespaceAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:91:2
 		if _, __err = __buf.WriteString("<p"); __err != nil {
 			return
 		}
+//line general.goht:91:2
 		var __var1 string
-		__var1, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:91:2
+		__var1, __err = goht.BuildClassList( /*line general.goht:91:12*/ myDynamicValue)
+//line general.goht:91:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var1 + "\""); __err != nil {
+//line general.goht:91:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 			return
 		}
+//line general.goht:91:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p><p"); __err != nil {
 			return
 		}
+//line general.goht:92:2
 		var __var2 string
-		__var2, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:92:2
+		__var2, __err = goht.BuildClassList( /*line general.goht:92:11*/ myDynamicValue)
+//line general.goht:92:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line general.goht:92:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line general.goht:92:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p><p"); __err != nil {
 			return
 		}
+//line general.goht:93:2
 		var __var3 string
-		__var3, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:93:2
+		__var3, __err = goht.BuildClassList( /*line general.goht:93:12*/ myDynamicValue)
+//line general.goht:93:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var3 + "\""); __err != nil {
+//line general.goht:93:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 			return
 		}
+//line general.goht:93:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p><p"); __err != nil {
 			return
 		}
+//line general.goht:94:2
 		var __var4 string
-		__var4, __err = goht.BuildClassList(myDynamicValue)
+//line general.goht:94:2
+		__var4, __err = goht.BuildClassList( /*line general.goht:94:12*/ myDynamicValue)
+//line general.goht:94:2
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+//line general.goht:94:2
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
+//line general.goht:94:2
 		if _, __err = __buf.WriteString(" id=\"bar\">This is a paragraph.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:94:44
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -465,31 +655,38 @@ func SlimWhitespaceAttrs() goht.Template {
 	})
 }
 
+//line general.goht:97:1
 // The dynamic attribute values may also include formatting rules just like
 // the interpolated values. The attribute values are always evaluated as
 // strings and are always rendered inside double quotes in the final HTML.
 
 var intVar = 10
 
-func FormattedValue() goht.Template {
+//line general.goht:103:7
+func /*line general.goht:103:6*/ FormattedValue() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "FormattedValue", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:104:3
 		if _, __err = __buf.WriteString("<textarea rows=\""); __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(goht.EscapeString(goht.FormatString("%d", intVar)) + "\""); __err != nil {
+//line general.goht:104:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.FormatString("%d" /*line general.goht:104:21*/, intVar)) + "\""); __err != nil {
 			return
 		}
+//line general.goht:104:3
 		if _, __err = __buf.WriteString(" cols=\"80\"></textarea>\n"); __err != nil {
 			return
 		}
+//line general.goht:104:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -497,25 +694,31 @@ func FormattedValue() goht.Template {
 	})
 }
 
-func HamlFormattedValue() goht.Template {
+//line general.goht:107:7
+func /*line general.goht:107:6*/ HamlFormattedValue() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlFormattedValue", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:108:3
 		if _, __err = __buf.WriteString("<textarea rows=\""); __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(goht.EscapeString(goht.FormatString("%d", intVar)) + "\""); __err != nil {
+//line general.goht:108:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.FormatString("%d" /*line general.goht:108:21*/, intVar)) + "\""); __err != nil {
 			return
 		}
+//line general.goht:108:3
 		if _, __err = __buf.WriteString(" cols=\"80\"></textarea>\n"); __err != nil {
 			return
 		}
+//line general.goht:108:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -523,25 +726,31 @@ func HamlFormattedValue() goht.Template {
 	})
 }
 
-func SlimFormattedValue() goht.Template {
+//line general.goht:111:7
+func /*line general.goht:111:6*/ SlimFormattedValue() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimFormattedValue", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:112:2
 		if _, __err = __buf.WriteString("<textarea rows=\""); __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(goht.EscapeString(goht.FormatString("%d", intVar)) + "\""); __err != nil {
+//line general.goht:112:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr(goht.FormatString("%d" /*line general.goht:112:20*/, intVar)) + "\""); __err != nil {
 			return
 		}
+//line general.goht:112:2
 		if _, __err = __buf.WriteString(" cols=\"80\"></textarea>\n"); __err != nil {
 			return
 		}
+//line general.goht:112:2
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
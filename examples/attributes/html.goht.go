@@ -0,0 +1,93 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package attributes
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line html.goht:3:1
+// Attributes may also be written in the HTML style, wrapped in parentheses.
+// The attributes are separated by whitespace, and a value is given with
+// `=`. Values are either quoted strings or interpolated Go code, and an
+// attribute without a value is written as a boolean attribute.
+// Slim also accepts attributes wrapped in brackets, and attributes without
+// any wrapper directly after the tag, as long as each of their values is
+// quoted or interpolated.
+// Conditional attributes, nested data and aria attributes, and the
+// `@attributes` command are only available in the `{...}` style.
+
+var title = "GoHT"
+
+//line html.goht:15:7
+func /*line html.goht:15:6*/ HTMLStyleAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HTMLStyleAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line html.goht:16:3
+		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" title=\""); __err != nil {
+			return
+		}
+//line html.goht:16:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line html.goht:16:51*/ title) + "\""); __err != nil {
+			return
+		}
+//line html.goht:16:3
+		if _, __err = __buf.WriteString(" target=\"_blank\">GoHT</a>\n<input type=\"checkbox\" checked><p class=\"intro\" id=\"intro\" lang=\"en\">Multiline</p>\n"); __err != nil {
+			return
+		}
+//line html.goht:21:4
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line html.goht:24:7
+func /*line html.goht:24:6*/ SlimHTMLStyleAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimHTMLStyleAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line html.goht:25:2
+		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" title=\""); __err != nil {
+			return
+		}
+//line html.goht:25:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line html.goht:25:50*/ title) + "\""); __err != nil {
+			return
+		}
+//line html.goht:25:2
+		if _, __err = __buf.WriteString(" target=\"_blank\">GoHT</a><input type=\"checkbox\" checked><p class=\"intro\" id=\"intro\" lang=\""); __err != nil {
+			return
+		}
+//line html.goht:27:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line html.goht:27:27*/ "en") + "\""); __err != nil {
+			return
+		}
+//line html.goht:27:2
+		if _, __err = __buf.WriteString(">Bare</p><a href=\"/\">Home</a>\n"); __err != nil {
+			return
+		}
+//line html.goht:28:13
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
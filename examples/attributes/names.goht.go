@@ -7,24 +7,29 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line names.goht:3:1
 // For most attribute names you can include the name in the list
 // of attributes just as you expect it to appear in the HTML. Names
 // that contain alphanumeric characters, dashes (-), and
 // underscores (_) are all acceptable as-is.
 
-func SimpleNames() goht.Template {
+//line names.goht:8:7
+func /*line names.goht:8:6*/ SimpleNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SimpleNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:9:3
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" data-foo=\"bar\" odd_name=\"baz\" _=\"I&#39;m a _hyperscript attribute!\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:14:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -32,19 +37,23 @@ func SimpleNames() goht.Template {
 	})
 }
 
-func HamlSimpleNames() goht.Template {
+//line names.goht:17:7
+func /*line names.goht:17:6*/ HamlSimpleNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlSimpleNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:18:3
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" data-foo=\"bar\" odd_name=\"baz\" _=\"I&#39;m a _hyperscript attribute!\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:23:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -52,19 +61,23 @@ func HamlSimpleNames() goht.Template {
 	})
 }
 
-func SlimSimpleNames() goht.Template {
+//line names.goht:26:7
+func /*line names.goht:26:6*/ SlimSimpleNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimSimpleNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:27:2
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" data-foo=\"bar\" odd_name=\"baz\" _=\"I&#39;m a _hyperscript attribute!\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:32:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -72,6 +85,7 @@ func SlimSimpleNames() goht.Template {
 	})
 }
 
+//line names.goht:35:1
 // For more complex names, such as data attributes, you can use
 // enclose the name in in double quotes or backticks.
 // - Names that start with an at sign (@).
@@ -79,19 +93,23 @@ func SlimSimpleNames() goht.Template {
 // - Names that contain a question mark (?).
 // The names will be rendered into the HTML without the quotes.
 
-func ComplexNames() goht.Template {
+//line names.goht:42:7
+func /*line names.goht:42:6*/ ComplexNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ComplexNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:43:3
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" :class=\"show ? &#39;&#39; : &#39;hidden&#39;\" @click=\"show = !show\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:47:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -99,19 +117,23 @@ func ComplexNames() goht.Template {
 	})
 }
 
-func HamlComplexNames() goht.Template {
+//line names.goht:50:7
+func /*line names.goht:50:6*/ HamlComplexNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlComplexNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:51:3
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" :class=\"show ? &#39;&#39; : &#39;hidden&#39;\" @click=\"show = !show\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:55:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -119,19 +141,23 @@ func HamlComplexNames() goht.Template {
 	})
 }
 
-func SlimComplexNames() goht.Template {
+//line names.goht:58:7
+func /*line names.goht:58:6*/ SlimComplexNames() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimComplexNames", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line names.goht:59:2
 		if _, __err = __buf.WriteString("<a href=\"https://github.com/stackus/goht\" :class=\"show ? &#39;&#39; : &#39;hidden&#39;\" @click=\"show = !show\">Goht</a>\n"); __err != nil {
 			return
 		}
+//line names.goht:63:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
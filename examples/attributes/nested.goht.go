@@ -0,0 +1,178 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package attributes
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line nested.goht:3:1
+// The data and aria attributes may be given a hash of attributes, which are
+// expanded into attributes with the prefix `data-` or `aria-`. Underscores
+// in the names of the nested attributes are replaced by hyphens, so
+// `data: {user_id: "1"}` is written as `data-user-id="1"`. The values of the
+// nested attributes may be static or dynamic, and the hashes may be nested
+// further.
+// A dynamic value that is a map, such as `data: #{settings}`, is expanded
+// into the prefixed attributes when the template is rendered. A key with a
+// true value is written as a boolean attribute, and a key with a false value
+// is left out.
+
+var userID = "42"
+
+var settings = map[string]any{
+	"theme":       "dark",
+	"page_size":   25,
+	"beta":        true,
+	"deprecated":  false,
+	"permissions": map[string]bool{"edit": true},
+}
+
+//line nested.goht:24:7
+func /*line nested.goht:24:6*/ NestedAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "NestedAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line nested.goht:25:3
+		if _, __err = __buf.WriteString("<div data-user-id=\""); __err != nil {
+			return
+		}
+//line nested.goht:25:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line nested.goht:25:24*/ userID) + "\""); __err != nil {
+			return
+		}
+//line nested.goht:25:3
+		if _, __err = __buf.WriteString(" data-role=\"admin\">User</div>\n<button aria-label=\"Close\""); __err != nil {
+			return
+		}
+//line nested.goht:26:3
+		if /*line nested.goht:26:44*/ false {
+//line nested.goht:26:3
+			if _, __err = __buf.WriteString(" aria-expanded=\"true\""); __err != nil {
+				return
+			}
+//line nested.goht:26:3
+		} else {
+//line nested.goht:26:3
+			if _, __err = __buf.WriteString(" aria-expanded=\"false\""); __err != nil {
+				return
+			}
+//line nested.goht:26:3
+		}
+//line nested.goht:26:3
+		if _, __err = __buf.WriteString(">X</button>\n<div data-user-id=\""); __err != nil {
+			return
+		}
+//line nested.goht:27:3
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line nested.goht:27:26*/ userID) + "\""); __err != nil {
+			return
+		}
+//line nested.goht:27:3
+		if _, __err = __buf.WriteString(">Nested</div>\n<div"); __err != nil {
+			return
+		}
+//line nested.goht:28:3
+		var __var1 string
+//line nested.goht:28:3
+		__var1, __err = goht.BuildHashAttributeList("data" /*line nested.goht:28:13*/, settings)
+//line nested.goht:28:3
+		if __err != nil {
+			return
+		}
+//line nested.goht:28:3
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line nested.goht:28:3
+		if _, __err = __buf.WriteString(">Settings</div>\n"); __err != nil {
+			return
+		}
+//line nested.goht:28:26
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line nested.goht:31:7
+func /*line nested.goht:31:6*/ SlimNestedAttrs() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimNestedAttrs", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line nested.goht:32:2
+		if _, __err = __buf.WriteString("<div data-user-id=\""); __err != nil {
+			return
+		}
+//line nested.goht:32:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line nested.goht:32:23*/ userID) + "\""); __err != nil {
+			return
+		}
+//line nested.goht:32:2
+		if _, __err = __buf.WriteString(" data-role=\"admin\">User</div><button aria-label=\"Close\""); __err != nil {
+			return
+		}
+//line nested.goht:33:2
+		if /*line nested.goht:33:43*/ false {
+//line nested.goht:33:2
+			if _, __err = __buf.WriteString(" aria-expanded=\"true\""); __err != nil {
+				return
+			}
+//line nested.goht:33:2
+		} else {
+//line nested.goht:33:2
+			if _, __err = __buf.WriteString(" aria-expanded=\"false\""); __err != nil {
+				return
+			}
+//line nested.goht:33:2
+		}
+//line nested.goht:33:2
+		if _, __err = __buf.WriteString(">X</button><div data-user-id=\""); __err != nil {
+			return
+		}
+//line nested.goht:34:2
+		if _, __err = __buf.WriteString(goht.EscapeAttr( /*line nested.goht:34:25*/ userID) + "\""); __err != nil {
+			return
+		}
+//line nested.goht:34:2
+		if _, __err = __buf.WriteString(">Nested</div><div"); __err != nil {
+			return
+		}
+//line nested.goht:35:2
+		var __var1 string
+//line nested.goht:35:2
+		__var1, __err = goht.BuildHashAttributeList("data" /*line nested.goht:35:12*/, settings)
+//line nested.goht:35:2
+		if __err != nil {
+			return
+		}
+//line nested.goht:35:2
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line nested.goht:35:2
+		if _, __err = __buf.WriteString(">Settings</div>\n"); __err != nil {
+			return
+		}
+//line nested.goht:35:25
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
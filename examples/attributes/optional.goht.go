@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line optional.goht:3:1
 // Attributes may conditionally appear in the output. For example, you may
 // want to add the `disabled` attribute to a button if a variable is true.
 // When the same variable is false, you want the attribute to be omitted.
@@ -21,35 +22,47 @@ var disabled = true
 
 var foo = "bar"
 
-func ConditionalAttrs() goht.Template {
+//line optional.goht:17:7
+func /*line optional.goht:17:6*/ ConditionalAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ConditionalAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line optional.goht:18:3
 		if _, __err = __buf.WriteString("<button"); __err != nil {
 			return
 		}
-		if disabled {
+//line optional.goht:18:3
+		if /*line optional.goht:18:21*/ disabled {
+//line optional.goht:18:3
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:18:3
 		}
+//line optional.goht:18:3
 		if _, __err = __buf.WriteString(">Click me!</button>\n<button"); __err != nil {
 			return
 		}
-		if foo == "bar" {
+//line optional.goht:19:3
+		if /*line optional.goht:19:21*/ foo == "bar" {
+//line optional.goht:19:3
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:19:3
 		}
+//line optional.goht:19:3
 		if _, __err = __buf.WriteString(">Click me!</button>\n"); __err != nil {
 			return
 		}
+//line optional.goht:19:37
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -57,35 +70,47 @@ func ConditionalAttrs() goht.Template {
 	})
 }
 
-func HamlConditionalAttrs() goht.Template {
+//line optional.goht:22:7
+func /*line optional.goht:22:6*/ HamlConditionalAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlConditionalAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line optional.goht:23:3
 		if _, __err = __buf.WriteString("<button"); __err != nil {
 			return
 		}
-		if disabled {
+//line optional.goht:23:3
+		if /*line optional.goht:23:21*/ disabled {
+//line optional.goht:23:3
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:23:3
 		}
+//line optional.goht:23:3
 		if _, __err = __buf.WriteString(">Click me!</button>\n<button"); __err != nil {
 			return
 		}
-		if foo == "bar" {
+//line optional.goht:24:3
+		if /*line optional.goht:24:21*/ foo == "bar" {
+//line optional.goht:24:3
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:24:3
 		}
+//line optional.goht:24:3
 		if _, __err = __buf.WriteString(">Click me!</button>\n"); __err != nil {
 			return
 		}
+//line optional.goht:24:37
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -93,35 +118,47 @@ func HamlConditionalAttrs() goht.Template {
 	})
 }
 
-func SlimConditionalAttrs() goht.Template {
+//line optional.goht:27:7
+func /*line optional.goht:27:6*/ SlimConditionalAttrs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimConditionalAttrs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line optional.goht:28:2
 		if _, __err = __buf.WriteString("<button"); __err != nil {
 			return
 		}
-		if disabled {
+//line optional.goht:28:2
+		if /*line optional.goht:28:20*/ disabled {
+//line optional.goht:28:2
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:28:2
 		}
+//line optional.goht:28:2
 		if _, __err = __buf.WriteString(">Click me!</button><button"); __err != nil {
 			return
 		}
-		if foo == "bar" {
+//line optional.goht:29:2
+		if /*line optional.goht:29:20*/ foo == "bar" {
+//line optional.goht:29:2
 			if _, __err = __buf.WriteString(" disabled"); __err != nil {
 				return
 			}
+//line optional.goht:29:2
 		}
+//line optional.goht:29:2
 		if _, __err = __buf.WriteString(">Click me!</button>\n"); __err != nil {
 			return
 		}
+//line optional.goht:29:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line children.goht:3:1
 // Any template can be included into another template; assuming that
 // you have not created a circular reference, this will not cause the
 // compiler to loop but will instead cause the generated code to
@@ -17,25 +18,31 @@ import "github.com/stackus/goht"
 // The `@children` command is used in combination with the rendering
 // code syntax `=`.
 
-func ChildrenExample() goht.Template {
+//line children.goht:13:7
+func /*line children.goht:13:6*/ ChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line children.goht:14:3
 		if _, __err = __buf.WriteString("<p>\nThe following was passed in from the calling template:\n"); __err != nil {
 			return
 		}
+//line children.goht:16:14
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line children.goht:16:14
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line children.goht:16:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -43,25 +50,31 @@ func ChildrenExample() goht.Template {
 	})
 }
 
-func HamlChildrenExample() goht.Template {
+//line children.goht:19:7
+func /*line children.goht:19:6*/ HamlChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line children.goht:20:3
 		if _, __err = __buf.WriteString("<p>\nThe following was passed in from the calling template:\n"); __err != nil {
 			return
 		}
+//line children.goht:22:14
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line children.goht:22:14
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line children.goht:22:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -69,25 +82,31 @@ func HamlChildrenExample() goht.Template {
 	})
 }
 
-func SlimChildrenExample() goht.Template {
+//line children.goht:25:7
+func /*line children.goht:25:6*/ SlimChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line children.goht:26:2
 		if _, __err = __buf.WriteString("<p><The>following was passed in from the calling template:</The>"); __err != nil {
 			return
 		}
+//line children.goht:28:14
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line children.goht:28:14
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line children.goht:28:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
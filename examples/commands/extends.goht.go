@@ -0,0 +1,469 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package commands
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line extends.goht:3:1
+// A template can extend another template and replace only the parts of it
+// that it needs to. The base template marks those parts with the `@block`
+// command, and the content nested below each block is its default content.
+//
+// A template that extends another template starts with the `@extends`
+// command and then overrides the blocks that it wants to change. Any block
+// that is not overridden keeps its default content. Content outside of the
+// blocks is not rendered, but any Go code is still run.
+
+//line extends.goht:12:7
+func /*line extends.goht:12:6*/ BaseLayout(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "BaseLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:13:3
+		if _, __err = __buf.WriteString("<html>\n<head>\n<title>\n"); __err != nil {
+			return
+		}
+//line extends.goht:16:14
+		if __bt := goht.GetBlock(ctx, "title"); __bt != nil {
+//line extends.goht:16:14
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:16:14
+		} else {
+//line extends.goht:17:8
+			var __var1 string
+//line extends.goht:17:8
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line extends.goht:17:7*/ title)); __err != nil {
+				return
+			}
+//line extends.goht:17:8
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line extends.goht:17:8
+		}
+//line extends.goht:17:8
+		if _, __err = __buf.WriteString("</title>\n</head>\n<body>\n"); __err != nil {
+			return
+		}
+//line extends.goht:19:13
+		if __bt := goht.GetBlock(ctx, "header"); __bt != nil {
+//line extends.goht:19:13
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:19:13
+		} else {
+//line extends.goht:20:6
+			if _, __err = __buf.WriteString("<h1>Welcome</h1>\n"); __err != nil {
+				return
+			}
+//line extends.goht:20:9
+		}
+//line extends.goht:21:13
+		if __bt := goht.GetBlock(ctx, "content"); __bt != nil {
+//line extends.goht:21:13
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:21:13
+		} else {
+//line extends.goht:22:6
+			if _, __err = __buf.WriteString("<p>There is nothing here yet.</p>\n"); __err != nil {
+				return
+			}
+//line extends.goht:22:8
+		}
+//line extends.goht:22:8
+		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line extends.goht:22:8
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:25:7
+func /*line extends.goht:25:6*/ PageExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PageExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:27:11
+		goht.DefineBlock(ctx, "content", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line extends.goht:27:11
+			__buf, __isBuf := __w.(goht.Buffer)
+//line extends.goht:27:11
+			if !__isBuf {
+//line extends.goht:27:11
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line extends.goht:27:11
+				defer goht.ReleaseBuffer(__buf)
+//line extends.goht:27:11
+			}
+//line extends.goht:28:4
+			if _, __err = __buf.WriteString("<p>The page content.</p>\n"); __err != nil {
+				return
+			}
+//line extends.goht:29:12
+			if __bt := goht.GetBlock(ctx, "aside"); __bt != nil {
+//line extends.goht:29:12
+				if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+					return
+				}
+//line extends.goht:29:12
+			}
+//line extends.goht:29:12
+			if !__isBuf {
+//line extends.goht:29:12
+				_, __err = io.Copy(__w, __buf)
+//line extends.goht:29:12
+			}
+//line extends.goht:29:12
+			return
+//line extends.goht:29:12
+		}))
+//line extends.goht:26:13
+		if __err = goht.Extend(ctx, __buf /*line extends.goht:26:11*/, BaseLayout("Page"), __sts...); __err != nil {
+			return
+		}
+//line extends.goht:26:13
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:32:1
+// Templates can extend templates that extend other templates. The blocks of
+// the most extended template take precedence. Blocks that are added in the
+// content of an overriding block can be overridden as well.
+
+//line extends.goht:36:7
+func /*line extends.goht:36:6*/ NestedPageExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "NestedPageExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:38:11
+		goht.DefineBlock(ctx, "header", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line extends.goht:38:11
+			__buf, __isBuf := __w.(goht.Buffer)
+//line extends.goht:38:11
+			if !__isBuf {
+//line extends.goht:38:11
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line extends.goht:38:11
+				defer goht.ReleaseBuffer(__buf)
+//line extends.goht:38:11
+			}
+//line extends.goht:39:4
+			if _, __err = __buf.WriteString("<h1>Nested Page</h1>\n"); __err != nil {
+				return
+			}
+//line extends.goht:39:7
+			if !__isBuf {
+//line extends.goht:39:7
+				_, __err = io.Copy(__w, __buf)
+//line extends.goht:39:7
+			}
+//line extends.goht:39:7
+			return
+//line extends.goht:39:7
+		}))
+//line extends.goht:40:11
+		goht.DefineBlock(ctx, "aside", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line extends.goht:40:11
+			__buf, __isBuf := __w.(goht.Buffer)
+//line extends.goht:40:11
+			if !__isBuf {
+//line extends.goht:40:11
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line extends.goht:40:11
+				defer goht.ReleaseBuffer(__buf)
+//line extends.goht:40:11
+			}
+//line extends.goht:41:4
+			if _, __err = __buf.WriteString("<aside>Extra details</aside>\n"); __err != nil {
+				return
+			}
+//line extends.goht:41:10
+			if !__isBuf {
+//line extends.goht:41:10
+				_, __err = io.Copy(__w, __buf)
+//line extends.goht:41:10
+			}
+//line extends.goht:41:10
+			return
+//line extends.goht:41:10
+		}))
+//line extends.goht:37:13
+		if __err = goht.Extend(ctx, __buf /*line extends.goht:37:11*/, PageExample(), __sts...); __err != nil {
+			return
+		}
+//line extends.goht:37:13
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:44:7
+func /*line extends.goht:44:6*/ SlimBaseLayout(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimBaseLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:45:2
+		if _, __err = __buf.WriteString("<html><head><title>"); __err != nil {
+			return
+		}
+//line extends.goht:48:14
+		if __bt := goht.GetBlock(ctx, "title"); __bt != nil {
+//line extends.goht:48:14
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:48:14
+		} else {
+//line extends.goht:49:8
+			var __var1 string
+//line extends.goht:49:8
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line extends.goht:49:7*/ title)); __err != nil {
+				return
+			}
+//line extends.goht:49:8
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line extends.goht:49:8
+		}
+//line extends.goht:49:8
+		if _, __err = __buf.WriteString("</title></head><body>"); __err != nil {
+			return
+		}
+//line extends.goht:51:13
+		if __bt := goht.GetBlock(ctx, "content"); __bt != nil {
+//line extends.goht:51:13
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:51:13
+		} else {
+//line extends.goht:52:5
+			if _, __err = __buf.WriteString("<p>There is nothing here yet.</p>"); __err != nil {
+				return
+			}
+//line extends.goht:52:7
+		}
+//line extends.goht:52:7
+		if _, __err = __buf.WriteString("</body></html>\n"); __err != nil {
+			return
+		}
+//line extends.goht:52:7
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:55:7
+func /*line extends.goht:55:6*/ SlimPageExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimPageExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:57:11
+		goht.DefineBlock(ctx, "content", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line extends.goht:57:11
+			__buf, __isBuf := __w.(goht.Buffer)
+//line extends.goht:57:11
+			if !__isBuf {
+//line extends.goht:57:11
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line extends.goht:57:11
+				defer goht.ReleaseBuffer(__buf)
+//line extends.goht:57:11
+			}
+//line extends.goht:58:3
+			if _, __err = __buf.WriteString("<p>The page content.</p>"); __err != nil {
+				return
+			}
+//line extends.goht:58:5
+			if !__isBuf {
+//line extends.goht:58:5
+				_, __err = io.Copy(__w, __buf)
+//line extends.goht:58:5
+			}
+//line extends.goht:58:5
+			return
+//line extends.goht:58:5
+		}))
+//line extends.goht:56:13
+		if __err = goht.Extend(ctx, __buf /*line extends.goht:56:11*/, SlimBaseLayout("Page"), __sts...); __err != nil {
+			return
+		}
+//line extends.goht:56:13
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:61:6
+func /*line extends.goht:61:5*/ EgoBaseLayout(title string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoBaseLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:62:2
+		if _, __err = __buf.WriteString("<html>\n<head>\n\t<title>"); __err != nil {
+			return
+		}
+//line extends.goht:64:19
+		if __bt := goht.GetBlock(ctx, "title"); __bt != nil {
+//line extends.goht:64:19
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:64:19
+		} else {
+//line extends.goht:64:33
+			var __var1 string
+//line extends.goht:64:33
+			if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line extends.goht:64:32*/ title)); __err != nil {
+				return
+			}
+//line extends.goht:64:33
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+			/*line extends.goht:64:43*/
+		}
+//line extends.goht:64:48
+		if _, __err = __buf.WriteString("</title>\n</head>\n<body>\n\t"); __err != nil {
+			return
+		}
+//line extends.goht:67:12
+		if __bt := goht.GetBlock(ctx, "content"); __bt != nil {
+//line extends.goht:67:12
+			if __err = __bt.Render(ctx, __buf, __sts...); __err != nil {
+				return
+			}
+//line extends.goht:67:12
+		} else {
+//line extends.goht:68:3
+			if _, __err = __buf.WriteString("<p>There is nothing here yet.</p>"); __err != nil {
+				return
+			}
+			/*line extends.goht:69:6*/
+		}
+//line extends.goht:69:11
+		if _, __err = __buf.WriteString("\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line extends.goht:69:11
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line extends.goht:74:6
+func /*line extends.goht:74:5*/ EgoPageExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoPageExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line extends.goht:76:11
+		goht.DefineBlock(ctx, "content", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line extends.goht:76:11
+			__buf, __isBuf := __w.(goht.Buffer)
+//line extends.goht:76:11
+			if !__isBuf {
+//line extends.goht:76:11
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line extends.goht:76:11
+				defer goht.ReleaseBuffer(__buf)
+//line extends.goht:76:11
+			}
+//line extends.goht:77:3
+			if _, __err = __buf.WriteString("<p>The page content.</p>"); __err != nil {
+				return
+			}
+//line extends.goht:77:3
+			if !__isBuf {
+//line extends.goht:77:3
+				_, __err = io.Copy(__w, __buf)
+//line extends.goht:77:3
+			}
+//line extends.goht:77:3
+			return
+//line extends.goht:77:3
+		}))
+//line extends.goht:75:13
+		if __err = goht.Extend(ctx, __buf /*line extends.goht:75:11*/, EgoBaseLayout("Page"), __sts...); __err != nil {
+			return
+		}
+//line extends.goht:75:13
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
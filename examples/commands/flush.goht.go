@@ -0,0 +1,195 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package commands
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line flush.goht:3:1
+// When a template is rendered in streaming mode, the rendered output
+// is written to the destination each time a flush point is reached.
+// The `@flush` command marks an explicit flush point in a template.
+// It is used in combination with the rendering code syntax `=`.
+//
+// Outside of streaming mode the `@flush` command does nothing.
+
+//line flush.goht:10:7
+func /*line flush.goht:10:6*/ FlushExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "FlushExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line flush.goht:11:5
+		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<title>Streaming</title>\n</head>\n"); __err != nil {
+			return
+		}
+//line flush.goht:15:11
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:16:4
+		if _, __err = __buf.WriteString("<body>\n<p>The head was flushed before this paragraph was rendered.</p>\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line flush.goht:17:7
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line flush.goht:20:7
+func /*line flush.goht:20:6*/ HamlFlushExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlFlushExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line flush.goht:21:5
+		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<title>Streaming</title>\n</head>\n"); __err != nil {
+			return
+		}
+//line flush.goht:25:11
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:26:4
+		if _, __err = __buf.WriteString("<body>\n<p>The head was flushed before this paragraph was rendered.</p>\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line flush.goht:27:7
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line flush.goht:30:7
+func /*line flush.goht:30:6*/ SlimFlushExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimFlushExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line flush.goht:31:9
+		if _, __err = __buf.WriteString("<!DOCTYPE html><html><head><title>Streaming</title></head>"); __err != nil {
+			return
+		}
+//line flush.goht:35:11
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:36:3
+		if _, __err = __buf.WriteString("<body><p>The head was flushed before this paragraph was rendered.</p></body></html>\n"); __err != nil {
+			return
+		}
+//line flush.goht:37:6
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line flush.goht:40:6
+func /*line flush.goht:40:5*/ EgoFlushExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoFlushExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line flush.goht:41:2
+		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n\t<title>Streaming</title>\n</head>\n"); __err != nil {
+			return
+		}
+//line flush.goht:46:12
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:46:14
+		if _, __err = __buf.WriteString("\n<body>\n\t<p>The head was flushed before this paragraph was rendered.</p>\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line flush.goht:46:14
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line flush.goht:53:1
+// Whitespace removal works the same way when the output is streamed,
+// even when a flush point falls between the tags that remove the
+// whitespace.
+
+//line flush.goht:57:7
+func /*line flush.goht:57:6*/ HamlFlushWhitespaceExample() goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlFlushWhitespaceExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line flush.goht:58:3
+		if _, __err = __buf.WriteString("<div>"); __err != nil {
+			return
+		}
+//line flush.goht:59:11
+		__buf.TrimFollowingSpace()
+//line flush.goht:59:11
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:60:4
+		__buf.TrimPrecedingSpace()
+//line flush.goht:60:4
+		if _, __err = __buf.WriteString("<p>\nSome text\n</p>"); __err != nil {
+			return
+		}
+//line flush.goht:62:11
+		__buf.TrimFollowingSpace()
+//line flush.goht:62:11
+		if __err = __buf.Flush(); __err != nil {
+			return
+		}
+//line flush.goht:63:4
+		if _, __err = __buf.WriteString("<p>after</p></div>\n"); __err != nil {
+			return
+		}
+//line flush.goht:63:6
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -0,0 +1,409 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package commands
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line fragment.goht:3:1
+// A fragment is a named part of a template that can be rendered on its
+// own with `goht.RenderFragment`. This allows one template to be used to
+// render both a full page and the partial updates for that page, such as
+// the responses to htmx requests.
+//
+// The `@fragment` command takes the name of the fragment, and the content
+// of the fragment is nested below it. When a fragment is rendered, all the
+// Go code in the template is still run, but only the content of the
+// fragment is written.
+//
+// When the template is rendered normally the fragment is rendered along
+// with the rest of the template.
+
+//line fragment.goht:16:7
+func /*line fragment.goht:16:6*/ FragmentExample(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "FragmentExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line fragment.goht:17:3
+		if _, __err = __buf.WriteString("<html>\n<body>\n<h1>Cart</h1>\n"); __err != nil {
+			return
+		}
+		/*line fragment.goht:20:5*/ count := len(items)
+//line fragment.goht:21:16
+		__buf.BeginFragment("cart-items")
+//line fragment.goht:21:16
+		{
+//line fragment.goht:22:6
+			if _, __err = __buf.WriteString("<ul id=\"cart-items\">\n"); __err != nil {
+				return
+			}
+			/*line fragment.goht:23:7*/ for _, item := range items {
+//line fragment.goht:24:8
+				if _, __err = __buf.WriteString("<li>"); __err != nil {
+					return
+				}
+//line fragment.goht:24:12
+				var __var1 string
+//line fragment.goht:24:12
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line fragment.goht:24:11*/ item)); __err != nil {
+					return
+				}
+//line fragment.goht:24:12
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line fragment.goht:24:12
+				if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+					return
+				}
+//line fragment.goht:24:12
+			}
+//line fragment.goht:24:12
+			if _, __err = __buf.WriteString("</ul>\n"); __err != nil {
+				return
+			}
+//line fragment.goht:24:12
+			__buf.EndFragment()
+//line fragment.goht:24:12
+		}
+//line fragment.goht:25:5
+		if _, __err = __buf.WriteString("<p>Thanks for shopping!</p>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:26:16
+		__buf.BeginFragment("cart-count")
+//line fragment.goht:26:16
+		{
+//line fragment.goht:27:6
+			if _, __err = __buf.WriteString("<span id=\"cart-count\">"); __err != nil {
+				return
+			}
+//line fragment.goht:27:22
+			var __var2 string
+//line fragment.goht:27:22
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line fragment.goht:27:23*/, count))); __err != nil {
+				return
+			}
+//line fragment.goht:27:22
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line fragment.goht:27:22
+			if _, __err = __buf.WriteString("</span>\n"); __err != nil {
+				return
+			}
+//line fragment.goht:27:22
+			__buf.EndFragment()
+//line fragment.goht:27:22
+		}
+//line fragment.goht:27:22
+		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:27:22
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line fragment.goht:30:7
+func /*line fragment.goht:30:6*/ HamlFragmentExample(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlFragmentExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line fragment.goht:31:3
+		if _, __err = __buf.WriteString("<html>\n<body>\n<h1>Cart</h1>\n"); __err != nil {
+			return
+		}
+		/*line fragment.goht:34:5*/ count := len(items)
+//line fragment.goht:35:16
+		__buf.BeginFragment("cart-items")
+//line fragment.goht:35:16
+		{
+//line fragment.goht:36:6
+			if _, __err = __buf.WriteString("<ul id=\"cart-items\">\n"); __err != nil {
+				return
+			}
+			/*line fragment.goht:37:7*/ for _, item := range items {
+//line fragment.goht:38:8
+				if _, __err = __buf.WriteString("<li>"); __err != nil {
+					return
+				}
+//line fragment.goht:38:12
+				var __var1 string
+//line fragment.goht:38:12
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line fragment.goht:38:11*/ item)); __err != nil {
+					return
+				}
+//line fragment.goht:38:12
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line fragment.goht:38:12
+				if _, __err = __buf.WriteString("</li>\n"); __err != nil {
+					return
+				}
+//line fragment.goht:38:12
+			}
+//line fragment.goht:38:12
+			if _, __err = __buf.WriteString("</ul>\n"); __err != nil {
+				return
+			}
+//line fragment.goht:38:12
+			__buf.EndFragment()
+//line fragment.goht:38:12
+		}
+//line fragment.goht:39:5
+		if _, __err = __buf.WriteString("<p>Thanks for shopping!</p>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:40:16
+		__buf.BeginFragment("cart-count")
+//line fragment.goht:40:16
+		{
+//line fragment.goht:41:6
+			if _, __err = __buf.WriteString("<span id=\"cart-count\">"); __err != nil {
+				return
+			}
+//line fragment.goht:41:22
+			var __var2 string
+//line fragment.goht:41:22
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line fragment.goht:41:23*/, count))); __err != nil {
+				return
+			}
+//line fragment.goht:41:22
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line fragment.goht:41:22
+			if _, __err = __buf.WriteString("</span>\n"); __err != nil {
+				return
+			}
+//line fragment.goht:41:22
+			__buf.EndFragment()
+//line fragment.goht:41:22
+		}
+//line fragment.goht:41:22
+		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:41:22
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line fragment.goht:44:7
+func /*line fragment.goht:44:6*/ SlimFragmentExample(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimFragmentExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line fragment.goht:45:2
+		if _, __err = __buf.WriteString("<html><body><h1>Cart</h1>"); __err != nil {
+			return
+		}
+		/*line fragment.goht:48:5*/ count := len(items)
+//line fragment.goht:49:16
+		__buf.BeginFragment("cart-items")
+//line fragment.goht:49:16
+		{
+//line fragment.goht:50:5
+			if _, __err = __buf.WriteString("<ul id=\"cart-items\">"); __err != nil {
+				return
+			}
+			/*line fragment.goht:51:7*/ for _, item := range items {
+//line fragment.goht:52:7
+				if _, __err = __buf.WriteString("<li>"); __err != nil {
+					return
+				}
+//line fragment.goht:52:11
+				var __var1 string
+//line fragment.goht:52:11
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line fragment.goht:52:10*/ item)); __err != nil {
+					return
+				}
+//line fragment.goht:52:11
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line fragment.goht:52:11
+				if _, __err = __buf.WriteString("</li>"); __err != nil {
+					return
+				}
+//line fragment.goht:52:11
+			}
+//line fragment.goht:52:11
+			if _, __err = __buf.WriteString("</ul>"); __err != nil {
+				return
+			}
+//line fragment.goht:52:11
+			__buf.EndFragment()
+//line fragment.goht:52:11
+		}
+//line fragment.goht:53:4
+		if _, __err = __buf.WriteString("<p>Thanks for shopping!</p>"); __err != nil {
+			return
+		}
+//line fragment.goht:54:16
+		__buf.BeginFragment("cart-count")
+//line fragment.goht:54:16
+		{
+//line fragment.goht:55:5
+			if _, __err = __buf.WriteString("<span id=\"cart-count\">"); __err != nil {
+				return
+			}
+//line fragment.goht:55:21
+			var __var2 string
+//line fragment.goht:55:21
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line fragment.goht:55:22*/, count))); __err != nil {
+				return
+			}
+//line fragment.goht:55:21
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line fragment.goht:55:21
+			if _, __err = __buf.WriteString("</span>"); __err != nil {
+				return
+			}
+//line fragment.goht:55:21
+			__buf.EndFragment()
+//line fragment.goht:55:21
+		}
+//line fragment.goht:55:21
+		if _, __err = __buf.WriteString("</body></html>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:55:21
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line fragment.goht:58:1
+// EGO templates use braces to surround the content of the fragment.
+
+//line fragment.goht:60:6
+func /*line fragment.goht:60:5*/ EgoFragmentExample(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoFragmentExample", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line fragment.goht:61:2
+		if _, __err = __buf.WriteString("<html>\n<body>\n\t<h1>Cart</h1>\n\t"); __err != nil {
+			return
+		}
+		/*line fragment.goht:64:5*/ count := len(items)
+//line fragment.goht:65:16
+		__buf.BeginFragment("cart-items")
+//line fragment.goht:65:16
+		{
+//line fragment.goht:66:3
+			if _, __err = __buf.WriteString("<ul id=\"cart-items\">\n\t\t"); __err != nil {
+				return
+			}
+			/*line fragment.goht:67:6*/ for _, item := range items {
+//line fragment.goht:68:4
+				if _, __err = __buf.WriteString("<li>"); __err != nil {
+					return
+				}
+//line fragment.goht:68:12
+				var __var1 string
+//line fragment.goht:68:12
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line fragment.goht:68:11*/ item)); __err != nil {
+					return
+				}
+//line fragment.goht:68:12
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line fragment.goht:68:19
+				if _, __err = __buf.WriteString("</li>"); __err != nil {
+					return
+				}
+				/*line fragment.goht:69:7*/
+			}
+//line fragment.goht:69:12
+			if _, __err = __buf.WriteString("\n\t</ul>"); __err != nil {
+				return
+			}
+//line fragment.goht:69:12
+			__buf.EndFragment()
+			/*line fragment.goht:71:6*/
+		}
+//line fragment.goht:71:11
+		if _, __err = __buf.WriteString("\n\t<p>Thanks for shopping!</p>\n\t"); __err != nil {
+			return
+		}
+//line fragment.goht:73:16
+		__buf.BeginFragment("cart-count")
+//line fragment.goht:73:16
+		{
+//line fragment.goht:74:3
+			if _, __err = __buf.WriteString("<span id=\"cart-count\">"); __err != nil {
+				return
+			}
+//line fragment.goht:74:28
+			var __var2 string
+//line fragment.goht:74:28
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line fragment.goht:74:29*/, count))); __err != nil {
+				return
+			}
+//line fragment.goht:74:28
+			if _, __err = __buf.WriteString(__var2); __err != nil {
+				return
+			}
+//line fragment.goht:74:39
+			if _, __err = __buf.WriteString("</span>"); __err != nil {
+				return
+			}
+//line fragment.goht:74:39
+			__buf.EndFragment()
+			/*line fragment.goht:75:6*/
+		}
+//line fragment.goht:75:11
+		if _, __err = __buf.WriteString("\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line fragment.goht:75:11
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -7,30 +7,37 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line render.goht:3:1
 // You include other templates using the `@render` command. It takes
 // the name of the template to render.
 // The `@render` command is used in combination with the rendering
 // code syntax `=`.
 
-func RenderExample() goht.Template {
+//line render.goht:8:7
+func /*line render.goht:8:6*/ RenderExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RenderExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:9:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
-		if __err = ChildrenExample().Render(ctx, __buf); __err != nil {
+//line render.goht:9:14
+		if __err = /*line render.goht:9:13*/ ChildrenExample().Render(ctx, __buf); __err != nil {
 			return
 		}
+//line render.goht:9:14
 		if _, __err = __buf.WriteString("</p>\n<p>the other template was rendered above.</p>\n"); __err != nil {
 			return
 		}
+//line render.goht:10:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -38,25 +45,31 @@ func RenderExample() goht.Template {
 	})
 }
 
-func HamlRenderExample() goht.Template {
+//line render.goht:13:7
+func /*line render.goht:13:6*/ HamlRenderExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRenderExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:14:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
-		if __err = ChildrenExample().Render(ctx, __buf); __err != nil {
+//line render.goht:14:14
+		if __err = /*line render.goht:14:13*/ ChildrenExample().Render(ctx, __buf); __err != nil {
 			return
 		}
+//line render.goht:14:14
 		if _, __err = __buf.WriteString("</p>\n<p>the other template was rendered above.</p>\n"); __err != nil {
 			return
 		}
+//line render.goht:15:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -64,25 +77,31 @@ func HamlRenderExample() goht.Template {
 	})
 }
 
-func SlimRenderExample() goht.Template {
+//line render.goht:18:7
+func /*line render.goht:18:6*/ SlimRenderExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimRenderExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:19:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
-		if __err = ChildrenExample().Render(ctx, __buf); __err != nil {
+//line render.goht:19:13
+		if __err = /*line render.goht:19:12*/ ChildrenExample().Render(ctx, __buf); __err != nil {
 			return
 		}
+//line render.goht:19:13
 		if _, __err = __buf.WriteString("</p><p>the other template was rendered above.</p>\n"); __err != nil {
 			return
 		}
+//line render.goht:20:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -90,40 +109,58 @@ func SlimRenderExample() goht.Template {
 	})
 }
 
+//line render.goht:23:1
 // You may also include nested content to be rendered by the template.
 // You do not need to include any opening or closing braces when you
 // are passing content on to be rendered by another template.
 
-func RenderWithChildrenExample() goht.Template {
+//line render.goht:27:7
+func /*line render.goht:27:6*/ RenderWithChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RenderWithChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:28:3
 		if _, __err = __buf.WriteString("<p>The other template will be rendered below.</p>\n"); __err != nil {
 			return
 		}
+//line render.goht:29:12
 		__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line render.goht:29:12
 			__buf, __isBuf := __w.(goht.Buffer)
+//line render.goht:29:12
 			if !__isBuf {
-				__buf = goht.GetBuffer()
+//line render.goht:29:12
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line render.goht:29:12
 				defer goht.ReleaseBuffer(__buf)
+//line render.goht:29:12
 			}
+//line render.goht:30:4
 			if _, __err = __buf.WriteString("<span>this content will be rendered by the other template.</span>\n"); __err != nil {
 				return
 			}
+//line render.goht:30:9
 			if !__isBuf {
+//line render.goht:30:9
 				_, __err = io.Copy(__w, __buf)
+//line render.goht:30:9
 			}
+//line render.goht:30:9
 			return
+//line render.goht:30:9
 		})
-		if __err = ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line render.goht:30:9
+		if __err = /*line render.goht:29:11*/ ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 			return
 		}
+//line render.goht:30:9
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -131,36 +168,53 @@ func RenderWithChildrenExample() goht.Template {
 	})
 }
 
-func HamlRenderWithChildrenExample() goht.Template {
+//line render.goht:33:7
+func /*line render.goht:33:6*/ HamlRenderWithChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRenderWithChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:34:3
 		if _, __err = __buf.WriteString("<p>The other template will be rendered below.</p>\n"); __err != nil {
 			return
 		}
+//line render.goht:35:12
 		__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line render.goht:35:12
 			__buf, __isBuf := __w.(goht.Buffer)
+//line render.goht:35:12
 			if !__isBuf {
-				__buf = goht.GetBuffer()
+//line render.goht:35:12
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line render.goht:35:12
 				defer goht.ReleaseBuffer(__buf)
+//line render.goht:35:12
 			}
+//line render.goht:36:4
 			if _, __err = __buf.WriteString("<span>this content will be rendered by the other template.</span>\n"); __err != nil {
 				return
 			}
+//line render.goht:36:9
 			if !__isBuf {
+//line render.goht:36:9
 				_, __err = io.Copy(__w, __buf)
+//line render.goht:36:9
 			}
+//line render.goht:36:9
 			return
+//line render.goht:36:9
 		})
-		if __err = ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line render.goht:36:9
+		if __err = /*line render.goht:35:11*/ ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 			return
 		}
+//line render.goht:36:9
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -168,39 +222,57 @@ func HamlRenderWithChildrenExample() goht.Template {
 	})
 }
 
-func SlimRenderWithChildrenExample() goht.Template {
+//line render.goht:39:7
+func /*line render.goht:39:6*/ SlimRenderWithChildrenExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimRenderWithChildrenExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line render.goht:40:2
 		if _, __err = __buf.WriteString("<p>The other template will be rendered below.</p>"); __err != nil {
 			return
 		}
+//line render.goht:41:12
 		__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line render.goht:41:12
 			__buf, __isBuf := __w.(goht.Buffer)
+//line render.goht:41:12
 			if !__isBuf {
-				__buf = goht.GetBuffer()
+//line render.goht:41:12
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line render.goht:41:12
 				defer goht.ReleaseBuffer(__buf)
+//line render.goht:41:12
 			}
+//line render.goht:42:3
 			if _, __err = __buf.WriteString("<span>this content will be rendered by the other template.</span>"); __err != nil {
 				return
 			}
+//line render.goht:42:8
 			if !__isBuf {
+//line render.goht:42:8
 				_, __err = io.Copy(__w, __buf)
+//line render.goht:42:8
 			}
+//line render.goht:42:8
 			return
+//line render.goht:42:8
 		})
-		if __err = ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line render.goht:42:8
+		if __err = /*line render.goht:41:11*/ ChildrenExample().Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 			return
 		}
+//line render.goht:42:8
 		if _, __err = __buf.WriteString("\n"); __err != nil {
 			return
 		}
+//line render.goht:42:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -0,0 +1,267 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package commands
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line scoped_slots.goht:3:1
+// A scoped slot passes a value from the template that owns the slot to the
+// template that is slotted into it. This lets a generic component, such as
+// a table, leave the rendering of each row or cell to the caller.
+//
+// The owning template passes the value in parentheses after the slot name.
+// The caller provides a `goht.SlotFunc` that receives the value and returns
+// the template to render for it:
+//
+// err := ItemTable(items).Render(ctx, w,
+// 	goht.SlotFunc[string](func(item string) goht.Template {
+// 		return ItemCell(item)
+// 	}).Slot("row"),
+// )
+//
+// The type of the value is checked when the slot is rendered. The owning
+// template can name the type of the value in brackets, so that a value of
+// another type fails to compile. As with other slots, the content nested
+// below the slot is the default content.
+
+//line scoped_slots.goht:22:7
+func /*line scoped_slots.goht:22:6*/ ItemTable(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ItemTable", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line scoped_slots.goht:23:3
+		if _, __err = __buf.WriteString("<table>\n"); __err != nil {
+			return
+		}
+		/*line scoped_slots.goht:24:4*/ for _, item := range items {
+//line scoped_slots.goht:25:5
+			if _, __err = __buf.WriteString("<tr>\n"); __err != nil {
+				return
+			}
+//line scoped_slots.goht:26:12
+			if __st := goht.GetSlottedTemplate(__sts, "row"); __st != nil {
+//line scoped_slots.goht:26:12
+				if __err = goht.RenderScopedSlot[string](ctx, __buf, __st /*line scoped_slots.goht:26:22*/, item, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
+					return
+				}
+//line scoped_slots.goht:26:12
+			} else {
+//line scoped_slots.goht:27:7
+				if _, __err = __buf.WriteString("<td>"); __err != nil {
+					return
+				}
+//line scoped_slots.goht:27:11
+				var __var1 string
+//line scoped_slots.goht:27:11
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line scoped_slots.goht:27:10*/ item)); __err != nil {
+					return
+				}
+//line scoped_slots.goht:27:11
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line scoped_slots.goht:27:11
+				if _, __err = __buf.WriteString("</td>\n"); __err != nil {
+					return
+				}
+//line scoped_slots.goht:27:11
+			}
+//line scoped_slots.goht:27:11
+			if _, __err = __buf.WriteString("</tr>\n"); __err != nil {
+				return
+			}
+//line scoped_slots.goht:27:11
+		}
+//line scoped_slots.goht:27:11
+		if _, __err = __buf.WriteString("</table>\n"); __err != nil {
+			return
+		}
+//line scoped_slots.goht:27:11
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line scoped_slots.goht:30:7
+func /*line scoped_slots.goht:30:6*/ ItemCell(item string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ItemCell", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line scoped_slots.goht:31:3
+		if _, __err = __buf.WriteString("<td class=\"item\">\n<strong>"); __err != nil {
+			return
+		}
+//line scoped_slots.goht:32:12
+		var __var1 string
+//line scoped_slots.goht:32:12
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line scoped_slots.goht:32:11*/ item)); __err != nil {
+			return
+		}
+//line scoped_slots.goht:32:12
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line scoped_slots.goht:32:12
+		if _, __err = __buf.WriteString("</strong>\n</td>\n"); __err != nil {
+			return
+		}
+//line scoped_slots.goht:32:12
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line scoped_slots.goht:35:7
+func /*line scoped_slots.goht:35:6*/ SlimItemTable(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimItemTable", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line scoped_slots.goht:36:2
+		if _, __err = __buf.WriteString("<table>"); __err != nil {
+			return
+		}
+		/*line scoped_slots.goht:37:4*/ for _, item := range items {
+//line scoped_slots.goht:38:4
+			if _, __err = __buf.WriteString("<tr>"); __err != nil {
+				return
+			}
+//line scoped_slots.goht:39:12
+			if __st := goht.GetSlottedTemplate(__sts, "row"); __st != nil {
+//line scoped_slots.goht:39:12
+				if __err = goht.RenderScopedSlot(ctx, __buf, __st /*line scoped_slots.goht:39:14*/, item, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
+					return
+				}
+//line scoped_slots.goht:39:12
+			} else {
+//line scoped_slots.goht:40:6
+				if _, __err = __buf.WriteString("<td>"); __err != nil {
+					return
+				}
+//line scoped_slots.goht:40:10
+				var __var1 string
+//line scoped_slots.goht:40:10
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line scoped_slots.goht:40:9*/ item)); __err != nil {
+					return
+				}
+//line scoped_slots.goht:40:10
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line scoped_slots.goht:40:10
+				if _, __err = __buf.WriteString("</td>"); __err != nil {
+					return
+				}
+//line scoped_slots.goht:40:10
+			}
+//line scoped_slots.goht:40:10
+			if _, __err = __buf.WriteString("</tr>"); __err != nil {
+				return
+			}
+//line scoped_slots.goht:40:10
+		}
+//line scoped_slots.goht:40:10
+		if _, __err = __buf.WriteString("</table>\n"); __err != nil {
+			return
+		}
+//line scoped_slots.goht:40:10
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line scoped_slots.goht:43:6
+func /*line scoped_slots.goht:43:5*/ EgoItemTable(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoItemTable", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line scoped_slots.goht:44:2
+		if _, __err = __buf.WriteString("<table>"); __err != nil {
+			return
+		}
+		/*line scoped_slots.goht:45:5*/ for _, item := range items {
+//line scoped_slots.goht:46:3
+			if _, __err = __buf.WriteString("<tr>\n\t"); __err != nil {
+				return
+			}
+//line scoped_slots.goht:47:11
+			if __st := goht.GetSlottedTemplate(__sts, "row"); __st != nil {
+//line scoped_slots.goht:47:11
+				if __err = goht.RenderScopedSlot(ctx, __buf, __st /*line scoped_slots.goht:47:13*/, item, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
+					return
+				}
+//line scoped_slots.goht:47:11
+			} else {
+//line scoped_slots.goht:48:4
+				if _, __err = __buf.WriteString("<td>"); __err != nil {
+					return
+				}
+//line scoped_slots.goht:48:12
+				var __var1 string
+//line scoped_slots.goht:48:12
+				if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line scoped_slots.goht:48:11*/ item)); __err != nil {
+					return
+				}
+//line scoped_slots.goht:48:12
+				if _, __err = __buf.WriteString(__var1); __err != nil {
+					return
+				}
+//line scoped_slots.goht:48:19
+				if _, __err = __buf.WriteString("</td>"); __err != nil {
+					return
+				}
+				/*line scoped_slots.goht:49:6*/
+			}
+//line scoped_slots.goht:50:3
+			if _, __err = __buf.WriteString("</tr>"); __err != nil {
+				return
+			}
+			/*line scoped_slots.goht:51:5*/
+		}
+//line scoped_slots.goht:52:2
+		if _, __err = __buf.WriteString("</table>\n"); __err != nil {
+			return
+		}
+//line scoped_slots.goht:52:2
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line slots.goht:2:1
 // GoHT templates can be built with a slot based architecture.
 // This allows for a more modular approach to building templates.
 //
@@ -17,29 +18,37 @@ import "github.com/stackus/goht"
 //
 // err := AdminName("SuperAdminUser").Render(ctx, w)
 
-func Name(userName string) goht.Template {
+//line slots.goht:12:7
+func /*line slots.goht:12:6*/ Name(userName string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Name", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line slots.goht:13:3
 		if _, __err = __buf.WriteString("<div class=\"user\">\n<span>"); __err != nil {
 			return
 		}
+//line slots.goht:14:10
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(userName)); __err != nil {
+//line slots.goht:14:10
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line slots.goht:14:9*/ userName)); __err != nil {
 			return
 		}
+//line slots.goht:14:10
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line slots.goht:14:10
 		if _, __err = __buf.WriteString("</span>\n</div>\n"); __err != nil {
 			return
 		}
+//line slots.goht:14:10
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -47,29 +56,37 @@ func Name(userName string) goht.Template {
 	})
 }
 
-func AdminName(adminName string) goht.Template {
+//line slots.goht:17:7
+func /*line slots.goht:17:6*/ AdminName(adminName string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "AdminName", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line slots.goht:18:3
 		if _, __err = __buf.WriteString("<div class=\"admin\">\n<span>"); __err != nil {
 			return
 		}
+//line slots.goht:19:10
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(adminName)); __err != nil {
+//line slots.goht:19:10
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line slots.goht:19:9*/ adminName)); __err != nil {
 			return
 		}
+//line slots.goht:19:10
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line slots.goht:19:10
 		if _, __err = __buf.WriteString("</span>\n</div>\n"); __err != nil {
 			return
 		}
+//line slots.goht:19:10
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -77,6 +94,7 @@ func AdminName(adminName string) goht.Template {
 	})
 }
 
+//line slots.goht:22:1
 // In your application code, you can also slot in either of the two "Name"
 // templates by passing in the template as an optional third argument to
 // the `Render` call for the main "parent" template.
@@ -97,39 +115,53 @@ func AdminName(adminName string) goht.Template {
 // will not be rendered in the final output. However, if we provide default content
 // then we can have a fallback to use if no content is passed in.
 
-func SlotTemplate() goht.Template {
+//line slots.goht:42:7
+func /*line slots.goht:42:6*/ SlotTemplate() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlotTemplate", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line slots.goht:43:3
 		if _, __err = __buf.WriteString("<div class=\"name-content\">"); __err != nil {
 			return
 		}
+//line slots.goht:44:10
 		if __st := goht.GetSlottedTemplate(__sts, "name"); __st != nil {
+//line slots.goht:44:10
 			if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
 				return
 			}
+//line slots.goht:44:10
 		}
+//line slots.goht:44:10
 		if _, __err = __buf.WriteString("</div><div class=\"actions\">"); __err != nil {
 			return
 		}
+//line slots.goht:46:10
 		if __st := goht.GetSlottedTemplate(__sts, "actions"); __st != nil {
+//line slots.goht:46:10
 			if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
 				return
 			}
+//line slots.goht:46:10
 		} else {
+//line slots.goht:47:6
 			if _, __err = __buf.WriteString("No available actions."); __err != nil {
 				return
 			}
+//line slots.goht:47:6
 		}
+//line slots.goht:47:6
 		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
 			return
 		}
+//line slots.goht:47:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -137,43 +169,59 @@ func SlotTemplate() goht.Template {
 	})
 }
 
-func SlotWithDefaultTemplate() goht.Template {
+//line slots.goht:50:6
+func /*line slots.goht:50:5*/ SlotWithDefaultTemplate() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlotWithDefaultTemplate", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line slots.goht:51:2
 		if _, __err = __buf.WriteString("<div class=\"name-content\">\n\t"); __err != nil {
 			return
 		}
+//line slots.goht:52:11
 		if __st := goht.GetSlottedTemplate(__sts, "name"); __st != nil {
+//line slots.goht:52:11
 			if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
 				return
 			}
+//line slots.goht:52:11
 		} else {
+//line slots.goht:53:4
 			if _, __err = __buf.WriteString("<span>No user name has been set.</span>"); __err != nil {
 				return
 			}
+			/*line slots.goht:54:6*/
 		}
+//line slots.goht:55:2
 		if _, __err = __buf.WriteString("</div>\n<div class=\"actions\">\n\t"); __err != nil {
 			return
 		}
+//line slots.goht:57:11
 		if __st := goht.GetSlottedTemplate(__sts, "actions"); __st != nil {
+//line slots.goht:57:11
 			if __err = __st.Render(ctx, __buf, append(__st.SlottedTemplates(), __sts...)...); __err != nil {
 				return
 			}
+//line slots.goht:57:11
 		} else {
+//line slots.goht:58:4
 			if _, __err = __buf.WriteString("<span>No actions have been set.</span>"); __err != nil {
 				return
 			}
+			/*line slots.goht:59:6*/
 		}
+//line slots.goht:60:2
 		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
 			return
 		}
+//line slots.goht:60:2
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -181,6 +229,7 @@ func SlotWithDefaultTemplate() goht.Template {
 	})
 }
 
+//line slots.goht:63:1
 // When you pass in a template to a slot, that template can also have its own
 // slots. This is done by adding one templates to the optional second parameter
 // to the `Slot` method.
@@ -0,0 +1,480 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package commands
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line stacks.goht:3:1
+// A template can push content onto a named stack with the `@push` command,
+// and the content of the stack is written wherever the `@stack` command is
+// used. This lets a component that is rendered deep in a page add the
+// scripts and styles that it needs to the head of the layout, even though
+// the head has already been written by the time the component is rendered.
+//
+// A push can be given a key, which is any Go string expression. Only the
+// first push of a key onto a stack is kept, so a component that is rendered
+// many times only adds its scripts once.
+
+//line stacks.goht:13:7
+func /*line stacks.goht:13:6*/ StackLayout(charts []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "StackLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:14:3
+		if _, __err = __buf.WriteString("<html>\n<head>\n<title>Charts</title>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:17:13
+		if _, __err = __buf.WriteStack("styles"); __err != nil {
+			return
+		}
+//line stacks.goht:18:13
+		if _, __err = __buf.WriteStack("scripts"); __err != nil {
+			return
+		}
+//line stacks.goht:18:13
+		if _, __err = __buf.WriteString("</head>\n<body>\n"); __err != nil {
+			return
+		}
+		/*line stacks.goht:20:5*/ for _, chart := range charts {
+//line stacks.goht:21:15
+			if __err = /*line stacks.goht:21:14*/ ChartWidget(chart).Render(ctx, __buf); __err != nil {
+				return
+			}
+//line stacks.goht:21:15
+		}
+//line stacks.goht:21:15
+		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:21:15
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line stacks.goht:24:7
+func /*line stacks.goht:24:6*/ ChartWidget(name string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ChartWidget", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:25:10
+		if __err = __buf.Push(ctx, "scripts", "chart", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:25:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:25:10
+			if !__isBuf {
+//line stacks.goht:25:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:25:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:25:10
+			}
+//line stacks.goht:26:4
+			if _, __err = __buf.WriteString("<script src=\"/js/chart.js\"></script>\n"); __err != nil {
+				return
+			}
+//line stacks.goht:26:4
+			if !__isBuf {
+//line stacks.goht:26:4
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:26:4
+			}
+//line stacks.goht:26:4
+			return
+//line stacks.goht:26:4
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:27:10
+		if __err = __buf.Push(ctx, "styles", "", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:27:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:27:10
+			if !__isBuf {
+//line stacks.goht:27:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:27:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:27:10
+			}
+//line stacks.goht:28:4
+			if _, __err = __buf.WriteString("<link rel=\"stylesheet\" href=\""); __err != nil {
+				return
+			}
+//line stacks.goht:28:4
+			if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL( /*line stacks.goht:28:35*/ "/css/"+name+".css")) + "\""); __err != nil {
+				return
+			}
+//line stacks.goht:28:4
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+//line stacks.goht:28:4
+			if !__isBuf {
+//line stacks.goht:28:4
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:28:4
+			}
+//line stacks.goht:28:4
+			return
+//line stacks.goht:28:4
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:29:3
+		if _, __err = __buf.WriteString("<div class=\"chart\">"); __err != nil {
+			return
+		}
+//line stacks.goht:29:10
+		var __var1 string
+//line stacks.goht:29:10
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line stacks.goht:29:9*/ name)); __err != nil {
+			return
+		}
+//line stacks.goht:29:10
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line stacks.goht:29:10
+		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:29:10
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line stacks.goht:32:7
+func /*line stacks.goht:32:6*/ SlimStackLayout(charts []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimStackLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:33:2
+		if _, __err = __buf.WriteString("<html><head><title>Charts</title>"); __err != nil {
+			return
+		}
+//line stacks.goht:36:13
+		if _, __err = __buf.WriteStack("styles"); __err != nil {
+			return
+		}
+//line stacks.goht:37:13
+		if _, __err = __buf.WriteStack("scripts"); __err != nil {
+			return
+		}
+//line stacks.goht:37:13
+		if _, __err = __buf.WriteString("</head><body>"); __err != nil {
+			return
+		}
+		/*line stacks.goht:39:5*/ for _, chart := range charts {
+//line stacks.goht:40:15
+			if __err = /*line stacks.goht:40:14*/ SlimChartWidget(chart).Render(ctx, __buf); __err != nil {
+				return
+			}
+//line stacks.goht:40:15
+		}
+//line stacks.goht:40:15
+		if _, __err = __buf.WriteString("</body></html>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:40:15
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line stacks.goht:43:7
+func /*line stacks.goht:43:6*/ SlimChartWidget(name string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimChartWidget", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:44:10
+		if __err = __buf.Push(ctx, "scripts", "chart", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:44:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:44:10
+			if !__isBuf {
+//line stacks.goht:44:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:44:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:44:10
+			}
+//line stacks.goht:45:3
+			if _, __err = __buf.WriteString("<script src=\"/js/chart.js\"></script>"); __err != nil {
+				return
+			}
+//line stacks.goht:45:3
+			if !__isBuf {
+//line stacks.goht:45:3
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:45:3
+			}
+//line stacks.goht:45:3
+			return
+//line stacks.goht:45:3
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:46:10
+		if __err = __buf.Push(ctx, "styles", "", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:46:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:46:10
+			if !__isBuf {
+//line stacks.goht:46:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:46:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:46:10
+			}
+//line stacks.goht:47:3
+			if _, __err = __buf.WriteString("<link rel=\"stylesheet\" href=\""); __err != nil {
+				return
+			}
+//line stacks.goht:47:3
+			if _, __err = __buf.WriteString(goht.EscapeAttr(goht.EscapeURL( /*line stacks.goht:47:34*/ "/css/"+name+".css")) + "\""); __err != nil {
+				return
+			}
+//line stacks.goht:47:3
+			if _, __err = __buf.WriteString(">"); __err != nil {
+				return
+			}
+//line stacks.goht:47:3
+			if !__isBuf {
+//line stacks.goht:47:3
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:47:3
+			}
+//line stacks.goht:47:3
+			return
+//line stacks.goht:47:3
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:48:3
+		if _, __err = __buf.WriteString("<div class=\"chart\">"); __err != nil {
+			return
+		}
+//line stacks.goht:48:10
+		var __var1 string
+//line stacks.goht:48:10
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line stacks.goht:48:9*/ name)); __err != nil {
+			return
+		}
+//line stacks.goht:48:10
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line stacks.goht:48:10
+		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:48:10
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line stacks.goht:51:6
+func /*line stacks.goht:51:5*/ EgoStackLayout(charts []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoStackLayout", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:52:2
+		if _, __err = __buf.WriteString("<html>\n<head>\n\t<title>Charts</title>\n\t"); __err != nil {
+			return
+		}
+//line stacks.goht:55:12
+		if _, __err = __buf.WriteStack("styles"); __err != nil {
+			return
+		}
+//line stacks.goht:55:21
+		if _, __err = __buf.WriteString("\n\t"); __err != nil {
+			return
+		}
+//line stacks.goht:56:12
+		if _, __err = __buf.WriteStack("scripts"); __err != nil {
+			return
+		}
+//line stacks.goht:56:22
+		if _, __err = __buf.WriteString("\n</head>\n<body>"); __err != nil {
+			return
+		}
+		/*line stacks.goht:59:6*/ for _, chart := range charts {
+//line stacks.goht:60:13
+			if __err = /*line stacks.goht:60:12*/ EgoChartWidget(chart).Render(ctx, __buf); __err != nil {
+				return
+			}
+			/*line stacks.goht:61:6*/
+		}
+//line stacks.goht:61:11
+		if _, __err = __buf.WriteString("\n</body>\n</html>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:61:11
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line stacks.goht:66:6
+func /*line stacks.goht:66:5*/ EgoChartWidget(name string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoChartWidget", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line stacks.goht:67:10
+		if __err = __buf.Push(ctx, "scripts", "chart", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:67:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:67:10
+			if !__isBuf {
+//line stacks.goht:67:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:67:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:67:10
+			}
+//line stacks.goht:68:2
+			if _, __err = __buf.WriteString("<script src=\"/js/chart.js\"></script>"); __err != nil {
+				return
+			}
+//line stacks.goht:68:2
+			if !__isBuf {
+//line stacks.goht:68:2
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:68:2
+			}
+//line stacks.goht:68:2
+			return
+//line stacks.goht:68:2
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:69:10
+		if _, __err = __buf.WriteString("\n"); __err != nil {
+			return
+		}
+//line stacks.goht:70:10
+		if __err = __buf.Push(ctx, "styles", "", goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line stacks.goht:70:10
+			__buf, __isBuf := __w.(goht.Buffer)
+//line stacks.goht:70:10
+			if !__isBuf {
+//line stacks.goht:70:10
+				__buf = goht.AcquireBuffer(ctx, __w)
+//line stacks.goht:70:10
+				defer goht.ReleaseBuffer(__buf)
+//line stacks.goht:70:10
+			}
+//line stacks.goht:71:2
+			if _, __err = __buf.WriteString("<link rel=\"stylesheet\" href=\"/css/"); __err != nil {
+				return
+			}
+//line stacks.goht:71:40
+			var __var1 string
+//line stacks.goht:71:40
+			if __var1, __err = goht.CaptureErrors(goht.EscapeAttr(goht.EscapeURLPart( /*line stacks.goht:71:39*/ name))); __err != nil {
+				return
+			}
+//line stacks.goht:71:40
+			if _, __err = __buf.WriteString(__var1); __err != nil {
+				return
+			}
+//line stacks.goht:71:47
+			if _, __err = __buf.WriteString(".css\">"); __err != nil {
+				return
+			}
+//line stacks.goht:71:47
+			if !__isBuf {
+//line stacks.goht:71:47
+				_, __err = io.Copy(__w, __buf)
+//line stacks.goht:71:47
+			}
+//line stacks.goht:71:47
+			return
+//line stacks.goht:71:47
+		})); __err != nil {
+			return
+		}
+//line stacks.goht:72:10
+		if _, __err = __buf.WriteString("\n<div class=\"chart\">"); __err != nil {
+			return
+		}
+//line stacks.goht:73:25
+		var __var2 string
+//line stacks.goht:73:25
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line stacks.goht:73:24*/ name)); __err != nil {
+			return
+		}
+//line stacks.goht:73:25
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line stacks.goht:73:32
+		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
+			return
+		}
+//line stacks.goht:73:32
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
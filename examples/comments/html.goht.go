@@ -7,24 +7,29 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line html.goht:3:1
 // HTML comments can be included and will be added to the rendered
 // output.
 // HTML comments are added using the forward slash at the beginning
 // of the line
 
-func HtmlComments() goht.Template {
+//line html.goht:8:7
+func /*line html.goht:8:6*/ HtmlComments() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HtmlComments", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:9:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p>\n<!--This is a HTML comment-->\n"); __err != nil {
 			return
 		}
+//line html.goht:10:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -32,19 +37,23 @@ func HtmlComments() goht.Template {
 	})
 }
 
-func HamlHtmlComments() goht.Template {
+//line html.goht:13:7
+func /*line html.goht:13:6*/ HamlHtmlComments() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlHtmlComments", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:14:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p>\n<!--This is a HTML comment-->\n"); __err != nil {
 			return
 		}
+//line html.goht:15:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -52,22 +61,27 @@ func HamlHtmlComments() goht.Template {
 	})
 }
 
+//line html.goht:18:1
 // HTML comments in the Slim syntax use "/!" to indicate the start of
 // the comment.
 
-func SlimHtmlComments() goht.Template {
+//line html.goht:21:7
+func /*line html.goht:21:6*/ SlimHtmlComments() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimHtmlComments", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:22:2
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p><!--This is a HTML comment-->\n"); __err != nil {
 			return
 		}
+//line html.goht:23:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -75,23 +89,28 @@ func SlimHtmlComments() goht.Template {
 	})
 }
 
+//line html.goht:26:1
 // You may also use them to comment out nested elements. This does
 // not stop the nested elements from being parsed, just from being
 // displayed.
 
-func HtmlCommentsNested() goht.Template {
+//line html.goht:30:7
+func /*line html.goht:30:6*/ HtmlCommentsNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HtmlCommentsNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:31:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p>\n<!--\n<p>This is a paragraph that is commented out</p>\n-->\n"); __err != nil {
 			return
 		}
+//line html.goht:33:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -99,19 +118,23 @@ func HtmlCommentsNested() goht.Template {
 	})
 }
 
-func HamlHtmlCommentsNested() goht.Template {
+//line html.goht:36:7
+func /*line html.goht:36:6*/ HamlHtmlCommentsNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlHtmlCommentsNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:37:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p>\n<!--\n<p>This is a paragraph that is commented out</p>\n-->\n"); __err != nil {
 			return
 		}
+//line html.goht:39:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -119,19 +142,23 @@ func HamlHtmlCommentsNested() goht.Template {
 	})
 }
 
-func SlimHtmlCommentsNested() goht.Template {
+//line html.goht:42:7
+func /*line html.goht:42:6*/ SlimHtmlCommentsNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimHtmlCommentsNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line html.goht:43:2
 		if _, __err = __buf.WriteString("<p>This is a paragraph</p><!--p This is a paragraph that is commented out-->\n"); __err != nil {
 			return
 		}
+//line html.goht:45:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line rubystyle.goht:3:1
 // You may use ruby style comments to completely remove a line or
 // even a block of nested elements.
 // This is accomplished by using the `-#` syntax.
@@ -16,19 +17,23 @@ import "github.com/stackus/goht"
 // not the parsed by the compiler and will not be included in the
 // output.
 
-func RubyStyle() goht.Template {
+//line rubystyle.goht:12:7
+func /*line rubystyle.goht:12:6*/ RubyStyle() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RubyStyle", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:13:3
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:13:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -36,19 +41,23 @@ func RubyStyle() goht.Template {
 	})
 }
 
-func HamlRubyStyle() goht.Template {
+//line rubystyle.goht:17:7
+func /*line rubystyle.goht:17:6*/ HamlRubyStyle() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRubyStyle", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:18:3
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:18:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -56,22 +65,27 @@ func HamlRubyStyle() goht.Template {
 	})
 }
 
+//line rubystyle.goht:22:1
 // In the Slim syntax "RubyStyle" comments use a "/" to indicate the
 // start of the comment
 
-func SlimRubyStyle() goht.Template {
+//line rubystyle.goht:25:7
+func /*line rubystyle.goht:25:6*/ SlimRubyStyle() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimRubyStyle", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:26:2
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:26:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -79,21 +93,26 @@ func SlimRubyStyle() goht.Template {
 	})
 }
 
+//line rubystyle.goht:30:1
 // Ruby style comments can comment nested content.
 
-func RubyStyleNested() goht.Template {
+//line rubystyle.goht:32:7
+func /*line rubystyle.goht:32:6*/ RubyStyleNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RubyStyleNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:33:3
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:33:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -101,19 +120,23 @@ func RubyStyleNested() goht.Template {
 	})
 }
 
-func HamlRubyStyleNested() goht.Template {
+//line rubystyle.goht:39:7
+func /*line rubystyle.goht:39:6*/ HamlRubyStyleNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRubyStyleNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:40:3
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:40:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -121,19 +144,23 @@ func HamlRubyStyleNested() goht.Template {
 	})
 }
 
-func SlimRubyStyleNested() goht.Template {
+//line rubystyle.goht:46:7
+func /*line rubystyle.goht:46:6*/ SlimRubyStyleNested() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimRubyStyleNested", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line rubystyle.goht:47:2
 		if _, __err = __buf.WriteString("<p>This is the only paragraph in the output.</p>\n"); __err != nil {
 			return
 		}
+//line rubystyle.goht:47:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
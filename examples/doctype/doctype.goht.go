@@ -9,19 +9,25 @@ import "github.com/stackus/goht"
 
 // Adds a doctype to the top of the page
 // HTML5 doctype is the default
-func Doctype() goht.Template {
+//
+//line doctype.goht:3:1
+//line doctype.goht:5:7
+func /*line doctype.goht:5:6*/ Doctype() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Doctype", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doctype.goht:6:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n"); __err != nil {
 			return
 		}
+//line doctype.goht:6:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -29,19 +35,23 @@ func Doctype() goht.Template {
 	})
 }
 
-func HamlDoctype() goht.Template {
+//line doctype.goht:9:7
+func /*line doctype.goht:9:6*/ HamlDoctype() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlDoctype", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doctype.goht:10:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n"); __err != nil {
 			return
 		}
+//line doctype.goht:10:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -49,19 +59,23 @@ func HamlDoctype() goht.Template {
 	})
 }
 
-func SlimDoctype() goht.Template {
+//line doctype.goht:13:7
+func /*line doctype.goht:13:6*/ SlimDoctype() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimDoctype", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doctype.goht:14:9
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n"); __err != nil {
 			return
 		}
+//line doctype.goht:14:9
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -4,11 +4,12 @@ import (
 	"bytes"
 	"context"
 	"flag"
-	"os"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"testing"
 
-	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stackus/errors"
 
 	"github.com/stackus/goht"
@@ -23,40 +24,11 @@ import (
 	"github.com/stackus/goht/examples/indents"
 	"github.com/stackus/goht/examples/tags"
 	unescape "github.com/stackus/goht/examples/unescaping"
+	"github.com/stackus/goht/gohttest"
 )
 
-var (
-	update = flag.Bool("update", false, "update the generated golden files")
-)
-
-func goldenFile(t *testing.T, fileName string, got []byte, update bool) ([]byte, error) {
-	t.Helper()
-
-	want, err := os.ReadFile(fileName)
-	if err != nil {
-		if !update || !errors.Is(err, os.ErrNotExist) {
-			return nil, err
-		}
-	}
-
-	// If the update flag is set, write the golden file when either the file does not exist or the contents do not match.
-	if update && (!bytes.Equal(want, got) || err != nil) {
-		// Create the directory if it doesn't exist
-		dir := filepath.Dir(fileName)
-		err := os.MkdirAll(dir, 0755)
-		if err != nil {
-			return nil, err
-		}
-		err = os.WriteFile(fileName, got, 0644)
-		if err != nil {
-			return nil, err
-		}
-
-		return got, nil
-	}
-
-	return want, nil
-}
+// the golden files are updated by gohttest when the tests are run with -update
+var _ = flag.Bool("update", false, "update the golden files")
 
 func TestHamlExamples(t *testing.T) {
 	tests := map[string]struct {
@@ -67,14 +39,26 @@ func TestHamlExamples(t *testing.T) {
 			template: attributes.HamlAttributesCmd(),
 			htmlFile: "attributes_attributesCmd",
 		},
+		"attributes_orderedAttributesCmd": {
+			template: attributes.OrderedAttributesCmd(attributes.ButtonProps{Label: "Close", Target: "#dialog"}),
+			htmlFile: "attributes_orderedAttributesCmd",
+		},
 		"attributes_classes": {
 			template: attributes.HamlClasses(),
 			htmlFile: "attributes_classes",
 		},
+		"attributes_composedClasses": {
+			template: attributes.ComposedClasses(true, attributes.CardProps{Elevated: true}),
+			htmlFile: "attributes_composedClasses",
+		},
 		"attributes_staticAttrs": {
 			template: attributes.HamlStaticAttrs(),
 			htmlFile: "attributes_staticAttrs",
 		},
+		"attributes_htmlStaticAttrs": {
+			template: attributes.HamlHTMLStaticAttrs(),
+			htmlFile: "attributes_staticAttrs",
+		},
 		"attributes_dynamicAttrs": {
 			template: attributes.DynamicAttrs(),
 			htmlFile: "attributes_dynamicAttrs",
@@ -103,6 +87,14 @@ func TestHamlExamples(t *testing.T) {
 			template: attributes.ConditionalAttrs(),
 			htmlFile: "attributes_conditionalAttrs",
 		},
+		"attributes_nestedAttrs": {
+			template: attributes.NestedAttrs(),
+			htmlFile: "attributes_nestedAttrs",
+		},
+		"attributes_htmlStyleAttrs": {
+			template: attributes.HTMLStyleAttrs(),
+			htmlFile: "attributes_htmlStyleAttrs",
+		},
 		"commands_childrenExample": {
 			template: commands.HamlChildrenExample(),
 			htmlFile: "commands_childrenExample",
@@ -111,6 +103,34 @@ func TestHamlExamples(t *testing.T) {
 			template: commands.HamlRenderExample(),
 			htmlFile: "commands_renderExample",
 		},
+		"commands_flushExample": {
+			template: commands.HamlFlushExample(),
+			htmlFile: "commands_flushExample",
+		},
+		"commands_fragmentExample": {
+			template: commands.HamlFragmentExample([]string{"Apples", "Bread"}),
+			htmlFile: "commands_fragmentExample",
+		},
+		"commands_pageExample": {
+			template: commands.PageExample(),
+			htmlFile: "commands_pageExample",
+		},
+		"commands_nestedPageExample": {
+			template: commands.NestedPageExample(),
+			htmlFile: "commands_nestedPageExample",
+		},
+		"commands_stacks": {
+			template: commands.StackLayout([]string{"sales", "visits"}),
+			htmlFile: "commands_stacks",
+		},
+		"commands_baseLayout": {
+			template: commands.BaseLayout("Base"),
+			htmlFile: "commands_baseLayout",
+		},
+		"commands_flushWhitespaceExample": {
+			template: commands.HamlFlushWhitespaceExample(),
+			htmlFile: "commands_flushWhitespaceExample",
+		},
 		"commands_renderWithChildrenExample": {
 			template: commands.HamlRenderWithChildrenExample(),
 			htmlFile: "commands_renderWithChildrenExample",
@@ -135,6 +155,14 @@ func TestHamlExamples(t *testing.T) {
 			template: doctype.HamlDoctype(),
 			htmlFile: "doctype_doctype",
 		},
+		"doctype_xhtml": {
+			template: doctype.XHTMLPage(true),
+			htmlFile: "doctype_xhtml",
+		},
+		"doctype_feed": {
+			template: doctype.Feed([]doctype.FeedItem{{Title: "A & B", Link: "https://example.com/a"}}),
+			htmlFile: "doctype_feed",
+		},
 		"filters_css": {
 			template: filters.HamlCss(),
 			htmlFile: "filters_css",
@@ -295,33 +323,19 @@ func TestHamlExamples(t *testing.T) {
 			template: unescape.HamlUnescapeText(),
 			htmlFile: "unescape_unescapeText",
 		},
+		"unescape_trustedValues": {
+			template: unescape.HamlTrustedValues(),
+			htmlFile: "unescape_trustedValues",
+		},
+		"unescape_sanitizedValues": {
+			template: unescape.HamlSanitizedValues(),
+			htmlFile: "unescape_sanitizedValues",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			var gotW bytes.Buffer
-			err := tt.template.Render(context.Background(), &gotW)
-			if err != nil {
-				t.Errorf("error generating template: %v", err)
-				return
-			}
-
-			got := gotW.Bytes()
-			goldenFileName := filepath.Join("testdata", "haml", tt.htmlFile+".html")
-			want, err := goldenFile(t, goldenFileName, got, *update)
-			if err != nil {
-				t.Errorf("error reading golden file: %v", err)
-				return
-			}
-
-			if bytes.Equal(want, got) {
-				return
-			}
-
-			dmp := diffmatchpatch.New()
-			diffs := dmp.DiffMain(string(want), string(got), true)
-			if len(diffs) > 1 {
-				t.Errorf("diff:\n%s", dmp.DiffPrettyText(diffs))
-			}
+			got := gohttest.Render(t, tt.template)
+			gohttest.Golden(t, filepath.Join("testdata", "haml", tt.htmlFile+".html"), got)
 		})
 	}
 }
@@ -335,14 +349,26 @@ func TestSlimExamples(t *testing.T) {
 			template: attributes.SlimAttributesCmd(),
 			htmlFile: "attributes_attributesCmd",
 		},
+		"attributes_orderedAttributesCmd": {
+			template: attributes.SlimOrderedAttributesCmd(attributes.ButtonProps{Label: "Close", Target: "#dialog"}),
+			htmlFile: "attributes_orderedAttributesCmd",
+		},
 		"attributes_classes": {
 			template: attributes.SlimClasses(),
 			htmlFile: "attributes_classes",
 		},
+		"attributes_composedClasses": {
+			template: attributes.SlimComposedClasses(true, attributes.CardProps{Elevated: true}),
+			htmlFile: "attributes_composedClasses",
+		},
 		"attributes_staticAttrs": {
 			template: attributes.SlimStaticAttrs(),
 			htmlFile: "attributes_staticAttrs",
 		},
+		"attributes_htmlStaticAttrs": {
+			template: attributes.SlimHTMLStaticAttrs(),
+			htmlFile: "attributes_staticAttrs",
+		},
 		"attributes_dynamicAttrs": {
 			template: attributes.SlimDynamicAttrs(),
 			htmlFile: "attributes_dynamicAttrs",
@@ -371,6 +397,14 @@ func TestSlimExamples(t *testing.T) {
 			template: attributes.SlimConditionalAttrs(),
 			htmlFile: "attributes_conditionalAttrs",
 		},
+		"attributes_nestedAttrs": {
+			template: attributes.SlimNestedAttrs(),
+			htmlFile: "attributes_nestedAttrs",
+		},
+		"attributes_htmlStyleAttrs": {
+			template: attributes.SlimHTMLStyleAttrs(),
+			htmlFile: "attributes_htmlStyleAttrs",
+		},
 		"commands_childrenExample": {
 			template: commands.SlimChildrenExample(),
 			htmlFile: "commands_childrenExample",
@@ -379,6 +413,22 @@ func TestSlimExamples(t *testing.T) {
 			template: commands.SlimRenderExample(),
 			htmlFile: "commands_renderExample",
 		},
+		"commands_flushExample": {
+			template: commands.SlimFlushExample(),
+			htmlFile: "commands_flushExample",
+		},
+		"commands_fragmentExample": {
+			template: commands.SlimFragmentExample([]string{"Apples", "Bread"}),
+			htmlFile: "commands_fragmentExample",
+		},
+		"commands_pageExample": {
+			template: commands.SlimPageExample(),
+			htmlFile: "commands_pageExample",
+		},
+		"commands_stacks": {
+			template: commands.SlimStackLayout([]string{"sales", "visits"}),
+			htmlFile: "commands_stacks",
+		},
 		"commands_renderWithChildrenExample": {
 			template: commands.SlimRenderWithChildrenExample(),
 			htmlFile: "commands_renderWithChildrenExample",
@@ -403,6 +453,14 @@ func TestSlimExamples(t *testing.T) {
 			template: doctype.SlimDoctype(),
 			htmlFile: "doctype_doctype",
 		},
+		"doctype_xhtml": {
+			template: doctype.SlimXHTMLPage(true),
+			htmlFile: "doctype_xhtml",
+		},
+		"doctype_feed": {
+			template: doctype.SlimFeed([]doctype.FeedItem{{Title: "A & B", Link: "https://example.com/a"}}),
+			htmlFile: "doctype_feed",
+		},
 		"filters_css": {
 			template: filters.SlimCss(),
 			htmlFile: "filters_css",
@@ -533,73 +591,336 @@ func TestSlimExamples(t *testing.T) {
 			template: unescape.SlimUnescapeCode(),
 			htmlFile: "unescape_unescapeCode",
 		},
+		"unescape_trustedValues": {
+			template: unescape.SlimTrustedValues(),
+			htmlFile: "unescape_trustedValues",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			var gotW bytes.Buffer
-			err := tt.template.Render(context.Background(), &gotW)
-			if err != nil {
-				t.Errorf("error generating template: %v", err)
-				return
+			got := gohttest.Render(t, tt.template)
+			gohttest.Golden(t, filepath.Join("testdata", "slim", tt.htmlFile+".html"), got)
+		})
+	}
+}
+
+func TestEgoExamples(t *testing.T) {
+	tests := map[string]struct {
+		template goht.Template
+		htmlFile string
+	}{
+		"commands_flushExample": {
+			template: commands.EgoFlushExample(),
+			htmlFile: "commands_flushExample",
+		},
+		"commands_fragmentExample": {
+			template: commands.EgoFragmentExample([]string{"Apples", "Bread"}),
+			htmlFile: "commands_fragmentExample",
+		},
+		"commands_pageExample": {
+			template: commands.EgoPageExample(),
+			htmlFile: "commands_pageExample",
+		},
+		"commands_stacks": {
+			template: commands.EgoStackLayout([]string{"sales", "visits"}),
+			htmlFile: "commands_stacks",
+		},
+		"hello_world": {
+			template: hello.EgoWorld(),
+			htmlFile: "hello_world",
+		},
+		"unescape_trustedValues": {
+			template: unescape.EgoTrustedValues(),
+			htmlFile: "unescape_trustedValues",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := gohttest.Render(t, tt.template)
+			gohttest.Golden(t, filepath.Join("testdata", "ego", tt.htmlFile+".html"), got)
+		})
+	}
+}
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushes []string
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushes = append(r.flushes, r.String())
+}
+
+func TestStreamingExamples(t *testing.T) {
+	tests := map[string]struct {
+		template goht.Template
+		options  []goht.StreamOption
+		flushes  int
+	}{
+		"haml_flush": {
+			template: commands.HamlFlushExample(),
+			flushes:  1,
+		},
+		"slim_flush": {
+			template: commands.SlimFlushExample(),
+			flushes:  1,
+		},
+		"ego_flush": {
+			template: commands.EgoFlushExample(),
+			flushes:  1,
+		},
+		"flush_after_head": {
+			template: hello.World(),
+			options:  []goht.StreamOption{goht.FlushAfter("head")},
+			flushes:  1,
+		},
+		"flush_after_each_term": {
+			template: hello.World(),
+			options:  []goht.StreamOption{goht.FlushAfter("p")},
+			flushes:  19,
+		},
+		"nested_whitespace_removal": {
+			template: tags.RemoveWhitespace(),
+			options:  []goht.StreamOption{goht.FlushAfter("p")},
+			flushes:  1,
+		},
+		"flush_whitespace_removal": {
+			template: commands.HamlFlushWhitespaceExample(),
+			flushes:  2,
+		},
+		"whitespace_removal": {
+			template: tags.HamlRemoveWhitespace(),
+			flushes:  0,
+		},
+		"haml_stacks": {
+			template: commands.StackLayout([]string{"sales", "visits"}),
+			options:  []goht.StreamOption{goht.FlushAfter("head", "div")},
+			flushes:  3,
+		},
+		"slim_stacks": {
+			template: commands.SlimStackLayout([]string{"sales", "visits"}),
+			options:  []goht.StreamOption{goht.FlushAfter("head", "div")},
+			flushes:  3,
+		},
+		"ego_stacks": {
+			template: commands.EgoStackLayout([]string{"sales", "visits"}),
+			options:  []goht.StreamOption{goht.FlushAfter("head", "div")},
+			flushes:  3,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var wantW bytes.Buffer
+			if err := tt.template.Render(context.Background(), &wantW); err != nil {
+				t.Fatalf("error rendering template: %v", err)
 			}
 
-			got := gotW.Bytes()
-			goldenFileName := filepath.Join("testdata", "slim", tt.htmlFile+".html")
-			want, err := goldenFile(t, goldenFileName, got, *update)
-			if err != nil {
-				t.Errorf("error reading golden file: %v", err)
-				return
+			var gotW flushRecorder
+			if err := goht.RenderStream(context.Background(), &gotW, tt.template, tt.options...); err != nil {
+				t.Fatalf("error streaming template: %v", err)
 			}
 
-			if bytes.Equal(want, got) {
-				return
+			if len(gotW.flushes) != tt.flushes {
+				t.Errorf("want %d flushes, got %d: %q", tt.flushes, len(gotW.flushes), gotW.flushes)
+			}
+			for _, flushed := range gotW.flushes {
+				if !bytes.HasPrefix(wantW.Bytes(), []byte(flushed)) {
+					t.Errorf("flushed output is not a prefix of the rendered output: %q", flushed)
+				}
 			}
 
-			dmp := diffmatchpatch.New()
-			diffs := dmp.DiffMain(string(want), string(got), true)
-			if len(diffs) > 1 {
-				t.Errorf("diff:\n%s", dmp.DiffPrettyText(diffs))
+			if !bytes.Equal(wantW.Bytes(), gotW.Bytes()) {
+				t.Errorf("streamed output does not match the rendered output:\n%s", gohttest.Diff(wantW.String(), gotW.String()))
 			}
 		})
 	}
 }
 
-func TestEgoExamples(t *testing.T) {
+func TestFragmentExamples(t *testing.T) {
+	items := []string{"Apples", "Bread"}
 	tests := map[string]struct {
 		template goht.Template
+		fragment string
 		htmlFile string
+		wantErr  error
 	}{
-		"hello_world": {
-			template: hello.EgoWorld(),
-			htmlFile: "hello_world",
+		"haml_cart_items": {
+			template: commands.HamlFragmentExample(items),
+			fragment: "cart-items",
+			htmlFile: "haml/commands_fragmentExample_cartItems",
+		},
+		"haml_cart_count": {
+			template: commands.HamlFragmentExample(items),
+			fragment: "cart-count",
+			htmlFile: "haml/commands_fragmentExample_cartCount",
+		},
+		"slim_cart_items": {
+			template: commands.SlimFragmentExample(items),
+			fragment: "cart-items",
+			htmlFile: "slim/commands_fragmentExample_cartItems",
+		},
+		"ego_cart_items": {
+			template: commands.EgoFragmentExample(items),
+			fragment: "cart-items",
+			htmlFile: "ego/commands_fragmentExample_cartItems",
+		},
+		"missing_fragment": {
+			template: commands.HamlFragmentExample(items),
+			fragment: "missing",
+			wantErr:  goht.ErrFragmentNotFound,
 		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			var gotW bytes.Buffer
-			err := tt.template.Render(context.Background(), &gotW)
-			if err != nil {
-				t.Errorf("error generating template: %v", err)
+			err := goht.RenderFragment(context.Background(), &gotW, tt.template, tt.fragment)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want error %v, got %v", tt.wantErr, err)
+				}
 				return
 			}
-
-			got := gotW.Bytes()
-			goldenFileName := filepath.Join("testdata", "ego", tt.htmlFile+".html")
-			want, err := goldenFile(t, goldenFileName, got, *update)
 			if err != nil {
-				t.Errorf("error reading golden file: %v", err)
+				t.Errorf("error rendering fragment: %v", err)
 				return
 			}
 
-			if bytes.Equal(want, got) {
-				return
+			gohttest.Golden(t, filepath.Join("testdata", tt.htmlFile+".html"), gotW.Bytes())
+		})
+	}
+}
+
+func TestFragmentExamples_Buffer(t *testing.T) {
+	items := []string{"Apples", "Bread"}
+	htmlFile := filepath.Join("testdata", "haml/commands_fragmentExample_cartItems.html")
+
+	t.Run("buffer", func(t *testing.T) {
+		buf := goht.GetBuffer()
+		defer goht.ReleaseBuffer(buf)
+		if err := goht.RenderFragment(context.Background(), buf, commands.HamlFragmentExample(items), "cart-items"); err != nil {
+			t.Fatalf("error rendering fragment: %v", err)
+		}
+
+		gohttest.Golden(t, htmlFile, buf.Bytes())
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		fragment := goht.TemplateFunc(func(ctx context.Context, w io.Writer, _ ...goht.SlottedTemplate) error {
+			return goht.RenderFragment(ctx, w, commands.HamlFragmentExample(items), "cart-items")
+		})
+		rec := httptest.NewRecorder()
+		goht.Handler(fragment).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("want status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		gohttest.Golden(t, htmlFile, rec.Body.Bytes())
+	})
+}
+
+func TestScopedSlotExamples(t *testing.T) {
+	items := []string{"Apples", "Bread"}
+	cell := goht.SlotFunc[string](func(item string) goht.Template {
+		return commands.ItemCell(item)
+	})
+	tests := map[string]struct {
+		template goht.Template
+		slots    []goht.SlottedTemplate
+		htmlFile string
+	}{
+		"haml": {
+			template: commands.ItemTable(items),
+			slots:    []goht.SlottedTemplate{cell.Slot("row")},
+			htmlFile: "haml/commands_scopedSlots",
+		},
+		"haml_default": {
+			template: commands.ItemTable(items),
+			htmlFile: "haml/commands_scopedSlotsDefault",
+		},
+		"slim": {
+			template: commands.SlimItemTable(items),
+			slots:    []goht.SlottedTemplate{cell.Slot("row")},
+			htmlFile: "slim/commands_scopedSlots",
+		},
+		"ego": {
+			template: commands.EgoItemTable(items),
+			slots:    []goht.SlottedTemplate{cell.Slot("row")},
+			htmlFile: "ego/commands_scopedSlots",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotW bytes.Buffer
+			if err := tt.template.Render(context.Background(), &gotW, tt.slots...); err != nil {
+				t.Fatalf("error rendering template: %v", err)
 			}
+			gohttest.Golden(t, filepath.Join("testdata", tt.htmlFile+".html"), gotW.Bytes())
+		})
+	}
+}
+
+func TestScopedSlotTypeMismatch(t *testing.T) {
+	cell := goht.SlotFunc[int](func(i int) goht.Template {
+		return commands.ItemCell("wrong")
+	})
+	err := commands.ItemTable([]string{"Apples"}).Render(context.Background(), io.Discard, cell.Slot("row"))
+	want := `goht: slot "row" expects a value of type int but got string`
+	if err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+}
 
-			dmp := diffmatchpatch.New()
-			diffs := dmp.DiffMain(string(want), string(got), true)
-			if len(diffs) > 1 {
-				t.Errorf("diff:\n%s", dmp.DiffPrettyText(diffs))
+func TestPanicExamples(t *testing.T) {
+	tests := map[string]struct {
+		template goht.Template
+		name     string
+		line     int
+	}{
+		"haml": {
+			template: example.HamlPanics([]string{"Apples"}),
+			name:     "HamlPanics",
+			line:     14,
+		},
+		"slim": {
+			template: example.SlimPanics([]string{"Apples"}),
+			name:     "SlimPanics",
+			line:     20,
+		},
+		"ego": {
+			template: example.EgoPanics([]string{"Apples"}),
+			name:     "EgoPanics",
+			line:     26,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotW bytes.Buffer
+			err := tt.template.Render(goht.WithPanicRecovery(context.Background()), &gotW)
+			var pErr *goht.PanicError
+			if !errors.As(err, &pErr) {
+				t.Fatalf("want a panic error, got %v", err)
+			}
+			if pErr.Template != tt.name {
+				t.Errorf("want template %s, got %s", tt.name, pErr.Template)
+			}
+			if filepath.Base(pErr.File) != "panics.goht" || pErr.Line != tt.line {
+				t.Errorf("want panics.goht:%d, got %s:%d", tt.line, pErr.File, pErr.Line)
+			}
+			if gotW.Len() != 0 {
+				t.Errorf("want no output, got %q", gotW.String())
 			}
 		})
 	}
 }
+
+func TestFragmentExampleDocument(t *testing.T) {
+	doc := gohttest.RenderDocument(t, commands.HamlFragmentExample([]string{"Apples", "Bread"}))
+
+	items := doc.Find("ul#cart-items > li")
+	if got := items.Texts(); len(got) != 2 || got[0] != "Apples" || got[1] != "Bread" {
+		t.Errorf("want the cart items Apples and Bread, got %q", got)
+	}
+	if got := doc.Find("#cart-count").Text(); got != "2" {
+		t.Errorf("want a cart count of 2, got %q", got)
+	}
+}
@@ -7,34 +7,43 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line css.goht:3:1
 // You can include CSS into your templates using the `css` filter. Inside
 // this filter you may include interpolated values.
 
 var color = "red"
 
-func Css() goht.Template {
+//line css.goht:8:7
+func /*line css.goht:8:6*/ Css() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Css", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line css.goht:9:3
 		if _, __err = __buf.WriteString("<style>\n.color {\n\tcolor: "); __err != nil {
 			return
 		}
+//line css.goht:11:13
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(color)); __err != nil {
+//line css.goht:11:13
+		if __var1, __err = goht.CaptureErrors(goht.EscapeCSS( /*line css.goht:11:12*/ color)); __err != nil {
 			return
 		}
+//line css.goht:11:13
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line css.goht:11:19
 		if _, __err = __buf.WriteString(";\n}\n</style>"); __err != nil {
 			return
 		}
+//line css.goht:12:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -42,29 +51,37 @@ func Css() goht.Template {
 	})
 }
 
-func HamlCss() goht.Template {
+//line css.goht:15:7
+func /*line css.goht:15:6*/ HamlCss() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlCss", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line css.goht:16:3
 		if _, __err = __buf.WriteString("<style>\n.color {\n\tcolor: "); __err != nil {
 			return
 		}
+//line css.goht:18:13
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(color)); __err != nil {
+//line css.goht:18:13
+		if __var1, __err = goht.CaptureErrors(goht.EscapeCSS( /*line css.goht:18:12*/ color)); __err != nil {
 			return
 		}
+//line css.goht:18:13
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line css.goht:18:19
 		if _, __err = __buf.WriteString(";\n}\n</style>"); __err != nil {
 			return
 		}
+//line css.goht:19:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -72,29 +89,37 @@ func HamlCss() goht.Template {
 	})
 }
 
-func SlimCss() goht.Template {
+//line css.goht:22:7
+func /*line css.goht:22:6*/ SlimCss() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimCss", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line css.goht:23:3
 		if _, __err = __buf.WriteString("<style>\n.color {\n\tcolor: "); __err != nil {
 			return
 		}
+//line css.goht:25:13
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(color)); __err != nil {
+//line css.goht:25:13
+		if __var1, __err = goht.CaptureErrors(goht.EscapeCSS( /*line css.goht:25:12*/ color)); __err != nil {
 			return
 		}
+//line css.goht:25:13
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line css.goht:25:19
 		if _, __err = __buf.WriteString(";\n}\n</style>\n"); __err != nil {
 			return
 		}
+//line css.goht:26:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,35 +7,44 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line javascript.goht:3:1
 // You can include JavaScript into your templates using the JavaScript
 // filter `:javascript`. You may include interpolation values within
 // the JavaScript code to have them replaced with values at render time.
 
 var name = "Bob"
 
-func JavaScript() goht.Template {
+//line javascript.goht:9:7
+func /*line javascript.goht:9:6*/ JavaScript() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "JavaScript", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line javascript.goht:10:3
 		if _, __err = __buf.WriteString("<script>\nconsole.log(\"Hello "); __err != nil {
 			return
 		}
+//line javascript.goht:11:24
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(name)); __err != nil {
+//line javascript.goht:11:24
+		if __var1, __err = goht.CaptureErrors(goht.EscapeJSString( /*line javascript.goht:11:23*/ name)); __err != nil {
 			return
 		}
+//line javascript.goht:11:24
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line javascript.goht:11:29
 		if _, __err = __buf.WriteString("!\");\n</script>"); __err != nil {
 			return
 		}
+//line javascript.goht:11:29
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -43,29 +52,37 @@ func JavaScript() goht.Template {
 	})
 }
 
-func HamlJavaScript() goht.Template {
+//line javascript.goht:14:7
+func /*line javascript.goht:14:6*/ HamlJavaScript() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlJavaScript", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line javascript.goht:15:3
 		if _, __err = __buf.WriteString("<script>\nconsole.log(\"Hello "); __err != nil {
 			return
 		}
+//line javascript.goht:16:24
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(name)); __err != nil {
+//line javascript.goht:16:24
+		if __var1, __err = goht.CaptureErrors(goht.EscapeJSString( /*line javascript.goht:16:23*/ name)); __err != nil {
 			return
 		}
+//line javascript.goht:16:24
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line javascript.goht:16:29
 		if _, __err = __buf.WriteString("!\");\n</script>"); __err != nil {
 			return
 		}
+//line javascript.goht:16:29
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -73,29 +90,37 @@ func HamlJavaScript() goht.Template {
 	})
 }
 
-func SlimJavaScript() goht.Template {
+//line javascript.goht:19:7
+func /*line javascript.goht:19:6*/ SlimJavaScript() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimJavaScript", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line javascript.goht:20:3
 		if _, __err = __buf.WriteString("<script>\nconsole.log(\"Hello "); __err != nil {
 			return
 		}
+//line javascript.goht:21:24
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(name)); __err != nil {
+//line javascript.goht:21:24
+		if __var1, __err = goht.CaptureErrors(goht.EscapeJSString( /*line javascript.goht:21:23*/ name)); __err != nil {
 			return
 		}
+//line javascript.goht:21:24
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line javascript.goht:21:29
 		if _, __err = __buf.WriteString("!\");\n</script>\n"); __err != nil {
 			return
 		}
+//line javascript.goht:21:29
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
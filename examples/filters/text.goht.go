@@ -7,34 +7,43 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line text.goht:3:1
 // The `:plain` filter can be used to display a large amount of text
 // without any parsing. Lines may begin with Haml syntax and it will
 // be ignored.
 // Variable interpolation is still performed.
 
-func Plain() goht.Template {
+//line text.goht:8:7
+func /*line text.goht:8:6*/ Plain() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Plain", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:9:3
 		if _, __err = __buf.WriteString("<p>\nThis is plain text. It <pre>will</pre> be displayed as HTML.\n"); __err != nil {
 			return
 		}
+//line text.goht:12:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors("This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
+//line text.goht:12:6
+		if __var1, __err = goht.CaptureErrors( /*line text.goht:12:5*/ "This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
 			return
 		}
+//line text.goht:12:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:12:67
 		if _, __err = __buf.WriteString("\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:12:67
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -42,29 +51,37 @@ func Plain() goht.Template {
 	})
 }
 
-func HamlPlain() goht.Template {
+//line text.goht:15:7
+func /*line text.goht:15:6*/ HamlPlain() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPlain", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:16:3
 		if _, __err = __buf.WriteString("<p>\nThis is plain text. It <pre>will</pre> be displayed as HTML.\n"); __err != nil {
 			return
 		}
+//line text.goht:19:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors("This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
+//line text.goht:19:6
+		if __var1, __err = goht.CaptureErrors( /*line text.goht:19:5*/ "This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
 			return
 		}
+//line text.goht:19:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:19:67
 		if _, __err = __buf.WriteString("\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:19:67
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -72,29 +89,37 @@ func HamlPlain() goht.Template {
 	})
 }
 
-func Escaped() goht.Template {
+//line text.goht:22:7
+func /*line text.goht:22:6*/ Escaped() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Escaped", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:23:3
 		if _, __err = __buf.WriteString("<p>\nThis is escaped text. It &lt;pre&gt;will not&lt;/pre&gt; be displayed as HTML.\n"); __err != nil {
 			return
 		}
+//line text.goht:26:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString("This <pre>\"will not\"</pre> be interpolated with HTML intact.")); __err != nil {
+//line text.goht:26:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line text.goht:26:5*/ "This <pre>\"will not\"</pre> be interpolated with HTML intact.")); __err != nil {
 			return
 		}
+//line text.goht:26:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:26:71
 		if _, __err = __buf.WriteString("\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:26:71
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -102,29 +127,37 @@ func Escaped() goht.Template {
 	})
 }
 
-func HamlEscaped() goht.Template {
+//line text.goht:29:7
+func /*line text.goht:29:6*/ HamlEscaped() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlEscaped", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:30:3
 		if _, __err = __buf.WriteString("<p>\nThis is escaped text. It &lt;pre&gt;will not&lt;/pre&gt; be displayed as HTML.\n"); __err != nil {
 			return
 		}
+//line text.goht:33:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString("This <pre>\"will not\"</pre> be interpolated with HTML intact.")); __err != nil {
+//line text.goht:33:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line text.goht:33:5*/ "This <pre>\"will not\"</pre> be interpolated with HTML intact.")); __err != nil {
 			return
 		}
+//line text.goht:33:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:33:71
 		if _, __err = __buf.WriteString("\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:33:71
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -132,29 +165,37 @@ func HamlEscaped() goht.Template {
 	})
 }
 
-func Preserve() goht.Template {
+//line text.goht:36:7
+func /*line text.goht:36:6*/ Preserve() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Preserve", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:37:3
 		if _, __err = __buf.WriteString("<p>\nThis is preserved text. It <pre>will</pre> be displayed as HTML.&#x000A;"); __err != nil {
 			return
 		}
+//line text.goht:40:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors("This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
+//line text.goht:40:6
+		if __var1, __err = goht.CaptureErrors( /*line text.goht:40:5*/ "This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
 			return
 		}
+//line text.goht:40:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:40:67
 		if _, __err = __buf.WriteString("&#x000A;\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:40:67
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -162,29 +203,37 @@ func Preserve() goht.Template {
 	})
 }
 
-func HamlPreserve() goht.Template {
+//line text.goht:43:7
+func /*line text.goht:43:6*/ HamlPreserve() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPreserve", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line text.goht:44:3
 		if _, __err = __buf.WriteString("<p>\nThis is preserved text. It <pre>will</pre> be displayed as HTML.&#x000A;"); __err != nil {
 			return
 		}
+//line text.goht:47:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors("This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
+//line text.goht:47:6
+		if __var1, __err = goht.CaptureErrors( /*line text.goht:47:5*/ "This <pre>\"will\"</pre> be interpolated with HTML intact."); __err != nil {
 			return
 		}
+//line text.goht:47:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line text.goht:47:67
 		if _, __err = __buf.WriteString("&#x000A;\n</p>\n"); __err != nil {
 			return
 		}
+//line text.goht:47:67
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
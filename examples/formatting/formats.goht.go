@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line formats.goht:2:1
 // Normally, only strings are allowed as the value printed using the
 // interpolated value in the template. However, if you provide a format
 // before the value that you want outputted then it will be used to
@@ -22,79 +23,107 @@ var floatVar = 123.456
 var boolVar = true
 var stringVar = "Hello"
 
-func IntExample() goht.Template {
+//line formats.goht:17:7
+func /*line formats.goht:17:6*/ IntExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "IntExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:18:3
 		if _, __err = __buf.WriteString("<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:18:23
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d", intVar))); __err != nil {
+//line formats.goht:18:23
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line formats.goht:18:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:18:23
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:18:33
 		if _, __err = __buf.WriteString(").</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:19:23
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b", intVar))); __err != nil {
+//line formats.goht:19:23
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b" /*line formats.goht:19:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:19:23
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:19:33
 		if _, __err = __buf.WriteString(") in binary.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:20:23
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o", intVar))); __err != nil {
+//line formats.goht:20:23
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o" /*line formats.goht:20:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:20:23
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:20:33
 		if _, __err = __buf.WriteString(") in octal.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:21:23
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", intVar))); __err != nil {
+//line formats.goht:21:23
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:21:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:21:23
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:21:33
 		if _, __err = __buf.WriteString(") in hex.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:22:23
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", intVar))); __err != nil {
+//line formats.goht:22:23
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:22:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:22:23
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:22:33
 		if _, __err = __buf.WriteString(") in hex with uppercase.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:23:23
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c", intVar))); __err != nil {
+//line formats.goht:23:23
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c" /*line formats.goht:23:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:23:23
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:23:33
 		if _, __err = __buf.WriteString(") as a character.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:23:33
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -102,79 +131,107 @@ func IntExample() goht.Template {
 	})
 }
 
-func HamlIntExample() goht.Template {
+//line formats.goht:26:7
+func /*line formats.goht:26:6*/ HamlIntExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlIntExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:27:3
 		if _, __err = __buf.WriteString("<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:27:23
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d", intVar))); __err != nil {
+//line formats.goht:27:23
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line formats.goht:27:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:27:23
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:27:33
 		if _, __err = __buf.WriteString(").</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:28:23
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b", intVar))); __err != nil {
+//line formats.goht:28:23
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b" /*line formats.goht:28:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:28:23
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:28:33
 		if _, __err = __buf.WriteString(") in binary.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:29:23
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o", intVar))); __err != nil {
+//line formats.goht:29:23
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o" /*line formats.goht:29:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:29:23
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:29:33
 		if _, __err = __buf.WriteString(") in octal.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:30:23
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", intVar))); __err != nil {
+//line formats.goht:30:23
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:30:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:30:23
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:30:33
 		if _, __err = __buf.WriteString(") in hex.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:31:23
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", intVar))); __err != nil {
+//line formats.goht:31:23
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:31:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:31:23
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:31:33
 		if _, __err = __buf.WriteString(") in hex with uppercase.</p>\n<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:32:23
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c", intVar))); __err != nil {
+//line formats.goht:32:23
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c" /*line formats.goht:32:24*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:32:23
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:32:33
 		if _, __err = __buf.WriteString(") as a character.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:32:33
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -182,79 +239,107 @@ func HamlIntExample() goht.Template {
 	})
 }
 
-func SlimIntExample() goht.Template {
+//line formats.goht:35:7
+func /*line formats.goht:35:6*/ SlimIntExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimIntExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:36:2
 		if _, __err = __buf.WriteString("<p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:36:22
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d", intVar))); __err != nil {
+//line formats.goht:36:22
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line formats.goht:36:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:36:22
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:36:32
 		if _, __err = __buf.WriteString(").</p><p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:37:22
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b", intVar))); __err != nil {
+//line formats.goht:37:22
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%b" /*line formats.goht:37:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:37:22
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:37:32
 		if _, __err = __buf.WriteString(") in binary.</p><p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:38:22
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o", intVar))); __err != nil {
+//line formats.goht:38:22
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%o" /*line formats.goht:38:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:38:22
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:38:32
 		if _, __err = __buf.WriteString(") in octal.</p><p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:39:22
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", intVar))); __err != nil {
+//line formats.goht:39:22
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:39:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:39:22
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:39:32
 		if _, __err = __buf.WriteString(") in hex.</p><p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:40:22
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", intVar))); __err != nil {
+//line formats.goht:40:22
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:40:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:40:22
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:40:32
 		if _, __err = __buf.WriteString(") in hex with uppercase.</p><p>The integer is ("); __err != nil {
 			return
 		}
+//line formats.goht:41:22
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c", intVar))); __err != nil {
+//line formats.goht:41:22
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%c" /*line formats.goht:41:23*/, intVar))); __err != nil {
 			return
 		}
+//line formats.goht:41:22
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:41:32
 		if _, __err = __buf.WriteString(") as a character.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:41:32
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -262,79 +347,107 @@ func SlimIntExample() goht.Template {
 	})
 }
 
-func FloatExample() goht.Template {
+//line formats.goht:44:7
+func /*line formats.goht:44:6*/ FloatExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "FloatExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:45:3
 		if _, __err = __buf.WriteString("<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:45:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f", floatVar))); __err != nil {
+//line formats.goht:45:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f" /*line formats.goht:45:22*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:45:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:45:33
 		if _, __err = __buf.WriteString(").</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:46:21
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e", floatVar))); __err != nil {
+//line formats.goht:46:21
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e" /*line formats.goht:46:22*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:46:21
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:46:33
 		if _, __err = __buf.WriteString(") in scientific notation.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:47:21
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f", floatVar))); __err != nil {
+//line formats.goht:47:21
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f" /*line formats.goht:47:24*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:47:21
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:47:35
 		if _, __err = __buf.WriteString(") with 2 decimal places.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:48:21
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f", floatVar))); __err != nil {
+//line formats.goht:48:21
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f" /*line formats.goht:48:25*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:48:21
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:48:36
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:49:21
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f", floatVar))); __err != nil {
+//line formats.goht:49:21
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f" /*line formats.goht:49:26*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:49:21
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:49:37
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters and left aligned.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:50:21
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f", floatVar))); __err != nil {
+//line formats.goht:50:21
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f" /*line formats.goht:50:26*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:50:21
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:50:37
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters with 0s.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:50:37
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -342,79 +455,107 @@ func FloatExample() goht.Template {
 	})
 }
 
-func HamlFloatExample() goht.Template {
+//line formats.goht:53:7
+func /*line formats.goht:53:6*/ HamlFloatExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlFloatExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:54:3
 		if _, __err = __buf.WriteString("<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:54:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f", floatVar))); __err != nil {
+//line formats.goht:54:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f" /*line formats.goht:54:22*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:54:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:54:33
 		if _, __err = __buf.WriteString(").</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:55:21
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e", floatVar))); __err != nil {
+//line formats.goht:55:21
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e" /*line formats.goht:55:22*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:55:21
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:55:33
 		if _, __err = __buf.WriteString(") in scientific notation.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:56:21
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f", floatVar))); __err != nil {
+//line formats.goht:56:21
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f" /*line formats.goht:56:24*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:56:21
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:56:35
 		if _, __err = __buf.WriteString(") with 2 decimal places.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:57:21
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f", floatVar))); __err != nil {
+//line formats.goht:57:21
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f" /*line formats.goht:57:25*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:57:21
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:57:36
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:58:21
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f", floatVar))); __err != nil {
+//line formats.goht:58:21
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f" /*line formats.goht:58:26*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:58:21
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:58:37
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters and left aligned.</p>\n<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:59:21
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f", floatVar))); __err != nil {
+//line formats.goht:59:21
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f" /*line formats.goht:59:26*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:59:21
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:59:37
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters with 0s.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:59:37
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -422,79 +563,107 @@ func HamlFloatExample() goht.Template {
 	})
 }
 
-func SlimFloatExample() goht.Template {
+//line formats.goht:62:7
+func /*line formats.goht:62:6*/ SlimFloatExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimFloatExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:63:2
 		if _, __err = __buf.WriteString("<p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:63:20
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f", floatVar))); __err != nil {
+//line formats.goht:63:20
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%f" /*line formats.goht:63:21*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:63:20
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:63:32
 		if _, __err = __buf.WriteString(").</p><p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:64:20
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e", floatVar))); __err != nil {
+//line formats.goht:64:20
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%e" /*line formats.goht:64:21*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:64:20
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:64:32
 		if _, __err = __buf.WriteString(") in scientific notation.</p><p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:65:20
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f", floatVar))); __err != nil {
+//line formats.goht:65:20
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.2f" /*line formats.goht:65:23*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:65:20
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:65:34
 		if _, __err = __buf.WriteString(") with 2 decimal places.</p><p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:66:20
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f", floatVar))); __err != nil {
+//line formats.goht:66:20
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%9.2f" /*line formats.goht:66:24*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:66:20
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:66:35
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters.</p><p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:67:20
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f", floatVar))); __err != nil {
+//line formats.goht:67:20
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%-9.2f" /*line formats.goht:67:25*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:67:20
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:67:36
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters and left aligned.</p><p>The float is ("); __err != nil {
 			return
 		}
+//line formats.goht:68:20
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f", floatVar))); __err != nil {
+//line formats.goht:68:20
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%09.2f" /*line formats.goht:68:25*/, floatVar))); __err != nil {
 			return
 		}
+//line formats.goht:68:20
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:68:36
 		if _, __err = __buf.WriteString(") with 2 decimal places and padded to 9 characters with 0s.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:68:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -502,29 +671,37 @@ func SlimFloatExample() goht.Template {
 	})
 }
 
-func BoolExample() goht.Template {
+//line formats.goht:71:7
+func /*line formats.goht:71:6*/ BoolExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "BoolExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:72:3
 		if _, __err = __buf.WriteString("<p>The bool is ("); __err != nil {
 			return
 		}
+//line formats.goht:72:20
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t", boolVar))); __err != nil {
+//line formats.goht:72:20
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t" /*line formats.goht:72:21*/, boolVar))); __err != nil {
 			return
 		}
+//line formats.goht:72:20
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:72:31
 		if _, __err = __buf.WriteString(").</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:72:31
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -532,29 +709,37 @@ func BoolExample() goht.Template {
 	})
 }
 
-func HamlBoolExample() goht.Template {
+//line formats.goht:75:7
+func /*line formats.goht:75:6*/ HamlBoolExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlBoolExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:76:3
 		if _, __err = __buf.WriteString("<p>The bool is ("); __err != nil {
 			return
 		}
+//line formats.goht:76:20
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t", boolVar))); __err != nil {
+//line formats.goht:76:20
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t" /*line formats.goht:76:21*/, boolVar))); __err != nil {
 			return
 		}
+//line formats.goht:76:20
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:76:31
 		if _, __err = __buf.WriteString(").</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:76:31
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -562,29 +747,37 @@ func HamlBoolExample() goht.Template {
 	})
 }
 
-func SlimBoolExample() goht.Template {
+//line formats.goht:79:7
+func /*line formats.goht:79:6*/ SlimBoolExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimBoolExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:80:2
 		if _, __err = __buf.WriteString("<p>The bool is ("); __err != nil {
 			return
 		}
+//line formats.goht:80:19
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t", boolVar))); __err != nil {
+//line formats.goht:80:19
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%t" /*line formats.goht:80:20*/, boolVar))); __err != nil {
 			return
 		}
+//line formats.goht:80:19
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:80:30
 		if _, __err = __buf.WriteString(").</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:80:30
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -592,109 +785,149 @@ func SlimBoolExample() goht.Template {
 	})
 }
 
-func StringExample() goht.Template {
+//line formats.goht:83:7
+func /*line formats.goht:83:6*/ StringExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "StringExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:84:3
 		if _, __err = __buf.WriteString("<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:84:22
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(stringVar)); __err != nil {
+//line formats.goht:84:22
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line formats.goht:84:21*/ stringVar)); __err != nil {
 			return
 		}
+//line formats.goht:84:22
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:84:32
 		if _, __err = __buf.WriteString("). Strings do not require any additional formatting.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:85:22
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q", stringVar))); __err != nil {
+//line formats.goht:85:22
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q" /*line formats.goht:85:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:85:22
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:85:35
 		if _, __err = __buf.WriteString(") quoted.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:86:22
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", stringVar))); __err != nil {
+//line formats.goht:86:22
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:86:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:86:22
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:86:35
 		if _, __err = __buf.WriteString(") as hex.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:87:22
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", stringVar))); __err != nil {
+//line formats.goht:87:22
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:87:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:87:22
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:87:35
 		if _, __err = __buf.WriteString(") as hex with uppercase.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:88:22
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s", stringVar))); __err != nil {
+//line formats.goht:88:22
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s" /*line formats.goht:88:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:88:22
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:88:35
 		if _, __err = __buf.WriteString(") as is.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:89:22
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s", stringVar))); __err != nil {
+//line formats.goht:89:22
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s" /*line formats.goht:89:25*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:89:22
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:89:37
 		if _, __err = __buf.WriteString("), truncated to 4 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:90:22
 		var __var7 string
-		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s", stringVar))); __err != nil {
+//line formats.goht:90:22
+		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s" /*line formats.goht:90:24*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:90:22
 		if _, __err = __buf.WriteString(__var7); __err != nil {
 			return
 		}
+//line formats.goht:90:36
 		if _, __err = __buf.WriteString("), padded to 6 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:91:22
 		var __var8 string
-		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s", stringVar))); __err != nil {
+//line formats.goht:91:22
+		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s" /*line formats.goht:91:26*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:91:22
 		if _, __err = __buf.WriteString(__var8); __err != nil {
 			return
 		}
+//line formats.goht:91:38
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:92:22
 		var __var9 string
-		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q", stringVar))); __err != nil {
+//line formats.goht:92:22
+		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q" /*line formats.goht:92:26*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:92:22
 		if _, __err = __buf.WriteString(__var9); __err != nil {
 			return
 		}
+//line formats.goht:92:38
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters and quoted.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:92:38
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -702,109 +935,149 @@ func StringExample() goht.Template {
 	})
 }
 
-func HamlStringExample() goht.Template {
+//line formats.goht:95:7
+func /*line formats.goht:95:6*/ HamlStringExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlStringExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:96:3
 		if _, __err = __buf.WriteString("<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:96:22
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(stringVar)); __err != nil {
+//line formats.goht:96:22
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line formats.goht:96:21*/ stringVar)); __err != nil {
 			return
 		}
+//line formats.goht:96:22
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:96:32
 		if _, __err = __buf.WriteString("). Strings do not require any additional formatting.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:97:22
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q", stringVar))); __err != nil {
+//line formats.goht:97:22
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q" /*line formats.goht:97:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:97:22
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:97:35
 		if _, __err = __buf.WriteString(") quoted.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:98:22
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", stringVar))); __err != nil {
+//line formats.goht:98:22
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:98:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:98:22
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:98:35
 		if _, __err = __buf.WriteString(") as hex.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:99:22
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", stringVar))); __err != nil {
+//line formats.goht:99:22
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:99:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:99:22
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:99:35
 		if _, __err = __buf.WriteString(") as hex with uppercase.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:100:22
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s", stringVar))); __err != nil {
+//line formats.goht:100:22
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s" /*line formats.goht:100:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:100:22
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:100:35
 		if _, __err = __buf.WriteString(") as is.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:101:22
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s", stringVar))); __err != nil {
+//line formats.goht:101:22
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s" /*line formats.goht:101:25*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:101:22
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:101:37
 		if _, __err = __buf.WriteString("), truncated to 4 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:102:22
 		var __var7 string
-		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s", stringVar))); __err != nil {
+//line formats.goht:102:22
+		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s" /*line formats.goht:102:24*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:102:22
 		if _, __err = __buf.WriteString(__var7); __err != nil {
 			return
 		}
+//line formats.goht:102:36
 		if _, __err = __buf.WriteString("), padded to 6 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:103:22
 		var __var8 string
-		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s", stringVar))); __err != nil {
+//line formats.goht:103:22
+		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s" /*line formats.goht:103:26*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:103:22
 		if _, __err = __buf.WriteString(__var8); __err != nil {
 			return
 		}
+//line formats.goht:103:38
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters.</p>\n<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:104:22
 		var __var9 string
-		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q", stringVar))); __err != nil {
+//line formats.goht:104:22
+		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q" /*line formats.goht:104:26*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:104:22
 		if _, __err = __buf.WriteString(__var9); __err != nil {
 			return
 		}
+//line formats.goht:104:38
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters and quoted.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:104:38
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -812,109 +1085,149 @@ func HamlStringExample() goht.Template {
 	})
 }
 
-func SlimStringExample() goht.Template {
+//line formats.goht:107:7
+func /*line formats.goht:107:6*/ SlimStringExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimStringExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line formats.goht:108:2
 		if _, __err = __buf.WriteString("<p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:108:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(stringVar)); __err != nil {
+//line formats.goht:108:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line formats.goht:108:20*/ stringVar)); __err != nil {
 			return
 		}
+//line formats.goht:108:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line formats.goht:108:31
 		if _, __err = __buf.WriteString("). Strings do not require any additional formatting.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:109:21
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q", stringVar))); __err != nil {
+//line formats.goht:109:21
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%q" /*line formats.goht:109:22*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:109:21
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line formats.goht:109:34
 		if _, __err = __buf.WriteString(") quoted.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:110:21
 		var __var3 string
-		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x", stringVar))); __err != nil {
+//line formats.goht:110:21
+		if __var3, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%x" /*line formats.goht:110:22*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:110:21
 		if _, __err = __buf.WriteString(__var3); __err != nil {
 			return
 		}
+//line formats.goht:110:34
 		if _, __err = __buf.WriteString(") as hex.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:111:21
 		var __var4 string
-		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X", stringVar))); __err != nil {
+//line formats.goht:111:21
+		if __var4, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%X" /*line formats.goht:111:22*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:111:21
 		if _, __err = __buf.WriteString(__var4); __err != nil {
 			return
 		}
+//line formats.goht:111:34
 		if _, __err = __buf.WriteString(") as hex with uppercase.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:112:21
 		var __var5 string
-		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s", stringVar))); __err != nil {
+//line formats.goht:112:21
+		if __var5, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%s" /*line formats.goht:112:22*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:112:21
 		if _, __err = __buf.WriteString(__var5); __err != nil {
 			return
 		}
+//line formats.goht:112:34
 		if _, __err = __buf.WriteString(") as is.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:113:21
 		var __var6 string
-		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s", stringVar))); __err != nil {
+//line formats.goht:113:21
+		if __var6, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%.4s" /*line formats.goht:113:24*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:113:21
 		if _, __err = __buf.WriteString(__var6); __err != nil {
 			return
 		}
+//line formats.goht:113:36
 		if _, __err = __buf.WriteString("), truncated to 4 characters.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:114:21
 		var __var7 string
-		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s", stringVar))); __err != nil {
+//line formats.goht:114:21
+		if __var7, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6s" /*line formats.goht:114:23*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:114:21
 		if _, __err = __buf.WriteString(__var7); __err != nil {
 			return
 		}
+//line formats.goht:114:35
 		if _, __err = __buf.WriteString("), padded to 6 characters.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:115:21
 		var __var8 string
-		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s", stringVar))); __err != nil {
+//line formats.goht:115:21
+		if __var8, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4s" /*line formats.goht:115:25*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:115:21
 		if _, __err = __buf.WriteString(__var8); __err != nil {
 			return
 		}
+//line formats.goht:115:37
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters.</p><p>The string is ("); __err != nil {
 			return
 		}
+//line formats.goht:116:21
 		var __var9 string
-		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q", stringVar))); __err != nil {
+//line formats.goht:116:21
+		if __var9, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%6.4q" /*line formats.goht:116:25*/, stringVar))); __err != nil {
 			return
 		}
+//line formats.goht:116:21
 		if _, __err = __buf.WriteString(__var9); __err != nil {
 			return
 		}
+//line formats.goht:116:37
 		if _, __err = __buf.WriteString("), truncated to 4 characters and padded to 6 characters and quoted.</p>\n"); __err != nil {
 			return
 		}
+//line formats.goht:116:37
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line code.goht:3:1
 // You may include any Go code outside the Goht templates. It will
 // be included in the generated file as-is.
 // The included code, or any Go code can then be called from within the
@@ -16,30 +17,38 @@ func sayHello() string {
 	return "Hello, world!"
 }
 
-func ExecuteCode() goht.Template {
+//line code.goht:12:7
+func /*line code.goht:12:6*/ ExecuteCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ExecuteCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		foo := sayHello()
+		/*line code.goht:13:3*/ foo := sayHello()
+//line code.goht:14:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:14:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(foo)); __err != nil {
+//line code.goht:14:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:14:5*/ foo)); __err != nil {
 			return
 		}
+//line code.goht:14:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:14:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:14:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -47,30 +56,38 @@ func ExecuteCode() goht.Template {
 	})
 }
 
-func HamlExecuteCode() goht.Template {
+//line code.goht:17:7
+func /*line code.goht:17:6*/ HamlExecuteCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlExecuteCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		foo := sayHello()
+		/*line code.goht:18:3*/ foo := sayHello()
+//line code.goht:19:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:19:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(foo)); __err != nil {
+//line code.goht:19:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:19:5*/ foo)); __err != nil {
 			return
 		}
+//line code.goht:19:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:19:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:19:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -78,30 +95,38 @@ func HamlExecuteCode() goht.Template {
 	})
 }
 
-func SlimExecuteCode() goht.Template {
+//line code.goht:22:7
+func /*line code.goht:22:6*/ SlimExecuteCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimExecuteCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		foo := sayHello()
+		/*line code.goht:23:3*/ foo := sayHello()
+//line code.goht:24:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:24:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(foo)); __err != nil {
+//line code.goht:24:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:24:4*/ foo)); __err != nil {
 			return
 		}
+//line code.goht:24:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:24:5
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:24:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -109,29 +134,37 @@ func SlimExecuteCode() goht.Template {
 	})
 }
 
-func RenderCode() goht.Template {
+//line code.goht:27:7
+func /*line code.goht:27:6*/ RenderCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RenderCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line code.goht:28:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:28:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(sayHello())); __err != nil {
+//line code.goht:28:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:28:5*/ sayHello())); __err != nil {
 			return
 		}
+//line code.goht:28:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:28:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:28:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -139,29 +172,37 @@ func RenderCode() goht.Template {
 	})
 }
 
-func HamlRenderCode() goht.Template {
+//line code.goht:31:7
+func /*line code.goht:31:6*/ HamlRenderCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRenderCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line code.goht:32:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:32:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(sayHello())); __err != nil {
+//line code.goht:32:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:32:5*/ sayHello())); __err != nil {
 			return
 		}
+//line code.goht:32:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:32:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:32:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -169,29 +210,37 @@ func HamlRenderCode() goht.Template {
 	})
 }
 
-func SlimRenderCode() goht.Template {
+//line code.goht:35:7
+func /*line code.goht:35:6*/ SlimRenderCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimRenderCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line code.goht:36:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line code.goht:36:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(sayHello())); __err != nil {
+//line code.goht:36:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line code.goht:36:4*/ sayHello())); __err != nil {
 			return
 		}
+//line code.goht:36:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line code.goht:36:5
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line code.goht:36:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
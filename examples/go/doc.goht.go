@@ -7,21 +7,26 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line doc.goht:1:1
 // Package example is an examples package for the Goht language.
 
-func Doc() goht.Template {
+//line doc.goht:4:7
+func /*line doc.goht:4:6*/ Doc() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Doc", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doc.goht:5:3
 		if _, __err = __buf.WriteString("<div class=\"doc\">An example of package documentation.</div>\n"); __err != nil {
 			return
 		}
+//line doc.goht:5:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -29,19 +34,23 @@ func Doc() goht.Template {
 	})
 }
 
-func HamlDoc() goht.Template {
+//line doc.goht:8:7
+func /*line doc.goht:8:6*/ HamlDoc() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlDoc", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doc.goht:9:3
 		if _, __err = __buf.WriteString("<div class=\"doc\">An example of package documentation.</div>\n"); __err != nil {
 			return
 		}
+//line doc.goht:9:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -49,19 +58,23 @@ func HamlDoc() goht.Template {
 	})
 }
 
-func SlimDoc() goht.Template {
+//line doc.goht:12:7
+func /*line doc.goht:12:6*/ SlimDoc() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimDoc", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line doc.goht:13:3
 		if _, __err = __buf.WriteString("<div class=\"doc\">An example of package documentation.</div>\n"); __err != nil {
 			return
 		}
+//line doc.goht:13:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -11,35 +11,44 @@ import (
 	"strings"
 )
 
+//line imports.goht:9:1
 // Just like in Go files you can specify imports that are needed
 // by your Go code or Go code inlined into the Goht template.
 // Any imports that you specify here will be combined with the
 // imports used by the Goht compiler itself. Duplicate imports
 // will be removed.
 
-func ImportExample() goht.Template {
+//line imports.goht:15:7
+func /*line imports.goht:15:6*/ ImportExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ImportExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line imports.goht:16:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line imports.goht:16:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
+//line imports.goht:16:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line imports.goht:16:5*/ fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
 			return
 		}
+//line imports.goht:16:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line imports.goht:16:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line imports.goht:16:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -47,29 +56,37 @@ func ImportExample() goht.Template {
 	})
 }
 
-func HamlImportExample() goht.Template {
+//line imports.goht:19:7
+func /*line imports.goht:19:6*/ HamlImportExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlImportExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line imports.goht:20:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line imports.goht:20:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
+//line imports.goht:20:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line imports.goht:20:5*/ fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
 			return
 		}
+//line imports.goht:20:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line imports.goht:20:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line imports.goht:20:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -77,29 +94,37 @@ func HamlImportExample() goht.Template {
 	})
 }
 
-func SlimImportExample() goht.Template {
+//line imports.goht:23:7
+func /*line imports.goht:23:6*/ SlimImportExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimImportExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line imports.goht:24:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line imports.goht:24:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
+//line imports.goht:24:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line imports.goht:24:4*/ fmt.Sprintf("Hello, %s!", strings.TrimSuffix("World!", "!")))); __err != nil {
 			return
 		}
+//line imports.goht:24:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line imports.goht:24:5
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line imports.goht:24:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -10,36 +10,46 @@ import (
 	"fmt"
 )
 
+//line inlining.goht:7:1
 // You can include Go code to handle conditional and loop statements
 // in your Goht templates.
 
 var isAdmin = true
 
-func Conditional() goht.Template {
+//line inlining.goht:12:7
+func /*line inlining.goht:12:6*/ Conditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Conditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:13:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		if isAdmin {
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:14:4*/ if isAdmin {
+//line inlining.goht:15:5
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:17:4*/
 		} else {
+//line inlining.goht:18:5
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:19:4*/
 		}
+//line inlining.goht:20:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:20:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -47,31 +57,40 @@ func Conditional() goht.Template {
 	})
 }
 
-func HamlConditional() goht.Template {
+//line inlining.goht:23:7
+func /*line inlining.goht:23:6*/ HamlConditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlConditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:24:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		if isAdmin {
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:25:4*/ if isAdmin {
+//line inlining.goht:26:5
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:28:4*/
 		} else {
+//line inlining.goht:29:5
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:30:4*/
 		}
+//line inlining.goht:31:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:31:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -79,31 +98,40 @@ func HamlConditional() goht.Template {
 	})
 }
 
-func SlimConditional() goht.Template {
+//line inlining.goht:34:7
+func /*line inlining.goht:34:6*/ SlimConditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimConditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:35:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">"); __err != nil {
 			return
 		}
-		if isAdmin {
+		/*line inlining.goht:36:4*/ if isAdmin {
+//line inlining.goht:37:4
 			if _, __err = __buf.WriteString("<button><Edit>content</Edit></button>"); __err != nil {
 				return
 			}
+			/*line inlining.goht:39:4*/
 		} else {
+//line inlining.goht:40:4
 			if _, __err = __buf.WriteString("<button>Login</button>"); __err != nil {
 				return
 			}
+			/*line inlining.goht:41:4*/
 		}
+//line inlining.goht:42:3
 		if _, __err = __buf.WriteString("<button>View content</button></div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:42:10
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -111,6 +139,7 @@ func SlimConditional() goht.Template {
 	})
 }
 
+//line inlining.goht:45:1
 // However, we are using Haml and so we're into shortcuts. We can
 // continue to write out the brackets or we can use the shorthand
 // syntax.
@@ -119,31 +148,40 @@ func SlimConditional() goht.Template {
 // Shorthand statements include:
 // for, if, else, else if, switch
 
-func ShorthandConditional() goht.Template {
+//line inlining.goht:53:7
+func /*line inlining.goht:53:6*/ ShorthandConditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ShorthandConditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:54:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		if isAdmin {
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:55:4*/ if isAdmin {
+//line inlining.goht:56:5
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:58:5
 		} else {
+//line inlining.goht:59:5
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:59:12
 		}
+//line inlining.goht:60:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:60:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -151,31 +189,40 @@ func ShorthandConditional() goht.Template {
 	})
 }
 
-func HamlShorthandConditional() goht.Template {
+//line inlining.goht:63:7
+func /*line inlining.goht:63:6*/ HamlShorthandConditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlShorthandConditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:64:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		if isAdmin {
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:65:4*/ if isAdmin {
+//line inlining.goht:66:5
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:68:5
 		} else {
+//line inlining.goht:69:5
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:69:12
 		}
+//line inlining.goht:70:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:70:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -183,31 +230,40 @@ func HamlShorthandConditional() goht.Template {
 	})
 }
 
-func SlimShorthandConditional() goht.Template {
+//line inlining.goht:73:7
+func /*line inlining.goht:73:6*/ SlimShorthandConditional() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimShorthandConditional", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:74:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">"); __err != nil {
 			return
 		}
-		if isAdmin {
+		/*line inlining.goht:75:4*/ if isAdmin {
+//line inlining.goht:76:4
 			if _, __err = __buf.WriteString("<button>Edit content</button>"); __err != nil {
 				return
 			}
+//line inlining.goht:78:5
 		} else {
+//line inlining.goht:79:4
 			if _, __err = __buf.WriteString("<button>Login</button>"); __err != nil {
 				return
 			}
+//line inlining.goht:79:11
 		}
+//line inlining.goht:80:3
 		if _, __err = __buf.WriteString("<button>View content</button></div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:80:10
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -215,36 +271,46 @@ func SlimShorthandConditional() goht.Template {
 	})
 }
 
+//line inlining.goht:83:1
 // With a switch statement, we can use the case and default keywords
 // but we will need to nest these statements if we're using the
 // shorthand syntax. (win some, lose some)
 
-func ShorthandSwitch() goht.Template {
+//line inlining.goht:87:7
+func /*line inlining.goht:87:6*/ ShorthandSwitch() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ShorthandSwitch", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:88:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		switch isAdmin {
-		case true:
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:89:4*/ switch isAdmin {
+		/*line inlining.goht:90:5*/ case true:
+//line inlining.goht:91:6
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:93:5*/
 		case false:
+//line inlining.goht:94:6
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:94:13
 		}
+//line inlining.goht:95:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:95:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -252,32 +318,41 @@ func ShorthandSwitch() goht.Template {
 	})
 }
 
-func HamlShorthandSwitch() goht.Template {
+//line inlining.goht:98:7
+func /*line inlining.goht:98:6*/ HamlShorthandSwitch() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlShorthandSwitch", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:99:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		switch isAdmin {
-		case true:
-			if _, __err = __buf.WriteString("<button>~☢<\nEdit content\n>☢~</button>\n"); __err != nil {
+		/*line inlining.goht:100:4*/ switch isAdmin {
+		/*line inlining.goht:101:5*/ case true:
+//line inlining.goht:102:6
+			if _, __err = __buf.WriteString("<button>Edit content</button>\n"); __err != nil {
 				return
 			}
+			/*line inlining.goht:104:5*/
 		case false:
+//line inlining.goht:105:6
 			if _, __err = __buf.WriteString("<button>Login</button>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:105:13
 		}
+//line inlining.goht:106:4
 		if _, __err = __buf.WriteString("<button>View content</button>\n</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:106:11
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -285,32 +360,41 @@ func HamlShorthandSwitch() goht.Template {
 	})
 }
 
-func SlimShorthandSwitch() goht.Template {
+//line inlining.goht:109:7
+func /*line inlining.goht:109:6*/ SlimShorthandSwitch() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimShorthandSwitch", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:110:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">"); __err != nil {
 			return
 		}
-		switch isAdmin {
-		case true:
+		/*line inlining.goht:111:4*/ switch isAdmin {
+		/*line inlining.goht:112:5*/ case true:
+//line inlining.goht:113:5
 			if _, __err = __buf.WriteString("<button>Edit content</button>"); __err != nil {
 				return
 			}
+			/*line inlining.goht:115:5*/
 		case false:
+//line inlining.goht:116:5
 			if _, __err = __buf.WriteString("<button>Login</button>"); __err != nil {
 				return
 			}
+//line inlining.goht:116:12
 		}
+//line inlining.goht:117:3
 		if _, __err = __buf.WriteString("<button>View content</button></div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:117:10
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -318,50 +402,65 @@ func SlimShorthandSwitch() goht.Template {
 	})
 }
 
+//line inlining.goht:120:1
 // Haml supported splitting long code lines across multiple lines
 // using a comma. Each line that continues the statement must be indented
 // one additional level. To keep continuing the statement, you can
 // end the line with a comma.
 
-func HamlLongStatement() goht.Template {
+//line inlining.goht:125:7
+func /*line inlining.goht:125:6*/ HamlLongStatement() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlLongStatement", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:126:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">\n"); __err != nil {
 			return
 		}
-		action := longType{title: "Edit content", actions: "Edit content"}
+		/*line inlining.goht:127:4*/ action := longType{title: "Edit content", actions: "Edit content"}
+//line inlining.goht:127:5
 		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
 			return
 		}
+//line inlining.goht:131:4
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(fmt.Sprintf("Title: %s", action.title))); __err != nil {
+//line inlining.goht:131:4
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line inlining.goht:131:3*/ fmt.Sprintf("Title: %s", action.title))); __err != nil {
 			return
 		}
+//line inlining.goht:131:4
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
-		if action.title == "Edit content" && action.actions == "Edit content" {
+		/*line inlining.goht:134:3*/ if action.title == "Edit content" && action.actions == "Edit content" {
+//line inlining.goht:136:4
 			if _, __err = __buf.WriteString("<p>"); __err != nil {
 				return
 			}
+//line inlining.goht:136:7
 			var __var2 string
-			if __var2, __err = goht.CaptureErrors(goht.EscapeString(action.actions)); __err != nil {
+//line inlining.goht:136:7
+			if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line inlining.goht:136:6*/ action.actions)); __err != nil {
 				return
 			}
+//line inlining.goht:136:7
 			if _, __err = __buf.WriteString(__var2); __err != nil {
 				return
 			}
+//line inlining.goht:136:7
 			if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 				return
 			}
+//line inlining.goht:136:7
 		}
+//line inlining.goht:136:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -369,6 +468,7 @@ func HamlLongStatement() goht.Template {
 	})
 }
 
+//line inlining.goht:139:1
 // Slim supports splitting the control code across multiple lines which
 // is useful for long statements. The additional lines must be indented
 // one additional level.
@@ -381,43 +481,56 @@ type longType struct {
 	actions string
 }
 
-func SlimLongStatement() goht.Template {
+//line inlining.goht:151:7
+func /*line inlining.goht:151:6*/ SlimLongStatement() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimLongStatement", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inlining.goht:152:3
 		if _, __err = __buf.WriteString("<div class=\"actions\">"); __err != nil {
 			return
 		}
-		action := longType{title: "Edit content", actions: "Edit content"}
+		/*line inlining.goht:153:4*/ action := longType{title: "Edit content", actions: "Edit content"}
+//line inlining.goht:153:5
 		if _, __err = __buf.WriteString("</div><p>"); __err != nil {
 			return
 		}
+//line inlining.goht:157:4
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(action.actions)); __err != nil {
+//line inlining.goht:157:4
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line inlining.goht:157:3*/ action.actions)); __err != nil {
 			return
 		}
+//line inlining.goht:157:4
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line inlining.goht:157:4
 		if _, __err = __buf.WriteString("</p>"); __err != nil {
 			return
 		}
+//line inlining.goht:158:4
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(fmt.Sprintf("Title: %s", action.title))); __err != nil {
+//line inlining.goht:158:4
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line inlining.goht:158:3*/ fmt.Sprintf("Title: %s", action.title))); __err != nil {
 			return
 		}
+//line inlining.goht:158:4
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line inlining.goht:158:4
 		if _, __err = __buf.WriteString("\n"); __err != nil {
 			return
 		}
+//line inlining.goht:158:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,34 +7,43 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line interpolation.goht:3:1
 // Variables and calls to functions that return values can be interpolated
 // into the Goht templates.
 
 var someVar = "Hello"
 
-func InterpolateCode() goht.Template {
+//line interpolation.goht:8:7
+func /*line interpolation.goht:8:6*/ InterpolateCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "InterpolateCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:9:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:9:7
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:9:7
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:9:6*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:9:7
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:9:15
 		if _, __err = __buf.WriteString(", World!</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:9:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -42,29 +51,37 @@ func InterpolateCode() goht.Template {
 	})
 }
 
-func HamlInterpolateCode() goht.Template {
+//line interpolation.goht:12:7
+func /*line interpolation.goht:12:6*/ HamlInterpolateCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlInterpolateCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:13:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:13:7
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:13:7
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:13:6*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:13:7
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:13:15
 		if _, __err = __buf.WriteString(", World!</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:13:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -72,29 +89,37 @@ func HamlInterpolateCode() goht.Template {
 	})
 }
 
-func SlimInterpolateCode() goht.Template {
+//line interpolation.goht:16:7
+func /*line interpolation.goht:16:6*/ SlimInterpolateCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimInterpolateCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:17:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:17:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:17:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:17:5*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:17:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:17:14
 		if _, __err = __buf.WriteString(", World!</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:17:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -103,39 +128,53 @@ func SlimInterpolateCode() goht.Template {
 }
 
 // Interpolation is not done within Go code or within a string literal.
-func NoInterpolation() goht.Template {
+//
+//line interpolation.goht:20:1
+//line interpolation.goht:21:7
+func /*line interpolation.goht:21:6*/ NoInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "NoInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:22:3
 		if _, __err = __buf.WriteString("<p>Do the following; No interpolation is necessary.</p>\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:23:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar + ", World!")); __err != nil {
+//line interpolation.goht:23:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:23:5*/ someVar + ", World!")); __err != nil {
 			return
 		}
+//line interpolation.goht:23:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:23:6
 		if _, __err = __buf.WriteString("</p>\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:24:6
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString("No interpolation is #{performed} here.")); __err != nil {
+//line interpolation.goht:24:6
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:24:5*/ "No interpolation is #{performed} here.")); __err != nil {
 			return
 		}
+//line interpolation.goht:24:6
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line interpolation.goht:24:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:24:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -143,39 +182,51 @@ func NoInterpolation() goht.Template {
 	})
 }
 
-func HamlNoInterpolation() goht.Template {
+//line interpolation.goht:27:7
+func /*line interpolation.goht:27:6*/ HamlNoInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlNoInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:28:3
 		if _, __err = __buf.WriteString("<p>Do the following; No interpolation is necessary.</p>\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:29:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar + ", World!")); __err != nil {
+//line interpolation.goht:29:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:29:5*/ someVar + ", World!")); __err != nil {
 			return
 		}
+//line interpolation.goht:29:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:29:6
 		if _, __err = __buf.WriteString("</p>\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:30:6
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString("No interpolation is #{performed} here.")); __err != nil {
+//line interpolation.goht:30:6
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:30:5*/ "No interpolation is #{performed} here.")); __err != nil {
 			return
 		}
+//line interpolation.goht:30:6
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line interpolation.goht:30:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:30:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -183,39 +234,51 @@ func HamlNoInterpolation() goht.Template {
 	})
 }
 
-func SlimNoInterpolation() goht.Template {
+//line interpolation.goht:33:7
+func /*line interpolation.goht:33:6*/ SlimNoInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimNoInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:34:2
 		if _, __err = __buf.WriteString("<p>Do the following; No interpolation is necessary.</p><p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:35:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar + ", World!")); __err != nil {
+//line interpolation.goht:35:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:35:4*/ someVar + ", World!")); __err != nil {
 			return
 		}
+//line interpolation.goht:35:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:35:5
 		if _, __err = __buf.WriteString("</p><p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:36:5
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString("No interpolation is #{performed} here.")); __err != nil {
+//line interpolation.goht:36:5
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:36:4*/ "No interpolation is #{performed} here.")); __err != nil {
 			return
 		}
+//line interpolation.goht:36:5
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line interpolation.goht:36:5
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:36:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -223,42 +286,54 @@ func SlimNoInterpolation() goht.Template {
 	})
 }
 
+//line interpolation.goht:39:1
 // Because the interpolation and tag id share the same starting character,
 // a `#` you will need to escape the interpolation with a backslash when it
 // is the first character of a line.
 // This is only necessary when it is the first character of a line and not
 // when it is the first character of text following a tag.
 
-func EscapeInterpolation() goht.Template {
+//line interpolation.goht:45:7
+func /*line interpolation.goht:45:6*/ EscapeInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EscapeInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:46:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:46:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:46:4*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:46:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:46:13
 		if _, __err = __buf.WriteString(", World!\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:47:7
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:47:7
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:47:6*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:47:7
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line interpolation.goht:47:15
 		if _, __err = __buf.WriteString(", World!</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:47:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -266,36 +341,47 @@ func EscapeInterpolation() goht.Template {
 	})
 }
 
-func HamlEscapeInterpolation() goht.Template {
+//line interpolation.goht:50:7
+func /*line interpolation.goht:50:6*/ HamlEscapeInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlEscapeInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:51:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:51:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:51:4*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:51:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line interpolation.goht:51:13
 		if _, __err = __buf.WriteString(", World!\n<p>"); __err != nil {
 			return
 		}
+//line interpolation.goht:52:7
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(someVar)); __err != nil {
+//line interpolation.goht:52:7
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line interpolation.goht:52:6*/ someVar)); __err != nil {
 			return
 		}
+//line interpolation.goht:52:7
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line interpolation.goht:52:15
 		if _, __err = __buf.WriteString(", World!</p>\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:52:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -303,6 +389,7 @@ func HamlEscapeInterpolation() goht.Template {
 	})
 }
 
+//line interpolation.goht:55:1
 // There are also times when you want to ignore the interpolation and just
 // print the text. This is also handled with the backslash.
 // This can be done at the start of a line, after a tag or even mid-text.
@@ -317,19 +404,23 @@ func HamlEscapeInterpolation() goht.Template {
 // All three of the following uses of "someVar" are not interpolated, and
 // will render as "#{someVar}" in the final HTML.
 
-func IgnoreInterpolation() goht.Template {
+//line interpolation.goht:69:7
+func /*line interpolation.goht:69:6*/ IgnoreInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "IgnoreInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:70:4
 		if _, __err = __buf.WriteString("#{someVar}, World!\n<p>\n#{someVar}, World!\n</p>\nA greeting: #{someVar}, World!\n. this line begins with a period\n# this line begins with a hash\n% this line begins with a percent\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:76:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -337,19 +428,23 @@ func IgnoreInterpolation() goht.Template {
 	})
 }
 
-func HamlIgnoreInterpolation() goht.Template {
+//line interpolation.goht:79:7
+func /*line interpolation.goht:79:6*/ HamlIgnoreInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlIgnoreInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line interpolation.goht:80:4
 		if _, __err = __buf.WriteString("#{someVar}, World!\n<p>\n#{someVar}, World!\n</p>\nA greeting: #{someVar}, World!\n. this line begins with a period\n# this line begins with a hash\n% this line begins with a percent\n"); __err != nil {
 			return
 		}
+//line interpolation.goht:86:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
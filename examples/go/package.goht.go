@@ -7,21 +7,25 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line package.goht:3:1
 // Just like in a Go file, you may include a package statement at the
 // top of your file. This is optional, but if you do include it, it
 // must be the first line of the file. If you do not specify a package,
 // the generated code will use "main" as the package name.
 
-func PackageExample() goht.Template {
+//line package.goht:8:7
+func /*line package.goht:8:6*/ PackageExample() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PackageExample", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line package.goht:8:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
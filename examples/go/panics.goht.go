@@ -0,0 +1,173 @@
+// Code generated by GoHT v0.8.3 - DO NOT EDIT.
+// https://github.com/stackus/goht
+
+package example
+
+import "context"
+import "io"
+import "github.com/stackus/goht"
+
+//line panics.goht:3:1
+// The generated Go code contains line directives that point back to the
+// GoHT template. A panic, a compiler error, or a go vet warning will report
+// the template file and line instead of the generated file.
+//
+// A panic can be recovered into an error by rendering the template with a
+// context from `goht.WithPanicRecovery`. The returned `*goht.PanicError`
+// has the name of the template and the line in the template that panicked.
+
+//line panics.goht:11:7
+func /*line panics.goht:11:6*/ HamlPanics(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPanics", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line panics.goht:12:3
+		if _, __err = __buf.WriteString("<ul>\n<li>"); __err != nil {
+			return
+		}
+//line panics.goht:13:8
+		var __var1 string
+//line panics.goht:13:8
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:13:7*/ items[0])); __err != nil {
+			return
+		}
+//line panics.goht:13:8
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line panics.goht:13:8
+		if _, __err = __buf.WriteString("</li>\n<li>"); __err != nil {
+			return
+		}
+//line panics.goht:14:8
+		var __var2 string
+//line panics.goht:14:8
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:14:7*/ items[1])); __err != nil {
+			return
+		}
+//line panics.goht:14:8
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line panics.goht:14:8
+		if _, __err = __buf.WriteString("</li>\n</ul>\n"); __err != nil {
+			return
+		}
+//line panics.goht:14:8
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line panics.goht:17:7
+func /*line panics.goht:17:6*/ SlimPanics(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimPanics", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line panics.goht:18:2
+		if _, __err = __buf.WriteString("<ul><li>"); __err != nil {
+			return
+		}
+//line panics.goht:19:7
+		var __var1 string
+//line panics.goht:19:7
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:19:6*/ items[0])); __err != nil {
+			return
+		}
+//line panics.goht:19:7
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line panics.goht:19:7
+		if _, __err = __buf.WriteString("</li><li>"); __err != nil {
+			return
+		}
+//line panics.goht:20:7
+		var __var2 string
+//line panics.goht:20:7
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:20:6*/ items[1])); __err != nil {
+			return
+		}
+//line panics.goht:20:7
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line panics.goht:20:7
+		if _, __err = __buf.WriteString("</li></ul>\n"); __err != nil {
+			return
+		}
+//line panics.goht:20:7
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
+
+//line panics.goht:23:6
+func /*line panics.goht:23:5*/ EgoPanics(items []string) goht.Template {
+	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoPanics", &__err)
+		__buf, __isBuf := __w.(goht.Buffer)
+		if !__isBuf {
+			__buf = goht.AcquireBuffer(ctx, __w)
+			defer goht.ReleaseBuffer(__buf)
+		}
+		var __children goht.Template
+		ctx, __children = goht.PopChildren(ctx)
+		_ = __children
+//line panics.goht:24:2
+		if _, __err = __buf.WriteString("<ul>\n\t<li>"); __err != nil {
+			return
+		}
+//line panics.goht:25:11
+		var __var1 string
+//line panics.goht:25:11
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:25:10*/ items[0])); __err != nil {
+			return
+		}
+//line panics.goht:25:11
+		if _, __err = __buf.WriteString(__var1); __err != nil {
+			return
+		}
+//line panics.goht:25:22
+		if _, __err = __buf.WriteString("</li>\n\t<li>"); __err != nil {
+			return
+		}
+//line panics.goht:26:11
+		var __var2 string
+//line panics.goht:26:11
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line panics.goht:26:10*/ items[1])); __err != nil {
+			return
+		}
+//line panics.goht:26:11
+		if _, __err = __buf.WriteString(__var2); __err != nil {
+			return
+		}
+//line panics.goht:26:22
+		if _, __err = __buf.WriteString("</li>\n</ul>\n"); __err != nil {
+			return
+		}
+//line panics.goht:26:22
+		if !__isBuf {
+			_, __err = __w.Write(__buf.Bytes())
+		}
+		return
+	})
+}
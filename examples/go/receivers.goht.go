@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line receivers.goht:3:1
 type User struct {
 	Name string
 	Age  int
@@ -23,39 +24,51 @@ type User struct {
 //
 //   @render u.Details()
 
-func (u User) Details() goht.Template {
+//line receivers.goht:19:7
+func /*line receivers.goht:19:6*/ (u User) Details() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "User.Details", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line receivers.goht:20:3
 		if _, __err = __buf.WriteString("<div class=\"name\">User name: "); __err != nil {
 			return
 		}
+//line receivers.goht:20:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(u.Name)); __err != nil {
+//line receivers.goht:20:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line receivers.goht:20:20*/ u.Name)); __err != nil {
 			return
 		}
+//line receivers.goht:20:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line receivers.goht:20:21
 		if _, __err = __buf.WriteString("</div>\n<div class=\"age\">\nUser Age:\n"); __err != nil {
 			return
 		}
+//line receivers.goht:23:5
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.FormatString("%d", u.Age)); __err != nil {
+//line receivers.goht:23:5
+		if __var2, __err = goht.CaptureErrors(goht.FormatString("%d" /*line receivers.goht:23:6*/, u.Age)); __err != nil {
 			return
 		}
+//line receivers.goht:23:5
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line receivers.goht:23:13
 		if _, __err = __buf.WriteString("\n</div>\n"); __err != nil {
 			return
 		}
+//line receivers.goht:23:13
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -63,39 +76,51 @@ func (u User) Details() goht.Template {
 	})
 }
 
-func (u User) HamlDetails() goht.Template {
+//line receivers.goht:26:7
+func /*line receivers.goht:26:6*/ (u User) HamlDetails() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "User.HamlDetails", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line receivers.goht:27:3
 		if _, __err = __buf.WriteString("<div class=\"name\">User name: "); __err != nil {
 			return
 		}
+//line receivers.goht:27:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(u.Name)); __err != nil {
+//line receivers.goht:27:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line receivers.goht:27:20*/ u.Name)); __err != nil {
 			return
 		}
+//line receivers.goht:27:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line receivers.goht:27:21
 		if _, __err = __buf.WriteString("</div>\n<div class=\"age\">\nUser Age:\n"); __err != nil {
 			return
 		}
+//line receivers.goht:30:5
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.FormatString("%d", u.Age)); __err != nil {
+//line receivers.goht:30:5
+		if __var2, __err = goht.CaptureErrors(goht.FormatString("%d" /*line receivers.goht:30:6*/, u.Age)); __err != nil {
 			return
 		}
+//line receivers.goht:30:5
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line receivers.goht:30:13
 		if _, __err = __buf.WriteString("\n</div>\n"); __err != nil {
 			return
 		}
+//line receivers.goht:30:13
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -103,39 +128,51 @@ func (u User) HamlDetails() goht.Template {
 	})
 }
 
-func (u User) SlimDetails() goht.Template {
+//line receivers.goht:33:7
+func /*line receivers.goht:33:6*/ (u User) SlimDetails() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "User.SlimDetails", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line receivers.goht:34:3
 		if _, __err = __buf.WriteString("<div class=\"name\">User name: "); __err != nil {
 			return
 		}
+//line receivers.goht:34:21
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(u.Name)); __err != nil {
+//line receivers.goht:34:21
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line receivers.goht:34:20*/ u.Name)); __err != nil {
 			return
 		}
+//line receivers.goht:34:21
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line receivers.goht:34:21
 		if _, __err = __buf.WriteString("</div><div class=\"age\">User Age:"); __err != nil {
 			return
 		}
+//line receivers.goht:37:4
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d", u.Age))); __err != nil {
+//line receivers.goht:37:4
+		if __var2, __err = goht.CaptureErrors(goht.EscapeString(goht.FormatString("%d" /*line receivers.goht:37:5*/, u.Age))); __err != nil {
 			return
 		}
+//line receivers.goht:37:4
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line receivers.goht:37:4
 		if _, __err = __buf.WriteString("</div>\n"); __err != nil {
 			return
 		}
+//line receivers.goht:37:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
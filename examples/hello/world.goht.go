@@ -7,39 +7,50 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line world.goht:2:1
 // An example of several Haml features supported by Goht.
 
 var terms = []string{"foo", "bar", "baz", "fizz", "buzz", "quux"}
 
-func termsWrapper(term string) goht.Template {
+//line world.goht:6:7
+func /*line world.goht:6:6*/ termsWrapper(term string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "termsWrapper", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:7:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line world.goht:7:14
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line world.goht:7:14
 		if _, __err = __buf.WriteString("</p>\n<p>And it was passed in as well "); __err != nil {
 			return
 		}
+//line world.goht:8:36
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:8:36
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:8:35*/ term)); __err != nil {
 			return
 		}
+//line world.goht:8:36
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line world.goht:8:36
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line world.goht:8:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -47,35 +58,45 @@ func termsWrapper(term string) goht.Template {
 	})
 }
 
-func hamlTermsWrapper(term string) goht.Template {
+//line world.goht:11:7
+func /*line world.goht:11:6*/ hamlTermsWrapper(term string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "hamlTermsWrapper", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:12:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line world.goht:12:14
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line world.goht:12:14
 		if _, __err = __buf.WriteString("</p>\n<p>And it was passed in as well "); __err != nil {
 			return
 		}
+//line world.goht:13:36
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:13:36
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:13:35*/ term)); __err != nil {
 			return
 		}
+//line world.goht:13:36
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line world.goht:13:36
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line world.goht:13:36
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -83,35 +104,45 @@ func hamlTermsWrapper(term string) goht.Template {
 	})
 }
 
-func slimTermsWrapper(term string) goht.Template {
+//line world.goht:16:7
+func /*line world.goht:16:6*/ slimTermsWrapper(term string) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "slimTermsWrapper", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:17:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line world.goht:17:13
 		if __err = __children.Render(ctx, __buf, __sts...); __err != nil {
 			return
 		}
+//line world.goht:17:13
 		if _, __err = __buf.WriteString("</p><p>And it was passed in as well "); __err != nil {
 			return
 		}
+//line world.goht:18:35
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:18:35
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:18:34*/ term)); __err != nil {
 			return
 		}
+//line world.goht:18:35
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line world.goht:18:35
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line world.goht:18:35
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -119,51 +150,74 @@ func slimTermsWrapper(term string) goht.Template {
 	})
 }
 
-func World() goht.Template {
+//line world.goht:21:7
+func /*line world.goht:21:6*/ World() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "World", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:22:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\"><title>Hello World</title>\n<style>\nbody {\n\tcolor: white;\n\tfont-family: sans-serif;\n\tbackground-color: #333;\n}\n.term {\n\tfont-weight: bold;\n\tcolor: #99f;\n}\n</style></head>\n<body>\n<h1>Hello World</h1>\n<p>the following will loop a slice of strings and will pass each string into a child template</p>\n"); __err != nil {
 			return
 		}
-		for _, term := range terms {
+		/*line world.goht:40:5*/ for _, term := range terms {
+//line world.goht:41:14
 			__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line world.goht:41:14
 				__buf, __isBuf := __w.(goht.Buffer)
+//line world.goht:41:14
 				if !__isBuf {
-					__buf = goht.GetBuffer()
+//line world.goht:41:14
+					__buf = goht.AcquireBuffer(ctx, __w)
+//line world.goht:41:14
 					defer goht.ReleaseBuffer(__buf)
+//line world.goht:41:14
 				}
+//line world.goht:42:7
 				if _, __err = __buf.WriteString("<p class=\"term\">"); __err != nil {
 					return
 				}
+//line world.goht:42:15
 				var __var2 string
-				if __var2, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:42:15
+				if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:42:14*/ term)); __err != nil {
 					return
 				}
+//line world.goht:42:15
 				if _, __err = __buf.WriteString(__var2); __err != nil {
 					return
 				}
+//line world.goht:42:15
 				if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 					return
 				}
+//line world.goht:42:15
 				if !__isBuf {
+//line world.goht:42:15
 					_, __err = io.Copy(__w, __buf)
+//line world.goht:42:15
 				}
+//line world.goht:42:15
 				return
+//line world.goht:42:15
 			})
-			if __err = termsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line world.goht:42:15
+			if __err = /*line world.goht:41:13*/ termsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 				return
 			}
+//line world.goht:42:15
 		}
+//line world.goht:42:15
 		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
 			return
 		}
+//line world.goht:42:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -171,51 +225,74 @@ func World() goht.Template {
 	})
 }
 
-func HamlWorld() goht.Template {
+//line world.goht:45:7
+func /*line world.goht:45:6*/ HamlWorld() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlWorld", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:46:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\"><title>Hello World</title>\n<style>\nbody {\n\tcolor: white;\n\tfont-family: sans-serif;\n\tbackground-color: #333;\n}\n.term {\n\tfont-weight: bold;\n\tcolor: #99f;\n}\n</style></head>\n<body>\n<h1>Hello World</h1>\n<div>the following will loop a slice of strings and will pass each string into a child template</div>\n"); __err != nil {
 			return
 		}
-		for _, term := range terms {
+		/*line world.goht:64:5*/ for _, term := range terms {
+//line world.goht:65:14
 			__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line world.goht:65:14
 				__buf, __isBuf := __w.(goht.Buffer)
+//line world.goht:65:14
 				if !__isBuf {
-					__buf = goht.GetBuffer()
+//line world.goht:65:14
+					__buf = goht.AcquireBuffer(ctx, __w)
+//line world.goht:65:14
 					defer goht.ReleaseBuffer(__buf)
+//line world.goht:65:14
 				}
+//line world.goht:66:7
 				if _, __err = __buf.WriteString("<p class=\"term\">"); __err != nil {
 					return
 				}
+//line world.goht:66:15
 				var __var2 string
-				if __var2, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:66:15
+				if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:66:14*/ term)); __err != nil {
 					return
 				}
+//line world.goht:66:15
 				if _, __err = __buf.WriteString(__var2); __err != nil {
 					return
 				}
+//line world.goht:66:15
 				if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 					return
 				}
+//line world.goht:66:15
 				if !__isBuf {
+//line world.goht:66:15
 					_, __err = io.Copy(__w, __buf)
+//line world.goht:66:15
 				}
+//line world.goht:66:15
 				return
+//line world.goht:66:15
 			})
-			if __err = hamlTermsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line world.goht:66:15
+			if __err = /*line world.goht:65:13*/ hamlTermsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 				return
 			}
+//line world.goht:66:15
 		}
+//line world.goht:66:15
 		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
 			return
 		}
+//line world.goht:66:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -223,51 +300,74 @@ func HamlWorld() goht.Template {
 	})
 }
 
-func SlimWorld() goht.Template {
+//line world.goht:69:7
+func /*line world.goht:69:6*/ SlimWorld() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimWorld", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:70:9
 		if _, __err = __buf.WriteString("<!DOCTYPE html><html lang=\"en\"><head><meta charset=\"utf-8\"><title>Hello World</title><style>\nbody {\n\tcolor: white;\n\tfont-family: sans-serif;\n\tbackground-color: #333;\n}\n.term {\n\tfont-weight: bold;\n\tcolor: #99f;\n}\n</style></head><body><h1>Hello World</h1><p>the following will loop a slice of strings and will pass each string into a child template</p>"); __err != nil {
 			return
 		}
-		for _, term := range terms {
+		/*line world.goht:88:5*/ for _, term := range terms {
+//line world.goht:89:14
 			__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line world.goht:89:14
 				__buf, __isBuf := __w.(goht.Buffer)
+//line world.goht:89:14
 				if !__isBuf {
-					__buf = goht.GetBuffer()
+//line world.goht:89:14
+					__buf = goht.AcquireBuffer(ctx, __w)
+//line world.goht:89:14
 					defer goht.ReleaseBuffer(__buf)
+//line world.goht:89:14
 				}
+//line world.goht:90:6
 				if _, __err = __buf.WriteString("<p class=\"term\">"); __err != nil {
 					return
 				}
+//line world.goht:90:14
 				var __var2 string
-				if __var2, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:90:14
+				if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:90:13*/ term)); __err != nil {
 					return
 				}
+//line world.goht:90:14
 				if _, __err = __buf.WriteString(__var2); __err != nil {
 					return
 				}
+//line world.goht:90:14
 				if _, __err = __buf.WriteString("</p>"); __err != nil {
 					return
 				}
+//line world.goht:90:14
 				if !__isBuf {
+//line world.goht:90:14
 					_, __err = io.Copy(__w, __buf)
+//line world.goht:90:14
 				}
+//line world.goht:90:14
 				return
+//line world.goht:90:14
 			})
-			if __err = slimTermsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line world.goht:90:14
+			if __err = /*line world.goht:89:13*/ slimTermsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 				return
 			}
+//line world.goht:90:14
 		}
+//line world.goht:90:14
 		if _, __err = __buf.WriteString("</body></html>\n"); __err != nil {
 			return
 		}
+//line world.goht:90:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -275,51 +375,74 @@ func SlimWorld() goht.Template {
 	})
 }
 
-func EgoWorld() goht.Template {
+//line world.goht:93:6
+func /*line world.goht:93:5*/ EgoWorld() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "EgoWorld", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line world.goht:94:2
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n\t<head>\n\t\t<meta charset=\"utf-8\">\n\t\t<title>Hello World</title>\n\t\t<style>\n\t\t\tbody {\n\t\t\t\tcolor: white;\n\t\t\t\tfont-family: sans-serif;\n\t\t\t\tbackground-color: #333;\n\t\t\t}\n\t\t\t.term {\n\t\t\t\tfont-weight: bold;\n\t\t\t\tcolor: #99f;\n\t\t\t}\n\t\t</style>\n\t</head>\n\t<body>\n\t\t<h1>Hello World</h1>\n\t\t<p>the following will loop a slice of strings and will pass each string into a child template</p>\n\t\t"); __err != nil {
 			return
 		}
-		for _, term := range terms {
+		/*line world.goht:114:6*/ for _, term := range terms {
+//line world.goht:115:15
 			__var1 := goht.TemplateFunc(func(ctx context.Context, __w io.Writer, _ ...goht.SlottedTemplate) (__err error) {
+//line world.goht:115:15
 				__buf, __isBuf := __w.(goht.Buffer)
+//line world.goht:115:15
 				if !__isBuf {
-					__buf = goht.GetBuffer()
+//line world.goht:115:15
+					__buf = goht.AcquireBuffer(ctx, __w)
+//line world.goht:115:15
 					defer goht.ReleaseBuffer(__buf)
+//line world.goht:115:15
 				}
+//line world.goht:116:6
 				if _, __err = __buf.WriteString("<p class=\"term\">"); __err != nil {
 					return
 				}
+//line world.goht:116:26
 				var __var2 string
-				if __var2, __err = goht.CaptureErrors(goht.EscapeString(term)); __err != nil {
+//line world.goht:116:26
+				if __var2, __err = goht.CaptureErrors(goht.EscapeString( /*line world.goht:116:25*/ term)); __err != nil {
 					return
 				}
+//line world.goht:116:26
 				if _, __err = __buf.WriteString(__var2); __err != nil {
 					return
 				}
+//line world.goht:116:33
 				if _, __err = __buf.WriteString("</p>"); __err != nil {
 					return
 				}
+//line world.goht:116:33
 				if !__isBuf {
+//line world.goht:116:33
 					_, __err = io.Copy(__w, __buf)
+//line world.goht:116:33
 				}
+//line world.goht:116:33
 				return
+//line world.goht:116:33
 			})
-			if __err = termsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
+//line world.goht:116:33
+			if __err = /*line world.goht:115:14*/ termsWrapper(term).Render(goht.PushChildren(ctx, __var1), __buf, __sts...); __err != nil {
 				return
 			}
+			/*line world.goht:118:7*/
 		}
+//line world.goht:119:3
 		if _, __err = __buf.WriteString("</body>\n</html>\n"); __err != nil {
 			return
 		}
+//line world.goht:119:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line tabs.goht:3:1
 // You may use tabs to indent the Haml templates.
 // Goht does not change how indenting works in Haml. If you want
 // to use tabs, you must use tabs consistently.
@@ -14,19 +15,23 @@ import "github.com/stackus/goht"
 // asking for trouble IMO but you may use tabs in one template and
 // spaces in another.
 
-func UsingTabs() goht.Template {
+//line tabs.goht:10:7
+func /*line tabs.goht:10:6*/ UsingTabs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "UsingTabs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line tabs.goht:11:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\"><title>\nHello World\n</title>\n</head>\n<body>\n<h1>\nHello World\n</h1>\n<p>\nHello World\n</p>\n</body>\n</html>\n"); __err != nil {
 			return
 		}
+//line tabs.goht:21:16
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -34,19 +39,23 @@ func UsingTabs() goht.Template {
 	})
 }
 
-func HamlUsingTabs() goht.Template {
+//line tabs.goht:24:7
+func /*line tabs.goht:24:6*/ HamlUsingTabs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlUsingTabs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line tabs.goht:25:5
 		if _, __err = __buf.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\"><title>\nHello World\n</title>\n</head>\n<body>\n<h1>\nHello World\n</h1>\n<p>\nHello World\n</p>\n</body>\n</html>\n"); __err != nil {
 			return
 		}
+//line tabs.goht:35:16
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -54,19 +63,23 @@ func HamlUsingTabs() goht.Template {
 	})
 }
 
-func SlimUsingTabs() goht.Template {
+//line tabs.goht:38:7
+func /*line tabs.goht:38:6*/ SlimUsingTabs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimUsingTabs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line tabs.goht:39:9
 		if _, __err = __buf.WriteString("<!DOCTYPE html><html lang=\"en\"><head><meta charset=\"utf-8\"><title>Hello World</title></head><body><h1>Hello World</h1><p>Hello World</p></body></html>\n"); __err != nil {
 			return
 		}
+//line tabs.goht:49:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,21 +7,26 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line general.goht:2:1
 // You may specify the type of tag that you want created with `%`.
 
-func SpecifyTag() goht.Template {
+//line general.goht:4:7
+func /*line general.goht:4:6*/ SpecifyTag() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SpecifyTag", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:5:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph tag.</p>\n<main>This is a main tag.</main>\n"); __err != nil {
 			return
 		}
+//line general.goht:6:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -29,19 +34,23 @@ func SpecifyTag() goht.Template {
 	})
 }
 
-func HamlSpecifyTag() goht.Template {
+//line general.goht:9:7
+func /*line general.goht:9:6*/ HamlSpecifyTag() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlSpecifyTag", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:10:3
 		if _, __err = __buf.WriteString("<p>This is a paragraph tag.</p>\n<main>This is a main tag.</main>\n"); __err != nil {
 			return
 		}
+//line general.goht:11:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -49,19 +58,23 @@ func HamlSpecifyTag() goht.Template {
 	})
 }
 
-func SlimSpecifyTag() goht.Template {
+//line general.goht:14:7
+func /*line general.goht:14:6*/ SlimSpecifyTag() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimSpecifyTag", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:15:2
 		if _, __err = __buf.WriteString("<p>This is a paragraph tag.</p><main>This is a main tag.</main>\n"); __err != nil {
 			return
 		}
+//line general.goht:16:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -69,22 +82,27 @@ func SlimSpecifyTag() goht.Template {
 	})
 }
 
+//line general.goht:19:1
 // You may also let the tag default to a `div` when using the id or
 // class syntax's, `#` and `.` respectively.
 
-func DefaultToDivs() goht.Template {
+//line general.goht:22:7
+func /*line general.goht:22:6*/ DefaultToDivs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "DefaultToDivs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:23:3
 		if _, __err = __buf.WriteString("<div id=\"main\">This is a div tag with an id of `main`.</div>\n<div class=\"main\">This is a div tag with a class of `main`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:24:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -92,19 +110,23 @@ func DefaultToDivs() goht.Template {
 	})
 }
 
-func HamlDefaultToDivs() goht.Template {
+//line general.goht:27:7
+func /*line general.goht:27:6*/ HamlDefaultToDivs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlDefaultToDivs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:28:3
 		if _, __err = __buf.WriteString("<div id=\"main\">This is a div tag with an id of `main`.</div>\n<div class=\"main\">This is a div tag with a class of `main`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:29:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -112,19 +134,23 @@ func HamlDefaultToDivs() goht.Template {
 	})
 }
 
-func SlimDefaultToDivs() goht.Template {
+//line general.goht:32:7
+func /*line general.goht:32:6*/ SlimDefaultToDivs() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimDefaultToDivs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:33:3
 		if _, __err = __buf.WriteString("<div id=\"main\">This is a div tag with an id of `main`.</div><div class=\"main\">This is a div tag with a class of `main`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:34:8
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -132,22 +158,27 @@ func SlimDefaultToDivs() goht.Template {
 	})
 }
 
+//line general.goht:37:1
 // The three may also be combined. The `%` must come first, followed
 // by either the `#` or `.`. The `#` and `.` may be in any order.
 
-func Combined() goht.Template {
+//line general.goht:40:7
+func /*line general.goht:40:6*/ Combined() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Combined", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:41:3
 		if _, __err = __buf.WriteString("<p id=\"main\">This is a paragraph tag with an id of `main`.</p>\n<main class=\"main\">This is a main tag with a class of `main`.</main>\n<div id=\"main\" class=\"main\">This is a div tag with an id and class of `main`.</div>\n<p id=\"main\" class=\"main\">This is a paragraph tag with an id and class of `main`.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:44:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -155,19 +186,23 @@ func Combined() goht.Template {
 	})
 }
 
-func HamlCombined() goht.Template {
+//line general.goht:47:7
+func /*line general.goht:47:6*/ HamlCombined() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlCombined", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:48:3
 		if _, __err = __buf.WriteString("<p id=\"main\">This is a paragraph tag with an id of `main`.</p>\n<main class=\"main\">This is a main tag with a class of `main`.</main>\n<div id=\"main\" class=\"main\">This is a div tag with an id and class of `main`.</div>\n<p id=\"main\" class=\"main\">This is a paragraph tag with an id and class of `main`.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:51:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -175,19 +210,23 @@ func HamlCombined() goht.Template {
 	})
 }
 
-func SlimCombined() goht.Template {
+//line general.goht:54:7
+func /*line general.goht:54:6*/ SlimCombined() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimCombined", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:55:2
 		if _, __err = __buf.WriteString("<p id=\"main\">This is a paragraph tag with an id of `main`.</p><main class=\"main\">This is a main tag with a class of `main`.</main><div id=\"main\" class=\"main\">This is a div tag with an id and class of `main`.</div><p id=\"main\" class=\"main\">This is a paragraph tag with an id and class of `main`.</p>\n"); __err != nil {
 			return
 		}
+//line general.goht:58:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -195,23 +234,28 @@ func SlimCombined() goht.Template {
 	})
 }
 
+//line general.goht:61:1
 // The class operator may be repeated to add multiple classes.
 // Repeating the id operator will result in the id being overwritten
 // but will not throw an error.
 
-func MultipleClasses() goht.Template {
+//line general.goht:65:7
+func /*line general.goht:65:6*/ MultipleClasses() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "MultipleClasses", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:66:3
 		if _, __err = __buf.WriteString("<div class=\"main main2\">This is a div tag with two classes, `main` and `main2`.</div>\n<div id=\"main2\">This is a div tag with an id of `main2`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:67:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -219,19 +263,23 @@ func MultipleClasses() goht.Template {
 	})
 }
 
-func HamlMultipleClasses() goht.Template {
+//line general.goht:70:7
+func /*line general.goht:70:6*/ HamlMultipleClasses() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlMultipleClasses", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:71:3
 		if _, __err = __buf.WriteString("<div class=\"main main2\">This is a div tag with two classes, `main` and `main2`.</div>\n<div id=\"main2\">This is a div tag with an id of `main2`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:72:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -239,19 +287,23 @@ func HamlMultipleClasses() goht.Template {
 	})
 }
 
-func SlimMultipleClasses() goht.Template {
+//line general.goht:75:7
+func /*line general.goht:75:6*/ SlimMultipleClasses() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimMultipleClasses", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line general.goht:76:3
 		if _, __err = __buf.WriteString("<div class=\"main main2\">This is a div tag with two classes, `main` and `main2`.</div><div id=\"main2\">This is a div tag with an id of `main2`.</div>\n"); __err != nil {
 			return
 		}
+//line general.goht:77:14
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,22 +7,27 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line inline.goht:3:1
 // Slim allows you to inline tags for those times when you really
 // want to keep things concise.
 
-func SlimInlineTags() goht.Template {
+//line inline.goht:6:7
+func /*line inline.goht:6:6*/ SlimInlineTags() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimInlineTags", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line inline.goht:7:2
 		if _, __err = __buf.WriteString("<ul><li><a class=\"first\">First Item</a></li><li><a class=\"second\">First Item</a></li><li><a class=\"third\">First Item</a></li></ul>\n"); __err != nil {
 			return
 		}
+//line inline.goht:10:15
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
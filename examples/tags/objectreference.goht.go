@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line objectreference.goht:2:1
 // In HAML it is possible to include an object in the tag
 // declaration to make the addition of classes and ids easier.
 // This is done by using a pair of square brackets after the
@@ -32,35 +33,47 @@ func (f *Foo) ObjectClass() string {
 // if obj below has the id "bar" then the output will be:
 // <article id="foo_bar" class="foo">Foo article</article>
 
-func ObjectRefs(obj Foo) goht.Template {
+//line objectreference.goht:27:7
+func /*line objectreference.goht:27:6*/ ObjectRefs(obj Foo) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "ObjectRefs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line objectreference.goht:28:3
 		if _, __err = __buf.WriteString("<article"); __err != nil {
 			return
 		}
-		if __var1 := goht.ObjectID(obj); __var1 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var1 + "\""); __err != nil {
+//line objectreference.goht:28:3
+		if __var1 := goht.ObjectID( /*line objectreference.goht:28:10*/ obj); __var1 != "" {
+//line objectreference.goht:28:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:28:3
 		}
+//line objectreference.goht:28:3
 		var __var2 string
+//line objectreference.goht:28:3
 		__var2, __err = goht.BuildClassList(goht.ObjectClass(obj))
+//line objectreference.goht:28:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line objectreference.goht:28:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:28:3
 		if _, __err = __buf.WriteString(">Foo article</article>\n"); __err != nil {
 			return
 		}
+//line objectreference.goht:28:16
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -68,35 +81,47 @@ func ObjectRefs(obj Foo) goht.Template {
 	})
 }
 
-func HamlObjectRefs(obj Foo) goht.Template {
+//line objectreference.goht:31:7
+func /*line objectreference.goht:31:6*/ HamlObjectRefs(obj Foo) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlObjectRefs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line objectreference.goht:32:3
 		if _, __err = __buf.WriteString("<article"); __err != nil {
 			return
 		}
-		if __var1 := goht.ObjectID(obj); __var1 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var1 + "\""); __err != nil {
+//line objectreference.goht:32:3
+		if __var1 := goht.ObjectID( /*line objectreference.goht:32:10*/ obj); __var1 != "" {
+//line objectreference.goht:32:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:32:3
 		}
+//line objectreference.goht:32:3
 		var __var2 string
+//line objectreference.goht:32:3
 		__var2, __err = goht.BuildClassList(goht.ObjectClass(obj))
+//line objectreference.goht:32:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line objectreference.goht:32:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:32:3
 		if _, __err = __buf.WriteString(">Foo article</article>\n"); __err != nil {
 			return
 		}
+//line objectreference.goht:32:16
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -105,53 +130,75 @@ func HamlObjectRefs(obj Foo) goht.Template {
 }
 
 // You may include a prefix to be used with the id and class.
+//
+//line objectreference.goht:35:1
 var prefixVar = "article"
 
-func PrefixedObjectRefs(obj Foo) goht.Template {
+//line objectreference.goht:38:7
+func /*line objectreference.goht:38:6*/ PrefixedObjectRefs(obj Foo) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "PrefixedObjectRefs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line objectreference.goht:39:3
 		if _, __err = __buf.WriteString("<article"); __err != nil {
 			return
 		}
-		if __var1 := goht.ObjectID(obj, "prefix"); __var1 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var1 + "\""); __err != nil {
+//line objectreference.goht:39:3
+		if __var1 := goht.ObjectID( /*line objectreference.goht:39:10*/ obj, "prefix"); __var1 != "" {
+//line objectreference.goht:39:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:39:3
 		}
+//line objectreference.goht:39:3
 		var __var2 string
+//line objectreference.goht:39:3
 		__var2, __err = goht.BuildClassList(goht.ObjectClass(obj, "prefix"))
+//line objectreference.goht:39:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line objectreference.goht:39:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:39:3
 		if _, __err = __buf.WriteString(">Foo article with id \"prefix_foo_bar\" and class \"prefix_foo\"</article>\n<article"); __err != nil {
 			return
 		}
-		if __var3 := goht.ObjectID(obj, prefixVar); __var3 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var3 + "\""); __err != nil {
+//line objectreference.goht:40:3
+		if __var3 := goht.ObjectID( /*line objectreference.goht:40:10*/ obj, prefixVar); __var3 != "" {
+//line objectreference.goht:40:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:40:3
 		}
+//line objectreference.goht:40:3
 		var __var4 string
+//line objectreference.goht:40:3
 		__var4, __err = goht.BuildClassList(goht.ObjectClass(obj, prefixVar))
+//line objectreference.goht:40:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+//line objectreference.goht:40:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:40:3
 		if _, __err = __buf.WriteString(">Foo article with id \"article_foo_bar\" and class \"article_foo\"</article>\n"); __err != nil {
 			return
 		}
+//line objectreference.goht:40:27
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -159,51 +206,71 @@ func PrefixedObjectRefs(obj Foo) goht.Template {
 	})
 }
 
-func HamlPrefixedObjectRefs(obj Foo) goht.Template {
+//line objectreference.goht:43:7
+func /*line objectreference.goht:43:6*/ HamlPrefixedObjectRefs(obj Foo) goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlPrefixedObjectRefs", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line objectreference.goht:44:3
 		if _, __err = __buf.WriteString("<article"); __err != nil {
 			return
 		}
-		if __var1 := goht.ObjectID(obj, "prefix"); __var1 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var1 + "\""); __err != nil {
+//line objectreference.goht:44:3
+		if __var1 := goht.ObjectID( /*line objectreference.goht:44:10*/ obj, "prefix"); __var1 != "" {
+//line objectreference.goht:44:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var1) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:44:3
 		}
+//line objectreference.goht:44:3
 		var __var2 string
+//line objectreference.goht:44:3
 		__var2, __err = goht.BuildClassList(goht.ObjectClass(obj, "prefix"))
+//line objectreference.goht:44:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var2 + "\""); __err != nil {
+//line objectreference.goht:44:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var2) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:44:3
 		if _, __err = __buf.WriteString(">Foo article with id \"prefix_foo_bar\" and class \"prefix_foo\"</article>\n<article"); __err != nil {
 			return
 		}
-		if __var3 := goht.ObjectID(obj, prefixVar); __var3 != "" {
-			if _, __err = __buf.WriteString(" id=\"" + __var3 + "\""); __err != nil {
+//line objectreference.goht:45:3
+		if __var3 := goht.ObjectID( /*line objectreference.goht:45:10*/ obj, prefixVar); __var3 != "" {
+//line objectreference.goht:45:3
+			if _, __err = __buf.WriteString(" id=\"" + goht.EscapeString(__var3) + "\""); __err != nil {
 				return
 			}
+//line objectreference.goht:45:3
 		}
+//line objectreference.goht:45:3
 		var __var4 string
+//line objectreference.goht:45:3
 		__var4, __err = goht.BuildClassList(goht.ObjectClass(obj, prefixVar))
+//line objectreference.goht:45:3
 		if __err != nil {
 			return
 		}
-		if _, __err = __buf.WriteString(" class=\"" + __var4 + "\""); __err != nil {
+//line objectreference.goht:45:3
+		if _, __err = __buf.WriteString(" class=\"" + goht.EscapeString(__var4) + "\""); __err != nil {
 			return
 		}
+//line objectreference.goht:45:3
 		if _, __err = __buf.WriteString(">Foo article with id \"article_foo_bar\" and class \"article_foo\"</article>\n"); __err != nil {
 			return
 		}
+//line objectreference.goht:45:27
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line selfclosing.goht:2:1
 // There are several tags that are self closing, meaning they
 // don't have or require a closing tag. For example, the <img> tag
 // is used to embed an image in an HTML document. It is self closing
@@ -15,19 +16,23 @@ import "github.com/stackus/goht"
 // Nesting content inside a self closing tag will cause the parser
 // to display an error.
 
-func SelfClosing() goht.Template {
+//line selfclosing.goht:10:7
+func /*line selfclosing.goht:10:6*/ SelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:11:3
 		if _, __err = __buf.WriteString("<img src=\"logo.png\" alt=\"logo\"><p>\nA paragraph is not self closing.\n<img src=\"logo.png\" alt=\"logo\"></p>\n"); __err != nil {
 			return
 		}
+//line selfclosing.goht:14:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -35,19 +40,23 @@ func SelfClosing() goht.Template {
 	})
 }
 
-func HamlSelfClosing() goht.Template {
+//line selfclosing.goht:17:7
+func /*line selfclosing.goht:17:6*/ HamlSelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlSelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:18:3
 		if _, __err = __buf.WriteString("<img src=\"logo.png\" alt=\"logo\"><p>\nA paragraph is not self closing.\n<img src=\"logo.png\" alt=\"logo\"></p>\n"); __err != nil {
 			return
 		}
+//line selfclosing.goht:21:4
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -55,19 +64,23 @@ func HamlSelfClosing() goht.Template {
 	})
 }
 
-func SlimSelfClosing() goht.Template {
+//line selfclosing.goht:24:7
+func /*line selfclosing.goht:24:6*/ SlimSelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimSelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:25:2
 		if _, __err = __buf.WriteString("<img src=\"logo.png\" alt=\"logo\"><p>A paragraph is not self closing.<img src=\"logo.png\" alt=\"logo\"></p>\n"); __err != nil {
 			return
 		}
+//line selfclosing.goht:28:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -75,6 +88,7 @@ func SlimSelfClosing() goht.Template {
 	})
 }
 
+//line selfclosing.goht:31:1
 // You may also use the self closing tag syntax to create a tag
 // that is not self closing. This is useful for creating tags
 // that are not known by the parser.
@@ -85,19 +99,23 @@ func SlimSelfClosing() goht.Template {
 // 	"isindex", "keygen", "link", "menuitem",
 // 	"meta", "param", "source", "track", "wbr",
 
-func AlsoSelfClosing() goht.Template {
+//line selfclosing.goht:41:7
+func /*line selfclosing.goht:41:6*/ AlsoSelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "AlsoSelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:42:3
 		if _, __err = __buf.WriteString("<isNowSelfClosing/>"); __err != nil {
 			return
 		}
+//line selfclosing.goht:42:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -105,19 +123,23 @@ func AlsoSelfClosing() goht.Template {
 	})
 }
 
-func HamlAlsoSelfClosing() goht.Template {
+//line selfclosing.goht:45:7
+func /*line selfclosing.goht:45:6*/ HamlAlsoSelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlAlsoSelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:46:3
 		if _, __err = __buf.WriteString("<isNowSelfClosing/>"); __err != nil {
 			return
 		}
+//line selfclosing.goht:46:3
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -125,19 +147,23 @@ func HamlAlsoSelfClosing() goht.Template {
 	})
 }
 
-func SlimAlsoSelfClosing() goht.Template {
+//line selfclosing.goht:49:7
+func /*line selfclosing.goht:49:6*/ SlimAlsoSelfClosing() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimAlsoSelfClosing", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line selfclosing.goht:50:2
 		if _, __err = __buf.WriteString("<isNowSelfClosing/>\n"); __err != nil {
 			return
 		}
+//line selfclosing.goht:50:2
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
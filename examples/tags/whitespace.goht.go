@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line whitespace.goht:2:1
 // Whitespace will be added between tags when using Haml and between
 // tags and text if that text is on a new line after the tag.
 //
@@ -24,19 +25,23 @@ import "github.com/stackus/goht"
 // Whitespace can have subtle effects on the final output of the
 // rendered HTML, so it is important to understand how it works.
 
-func Whitespace() goht.Template {
+//line whitespace.goht:19:7
+func /*line whitespace.goht:19:6*/ Whitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "Whitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line whitespace.goht:20:3
 		if _, __err = __buf.WriteString("<p>This text has no whitespace between it and the tag.</p>\n<p>\nThis text has whitespace between it and the tag.\n<p>This tag has whitespace between it and the tag above.</p>\n</p>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:23:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -44,19 +49,23 @@ func Whitespace() goht.Template {
 	})
 }
 
-func HamlWhitespace() goht.Template {
+//line whitespace.goht:26:7
+func /*line whitespace.goht:26:6*/ HamlWhitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlWhitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line whitespace.goht:27:3
 		if _, __err = __buf.WriteString("<p>This text has no whitespace between it and the tag.</p>\n<p>\nThis text has whitespace between it and the tag.\n<p>This tag has whitespace between it and the tag above.</p>\n</p>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:30:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -64,21 +73,26 @@ func HamlWhitespace() goht.Template {
 	})
 }
 
+//line whitespace.goht:33:1
 // Slim does not keep whitespace between tags by default.
 
-func SlimWhitespace() goht.Template {
+//line whitespace.goht:35:7
+func /*line whitespace.goht:35:6*/ SlimWhitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimWhitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line whitespace.goht:36:2
 		if _, __err = __buf.WriteString("<p>This text has no whitespace between it and the tag.</p><p><This>text has NO whitespace between it and the tag.</This><p>This tag has NO whitespace between it and the tag above.</p></p>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:39:5
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -86,6 +100,7 @@ func SlimWhitespace() goht.Template {
 	})
 }
 
+//line whitespace.goht:42:1
 // You can control the whitespace that will be rendered between tags
 // in the final output by using the `>` and <` operators.
 // The `>` operator will remove all whitespace outside the tag, and
@@ -95,19 +110,23 @@ func SlimWhitespace() goht.Template {
 // You can use either or both of these operators on a tag, when using
 // both, the order does not matter.
 
-func RemoveWhitespace() goht.Template {
+//line whitespace.goht:51:7
+func /*line whitespace.goht:51:6*/ RemoveWhitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "RemoveWhitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		if _, __err = __buf.WriteString("<p>~☢<\nThis text has no whitespace between it and the parent tag.\n>☢~</p>\n<p>\nThere is whitespace between this text and the parent tag.\n>☢~<p>~☢<\nThis text has no whitespace between it and the parent tag.\nThere is also no whitespace between this tag and the sibling text above it.\nFinally, the tag has no whitespace between it and the outer tag.\n>☢~</p>~☢<</p>\n"); __err != nil {
+//line whitespace.goht:52:3
+		if _, __err = __buf.WriteString("<p>This text has no whitespace between it and the parent tag.</p>\n<p>\nThere is whitespace between this text and the parent tag.<p>This text has no whitespace between it and the parent tag.\nThere is also no whitespace between this tag and the sibling text above it.\nFinally, the tag has no whitespace between it and the outer tag.</p></p>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:59:68
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -115,19 +134,23 @@ func RemoveWhitespace() goht.Template {
 	})
 }
 
-func HamlRemoveWhitespace() goht.Template {
+//line whitespace.goht:62:7
+func /*line whitespace.goht:62:6*/ HamlRemoveWhitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlRemoveWhitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		if _, __err = __buf.WriteString("<p>~☢<\nThis text has no whitespace between it and the parent tag.\n>☢~</p>\n<p>\nThere is whitespace between this text and the parent tag.\n>☢~<p>~☢<\nThis text has no whitespace between it and the parent tag.\nThere is also no whitespace between this tag and the sibling text above it.\nFinally, the tag has no whitespace between it and the outer tag.\n>☢~</p>~☢<</p>\n"); __err != nil {
+//line whitespace.goht:63:3
+		if _, __err = __buf.WriteString("<p>This text has no whitespace between it and the parent tag.</p>\n<p>\nThere is whitespace between this text and the parent tag.<p>This text has no whitespace between it and the parent tag.\nThere is also no whitespace between this tag and the sibling text above it.\nFinally, the tag has no whitespace between it and the outer tag.</p></p>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:70:68
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -135,6 +158,7 @@ func HamlRemoveWhitespace() goht.Template {
 	})
 }
 
+//line whitespace.goht:73:1
 // You can add whitespace between tags by using the `>` and `<` operators.
 // The `>` operator will add whitespace after the tag, and the `<` operator
 // will add whitespace before the tag.
@@ -144,19 +168,23 @@ func HamlRemoveWhitespace() goht.Template {
 // p< Text
 // p<> Text
 
-func SlimAddWhitespace() goht.Template {
+//line whitespace.goht:82:7
+func /*line whitespace.goht:82:6*/ SlimAddWhitespace() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimAddWhitespace", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line whitespace.goht:83:2
 		if _, __err = __buf.WriteString("<div>This tag has whitespace after it.</div> <div>There is whitespace between this text and the parent tag. <p>There is whitespace before and after this tag.</p> </div>\n"); __err != nil {
 			return
 		}
+//line whitespace.goht:86:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
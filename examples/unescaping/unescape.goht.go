@@ -7,6 +7,7 @@ import "context"
 import "io"
 import "github.com/stackus/goht"
 
+//line unescape.goht:3:1
 // The text from variables are assumed to need HTML escaping. If you
 // want to include raw HTML, or you have already processed the variable
 // then you will need to unescape the text. This is accomplished with
@@ -15,39 +16,51 @@ import "github.com/stackus/goht"
 // when you are sure that the text is safe. If you are unsure, then
 // you should use the default escaping.
 
-func UnescapeCode() goht.Template {
+//line unescape.goht:11:7
+func /*line unescape.goht:11:6*/ UnescapeCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "UnescapeCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line unescape.goht:12:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line unescape.goht:12:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString("This is <em>NOT</em> unescaped HTML. (Ampersands everywhere!)")); __err != nil {
+//line unescape.goht:12:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line unescape.goht:12:5*/ "This is <em>NOT</em> unescaped HTML. (Ampersands everywhere!)")); __err != nil {
 			return
 		}
+//line unescape.goht:12:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line unescape.goht:12:6
 		if _, __err = __buf.WriteString("</p>\n<p>"); __err != nil {
 			return
 		}
+//line unescape.goht:13:7
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors("This <em>is</em> unescaped HTML."); __err != nil {
+//line unescape.goht:13:7
+		if __var2, __err = goht.CaptureErrors( /*line unescape.goht:13:6*/ "This <em>is</em> unescaped HTML."); __err != nil {
 			return
 		}
+//line unescape.goht:13:7
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line unescape.goht:13:7
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:13:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -55,39 +68,51 @@ func UnescapeCode() goht.Template {
 	})
 }
 
-func HamlUnescapeCode() goht.Template {
+//line unescape.goht:16:7
+func /*line unescape.goht:16:6*/ HamlUnescapeCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlUnescapeCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line unescape.goht:17:3
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line unescape.goht:17:6
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString("This is <em>NOT</em> unescaped HTML. (Ampersands everywhere!)")); __err != nil {
+//line unescape.goht:17:6
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line unescape.goht:17:5*/ "This is <em>NOT</em> unescaped HTML. (Ampersands everywhere!)")); __err != nil {
 			return
 		}
+//line unescape.goht:17:6
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line unescape.goht:17:6
 		if _, __err = __buf.WriteString("</p>\n<p>"); __err != nil {
 			return
 		}
+//line unescape.goht:18:7
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors("This <em>is</em> unescaped HTML."); __err != nil {
+//line unescape.goht:18:7
+		if __var2, __err = goht.CaptureErrors( /*line unescape.goht:18:6*/ "This <em>is</em> unescaped HTML."); __err != nil {
 			return
 		}
+//line unescape.goht:18:7
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line unescape.goht:18:7
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:18:7
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -95,39 +120,51 @@ func HamlUnescapeCode() goht.Template {
 	})
 }
 
-func SlimUnescapeCode() goht.Template {
+//line unescape.goht:21:7
+func /*line unescape.goht:21:6*/ SlimUnescapeCode() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "SlimUnescapeCode", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line unescape.goht:22:2
 		if _, __err = __buf.WriteString("<p>"); __err != nil {
 			return
 		}
+//line unescape.goht:22:5
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString("This is <em>is</em> escaped HTML. (Ampersands everywhere!)")); __err != nil {
+//line unescape.goht:22:5
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line unescape.goht:22:4*/ "This is <em>is</em> escaped HTML. (Ampersands everywhere!)")); __err != nil {
 			return
 		}
+//line unescape.goht:22:5
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line unescape.goht:22:5
 		if _, __err = __buf.WriteString("</p><p>"); __err != nil {
 			return
 		}
+//line unescape.goht:23:6
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors("This is <em>NOT</em> escaped HTML."); __err != nil {
+//line unescape.goht:23:6
+		if __var2, __err = goht.CaptureErrors( /*line unescape.goht:23:5*/ "This is <em>NOT</em> escaped HTML."); __err != nil {
 			return
 		}
+//line unescape.goht:23:6
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line unescape.goht:23:6
 		if _, __err = __buf.WriteString("</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:23:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -135,42 +172,55 @@ func SlimUnescapeCode() goht.Template {
 	})
 }
 
+//line unescape.goht:26:1
 // It can also affect the interpolated values.
 
-func UnescapeInterpolation() goht.Template {
+//line unescape.goht:28:7
+func /*line unescape.goht:28:6*/ UnescapeInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "UnescapeInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		var html = "<em>is</em>"
+		/*line unescape.goht:29:3*/ var html = "<em>is</em>"
+//line unescape.goht:30:3
 		if _, __err = __buf.WriteString("<p>This "); __err != nil {
 			return
 		}
+//line unescape.goht:30:12
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(html)); __err != nil {
+//line unescape.goht:30:12
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line unescape.goht:30:11*/ html)); __err != nil {
 			return
 		}
+//line unescape.goht:30:12
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line unescape.goht:30:17
 		if _, __err = __buf.WriteString(" is escaped. (Ampersands everywhere!)</p>\n<p>This "); __err != nil {
 			return
 		}
+//line unescape.goht:31:13
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(html); __err != nil {
+//line unescape.goht:31:13
+		if __var2, __err = goht.CaptureErrors( /*line unescape.goht:31:12*/ html); __err != nil {
 			return
 		}
+//line unescape.goht:31:13
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line unescape.goht:31:18
 		if _, __err = __buf.WriteString(" is NOT escaped.</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:31:18
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -178,40 +228,52 @@ func UnescapeInterpolation() goht.Template {
 	})
 }
 
-func HamlUnescapeInterpolation() goht.Template {
+//line unescape.goht:34:7
+func /*line unescape.goht:34:6*/ HamlUnescapeInterpolation() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlUnescapeInterpolation", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
-		var html = "<em>is</em>"
+		/*line unescape.goht:35:3*/ var html = "<em>is</em>"
+//line unescape.goht:36:3
 		if _, __err = __buf.WriteString("<p>This "); __err != nil {
 			return
 		}
+//line unescape.goht:36:12
 		var __var1 string
-		if __var1, __err = goht.CaptureErrors(goht.EscapeString(html)); __err != nil {
+//line unescape.goht:36:12
+		if __var1, __err = goht.CaptureErrors(goht.EscapeString( /*line unescape.goht:36:11*/ html)); __err != nil {
 			return
 		}
+//line unescape.goht:36:12
 		if _, __err = __buf.WriteString(__var1); __err != nil {
 			return
 		}
+//line unescape.goht:36:17
 		if _, __err = __buf.WriteString(" is escaped. (Ampersands everywhere!)</p>\n<p>This "); __err != nil {
 			return
 		}
+//line unescape.goht:37:13
 		var __var2 string
-		if __var2, __err = goht.CaptureErrors(html); __err != nil {
+//line unescape.goht:37:13
+		if __var2, __err = goht.CaptureErrors( /*line unescape.goht:37:12*/ html); __err != nil {
 			return
 		}
+//line unescape.goht:37:13
 		if _, __err = __buf.WriteString(__var2); __err != nil {
 			return
 		}
+//line unescape.goht:37:18
 		if _, __err = __buf.WriteString(" is NOT escaped.</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:37:18
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -219,23 +281,28 @@ func HamlUnescapeInterpolation() goht.Template {
 	})
 }
 
+//line unescape.goht:40:1
 // The plain text that you write into your Goht templates will not be
 // altered by the addition of the `!` operator. It is expected that this
 // text has already been HTML escaped properly.
 
-func UnescapeText() goht.Template {
+//line unescape.goht:44:7
+func /*line unescape.goht:44:6*/ UnescapeText() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "UnescapeText", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line unescape.goht:45:3
 		if _, __err = __buf.WriteString("<p>This <em>is</em> HTML.</p>\n<p>This <em>is</em> HTML.</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:46:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
@@ -243,19 +310,23 @@ func UnescapeText() goht.Template {
 	})
 }
 
-func HamlUnescapeText() goht.Template {
+//line unescape.goht:49:7
+func /*line unescape.goht:49:6*/ HamlUnescapeText() goht.Template {
 	return goht.TemplateFunc(func(ctx context.Context, __w io.Writer, __sts ...goht.SlottedTemplate) (__err error) {
+		defer goht.RecoverPanic(ctx, "HamlUnescapeText", &__err)
 		__buf, __isBuf := __w.(goht.Buffer)
 		if !__isBuf {
-			__buf = goht.GetBuffer()
+			__buf = goht.AcquireBuffer(ctx, __w)
 			defer goht.ReleaseBuffer(__buf)
 		}
 		var __children goht.Template
 		ctx, __children = goht.PopChildren(ctx)
 		_ = __children
+//line unescape.goht:50:3
 		if _, __err = __buf.WriteString("<p>This <em>is</em> HTML.</p>\n<p>This <em>is</em> HTML.</p>\n"); __err != nil {
 			return
 		}
+//line unescape.goht:51:6
 		if !__isBuf {
 			_, __err = __w.Write(__buf.Bytes())
 		}
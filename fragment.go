@@ -0,0 +1,84 @@
+package goht
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrFragmentNotFound is returned by RenderFragment when the template did
+// not render the requested fragment.
+var ErrFragmentNotFound = errors.New("goht: fragment not found")
+
+type fragmentContextKey struct{}
+
+type fragment struct {
+	name    string
+	depth   int
+	found   bool
+	claimed bool
+}
+
+// RenderFragment renders only the named fragment of the template into w.
+//
+// Fragments are marked in a template with the @fragment command. The whole
+// template is still executed, so any Go code that the fragment depends on
+// will run, but everything outside the fragment is discarded. When the
+// fragment is rendered more than once, as it would be inside a loop, each
+// rendering is written.
+//
+// ErrFragmentNotFound is returned if the template did not render the fragment.
+func RenderFragment(ctx context.Context, w io.Writer, t Template, name string) error {
+	f := &fragment{name: name}
+	// templates render directly into a Buffer without acquiring one, so the
+	// fragment is claimed here for a Buffer
+	if buf, ok := w.(Buffer); ok {
+		f.claimed = true
+		buf.fragment = f
+		w = buf
+	}
+	if err := t.Render(context.WithValue(ctx, fragmentContextKey{}, f), w); err != nil {
+		return err
+	}
+	if !f.found {
+		return fmt.Errorf("%w: %q", ErrFragmentNotFound, name)
+	}
+	return nil
+}
+
+// BeginFragment marks the start of the named fragment.
+func (b Buffer) BeginFragment(name string) {
+	if b.fragment == nil {
+		return
+	}
+	if b.fragment.depth > 0 || name == b.fragment.name {
+		b.fragment.depth++
+		b.fragment.found = true
+	}
+}
+
+// EndFragment marks the end of the last fragment that was started.
+func (b Buffer) EndFragment() {
+	if b.fragment != nil && b.fragment.depth > 0 {
+		b.fragment.depth--
+	}
+}
+
+// discarding returns true when the buffer is rendering a fragment and the
+// content being written is outside of it.
+func (b Buffer) discarding() bool {
+	return b.fragment != nil && b.fragment.depth == 0
+}
+
+// Write appends p to the buffer; when rendering a fragment, content written
+// outside the fragment is discarded.
+func (b Buffer) Write(p []byte) (int, error) {
+	if b.discarding() {
+		return len(p), nil
+	}
+	if b.trimSpace != nil && *b.trimSpace {
+		return b.WriteString(string(p))
+	}
+	return b.Buffer.Write(p)
+}
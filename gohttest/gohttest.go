@@ -0,0 +1,154 @@
+// Package gohttest provides helpers for testing the output of GoHT templates.
+//
+// Templates can be rendered and compared against golden files, compared
+// structurally against HTML that ignores insignificant whitespace and
+// attribute order, or parsed into a Document and queried with CSS selectors:
+//
+//	doc := gohttest.RenderDocument(t, CartPage(items))
+//	if got := doc.Find("ul > li.active").Text(); got != "Apples" {
+//		t.Errorf("want the active item to be Apples, got %q", got)
+//	}
+//
+// Golden files are written or updated when the tests are run with the
+// -update flag of the test package, or with the GOHTTEST_UPDATE environment
+// variable set to true:
+//
+//	var _ = flag.Bool("update", false, "update the golden files")
+//
+//	go test ./... -update
+//	GOHTTEST_UPDATE=true go test ./...
+package gohttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/stackus/goht"
+)
+
+// updateEnv is the environment variable that updates the golden files.
+const updateEnv = "GOHTTEST_UPDATE"
+
+// Update writes the golden files instead of comparing with them. A test
+// package can set it directly, instead of with the -update flag or the
+// environment variable.
+var Update bool
+
+// updating reports whether the golden files are to be written.
+//
+// The -update flag is looked up instead of being defined here; a test package
+// that defines its own -update flag would otherwise panic with "flag
+// redefined".
+func updating() bool {
+	if Update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if g, ok := f.Value.(flag.Getter); ok {
+			if update, ok := g.Get().(bool); ok && update {
+				return true
+			}
+		}
+	}
+	update, _ := strconv.ParseBool(os.Getenv(updateEnv))
+	return update
+}
+
+// Render renders the template with a background context and returns the
+// output; failing the test if the template returns an error.
+func Render(t testing.TB, tmpl goht.Template) []byte {
+	t.Helper()
+	return RenderContext(t, context.Background(), tmpl)
+}
+
+// RenderContext renders the template with the context and returns the
+// output; failing the test if the template returns an error.
+func RenderContext(t testing.TB, ctx context.Context, tmpl goht.Template) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := tmpl.Render(ctx, &buf); err != nil {
+		t.Fatalf("error rendering template: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// RenderDocument renders the template and parses the output into a Document.
+func RenderDocument(t testing.TB, tmpl goht.Template) *Document {
+	t.Helper()
+	return NewDocument(string(Render(t, tmpl)))
+}
+
+// Golden compares got with the contents of the golden file; reporting a diff
+// when they are not exactly the same.
+//
+// When the tests are run with the -update flag the golden file, and any
+// missing directories, are written instead.
+func Golden(t testing.TB, fileName string, got []byte) {
+	t.Helper()
+	want, ok := readGolden(t, fileName, got)
+	if !ok || bytes.Equal(want, got) {
+		return
+	}
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMain(string(want), string(got), true)
+	t.Errorf("%s does not match:\n%s", fileName, dmp.DiffPrettyText(diffs))
+}
+
+// GoldenHTML compares got with the contents of the golden file structurally
+// with Diff; ignoring insignificant whitespace and the order of attributes.
+//
+// When the tests are run with the -update flag the golden file is written
+// instead.
+func GoldenHTML(t testing.TB, fileName string, got []byte) {
+	t.Helper()
+	want, ok := readGolden(t, fileName, got)
+	if !ok {
+		return
+	}
+	if diff := Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s does not match:\n%s", fileName, diff)
+	}
+}
+
+// AssertHTML compares the HTML structurally with Diff; reporting the
+// differences when they are not equal.
+func AssertHTML(t testing.TB, want, got string) {
+	t.Helper()
+	if diff := Diff(want, got); diff != "" {
+		t.Errorf("html does not match:\n%s", diff)
+	}
+}
+
+// readGolden returns the contents of the golden file. When updating, the
+// golden file is written if it is missing or different, and false is returned.
+func readGolden(t testing.TB, fileName string, got []byte) ([]byte, bool) {
+	t.Helper()
+	want, err := os.ReadFile(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("error reading golden file: %v", err)
+	}
+	if updating() {
+		if err != nil || !bytes.Equal(want, got) {
+			if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+				t.Fatalf("error creating golden file directory: %v", err)
+			}
+			if err := os.WriteFile(fileName, got, 0644); err != nil {
+				t.Fatalf("error writing golden file: %v", err)
+			}
+		}
+		return nil, false
+	}
+	if err != nil {
+		t.Errorf("golden file %s does not exist; run the tests with -update or %s=true to create it", fileName, updateEnv)
+		return nil, false
+	}
+	return want, true
+}
@@ -0,0 +1,225 @@
+package gohttest
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stackus/goht"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head><title>Cart &amp; Items</title></head>
+<body>
+	<h1 id="title" class="heading large">Cart</h1>
+	<ul class="items">
+		<li class="item">Bread
+		<li class="item active" data-id="2">Apples
+		<li class="item"><a href="/milk" target=_blank>Milk</a>
+	</ul>
+	<p>First<p>Second</p>
+	<script>if (a < b) { run("</p>"); }</script>
+	<img src="a.png" alt="An image">
+	<!-- a comment -->
+</body>
+</html>`
+
+func TestSelectors(t *testing.T) {
+	doc := NewDocument(page)
+	tests := map[string]struct {
+		selector string
+		want     []string
+	}{
+		"type":                 {selector: "li", want: []string{"Bread", "Apples", "Milk"}},
+		"id":                   {selector: "#title", want: []string{"Cart"}},
+		"class":                {selector: ".active", want: []string{"Apples"}},
+		"compound":             {selector: "li.item.active", want: []string{"Apples"}},
+		"child":                {selector: "ul > li.active", want: []string{"Apples"}},
+		"descendant":           {selector: "body a", want: []string{"Milk"}},
+		"not a child":          {selector: "ul > a", want: []string{}},
+		"next sibling":         {selector: "li.active + li", want: []string{"Milk"}},
+		"subsequent siblings":  {selector: "li:first-child ~ li", want: []string{"Apples", "Milk"}},
+		"attribute":            {selector: "[data-id]", want: []string{"Apples"}},
+		"attribute value":      {selector: `li[data-id="2"]`, want: []string{"Apples"}},
+		"attribute prefix":     {selector: "a[href^='/m']", want: []string{"Milk"}},
+		"attribute word":       {selector: "[class~=large]", want: []string{"Cart"}},
+		"first child":          {selector: "li:first-child", want: []string{"Bread"}},
+		"last child":           {selector: "li:last-child", want: []string{"Milk"}},
+		"nth child":            {selector: "li:nth-child(2)", want: []string{"Apples"}},
+		"nth child odd":        {selector: "li:nth-child(odd)", want: []string{"Bread", "Milk"}},
+		"nth last child":       {selector: "li:nth-last-child(-n+2)", want: []string{"Apples", "Milk"}},
+		"not":                  {selector: "li:not(.active)", want: []string{"Bread", "Milk"}},
+		"list":                 {selector: "h1, a", want: []string{"Cart", "Milk"}},
+		"implicitly closed p":  {selector: "body > p", want: []string{"First", "Second"}},
+		"script is raw text":   {selector: "script", want: []string{`if (a < b) { run("</p>"); }`}},
+		"title is unescaped":   {selector: "title", want: []string{"Cart & Items"}},
+		"universal with child": {selector: "ul > *", want: []string{"Bread", "Apples", "Milk"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := doc.Find(tt.selector).Texts()
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Find(%q) = %q, want %q", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	doc := NewDocument(page)
+	if got := doc.Find("ul").Find("a").Len(); got != 1 {
+		t.Errorf("want 1 link, got %d", got)
+	}
+	if v, ok := doc.Find("a").Attr("target"); !ok || v != "_blank" {
+		t.Errorf("want target _blank, got %q", v)
+	}
+	if _, ok := doc.Find("img").Attr("title"); ok {
+		t.Errorf("want no title attribute")
+	}
+	if !doc.Find("h1").HasClass("large") {
+		t.Errorf("want h1 to have the class large")
+	}
+	if got := doc.Find("li").Last().HTML(); got != `<li class="item"><a href="/milk" target="_blank">Milk</a>
+	</li>` {
+		t.Errorf("unexpected html: %q", got)
+	}
+	if got := doc.Find("ul").Children().Filter(".active").Text(); got != "Apples" {
+		t.Errorf("want Apples, got %q", got)
+	}
+	if !doc.Find("li").Eq(1).Is("[data-id]") {
+		t.Errorf("want the second item to have a data-id")
+	}
+}
+
+func TestInvalidSelector(t *testing.T) {
+	for _, sel := range []string{"", "ul >", "li[", "li:unknown", "a,,b", "li:nth-child(x)"} {
+		if _, err := compileSelector(sel); err == nil {
+			t.Errorf("want an error for %q", sel)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		want  string
+		got   string
+		equal bool
+	}{
+		"whitespace": {
+			want:  "<ul><li>a</li><li>b  c</li></ul>",
+			got:   "<ul>\n\t<li>a</li>\n\t<li>\n\t\tb\n\t\tc\n\t</li>\n</ul>\n",
+			equal: true,
+		},
+		"attribute order": {
+			want:  `<a href="/" class="x">a</a>`,
+			got:   `<a class='x' href=/>a</a>`,
+			equal: true,
+		},
+		"entities": {
+			want:  `<p>&#39;a&#39; &amp; b</p>`,
+			got:   `<p>'a' &amp; b</p>`,
+			equal: true,
+		},
+		"preformatted": {
+			want: "<pre>a\n  b</pre>",
+			got:  "<pre>a b</pre>",
+		},
+		"text": {
+			want: "<p>a</p>",
+			got:  "<p>b</p>",
+		},
+		"attribute value": {
+			want: `<a href="/a">a</a>`,
+			got:  `<a href="/b">a</a>`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff := Diff(tt.want, tt.got)
+			if (diff == "") != tt.equal {
+				t.Errorf("want equal %v, got diff:\n%s", tt.equal, diff)
+			}
+		})
+	}
+}
+
+func TestDiffOutput(t *testing.T) {
+	got := Diff("<ul><li>a</li><li>b</li></ul>", "<ul><li>a</li><li>c</li></ul>")
+	want := `  <ul>
+    <li>
+      a
+    </li>
+    <li>
+-     b
++     c
+    </li>
+  </ul>
+`
+	if got != want {
+		t.Errorf("got diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderDocument(t *testing.T) {
+	tmpl := goht.TemplateFunc(func(ctx context.Context, w io.Writer, _ ...goht.SlottedTemplate) error {
+		_, err := io.WriteString(w, `<ul><li class="active">Apples</li><li>Bread</li></ul>`)
+		return err
+	})
+	doc := RenderDocument(t, tmpl)
+	if got := doc.Find("ul > li.active").Text(); got != "Apples" {
+		t.Errorf("want Apples, got %q", got)
+	}
+	AssertHTML(t, "<ul>\n<li class=active>Apples</li>\n<li>Bread</li>\n</ul>", string(Render(t, tmpl)))
+}
+
+// update is defined by the test package as it would be by any other; gohttest
+// only looks the flag up
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestUpdateFlag(t *testing.T) {
+	defer func(old bool) { *update = old }(*update)
+	*update = false
+	if updating() {
+		t.Fatal("want no updates without the -update flag")
+	}
+	if err := flag.Set("update", "true"); err != nil || !updating() {
+		t.Errorf("want the -update flag of the test package to update the golden files, got %v", err)
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	tests := map[string]struct {
+		flag bool
+		env  string
+		want bool
+	}{
+		"not updating":     {},
+		"update flag":      {flag: true, want: true},
+		"update env":       {env: "true", want: true},
+		"update env false": {env: "false"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(old bool) { Update = old }(Update)
+			Update = tt.flag
+			t.Setenv(updateEnv, tt.env)
+
+			fileName := filepath.Join(t.TempDir(), "golden", "page.html")
+			if tt.want {
+				Golden(t, fileName, []byte(page))
+			}
+			got, err := os.ReadFile(fileName)
+			if tt.want && (err != nil || string(got) != page) {
+				t.Errorf("want the golden file to be written, got %q, %v", got, err)
+			}
+			if updating() != tt.want {
+				t.Errorf("want updating to be %t", tt.want)
+			}
+		})
+	}
+}
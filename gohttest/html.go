@@ -0,0 +1,265 @@
+package gohttest
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// NodeType is the type of a Node.
+type NodeType int
+
+const (
+	DocumentNode NodeType = iota
+	ElementNode
+	TextNode
+	CommentNode
+	DoctypeNode
+)
+
+// Node is a node of a parsed HTML document.
+type Node struct {
+	Type NodeType
+	// Data is the lowercase tag name of an element, the unescaped text of a
+	// text node, or the content of a comment or doctype.
+	Data     string
+	Attrs    []Attribute
+	Parent   *Node
+	Children []*Node
+}
+
+// Attribute is an attribute of an element with its unescaped value.
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Attr returns the value of the named attribute.
+func (n *Node) Attr(name string) (string, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+func (n *Node) appendChild(c *Node) {
+	c.Parent = n
+	n.Children = append(n.Children, c)
+}
+
+// voidElements are the elements that do not have any content or a closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements are the elements whose content is not parsed as HTML. The
+// value is true when the content may contain character references.
+var rawTextElements = map[string]bool{
+	"script": false, "style": false, "textarea": true, "title": true,
+}
+
+// closedBy are the elements that are implicitly closed when one of the
+// given elements is opened inside of them.
+var closedBy = map[string][]string{
+	"li":     {"li"},
+	"dt":     {"dt", "dd"},
+	"dd":     {"dt", "dd"},
+	"tr":     {"tr"},
+	"td":     {"td", "th", "tr"},
+	"th":     {"td", "th", "tr"},
+	"option": {"option", "optgroup"},
+	"p": {
+		"address", "article", "aside", "blockquote", "details", "div", "dl",
+		"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2",
+		"h3", "h4", "h5", "h6", "header", "hr", "main", "nav", "ol", "p",
+		"pre", "section", "table", "ul",
+	},
+}
+
+// Parse parses the HTML into a tree of nodes.
+//
+// The parser is lenient; it never fails and will do its best to make sense
+// of any markup. Missing end tags are inferred for the common cases, and
+// unmatched end tags are ignored.
+func Parse(s string) *Node {
+	root := &Node{Type: DocumentNode}
+	cur := root
+	text := func(t string) {
+		if t == "" {
+			return
+		}
+		if last := len(cur.Children) - 1; last >= 0 && cur.Children[last].Type == TextNode {
+			cur.Children[last].Data += t
+			return
+		}
+		cur.appendChild(&Node{Type: TextNode, Data: t})
+	}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			text(html.UnescapeString(s))
+			break
+		}
+		text(html.UnescapeString(s[:i]))
+		s = s[i:]
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				end = len(s) - 4
+				s += "-->"
+			}
+			cur.appendChild(&Node{Type: CommentNode, Data: s[4 : 4+end]})
+			s = s[4+end+3:]
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				end = len(s)
+				s += ">"
+			}
+			if indexFold(s[:end], "<!doctype") == 0 {
+				cur.appendChild(&Node{Type: DoctypeNode, Data: strings.TrimSpace(s[9:end])})
+			}
+			s = s[end+1:]
+		case strings.HasPrefix(s, "</"):
+			name, rest, ok := parseTagName(s[2:])
+			if !ok {
+				text("<")
+				s = s[1:]
+				continue
+			}
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			s = rest[end+1:]
+			for n := cur; n != root; n = n.Parent {
+				if n.Data == name {
+					cur = n.Parent
+					break
+				}
+			}
+		default:
+			el, rest, selfClosing, ok := parseStartTag(s)
+			if !ok {
+				text("<")
+				s = s[1:]
+				continue
+			}
+			s = rest
+			for n := cur; n != root; n = n.Parent {
+				if !slices.Contains(closedBy[n.Data], el.Data) {
+					break
+				}
+				cur = n.Parent
+			}
+			cur.appendChild(el)
+			if decode, ok := rawTextElements[el.Data]; ok && !selfClosing {
+				end := indexFold(s, "</"+el.Data)
+				if end < 0 {
+					end = len(s)
+				}
+				content := s[:end]
+				if decode {
+					content = html.UnescapeString(content)
+				}
+				if content != "" {
+					el.appendChild(&Node{Type: TextNode, Data: content})
+				}
+				s = s[end:]
+				if gt := strings.IndexByte(s, '>'); gt >= 0 {
+					s = s[gt+1:]
+				}
+				continue
+			}
+			if !voidElements[el.Data] && !selfClosing {
+				cur = el
+			}
+		}
+	}
+	return root
+}
+
+// parseTagName returns the lowercase tag name at the start of s.
+func parseTagName(s string) (name, rest string, ok bool) {
+	i := 0
+	for i < len(s) && (isLetter(s[i]) || i > 0 && (s[i] == '-' || '0' <= s[i] && s[i] <= '9')) {
+		i++
+	}
+	if i == 0 {
+		return "", s, false
+	}
+	return strings.ToLower(s[:i]), s[i:], true
+}
+
+// parseStartTag parses the start tag at the start of s.
+func parseStartTag(s string) (el *Node, rest string, selfClosing, ok bool) {
+	name, s, ok := parseTagName(s[1:])
+	if !ok {
+		return nil, s, false, false
+	}
+	el = &Node{Type: ElementNode, Data: name}
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == '>':
+			return el, s[1:], selfClosing, true
+		case c == '/':
+			selfClosing = true
+			s = s[1:]
+		case isSpace(c):
+			s = s[1:]
+		default:
+			selfClosing = false
+			i := 0
+			for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && (s[i] != '/' || i == 0) {
+				i++
+			}
+			attr := Attribute{Name: strings.ToLower(s[:i])}
+			s = strings.TrimLeft(s[i:], " \t\n\r\f")
+			if strings.HasPrefix(s, "=") {
+				s = strings.TrimLeft(s[1:], " \t\n\r\f")
+				var value string
+				if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+					end := strings.IndexByte(s[1:], s[0])
+					if end < 0 {
+						return nil, s, false, false
+					}
+					value, s = s[1:1+end], s[end+2:]
+				} else {
+					i := 0
+					for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+						i++
+					}
+					value, s = s[:i], s[i:]
+				}
+				attr.Value = html.UnescapeString(value)
+			}
+			if _, exists := el.Attr(attr.Name); !exists {
+				el.Attrs = append(el.Attrs, attr)
+			}
+		}
+	}
+	return nil, s, false, false
+}
+
+// indexFold returns the index of the first case-insensitive match of substr in s.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,96 @@
+package gohttest
+
+import (
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// preformattedElements are the elements whose whitespace is significant.
+var preformattedElements = map[string]bool{
+	"pre": true, "textarea": true, "script": true, "style": true,
+}
+
+// Normalize returns the HTML in a normalized form that can be compared
+// structurally.
+//
+// Each element, text and comment is written on its own line and indented by
+// its depth. Attributes are sorted by name, and whitespace within text is
+// collapsed and trimmed, except inside of pre, textarea, script and style
+// elements. Text that is only whitespace is removed.
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, c := range Parse(s).Children {
+		writeNormalized(&b, c, 0, false)
+	}
+	return b.String()
+}
+
+func writeNormalized(b *strings.Builder, n *Node, depth int, preformatted bool) {
+	indent := strings.Repeat("  ", depth)
+	switch n.Type {
+	case TextNode:
+		text := n.Data
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		if !preformatted {
+			text = strings.Join(strings.Fields(text), " ")
+		}
+		b.WriteString(indent + html.EscapeString(text) + "\n")
+	case CommentNode:
+		b.WriteString(indent + "<!--" + strings.TrimSpace(n.Data) + "-->\n")
+	case DoctypeNode:
+		b.WriteString(indent + "<!DOCTYPE " + strings.ToLower(n.Data) + ">\n")
+	case ElementNode:
+		attrs := slices.Clone(n.Attrs)
+		slices.SortFunc(attrs, func(a, b Attribute) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		b.WriteString(indent + "<" + n.Data)
+		for _, attr := range attrs {
+			b.WriteString(" " + attr.Name + `="` + html.EscapeString(attr.Value) + `"`)
+		}
+		b.WriteString(">\n")
+		if voidElements[n.Data] {
+			return
+		}
+		for _, c := range n.Children {
+			writeNormalized(b, c, depth+1, preformatted || preformattedElements[n.Data])
+		}
+		b.WriteString(indent + "</" + n.Data + ">\n")
+	}
+}
+
+// Diff compares two HTML documents structurally; returning an empty string
+// when they are equal, or a line diff of their normalized forms.
+//
+// Lines that are only in want are prefixed with "-", and lines that are only
+// in got are prefixed with "+".
+func Diff(want, got string) string {
+	want, got = Normalize(want), Normalize(got)
+	if want == got {
+		return ""
+	}
+	dmp := diffmatchpatch.New()
+	wantChars, gotChars, lines := dmp.DiffLinesToChars(want, got)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(wantChars, gotChars, false), lines)
+	var b strings.Builder
+	for _, d := range diffs {
+		prefix := "  "
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		}
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line != "" {
+				b.WriteString(prefix + line)
+			}
+		}
+	}
+	return b.String()
+}
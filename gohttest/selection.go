@@ -0,0 +1,214 @@
+package gohttest
+
+import (
+	"html"
+	"strings"
+)
+
+// Document is a parsed HTML document.
+type Document struct {
+	*Selection
+	Root *Node
+}
+
+// NewDocument parses the HTML into a Document.
+func NewDocument(s string) *Document {
+	root := Parse(s)
+	return &Document{
+		Selection: &Selection{Nodes: []*Node{root}},
+		Root:      root,
+	}
+}
+
+// Selection is a list of nodes from a Document.
+type Selection struct {
+	Nodes []*Node
+}
+
+// Find returns the elements within the selection that match the CSS selector.
+//
+// The elements are returned in document order. Find panics if the selector is
+// not valid.
+func (s *Selection) Find(sel string) *Selection {
+	compiled := mustCompileSelector(sel)
+	result := &Selection{}
+	seen := make(map[*Node]bool)
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for _, c := range n.Children {
+			if !seen[c] && compiled.match(c) {
+				seen[c] = true
+				result.Nodes = append(result.Nodes, c)
+			}
+			walk(c)
+		}
+	}
+	for _, n := range s.Nodes {
+		walk(n)
+	}
+	return result
+}
+
+// Filter returns the nodes of the selection that match the CSS selector.
+//
+// Filter panics if the selector is not valid.
+func (s *Selection) Filter(sel string) *Selection {
+	compiled := mustCompileSelector(sel)
+	result := &Selection{}
+	for _, n := range s.Nodes {
+		if compiled.match(n) {
+			result.Nodes = append(result.Nodes, n)
+		}
+	}
+	return result
+}
+
+// Is returns true if any of the nodes of the selection match the CSS selector.
+func (s *Selection) Is(sel string) bool {
+	return s.Filter(sel).Len() > 0
+}
+
+// Children returns the child elements of the nodes in the selection.
+func (s *Selection) Children() *Selection {
+	result := &Selection{}
+	for _, n := range s.Nodes {
+		for _, c := range n.Children {
+			if c.Type == ElementNode {
+				result.Nodes = append(result.Nodes, c)
+			}
+		}
+	}
+	return result
+}
+
+// Len returns the number of nodes in the selection.
+func (s *Selection) Len() int {
+	return len(s.Nodes)
+}
+
+// Eq returns a selection of the node at the index, or an empty selection when
+// the index is out of range. A negative index counts from the end.
+func (s *Selection) Eq(i int) *Selection {
+	if i < 0 {
+		i += len(s.Nodes)
+	}
+	if i < 0 || i >= len(s.Nodes) {
+		return &Selection{}
+	}
+	return &Selection{Nodes: []*Node{s.Nodes[i]}}
+}
+
+// First returns a selection of the first node.
+func (s *Selection) First() *Selection {
+	return s.Eq(0)
+}
+
+// Last returns a selection of the last node.
+func (s *Selection) Last() *Selection {
+	return s.Eq(-1)
+}
+
+// Each calls fn for each node of the selection.
+func (s *Selection) Each(fn func(i int, s *Selection)) {
+	for i := range s.Nodes {
+		fn(i, s.Eq(i))
+	}
+}
+
+// Text returns the combined text of the nodes and their descendants.
+//
+// Whitespace within the text is collapsed and trimmed, the same as it would
+// be displayed by a browser outside of a pre element.
+func (s *Selection) Text() string {
+	var b strings.Builder
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n.Type == TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	for _, n := range s.Nodes {
+		walk(n)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Texts returns the text of each node of the selection.
+func (s *Selection) Texts() []string {
+	texts := make([]string, len(s.Nodes))
+	for i := range s.Nodes {
+		texts[i] = s.Eq(i).Text()
+	}
+	return texts
+}
+
+// Attr returns the value of the named attribute of the first node.
+func (s *Selection) Attr(name string) (string, bool) {
+	if len(s.Nodes) == 0 {
+		return "", false
+	}
+	return s.Nodes[0].Attr(strings.ToLower(name))
+}
+
+// HasClass returns true if any of the nodes has the class.
+func (s *Selection) HasClass(class string) bool {
+	for _, n := range s.Nodes {
+		if hasClass(n, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTML returns the markup of the nodes of the selection.
+func (s *Selection) HTML() string {
+	var b strings.Builder
+	for _, n := range s.Nodes {
+		writeHTML(&b, n)
+	}
+	return b.String()
+}
+
+func writeHTML(b *strings.Builder, n *Node) {
+	switch n.Type {
+	case DocumentNode:
+		for _, c := range n.Children {
+			writeHTML(b, c)
+		}
+	case TextNode:
+		if decode, ok := rawTextElements[n.Parent.Data]; ok && !decode {
+			b.WriteString(n.Data)
+			return
+		}
+		b.WriteString(html.EscapeString(n.Data))
+	case CommentNode:
+		b.WriteString("<!--" + n.Data + "-->")
+	case DoctypeNode:
+		b.WriteString("<!DOCTYPE " + n.Data + ">")
+	case ElementNode:
+		b.WriteString("<" + n.Data)
+		for _, attr := range n.Attrs {
+			b.WriteString(" " + attr.Name + `="` + html.EscapeString(attr.Value) + `"`)
+		}
+		b.WriteString(">")
+		if voidElements[n.Data] {
+			return
+		}
+		for _, c := range n.Children {
+			writeHTML(b, c)
+		}
+		b.WriteString("</" + n.Data + ">")
+	}
+}
+
+func mustCompileSelector(sel string) selector {
+	compiled, err := compileSelector(sel)
+	if err != nil {
+		panic("gohttest: " + err.Error())
+	}
+	return compiled
+}
@@ -0,0 +1,436 @@
+package gohttest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selector is a compiled group of complex selectors (e.g. "ul > li, p.note").
+type selector []complexSelector
+
+// complexSelector is a chain of compound selectors joined by combinators.
+type complexSelector struct {
+	parts []compoundSelector
+	// combinators[i] joins parts[i] and parts[i+1]; one of ' ', '>', '+' or '~'
+	combinators []byte
+}
+
+// compoundSelector is a sequence of simple selectors that all match the same element.
+type compoundSelector struct {
+	tag   string
+	conds []func(n *Node) bool
+}
+
+func (s selector) match(n *Node) bool {
+	for _, c := range s {
+		if c.matchAt(len(c.parts)-1, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c complexSelector) matchAt(i int, n *Node) bool {
+	if !c.parts[i].match(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch c.combinators[i-1] {
+	case '>':
+		return isElement(n.Parent) && c.matchAt(i-1, n.Parent)
+	case '+':
+		prev := previousElement(n)
+		return prev != nil && c.matchAt(i-1, prev)
+	case '~':
+		for prev := previousElement(n); prev != nil; prev = previousElement(prev) {
+			if c.matchAt(i-1, prev) {
+				return true
+			}
+		}
+	default:
+		for p := n.Parent; isElement(p); p = p.Parent {
+			if c.matchAt(i-1, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c compoundSelector) match(n *Node) bool {
+	if n.Type != ElementNode || c.tag != "" && c.tag != "*" && c.tag != n.Data {
+		return false
+	}
+	for _, cond := range c.conds {
+		if !cond(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func isElement(n *Node) bool {
+	return n != nil && n.Type == ElementNode
+}
+
+func previousElement(n *Node) *Node {
+	if n.Parent == nil {
+		return nil
+	}
+	var prev *Node
+	for _, c := range n.Parent.Children {
+		if c == n {
+			return prev
+		}
+		if c.Type == ElementNode {
+			prev = c
+		}
+	}
+	return nil
+}
+
+// elementIndex returns the position of n among its element siblings,
+// starting from 1, and the number of element siblings.
+func elementIndex(n *Node) (index, count int) {
+	if n.Parent == nil {
+		return 1, 1
+	}
+	for _, c := range n.Parent.Children {
+		if c.Type != ElementNode {
+			continue
+		}
+		count++
+		if c == n {
+			index = count
+		}
+	}
+	return index, count
+}
+
+// compileSelector compiles a CSS selector.
+//
+// Type, universal, id, class and attribute selectors are supported along with
+// the descendant, child, next-sibling and subsequent-sibling combinators,
+// selector lists, and the :first-child, :last-child, :only-child,
+// :nth-child(), :nth-last-child() and :not() pseudo-classes.
+func compileSelector(s string) (selector, error) {
+	p := &selectorParser{s: s}
+	sel, err := p.parseList()
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", s, err)
+	}
+	if p.pos < len(p.s) {
+		return nil, fmt.Errorf("invalid selector %q: unexpected %q", s, p.s[p.pos:])
+	}
+	return sel, nil
+}
+
+type selectorParser struct {
+	s   string
+	pos int
+}
+
+func (p *selectorParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.s) && isSpace(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) parseList() (selector, error) {
+	var sel selector
+	for {
+		p.skipSpace()
+		c, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, c)
+		p.skipSpace()
+		if p.peek() != ',' {
+			return sel, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *selectorParser) parseComplex() (complexSelector, error) {
+	var c complexSelector
+	for {
+		part, err := p.parseCompound()
+		if err != nil {
+			return c, err
+		}
+		c.parts = append(c.parts, part)
+
+		combinator := byte(' ')
+		if !p.skipSpace() {
+			combinator = 0
+		}
+		switch p.peek() {
+		case '>', '+', '~':
+			combinator = p.peek()
+			p.pos++
+			p.skipSpace()
+		case 0, ',', ')':
+			return c, nil
+		}
+		if combinator == 0 {
+			return c, fmt.Errorf("unexpected %q", p.s[p.pos:])
+		}
+		c.combinators = append(c.combinators, combinator)
+	}
+}
+
+func (p *selectorParser) parseCompound() (compoundSelector, error) {
+	var c compoundSelector
+	if p.peek() == '*' {
+		c.tag = "*"
+		p.pos++
+	} else if name := p.parseName(); name != "" {
+		c.tag = strings.ToLower(name)
+	}
+	for {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			id := p.parseName()
+			if id == "" {
+				return c, fmt.Errorf("id expected")
+			}
+			c.conds = append(c.conds, func(n *Node) bool {
+				v, ok := n.Attr("id")
+				return ok && v == id
+			})
+		case '.':
+			p.pos++
+			class := p.parseName()
+			if class == "" {
+				return c, fmt.Errorf("class name expected")
+			}
+			c.conds = append(c.conds, func(n *Node) bool {
+				return hasClass(n, class)
+			})
+		case '[':
+			cond, err := p.parseAttribute()
+			if err != nil {
+				return c, err
+			}
+			c.conds = append(c.conds, cond)
+		case ':':
+			cond, err := p.parsePseudo()
+			if err != nil {
+				return c, err
+			}
+			c.conds = append(c.conds, cond)
+		default:
+			if c.tag == "" && len(c.conds) == 0 {
+				return c, fmt.Errorf("selector expected at %q", p.s[p.pos:])
+			}
+			return c, nil
+		}
+	}
+}
+
+func (p *selectorParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !isLetter(c) && !('0' <= c && c <= '9') && c != '-' && c != '_' && c < 0x80 {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *selectorParser) parseAttribute() (func(n *Node) bool, error) {
+	p.pos++ // [
+	p.skipSpace()
+	name := strings.ToLower(p.parseName())
+	if name == "" {
+		return nil, fmt.Errorf("attribute name expected")
+	}
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return func(n *Node) bool {
+			_, ok := n.Attr(name)
+			return ok
+		}, nil
+	}
+	op := ""
+	if strings.IndexByte("~|^$*", p.peek()) >= 0 {
+		op = string(p.peek())
+		p.pos++
+	}
+	if p.peek() != '=' {
+		return nil, fmt.Errorf("attribute operator expected")
+	}
+	p.pos++
+	p.skipSpace()
+	var value string
+	if q := p.peek(); q == '"' || q == '\'' {
+		end := strings.IndexByte(p.s[p.pos+1:], q)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated attribute value")
+		}
+		value = p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	} else {
+		value = p.parseName()
+	}
+	p.skipSpace()
+	if p.peek() != ']' {
+		return nil, fmt.Errorf("] expected")
+	}
+	p.pos++
+	return func(n *Node) bool {
+		v, ok := n.Attr(name)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "~":
+			for _, field := range strings.Fields(v) {
+				if field == value {
+					return true
+				}
+			}
+			return false
+		case "|":
+			return v == value || strings.HasPrefix(v, value+"-")
+		case "^":
+			return value != "" && strings.HasPrefix(v, value)
+		case "$":
+			return value != "" && strings.HasSuffix(v, value)
+		case "*":
+			return value != "" && strings.Contains(v, value)
+		}
+		return v == value
+	}, nil
+}
+
+func (p *selectorParser) parsePseudo() (func(n *Node) bool, error) {
+	p.pos++ // :
+	name := strings.ToLower(p.parseName())
+	switch name {
+	case "first-child":
+		return func(n *Node) bool {
+			i, _ := elementIndex(n)
+			return i == 1
+		}, nil
+	case "last-child":
+		return func(n *Node) bool {
+			i, count := elementIndex(n)
+			return i == count
+		}, nil
+	case "only-child":
+		return func(n *Node) bool {
+			_, count := elementIndex(n)
+			return count == 1
+		}, nil
+	case "nth-child", "nth-last-child":
+		arg, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		a, b, err := parseNth(arg)
+		if err != nil {
+			return nil, err
+		}
+		last := name == "nth-last-child"
+		return func(n *Node) bool {
+			i, count := elementIndex(n)
+			if last {
+				i = count - i + 1
+			}
+			if a == 0 {
+				return i == b
+			}
+			return (i-b)%a == 0 && (i-b)/a >= 0
+		}, nil
+	case "not":
+		arg, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		inner, err := compileSelector(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(n *Node) bool {
+			return !inner.match(n)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported pseudo-class :%s", name)
+}
+
+func (p *selectorParser) parseArgument() (string, error) {
+	if p.peek() != '(' {
+		return "", fmt.Errorf("( expected")
+	}
+	end := strings.IndexByte(p.s[p.pos:], ')')
+	if end < 0 {
+		return "", fmt.Errorf(") expected")
+	}
+	arg := strings.TrimSpace(p.s[p.pos+1 : p.pos+end])
+	p.pos += end + 1
+	return arg, nil
+}
+
+// parseNth parses the an+b argument of the :nth-child() pseudo-classes.
+func parseNth(s string) (a, b int, err error) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch s {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+	n := strings.IndexByte(s, 'n')
+	if n < 0 {
+		b, err = strconv.Atoi(s)
+		return 0, b, err
+	}
+	switch s[:n] {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		if a, err = strconv.Atoi(s[:n]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if rest := s[n+1:]; rest != "" {
+		if b, err = strconv.Atoi(rest); err != nil {
+			return 0, 0, err
+		}
+	}
+	return a, b, nil
+}
+
+func hasClass(n *Node, class string) bool {
+	v, ok := n.Attr("class")
+	if !ok {
+		return false
+	}
+	for _, field := range strings.Fields(v) {
+		if field == class {
+			return true
+		}
+	}
+	return false
+}
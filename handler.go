@@ -0,0 +1,177 @@
+package goht
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrNilTemplate is returned to the error template when the function given to
+// HandlerFunc returns a nil template without an error.
+var ErrNilTemplate = errors.New("goht: handler returned a nil template")
+
+// HandlerOption configures a handler created by Handler or HandlerFunc.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	status        int
+	contentType   string
+	errorTemplate func(r *http.Request, err error) Template
+	etag          bool
+}
+
+// WithStatus sets the status code of a successful response.
+//
+// The default status is 200 OK.
+func WithStatus(status int) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.status = status
+	}
+}
+
+// WithContentType sets the Content-Type of the response.
+//
+// The default content type is "text/html; charset=utf-8". A Content-Type
+// header that has already been set on the response is not replaced.
+func WithContentType(contentType string) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.contentType = contentType
+	}
+}
+
+// WithErrorTemplate sets the function that returns the template to render
+// when the template, or the function given to HandlerFunc, returns an error.
+//
+// The error template is rendered with a 500 Internal Server Error status, or
+// with the status of the error when it, or an error that it wraps, has a
+// StatusCode() int method that returns a valid status code. When the error
+// template fails to render, fn returns a nil template, or no error template
+// is set, a plain text error response is sent.
+func WithErrorTemplate(fn func(r *http.Request, err error) Template) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.errorTemplate = fn
+	}
+}
+
+// WithoutETag disables the ETag header and the conditional GET support.
+func WithoutETag() HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.etag = false
+	}
+}
+
+type handler struct {
+	template func(r *http.Request) (Template, error)
+	handlerConfig
+}
+
+// Handler returns an http.Handler that renders the template.
+//
+// The template is rendered into a buffer before anything is written to the
+// response, so an error from the template never results in half of a page
+// being sent. Successful responses include an ETag computed from the
+// rendered output, and requests with a matching If-None-Match header receive
+// a 304 Not Modified response.
+func Handler(t Template, options ...HandlerOption) http.Handler {
+	return HandlerFunc(func(*http.Request) (Template, error) {
+		return t, nil
+	}, options...)
+}
+
+// HandlerFunc returns an http.Handler that renders the template returned by
+// fn for each request.
+//
+// An error returned by fn is handled the same as an error from rendering the
+// template, and so is a nil template, with ErrNilTemplate. See Handler for
+// how the response is written.
+func HandlerFunc(fn func(r *http.Request) (Template, error), options ...HandlerOption) http.Handler {
+	h := &handler{
+		template: fn,
+		handlerConfig: handlerConfig{
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			etag:        true,
+		},
+	}
+	for _, option := range options {
+		option(&h.handlerConfig)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t, err := h.template(r)
+	if err == nil && t == nil {
+		err = ErrNilTemplate
+	}
+	if err == nil {
+		buf := GetBuffer()
+		defer ReleaseBuffer(buf)
+		if err = t.Render(r.Context(), buf); err == nil {
+			h.write(w, r, h.status, buf.Bytes())
+			return
+		}
+	}
+	h.error(w, r, err)
+}
+
+func (h *handler) error(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	var se interface{ StatusCode() int }
+	if errors.As(err, &se) && se.StatusCode() >= 100 && se.StatusCode() <= 999 {
+		status = se.StatusCode()
+	}
+	if h.errorTemplate != nil {
+		if t := h.errorTemplate(r, err); t != nil {
+			buf := GetBuffer()
+			defer ReleaseBuffer(buf)
+			if t.Render(r.Context(), buf) == nil {
+				h.write(w, r, status, buf.Bytes())
+				return
+			}
+		}
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+func (h *handler) write(w http.ResponseWriter, r *http.Request, status int, body []byte) {
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", h.contentType)
+	}
+	if h.etag && status == http.StatusOK {
+		etag := computeETag(body)
+		header.Set("ETag", etag)
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && etagMatches(r.Header.Get("If-None-Match"), etag) {
+			header.Del("Content-Type")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+// computeETag returns a strong entity tag for the body.
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether the If-None-Match header matches the etag
+// using the weak comparison that is required for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package goht_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stackus/goht"
+	"github.com/stackus/goht/compiler/testdata"
+)
+
+// etag returns the ETag that the handlers send for the body.
+func etag(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+type statusError int
+
+func (e statusError) Error() string   { return http.StatusText(int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestHandler(t *testing.T) {
+	errorPage := goht.WithErrorTemplate(func(r *http.Request, err error) goht.Template {
+		return testdata.HandlerTest(err.Error())
+	})
+	hello := etag("<p>Hello</p>\n")
+	tests := map[string]struct {
+		handler     http.Handler
+		method      string
+		ifNoneMatch string
+		wantStatus  int
+		wantBody    string
+		wantType    string
+		wantETag    string
+	}{
+		"renders": {
+			handler:    goht.Handler(testdata.HandlerTest("Hello")),
+			wantStatus: http.StatusOK,
+			wantBody:   "<p>Hello</p>\n",
+			wantType:   "text/html; charset=utf-8",
+			wantETag:   hello,
+		},
+		"head": {
+			handler:    goht.Handler(testdata.HandlerTest("Hello")),
+			method:     http.MethodHead,
+			wantStatus: http.StatusOK,
+			wantType:   "text/html; charset=utf-8",
+			wantETag:   hello,
+		},
+		"status and content type": {
+			handler:    goht.Handler(testdata.HandlerTest("Created"), goht.WithStatus(http.StatusCreated), goht.WithContentType("application/xhtml+xml")),
+			wantStatus: http.StatusCreated,
+			wantBody:   "<p>Created</p>\n",
+			wantType:   "application/xhtml+xml",
+		},
+		"not modified": {
+			handler:     goht.Handler(testdata.HandlerTest("Hello")),
+			ifNoneMatch: `"other", W/` + hello,
+			wantStatus:  http.StatusNotModified,
+			wantETag:    hello,
+		},
+		"modified": {
+			handler:     goht.Handler(testdata.HandlerTest("Hello")),
+			ifNoneMatch: `"other"`,
+			wantStatus:  http.StatusOK,
+			wantBody:    "<p>Hello</p>\n",
+			wantType:    "text/html; charset=utf-8",
+			wantETag:    hello,
+		},
+		"without etag": {
+			handler:     goht.Handler(testdata.HandlerTest("Hello"), goht.WithoutETag()),
+			ifNoneMatch: hello,
+			wantStatus:  http.StatusOK,
+			wantBody:    "<p>Hello</p>\n",
+			wantType:    "text/html; charset=utf-8",
+		},
+		"render error": {
+			handler:    goht.Handler(testdata.FailingTest("Half a page", errors.New("boom"))),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+			wantType:   "text/plain; charset=utf-8",
+		},
+		"error template": {
+			handler:    goht.Handler(testdata.FailingTest("Half a page", errors.New("boom")), errorPage),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "<p>boom</p>\n",
+			wantType:   "text/html; charset=utf-8",
+		},
+		"failing error template": {
+			handler: goht.Handler(testdata.FailingTest("", errors.New("boom")), goht.WithErrorTemplate(func(r *http.Request, err error) goht.Template {
+				return testdata.FailingTest("Error", err)
+			})),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+			wantType:   "text/plain; charset=utf-8",
+		},
+		"nil error template": {
+			handler: goht.Handler(testdata.FailingTest("", statusError(http.StatusNotFound)), goht.WithErrorTemplate(func(r *http.Request, err error) goht.Template {
+				return nil
+			})),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Not Found\n",
+			wantType:   "text/plain; charset=utf-8",
+		},
+		"handler func": {
+			handler: goht.HandlerFunc(func(r *http.Request) (goht.Template, error) {
+				return testdata.HandlerTest(r.URL.Path), nil
+			}),
+			wantStatus: http.StatusOK,
+			wantBody:   "<p>/path</p>\n",
+			wantType:   "text/html; charset=utf-8",
+			wantETag:   etag("<p>/path</p>\n"),
+		},
+		"handler func error status": {
+			handler: goht.HandlerFunc(func(r *http.Request) (goht.Template, error) {
+				return nil, statusError(http.StatusNotFound)
+			}, errorPage),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "<p>Not Found</p>\n",
+			wantType:   "text/html; charset=utf-8",
+		},
+		"invalid error status": {
+			handler:    goht.Handler(testdata.FailingTest("", statusError(0)), errorPage),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "<p></p>\n",
+			wantType:   "text/html; charset=utf-8",
+		},
+		"out of range error status": {
+			handler:    goht.Handler(testdata.FailingTest("", statusError(1000))),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+			wantType:   "text/plain; charset=utf-8",
+		},
+		"handler func nil template": {
+			handler: goht.HandlerFunc(func(r *http.Request) (goht.Template, error) {
+				return nil, nil
+			}, errorPage),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "<p>goht: handler returned a nil template</p>\n",
+			wantType:   "text/html; charset=utf-8",
+		},
+		"wrapped error status": {
+			handler:    goht.Handler(testdata.FailingTest("", fmt.Errorf("loading the cart: %w", statusError(http.StatusNotFound))), errorPage),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "<p>loading the cart: Not Found</p>\n",
+			wantType:   "text/html; charset=utf-8",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, "/path", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("want status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("want body %q, got %q", tt.wantBody, got)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("want content type %q, got %q", tt.wantType, got)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("want etag %q, got %q", tt.wantETag, got)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/rs/zerolog"
 
@@ -99,8 +100,9 @@ func (s *Server) sanitizeCapabilities(capabilities *protocol.ServerCapabilities)
 	capabilities.FoldingRangeProvider = nil
 	capabilities.InlayHintProvider = nil
 
-	// Go formatting edits target generated Go and do not preserve GoHT/Haml layout.
-	capabilities.DocumentFormattingProvider = &protocol.Or_ServerCapabilities_documentFormattingProvider{Value: false}
+	// Go formatting edits target generated Go and do not preserve GoHT/Haml layout;
+	// documents are formatted by the GoHT formatter instead.
+	capabilities.DocumentFormattingProvider = &protocol.Or_ServerCapabilities_documentFormattingProvider{Value: true}
 	capabilities.DocumentRangeFormattingProvider = &protocol.Or_ServerCapabilities_documentRangeFormattingProvider{Value: false}
 	capabilities.DocumentOnTypeFormattingProvider = nil
 
@@ -516,8 +518,50 @@ func (s *Server) FoldingRanges(_ context.Context, _ *protocol.FoldingRangeParams
 	return []protocol.FoldingRange{}, nil
 }
 
-func (s *Server) Formatting(_ context.Context, _ *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
-	return []protocol.TextEdit{}, nil
+// Formatting is called when the client requests the document to be formatted.
+// The document is formatted by the GoHT formatter and replaced with a single
+// edit. Documents with errors are left as they are.
+func (s *Server) Formatting(_ context.Context, params *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
+	logger := s.logger.With().
+		Str("method", "Formatting").
+		Str("uri", string(params.TextDocument.URI)).
+		Logger()
+
+	doc, ok := s.srcs.Get(string(params.TextDocument.URI))
+	if !ok {
+		logger.Warn().Msg("document not found")
+		return []protocol.TextEdit{}, nil
+	}
+	contents := doc.String()
+	formatted, err := compiler.Format([]byte(contents))
+	if err != nil {
+		logger.Info().Err(err).Msg("unable to format the document")
+		return []protocol.TextEdit{}, nil
+	}
+	if string(formatted) == contents {
+		return []protocol.TextEdit{}, nil
+	}
+
+	return []protocol.TextEdit{{
+		Range: protocol.Range{
+			Start: protocol.Position{},
+			End:   documentEnd(doc.lines, s.positionEncoding),
+		},
+		NewText: string(formatted),
+	}}, nil
+}
+
+// documentEnd returns the position at the end of the last line of a document.
+func documentEnd(lines []string, encoding protocol.PositionEncodingKind) protocol.Position {
+	last := lines[len(lines)-1]
+	character := len(last)
+	if encoding != protocol.UTF8 {
+		character = len(utf16.Encode([]rune(last)))
+	}
+	return protocol.Position{
+		Line:      uint32(len(lines) - 1),
+		Character: uint32(character),
+	}
 }
 
 func (s *Server) Hover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
@@ -972,25 +1016,19 @@ func (s *Server) parseTemplate(ctx context.Context, uri protocol.DocumentURI, co
 	template, err := compiler.ParseString(contents)
 	if err != nil {
 		parseErr := err
-		diagnostic := protocol.Diagnostic{
-			Severity: protocol.SeverityError,
-			Source:   "goht",
-			Message:  err.Error(),
-		}
-		if posErr, ok := errors.AsType[compiler.PositionalError](err); ok {
-			diagnostic.Range = protocol.Range{
-				Start: protocol.Position{
-					Line:      uint32(posErr.Line),
-					Character: uint32(posErr.Column),
-				},
-				End: protocol.Position{
-					Line:      uint32(posErr.Line),
-					Character: uint32(posErr.Column),
-				},
+		var diagnostics []protocol.Diagnostic
+		if errList, ok := errors.AsType[compiler.ErrorList](err); ok {
+			for _, d := range errList.Diagnostics() {
+				diagnostics = append(diagnostics, parserDiagnostic(d))
 			}
-		}
-		diagnostics := []protocol.Diagnostic{
-			diagnostic,
+		} else if posErr, ok := errors.AsType[compiler.PositionalError](err); ok {
+			diagnostics = append(diagnostics, parserDiagnostic(posErr.Diagnostic()))
+		} else {
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Severity: protocol.SeverityError,
+				Source:   "goht",
+				Message:  err.Error(),
+			})
 		}
 		diagnostics = s.dc.WithParserDiagnostics(string(uri), diagnostics)
 		err = s.c.PublishDiagnostics(ctx, &protocol.PublishDiagnosticsParams{
@@ -1013,6 +1051,43 @@ func (s *Server) parseTemplate(ctx context.Context, uri protocol.DocumentURI, co
 	return template, nil
 }
 
+// parserDiagnostic returns the LSP diagnostic for a parser diagnostic. The
+// positions of the parser are one-based, while the positions of an LSP
+// diagnostic are zero-based.
+func parserDiagnostic(d compiler.Diagnostic) protocol.Diagnostic {
+	start := protocol.Position{
+		Line:      uint32(max(d.Line-1, 0)),
+		Character: uint32(max(d.Column-1, 0)),
+	}
+	end := start
+	if d.EndLine > 0 {
+		end = protocol.Position{
+			Line:      uint32(max(d.EndLine-1, 0)),
+			Character: uint32(max(d.EndColumn-1, 0)),
+		}
+	}
+	message := d.Message
+	if d.Hint != "" {
+		message += "\nhint: " + d.Hint
+	}
+	diagnostic := protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: start,
+			End:   end,
+		},
+		Severity: protocol.DiagnosticSeverity(d.Severity),
+		Source:   "goht",
+		Message:  message,
+	}
+	if d.Code != "" {
+		diagnostic.Code = string(d.Code)
+		diagnostic.CodeDescription = &protocol.CodeDescription{
+			Href: protocol.URI(d.Code.URL()),
+		}
+	}
+	return diagnostic
+}
+
 func clientSupportsUTF8(params *protocol.ParamInitialize) bool {
 	if params == nil || params.Capabilities.General == nil {
 		return false
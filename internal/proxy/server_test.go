@@ -2,10 +2,12 @@ package proxy
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
 
+	"github.com/stackus/goht/compiler"
 	"github.com/stackus/goht/internal/protocol"
 )
 
@@ -178,8 +180,8 @@ func TestServerInitializeSanitizesUnsupportedCapabilities(t *testing.T) {
 	if got.Capabilities.ExecuteCommandProvider != nil {
 		t.Fatalf("ExecuteCommandProvider = %#v, want nil", got.Capabilities.ExecuteCommandProvider)
 	}
-	if got.Capabilities.DocumentFormattingProvider == nil || got.Capabilities.DocumentFormattingProvider.Value != false {
-		t.Fatalf("DocumentFormattingProvider = %#v, want false", got.Capabilities.DocumentFormattingProvider)
+	if got.Capabilities.DocumentFormattingProvider == nil || got.Capabilities.DocumentFormattingProvider.Value != true {
+		t.Fatalf("DocumentFormattingProvider = %#v, want true", got.Capabilities.DocumentFormattingProvider)
 	}
 	if got.Capabilities.DocumentRangeFormattingProvider == nil || got.Capabilities.DocumentRangeFormattingProvider.Value != false {
 		t.Fatalf("DocumentRangeFormattingProvider = %#v, want false", got.Capabilities.DocumentRangeFormattingProvider)
@@ -533,6 +535,91 @@ func TestServerDidOpenInvalidGohtSkipsGeneratedGoDocument(t *testing.T) {
 	}
 }
 
+func TestServerDidOpenPublishesAllParseErrors(t *testing.T) {
+	server := &recordingServer{}
+	client := &recordingClient{}
+	proxy := newTestServer(server, client)
+
+	text := "@haml Test() {\n\t%p{id: bar}\n\t:unknown\n}\n"
+	if err := proxy.DidOpen(context.Background(), didOpenParams(text)); err != nil {
+		t.Fatalf("DidOpen() error = %v", err)
+	}
+
+	if len(client.diagnostics) == 0 {
+		t.Fatalf("diagnostics were not published")
+	}
+	got := client.diagnostics[len(client.diagnostics)-1].Diagnostics
+	want := []protocol.Range{
+		{Start: protocol.Position{Line: 1, Character: 8}, End: protocol.Position{Line: 1, Character: 9}},
+		{Start: protocol.Position{Line: 2, Character: 2}, End: protocol.Position{Line: 2, Character: 9}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("diagnostics = %v, want %d", got, len(want))
+	}
+	for i, d := range got {
+		if d.Range != want[i] {
+			t.Errorf("diagnostic %d range = %v, want %v", i, d.Range, want[i])
+		}
+	}
+	if code := got[1].Code; code != string(compiler.ErrUnknownFilter) {
+		t.Errorf("diagnostic code = %v, want %s", code, compiler.ErrUnknownFilter)
+	}
+	if got[1].CodeDescription == nil || got[1].CodeDescription.Href != protocol.URI(compiler.ErrUnknownFilter.URL()) {
+		t.Errorf("diagnostic code description = %v, want %s", got[1].CodeDescription, compiler.ErrUnknownFilter.URL())
+	}
+	if !strings.Contains(got[1].Message, "hint: ") {
+		t.Errorf("diagnostic message = %q, want a hint", got[1].Message)
+	}
+}
+
+func TestServerFormattingFormatsDocument(t *testing.T) {
+	server := &recordingServer{}
+	client := &recordingClient{}
+	proxy := newTestServer(server, client)
+
+	text := "package main\n\n@haml Test( name string ) {\n\t%p{id:\"a\"} héllo\n}"
+	if err := proxy.DidOpen(context.Background(), didOpenParams(text)); err != nil {
+		t.Fatalf("DidOpen() error = %v", err)
+	}
+
+	edits, err := proxy.Formatting(context.Background(), &protocol.DocumentFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: testGohtURI},
+	})
+	if err != nil {
+		t.Fatalf("Formatting() error = %v", err)
+	}
+	if len(edits) != 1 {
+		t.Fatalf("Formatting() edits = %v, want 1 edit", edits)
+	}
+	want := protocol.Range{End: protocol.Position{Line: 4, Character: 1}}
+	if edits[0].Range != want {
+		t.Errorf("edit range = %v, want %v", edits[0].Range, want)
+	}
+	if got, want := edits[0].NewText, "package main\n\n@haml Test(name string) {\n\t%p{id: \"a\"} héllo\n}\n"; got != want {
+		t.Errorf("edit text = %q, want %q", got, want)
+	}
+}
+
+func TestServerFormattingSkipsInvalidDocument(t *testing.T) {
+	server := &recordingServer{}
+	client := &recordingClient{}
+	proxy := newTestServer(server, client)
+
+	if err := proxy.DidOpen(context.Background(), didOpenParams(invalidGoht)); err != nil {
+		t.Fatalf("DidOpen() error = %v", err)
+	}
+
+	edits, err := proxy.Formatting(context.Background(), &protocol.DocumentFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: testGohtURI},
+	})
+	if err != nil {
+		t.Fatalf("Formatting() error = %v", err)
+	}
+	if len(edits) != 0 {
+		t.Errorf("Formatting() edits = %v, want none", edits)
+	}
+}
+
 func TestServerDidChangeInvalidGohtKeepsLastValidGeneratedState(t *testing.T) {
 	server := &recordingServer{}
 	client := &recordingClient{}
@@ -0,0 +1,20 @@
+package goht
+
+import (
+	"encoding/json"
+)
+
+// JSON returns the value encoded as JSON that is safe to be written into a
+// script element, such as a <script type="application/json"> data island.
+//
+// The characters <, > and & are written as \u003c, \u003e and \u0026 so the
+// JSON cannot close the script element or open a comment ("</script" or
+// "<!--"), and the line terminators U+2028 and U+2029 are escaped so the JSON
+// is also a valid JavaScript expression.
+func JSON(value any) (JS, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return JS(b), nil
+}
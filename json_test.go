@@ -0,0 +1,33 @@
+package goht
+
+import (
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	tests := map[string]struct {
+		value   any
+		want    JS
+		wantErr bool
+	}{
+		"string":       {value: "hello", want: `"hello"`},
+		"map":          {value: map[string]int{"b": 2, "a": 1}, want: `{"a":1,"b":2}`},
+		"nil":          {value: nil, want: `null`},
+		"close script": {value: "</script><script>alert(1)</script>", want: `"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"`},
+		"comment":      {value: "<!-- x -->", want: `"\u003c!-- x --\u003e"`},
+		"ampersand":    {value: "a & b", want: `"a \u0026 b"`},
+		"separators":   {value: "a\u2028b\u2029c", want: `"a\u2028b\u2029c"`},
+		"unsupported":  {value: func() {}, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := JSON(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("JSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package goht
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+type recoverContextKey struct{}
+
+// PanicError is returned by a template that panicked while being rendered
+// with a context from WithPanicRecovery.
+type PanicError struct {
+	// Template is the name of the template that panicked.
+	Template string
+	// File and Line are the position in the GoHT template file of the code
+	// that panicked. They are empty when the position could not be found.
+	File string
+	Line int
+	// Value is the value that was passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("goht: panic in template %s: %v", e.Template, e.Value)
+	}
+	return fmt.Sprintf("goht: panic in template %s at %s:%d: %v", e.Template, filepath.Base(e.File), e.Line, e.Value)
+}
+
+// Unwrap returns the panic value when it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// WithPanicRecovery returns a context that recovers panics in the templates
+// rendered with it.
+//
+// A panic is returned from the template that panicked as a *PanicError with
+// the name of the template and the position in the GoHT template file.
+func WithPanicRecovery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, recoverContextKey{}, true)
+}
+
+// RecoverPanic recovers a panic in the template and sets err to a *PanicError
+// when panic recovery has been enabled with WithPanicRecovery.
+//
+// It must be called with defer from the template.
+func RecoverPanic(ctx context.Context, template string, err *error) {
+	if enabled, _ := ctx.Value(recoverContextKey{}).(bool); !enabled {
+		return
+	}
+	v := recover()
+	if v == nil {
+		return
+	}
+	pErr := &PanicError{
+		Template: template,
+		Value:    v,
+		Stack:    debug.Stack(),
+	}
+	pErr.File, pErr.Line = panicPosition()
+	*err = pErr
+}
+
+// panicPosition returns the position of the first frame of the panicking
+// stack that is within a GoHT template file. The generated code contains
+// //line directives so these positions refer to the template file.
+func panicPosition() (string, int) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, ".goht") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package goht_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stackus/goht"
+	"github.com/stackus/goht/compiler/testdata"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	errValue := errors.New("some error")
+	tests := map[string]struct {
+		value   any
+		wantErr string
+	}{
+		"string": {
+			value:   "oops",
+			wantErr: "goht: panic in template PanicTest: oops",
+		},
+		"error": {
+			value:   errValue,
+			wantErr: "goht: panic in template PanicTest: some error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := testdata.PanicTest(tt.value).Render(goht.WithPanicRecovery(context.Background()), &buf)
+			var pErr *goht.PanicError
+			if !errors.As(err, &pErr) {
+				t.Fatalf("want a panic error, got %v", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("want error %q, got %q", tt.wantErr, err.Error())
+			}
+			if pErr.Value != tt.value {
+				t.Errorf("want value %v, got %v", tt.value, pErr.Value)
+			}
+			if !strings.Contains(string(pErr.Stack), "testdata.PanicTest") {
+				t.Errorf("want the stack to contain the template function:\n%s", pErr.Stack)
+			}
+			if err, ok := tt.value.(error); ok && !errors.Is(pErr, err) {
+				t.Errorf("want the panic error to wrap %v", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("want no output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestRecoverPanicDisabled(t *testing.T) {
+	defer func() {
+		if v := recover(); v != "oops" {
+			t.Errorf("want the panic to continue, got %v", v)
+		}
+	}()
+	_ = testdata.PanicTest("oops").Render(context.Background(), io.Discard)
+	t.Error("want a panic")
+}
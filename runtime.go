@@ -7,8 +7,9 @@ import (
 	"fmt"
 	"html"
 	"io"
-	"regexp"
+	"maps"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -67,14 +68,18 @@ func (st *slottedTemplate) SlottedTemplates() []SlottedTemplate {
 	return st.slottedTemplates
 }
 
-// little nuke alligators that eat whitespace; silly but important
+// NukeAfter and NukeBefore were the markers that templates wrote where the
+// Haml `<` and `>` operators remove whitespace. They are no longer written,
+// and the rendered output is no longer searched for them.
+//
+// Deprecated: the whitespace is removed when a template is generated. The
+// constants will be removed in a future release; regenerate the templates
+// that were generated by an earlier version.
 const (
 	NukeAfter  = "~☢<"
 	NukeBefore = ">☢~"
 )
 
-var nukeWhitespaceRe = regexp.MustCompile(NukeAfter + `\s*|\s*` + NukeBefore)
-
 type contextKey int
 
 const (
@@ -83,19 +88,30 @@ const (
 
 type ctxValue struct {
 	children *Template
+	// blocks are the blocks for the next template to be rendered; set when a
+	// template extends another
+	blocks map[string]Template
 }
 
+type blocksContextKey struct{}
+
 type Buffer struct {
 	*bytes.Buffer
+	stream   *stream
+	fragment *fragment
+	stacks   *stacks
+	// trimSpace is set while the leading whitespace of the content written
+	// to the buffer is being removed
+	trimSpace *bool
 }
 
 func (b *Buffer) Bytes() []byte {
-	return nukeWhitespaceRe.ReplaceAll(b.Buffer.Bytes(), nil)
+	return b.stacks.resolve(b.Buffer.Bytes())
 }
 
 var bufferPool = sync.Pool{
 	New: func() any {
-		return Buffer{new(bytes.Buffer)}
+		return Buffer{Buffer: new(bytes.Buffer), stacks: new(stacks), trimSpace: new(bool)}
 	},
 }
 
@@ -105,12 +121,22 @@ func GetBuffer() Buffer {
 
 func ReleaseBuffer(buf Buffer) {
 	buf.Reset()
+	buf.stream = nil
+	buf.fragment = nil
+	buf.stacks.reset()
+	*buf.trimSpace = false
 	bufferPool.Put(buf)
 }
 
 func PopChildren(ctx context.Context) (context.Context, Template) {
 	var value *ctxValue
 	ctx, value = getContext(ctx)
+	// the blocks only belong to the template that was extended and not to
+	// the other templates that it renders
+	if blocks, _ := ctx.Value(blocksContextKey{}).(map[string]Template); blocks != nil || value.blocks != nil {
+		ctx = context.WithValue(ctx, blocksContextKey{}, value.blocks)
+		value.blocks = nil
+	}
 	if value.children == nil {
 		return ctx, TemplateFunc(func(ctx context.Context, w io.Writer, slottedTemplates ...SlottedTemplate) error { return nil })
 	}
@@ -125,6 +151,48 @@ func PushChildren(ctx context.Context, children Template) context.Context {
 	return ctx
 }
 
+// DefineBlock defines the content of a block for the template that is
+// extended next with Extend.
+//
+// A block that has already been defined by a template further down the chain
+// of extending templates is kept.
+func DefineBlock(ctx context.Context, name string, t Template) {
+	_, value := getContext(ctx)
+	if value.blocks == nil {
+		value.blocks = inheritedBlocks(ctx)
+	}
+	if _, ok := value.blocks[name]; !ok {
+		value.blocks[name] = t
+	}
+}
+
+// GetBlock returns the content of the named block defined by an extending
+// template, or nil if the block has not been defined.
+func GetBlock(ctx context.Context, name string) Template {
+	blocks, _ := ctx.Value(blocksContextKey{}).(map[string]Template)
+	return blocks[name]
+}
+
+// Extend renders the base template with the blocks that have been defined
+// with DefineBlock.
+func Extend(ctx context.Context, w io.Writer, base Template, slottedTemplates ...SlottedTemplate) error {
+	_, value := getContext(ctx)
+	if value.blocks == nil {
+		value.blocks = inheritedBlocks(ctx)
+	}
+	return base.Render(ctx, w, slottedTemplates...)
+}
+
+// inheritedBlocks returns a copy of the blocks that were given to the current
+// template by the template that extended it.
+func inheritedBlocks(ctx context.Context) map[string]Template {
+	blocks := make(map[string]Template)
+	if inherited, ok := ctx.Value(blocksContextKey{}).(map[string]Template); ok {
+		maps.Copy(blocks, inherited)
+	}
+	return blocks
+}
+
 func GetSlottedTemplate(slottedTemplates []SlottedTemplate, slotName string) SlottedTemplate {
 	for _, st := range slottedTemplates {
 		if st.SlotName() == slotName {
@@ -153,62 +221,252 @@ func getContext(ctx context.Context) (context.Context, *ctxValue) {
 	return ctx, value
 }
 
-func CaptureErrors(s string, errs ...error) (string, error) {
-	return s, errors.Join(errs...)
+func CaptureErrors[T ~string](s T, errs ...error) (string, error) {
+	return string(s), errors.Join(errs...)
 }
 
+// BuildClassList builds the value of the class attribute from the classes of
+// an element.
+//
+// The classes may be given as a string of one or more classes, a []string,
+// a map[string]bool of conditional classes, a Classes list, a ClassLister, a
+// fmt.Stringer, or a []any of any of these. The classes are written in the
+// order that they are given, the keys of a map are sorted, and a class that
+// has already been written is left out.
 func BuildClassList(classes ...any) (string, error) {
-	var classList []string
+	var classList classList
+	if err := classList.add(classes...); err != nil {
+		return "", err
+	}
+	return strings.Join(classList.classes, ` `), nil
+}
+
+// classList is the de-duplicated list of classes of an element.
+type classList struct {
+	classes []string
+	seen    map[string]struct{}
+}
+
+func (l *classList) add(classes ...any) error {
 	for _, class := range classes {
 		switch class := class.(type) {
 		case string:
-			if class == "" {
-				continue
-			}
-			classList = append(classList, class)
+			l.addString(class)
 		case []string:
-			classList = append(classList, class...)
+			l.addString(class...)
 		case map[string]bool:
-			for cls, ok := range class {
-				if ok {
-					if cls == "" {
-						continue
-					}
-					classList = append(classList, cls)
+			for _, cls := range slices.Sorted(maps.Keys(class)) {
+				if class[cls] {
+					l.addString(cls)
 				}
 			}
+		case ClassLister:
+			l.addString(class.ClassList()...)
+		case fmt.Stringer:
+			l.addString(class.String())
+		case []any:
+			if err := l.add(class...); err != nil {
+				return err
+			}
 		default:
-			return "", fmt.Errorf("goht: invalid class type: %T", class)
+			return fmt.Errorf("goht: invalid class type: %T", class)
 		}
 	}
-	return strings.Join(classList, ` `), nil
+	return nil
 }
 
+func (l *classList) addString(classes ...string) {
+	for _, class := range classes {
+		for _, cls := range strings.Fields(class) {
+			if _, ok := l.seen[cls]; ok {
+				continue
+			}
+			if l.seen == nil {
+				l.seen = make(map[string]struct{})
+			}
+			l.seen[cls] = struct{}{}
+			l.classes = append(l.classes, cls)
+		}
+	}
+}
+
+// BuildAttributeList builds the attribute list for the @attributes command.
+//
+// The attributes may be given as an Attrs list, an Attributer, or a
+// map[string]string, map[string]bool or map[string]any. The attributes are
+// written in the order that they are given; the keys of a map are sorted.
+// See Attrs for how each type of value is written.
 func BuildAttributeList(attributes ...any) (string, error) {
-	var attributeList []string
+	return buildAttributeList(false, attributes...)
+}
+
+// BuildXHTMLAttributeList builds the attribute list for templates in the
+// xhtml and xml formats; boolean attributes are given their name as a value.
+func BuildXHTMLAttributeList(attributes ...any) (string, error) {
+	return buildAttributeList(true, attributes...)
+}
+
+func buildAttributeList(xhtml bool, attributes ...any) (string, error) {
+	var sb strings.Builder
 	for _, attribute := range attributes {
+		var err error
 		switch attribute := attribute.(type) {
+		case Attrs:
+			err = writeAttrs(&sb, xhtml, attribute)
+		case Attributer:
+			err = writeAttrs(&sb, xhtml, attribute.Attributes())
 		case map[string]bool:
-			for key, value := range attribute {
-				if value {
-					attributeList = append(attributeList, html.EscapeString(key))
-				}
-			}
+			err = writeAttributeMap(&sb, xhtml, attribute)
 		case map[string]string:
-			for key, value := range attribute {
-				attributeList = append(attributeList, html.EscapeString(key)+`="`+html.EscapeString(value)+`"`)
-			}
+			err = writeAttributeMap(&sb, xhtml, attribute)
+		case map[string]any:
+			err = writeAttributeMap(&sb, xhtml, attribute)
 		default:
 			return "", fmt.Errorf("goht: invalid attribute type: %T", attribute)
 		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimPrefix(sb.String(), " "), nil
+}
+
+// BuildHashAttributeList builds the attributes for the value of a data or
+// aria attribute. Each attribute in the list is preceded by a space.
+//
+// A map is expanded into one attribute for each of its keys, with the name
+// of the attribute as a prefix and any underscores in the keys replaced by
+// hyphens; `data: #{map[string]any{"user_id": 1}}` is written as
+// `data-user-id="1"`. Nested maps are expanded the same way. A key with a
+// true value is written as a boolean attribute, and a key with a false or nil
+// value is left out; the boolean values of aria attributes are written as
+// "true" or "false" instead. Any other value is written as the value of the named
+// attribute itself. A key that is empty, or that has characters other than
+// ASCII letters, digits and "_:.-", returns an error.
+func BuildHashAttributeList(name string, value any) (string, error) {
+	return buildHashAttributeList(false, name, value)
+}
+
+// BuildXHTMLHashAttributeList builds the attributes for the value of a data
+// or aria attribute in the xhtml and xml formats; boolean attributes are
+// given their name as a value.
+func BuildXHTMLHashAttributeList(name string, value any) (string, error) {
+	return buildHashAttributeList(true, name, value)
+}
+
+func buildHashAttributeList(xhtml bool, name string, value any) (string, error) {
+	var sb strings.Builder
+	if err := writeAttribute(&sb, xhtml, name, value); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// writeAttribute writes an attribute, preceded by a space, for any of the
+// value types that are supported by Attrs. A name with characters other
+// than ASCII letters, digits and "_:.-" returns an error, as the names of
+// Attrs, an Attributer or a map may come from user input.
+func writeAttribute(sb *strings.Builder, xhtml bool, name string, value any) error {
+	if !isHashAttributeKey(name) {
+		return fmt.Errorf("goht: invalid attribute name: %q", name)
+	}
+	var attrValue string
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case bool:
+		if IsARIAAttribute(name) {
+			// an aria attribute is written with its boolean value, as a bare
+			// aria attribute would be empty rather than true
+			sb.WriteString(" " + html.EscapeString(name) + `="` + strconv.FormatBool(value) + `"`)
+			return nil
+		}
+		if !value {
+			return nil
+		}
+		sb.WriteString(" " + html.EscapeString(name))
+		if xhtml {
+			sb.WriteString(`="` + html.EscapeString(name) + `"`)
+		}
+		return nil
+	case map[string]any:
+		return writeHashAttributes(sb, xhtml, name, value)
+	case map[string]string:
+		return writeHashAttributes(sb, xhtml, name, value)
+	case map[string]bool:
+		return writeHashAttributes(sb, xhtml, name, value)
+	case string:
+		attrValue = EscapeAttribute(name, value)
+	case HTML:
+		attrValue = EscapeAttribute(name, value)
+	case URL:
+		attrValue = EscapeAttribute(name, value)
+	case JS:
+		attrValue = EscapeAttribute(name, value)
+	case CSS:
+		attrValue = EscapeAttribute(name, value)
+	case fmt.Stringer:
+		attrValue = EscapeAttribute(name, value.String())
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		attrValue = fmt.Sprint(value)
+	default:
+		return fmt.Errorf("goht: invalid %s attribute type: %T", name, value)
+	}
+	sb.WriteString(" " + html.EscapeString(name) + `="` + attrValue + `"`)
+	return nil
+}
+
+func writeHashAttributes[V any](sb *strings.Builder, xhtml bool, name string, values map[string]V) error {
+	// for stable ordering of the attributes
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if !isHashAttributeKey(key) {
+			return fmt.Errorf("goht: invalid %s attribute name: %q", name, key)
+		}
+		if err := writeAttribute(sb, xhtml, name+"-"+strings.ReplaceAll(key, "_", "-"), values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsARIAAttribute returns true for the aria attributes, whose boolean values
+// are written as "true" or "false" rather than as boolean attributes.
+func IsARIAAttribute(name string) bool {
+	return len(name) > 5 && strings.EqualFold(name[:5], "aria-")
+}
+
+// isHashAttributeKey returns true when the key is made of ASCII letters,
+// digits and the runes "_:.-", so that the expanded name can't end the
+// attribute or add another one.
+func isHashAttributeKey(key string) bool {
+	if key == "" {
+		return false
 	}
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; !isASCIILetter(c) && !('0' <= c && c <= '9') && !strings.ContainsRune("_:.-", rune(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeAttributeMap[V any](sb *strings.Builder, xhtml bool, values map[string]V) error {
 	// for stable ordering of the attributes
-	slices.Sort(attributeList)
-	return strings.Join(attributeList, " "), nil
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if err := writeAttribute(sb, xhtml, key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func EscapeString(s string) string {
-	return html.EscapeString(s)
+func writeAttrs(sb *strings.Builder, xhtml bool, attrs Attrs) error {
+	for _, attr := range attrs {
+		if err := writeAttribute(sb, xhtml, attr.Key, attr.Value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func FormatString(format string, value any) string {
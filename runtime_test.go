@@ -0,0 +1,241 @@
+package goht
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildHashAttributeList(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		value   any
+		xhtml   bool
+		want    string
+		wantErr bool
+	}{
+		"map": {
+			value: map[string]any{"user_id": 1, "role": "admin"},
+			want:  ` data-role="admin" data-user-id="1"`,
+		},
+		"nested map": {
+			value: map[string]any{"user": map[string]string{"first_name": "<b>"}},
+			want:  ` data-user-first-name="&lt;b&gt;"`,
+		},
+		"booleans": {
+			value: map[string]bool{"active": true, "hidden": false},
+			want:  ` data-active`,
+		},
+		"xhtml booleans": {
+			value: map[string]any{"active": true, "missing": nil},
+			xhtml: true,
+			want:  ` data-active="data-active"`,
+		},
+		"aria booleans": {
+			name:  "aria",
+			value: map[string]any{"expanded": true, "hidden": false, "label": "Close"},
+			want:  ` aria-expanded="true" aria-hidden="false" aria-label="Close"`,
+		},
+		"xhtml aria booleans": {
+			name:  "aria",
+			value: map[string]bool{"expanded": false},
+			xhtml: true,
+			want:  ` aria-expanded="false"`,
+		},
+		"url context": {
+			value: map[string]string{"url": "javascript:alert(1)"},
+			want:  ` data-url="about:invalid#zGohtz"`,
+		},
+		"not a map": {
+			value: "value",
+			want:  ` data="value"`,
+		},
+		"invalid type": {
+			value:   []string{"a"},
+			wantErr: true,
+		},
+		"key with a space": {
+			value:   map[string]string{"x onmouseover": "alert(1)"},
+			wantErr: true,
+		},
+		"key with a quote": {
+			value:   map[string]any{"user": map[string]any{`x"`: 1}},
+			wantErr: true,
+		},
+		"empty key": {
+			value:   map[string]bool{"": true},
+			wantErr: true,
+		},
+		"key with punctuation": {
+			value: map[string]string{"x:y.z": "1"},
+			want:  ` data-x:y.z="1"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			build := BuildHashAttributeList
+			if tt.xhtml {
+				build = BuildXHTMLHashAttributeList
+			}
+			name := tt.name
+			if name == "" {
+				name = "data"
+			}
+			got, err := build(name, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+type testAttributer struct{}
+
+func (testAttributer) Attributes() Attrs {
+	return Attrs{{Key: "role", Value: "button"}, {Key: "tabindex", Value: 0}}
+}
+
+type testStringer struct{}
+
+func (testStringer) String() string { return "a&b" }
+
+func TestBuildAttributeList(t *testing.T) {
+	tests := map[string]struct {
+		attributes []any
+		xhtml      bool
+		want       string
+		wantErr    bool
+	}{
+		"maps": {
+			attributes: []any{map[string]bool{"b": true, "a": true, "c": false}, map[string]string{"z": "1", "y": "2"}},
+			want:       `a b y="2" z="1"`,
+		},
+		"ordered attrs": {
+			attributes: []any{Attrs{
+				{Key: "z", Value: "last"},
+				{Key: "a", Value: 1},
+				{Key: "f", Value: 1.5},
+				{Key: "s", Value: testStringer{}},
+				{Key: "href", Value: URL("javascript:void(0)")},
+				{Key: "on", Value: true},
+				{Key: "off", Value: false},
+				{Key: "none", Value: nil},
+			}},
+			want: `z="last" a="1" f="1.5" s="a&amp;b" href="javascript:void%280%29" on`,
+		},
+		"attributer": {
+			attributes: []any{map[string]any{"id": "x"}, testAttributer{}},
+			want:       `id="x" role="button" tabindex="0"`,
+		},
+		"xhtml booleans": {
+			attributes: []any{Attrs{{Key: "checked", Value: true}}},
+			xhtml:      true,
+			want:       `checked="checked"`,
+		},
+		"escaped values": {
+			attributes: []any{Attrs{{Key: "title", Value: HTML("<b>")}, {Key: "src", Value: "javascript:alert(1)"}}},
+			want:       `title="&lt;b&gt;" src="about:invalid#zGohtz"`,
+		},
+		"invalid attribute type": {
+			attributes: []any{"disabled"},
+			wantErr:    true,
+		},
+		"invalid value type": {
+			attributes: []any{Attrs{{Key: "a", Value: []int{1}}}},
+			wantErr:    true,
+		},
+		"invalid attrs name": {
+			attributes: []any{Attrs{{Key: "x onmouseover=alert(1) y", Value: "v"}}},
+			wantErr:    true,
+		},
+		"invalid map name": {
+			attributes: []any{map[string]bool{`x"><script>`: true}},
+			wantErr:    true,
+		},
+		"empty name": {
+			attributes: []any{map[string]string{"": "v"}},
+			wantErr:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			build := BuildAttributeList
+			if tt.xhtml {
+				build = BuildXHTMLAttributeList
+			}
+			got, err := build(tt.attributes...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+type testClassLister []string
+
+func (l testClassLister) ClassList() []string { return l }
+
+func TestBuildClassList(t *testing.T) {
+	tests := map[string]struct {
+		classes []any
+		want    string
+		wantErr bool
+	}{
+		"strings": {
+			classes: []any{"a b", "", []string{"c", " d "}},
+			want:    "a b c d",
+		},
+		"sorted map": {
+			classes: []any{map[string]bool{"z": true, "y": true, "x": false, "w": true}},
+			want:    "w y z",
+		},
+		"de-duplicated": {
+			classes: []any{"a b", []string{"b", "c"}, map[string]bool{"a": true, "d": true}},
+			want:    "a b c d",
+		},
+		"classes": {
+			classes: []any{Classes{"btn"}.If(true, "active").If(false, "disabled").Add("px-4")},
+			want:    "btn active px-4",
+		},
+		"class lister and stringer": {
+			classes: []any{testClassLister{"card"}, testStringer{}},
+			want:    "card a&b",
+		},
+		"any list": {
+			classes: []any{[]any{"a", []any{Classes{"b"}, map[string]bool{"c": true}}}},
+			want:    "a b c",
+		},
+		"invalid type": {
+			classes: []any{1},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := BuildClassList(tt.classes...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClassesAreNotShared(t *testing.T) {
+	base := Classes{"btn"}.Add("px-4")
+	a := base.Add("a")
+	b := base.Add("b")
+	if got := strings.Join(a, " "); got != "btn px-4 a" {
+		t.Errorf("want %q, got %q", "btn px-4 a", got)
+	}
+	if got := strings.Join(b, " "); got != "btn px-4 b" {
+		t.Errorf("want %q, got %q", "btn px-4 b", got)
+	}
+}
@@ -0,0 +1,212 @@
+package goht
+
+import (
+	"html"
+	"strings"
+)
+
+// sanitizeTags are the elements that SanitizeHTML keeps.
+var sanitizeTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "blockquote": true, "br": true,
+	"caption": true, "cite": true, "code": true, "dd": true, "del": true,
+	"div": true, "dl": true, "dt": true, "em": true, "figcaption": true,
+	"figure": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "hr": true, "i": true, "img": true, "ins": true,
+	"kbd": true, "li": true, "mark": true, "ol": true, "p": true, "pre": true,
+	"q": true, "s": true, "small": true, "span": true, "strong": true,
+	"sub": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "u": true, "ul": true,
+}
+
+// sanitizeDropped are the elements that SanitizeHTML removes along with their content.
+var sanitizeDropped = map[string]bool{
+	"embed": true, "frame": true, "frameset": true, "iframe": true,
+	"math": true, "noembed": true, "noframes": true, "noscript": true,
+	"object": true, "script": true, "select": true, "style": true,
+	"svg": true, "template": true, "textarea": true, "title": true,
+	"xmp": true,
+}
+
+// sanitizeVoid are the kept elements that do not have a closing tag.
+var sanitizeVoid = map[string]bool{
+	"br": true, "hr": true, "img": true,
+}
+
+// sanitizeAttributes are the attributes that SanitizeHTML keeps on any element.
+var sanitizeAttributes = map[string]bool{
+	"alt": true, "cite": true, "class": true, "colspan": true, "dir": true,
+	"height": true, "href": true, "lang": true, "rowspan": true, "src": true,
+	"title": true, "width": true,
+}
+
+// SanitizeHTML returns the markup as trusted HTML after removing anything
+// that could be used to run code.
+//
+// Only common formatting elements and attributes are kept; script, style and
+// embedded content is removed with its content, other elements are removed
+// and their content is kept. URLs in attributes are filtered the same as
+// EscapeURL, text is re-escaped, and any elements left open are closed.
+func SanitizeHTML(s string) HTML {
+	var b strings.Builder
+	var open []string
+	dropping := ""
+	text := func(t string) {
+		if dropping == "" {
+			b.WriteString(escapeHTML(html.UnescapeString(t)))
+		}
+	}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			text(s)
+			break
+		}
+		text(s[:i])
+		s = s[i:]
+		if strings.HasPrefix(s, "<!") || strings.HasPrefix(s, "<?") {
+			end := ">"
+			if strings.HasPrefix(s, "<!--") {
+				end = "-->"
+			}
+			if j := strings.Index(s, end); j >= 0 {
+				s = s[j+len(end):]
+			} else {
+				s = ""
+			}
+			continue
+		}
+		tag, rest, ok := parseSanitizeTag(s)
+		if !ok {
+			text("<")
+			s = s[1:]
+			continue
+		}
+		s = rest
+		switch {
+		case dropping != "":
+			if tag.closing && tag.name == dropping {
+				dropping = ""
+			}
+		case sanitizeDropped[tag.name]:
+			if !tag.closing && !tag.selfClosing {
+				dropping = tag.name
+			}
+		case !sanitizeTags[tag.name]:
+		case tag.closing:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tag.name {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		default:
+			b.WriteString("<" + tag.name)
+			for _, attr := range tag.attrs {
+				if !sanitizeAttributes[attr.name] {
+					continue
+				}
+				value := attr.value
+				if AttributeContext(attr.name) == URLContext {
+					if value = EscapeURL(value); value == unsafeURL {
+						continue
+					}
+				}
+				b.WriteString(" " + attr.name + `="` + escapeHTML(value) + `"`)
+			}
+			b.WriteString(">")
+			if !sanitizeVoid[tag.name] {
+				open = append(open, tag.name)
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return HTML(b.String())
+}
+
+type sanitizeTag struct {
+	name        string
+	closing     bool
+	selfClosing bool
+	attrs       []sanitizeAttr
+}
+
+type sanitizeAttr struct {
+	name  string
+	value string
+}
+
+// parseSanitizeTag parses the tag at the start of s; returning the tag and
+// the remainder of s. If s does not start with a complete tag ok is false.
+func parseSanitizeTag(s string) (tag sanitizeTag, rest string, ok bool) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		tag.closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && (isASCIILetter(s[i]) || i > start && '0' <= s[i] && s[i] <= '9') {
+		i++
+	}
+	if i == start {
+		return tag, s, false
+	}
+	tag.name = strings.ToLower(s[start:i])
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '>':
+			return tag, s[i+1:], true
+		case c == '/':
+			tag.selfClosing = true
+			i++
+		case isASCIISpace(c):
+			i++
+		default:
+			tag.selfClosing = false
+			start := i
+			for i < len(s) && !isASCIISpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+				i++
+			}
+			attr := sanitizeAttr{name: strings.ToLower(s[start:i])}
+			for i < len(s) && isASCIISpace(s[i]) {
+				i++
+			}
+			if i < len(s) && s[i] == '=' {
+				i++
+				for i < len(s) && isASCIISpace(s[i]) {
+					i++
+				}
+				if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+					end := strings.IndexByte(s[i+1:], s[i])
+					if end < 0 {
+						return tag, s, false
+					}
+					attr.value = s[i+1 : i+1+end]
+					i += end + 2
+				} else {
+					start := i
+					for i < len(s) && !isASCIISpace(s[i]) && s[i] != '>' {
+						i++
+					}
+					attr.value = s[start:i]
+				}
+				attr.value = html.UnescapeString(attr.value)
+			}
+			tag.attrs = append(tag.attrs, attr)
+		}
+	}
+	return tag, s, false
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isASCIISpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,36 @@
+package goht
+
+import (
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := map[string]struct {
+		html string
+		want HTML
+	}{
+		"text":            {html: "Fish & Chips", want: "Fish &amp; Chips"},
+		"entities":        {html: "&lt;b&gt; &amp;amp;", want: "&lt;b&gt; &amp;amp;"},
+		"formatting":      {html: "<p>Hello <strong>World</strong></p>", want: "<p>Hello <strong>World</strong></p>"},
+		"script":          {html: "a<script>alert(1)</script>b", want: "ab"},
+		"style":           {html: "<style>p{}</style><p>a</p>", want: "<p>a</p>"},
+		"unknown element": {html: "<blink>a</blink>", want: "a"},
+		"event handler":   {html: `<p onclick="alert(1)">a</p>`, want: "<p>a</p>"},
+		"unsafe url":      {html: `<a href="javascript:alert(1)">a</a>`, want: "<a>a</a>"},
+		"safe url":        {html: `<a href='https://example.com/?a=1&amp;b=2'>a</a>`, want: `<a href="https://example.com/?a=1&amp;b=2">a</a>`},
+		"image":           {html: `<img src=/a.png alt="An image"/>`, want: `<img src="/a.png" alt="An image">`},
+		"comment":         {html: "a<!-- <script> -->b", want: "ab"},
+		"unclosed":        {html: "<p><em>a", want: "<p><em>a</em></p>"},
+		"misnested":       {html: "<p><em>a</p>b</em>", want: "<p><em>a</em></p>b"},
+		"stray closing":   {html: "a</div>", want: "a"},
+		"less than":       {html: "1 < 2", want: "1 &lt; 2"},
+		"unterminated":    {html: `<a href="x`, want: "&lt;a href=&#34;x"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.html); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package goht
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// SlotFunc builds the template for a scoped slot from the value that the
+// owning template passes to the slot.
+//
+// A template owns a scoped slot by passing a value to it, such as each row of
+// a table with "=@slot row(item)". The caller then provides a SlotFunc for that
+// slot and decides how each value is rendered:
+//
+//	err := Table(items).Render(ctx, w,
+//		goht.SlotFunc[Item](func(item Item) goht.Template {
+//			return ItemRow(item)
+//		}).Slot("row"),
+//	)
+//
+// The slot is rendered with the SlotFunc when T is the type of the value;
+// a SlotFunc for any other type is returned as an error. The owning template
+// can name the type of the value, as in "=@slot row[Item](item)", so that a
+// value of any other type fails to compile.
+type SlotFunc[T any] func(value T) Template
+
+// Slot returns the SlotFunc as a SlottedTemplate for the named slot.
+func (f SlotFunc[T]) Slot(slotName string, slottedTemplates ...SlottedTemplate) SlottedTemplate {
+	return &scopedSlot[T]{
+		fn:               f,
+		slotName:         slotName,
+		slottedTemplates: slottedTemplates,
+	}
+}
+
+// scopedTemplate is a slotted template that is rendered with a value from
+// the owning template.
+type scopedTemplate interface {
+	valueType() reflect.Type
+}
+
+type scopedSlot[T any] struct {
+	fn               SlotFunc[T]
+	slotName         string
+	slottedTemplates []SlottedTemplate
+}
+
+// Render returns an error; a scoped slot can only be rendered with a value.
+func (s *scopedSlot[T]) Render(context.Context, io.Writer, ...SlottedTemplate) error {
+	return fmt.Errorf("goht: slot %q expects a value of type %s", s.slotName, reflect.TypeFor[T]())
+}
+
+func (s *scopedSlot[T]) Slot(slotName string, slottedTemplates ...SlottedTemplate) SlottedTemplate {
+	return s.fn.Slot(slotName, slottedTemplates...)
+}
+
+func (s *scopedSlot[T]) SlotName() string {
+	return s.slotName
+}
+
+func (s *scopedSlot[T]) SlottedTemplates() []SlottedTemplate {
+	return s.slottedTemplates
+}
+
+func (s *scopedSlot[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (s *scopedSlot[T]) render(ctx context.Context, w io.Writer, value T, slottedTemplates ...SlottedTemplate) error {
+	t := s.fn(value)
+	if t == nil {
+		return nil
+	}
+	return t.Render(ctx, w, slottedTemplates...)
+}
+
+// RenderScopedSlot renders the slotted template of a scoped slot with the
+// value from the owning template.
+//
+// Templates built by a SlotFunc[T] receive the value, and a SlotFunc for any
+// other type is returned as an error. Any other template is rendered as it
+// would be in a slot without a value.
+func RenderScopedSlot[T any](ctx context.Context, w io.Writer, st SlottedTemplate, value T, slottedTemplates ...SlottedTemplate) error {
+	switch s := st.(type) {
+	case *scopedSlot[T]:
+		return s.render(ctx, w, value, slottedTemplates...)
+	case scopedTemplate:
+		return fmt.Errorf("goht: slot %q expects a value of type %s but got %s", st.SlotName(), s.valueType(), reflect.TypeFor[T]())
+	}
+	return st.Render(ctx, w, slottedTemplates...)
+}
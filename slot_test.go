@@ -0,0 +1,93 @@
+package goht
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+func textTemplate(text string) Template {
+	return TemplateFunc(func(ctx context.Context, w io.Writer, _ ...SlottedTemplate) error {
+		_, err := io.WriteString(w, text)
+		return err
+	})
+}
+
+func TestRenderScopedSlot(t *testing.T) {
+	greeting := SlotFunc[string](func(name string) Template {
+		if name == "" {
+			return nil
+		}
+		return textTemplate("Hello, " + name)
+	})
+	ctx := context.Background()
+	tests := map[string]struct {
+		render  func(w io.Writer) error
+		want    string
+		wantErr string
+	}{
+		"slot func": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot(ctx, w, greeting.Slot("greeting"), "World")
+			},
+			want: "Hello, World",
+		},
+		"nil template": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot(ctx, w, greeting.Slot("greeting"), "")
+			},
+			want: "",
+		},
+		"named type": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot[string](ctx, w, greeting.Slot("greeting"), "World")
+			},
+			want: "Hello, World",
+		},
+		"wrong type": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot(ctx, w, greeting.Slot("greeting"), 42)
+			},
+			wantErr: `goht: slot "greeting" expects a value of type string but got int`,
+		},
+		"interface type": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot[any](ctx, w, greeting.Slot("greeting"), "World")
+			},
+			wantErr: `goht: slot "greeting" expects a value of type string but got interface {}`,
+		},
+		"template without a value": {
+			render: func(w io.Writer) error {
+				return RenderScopedSlot(ctx, w, textTemplate("Hello").Slot("greeting"), "World")
+			},
+			want: "Hello",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.render(&buf)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("want error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("want %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestSlotFuncWithoutValue(t *testing.T) {
+	slot := SlotFunc[int](func(int) Template { return textTemplate("") }).Slot("count")
+	want := `goht: slot "count" expects a value of type int`
+	if err := slot.Render(context.Background(), &bytes.Buffer{}); err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+}
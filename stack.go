@@ -0,0 +1,93 @@
+package goht
+
+import (
+	"bytes"
+	"context"
+)
+
+// stackPlaceholder starts the placeholder that is written for a stack and
+// replaced with the content of the stack once the template has been rendered.
+const stackPlaceholder = "\x00goht-stack:"
+
+// stacks holds the content that has been pushed onto the stacks of a buffer.
+type stacks struct {
+	content map[string]*bytes.Buffer
+	keys    map[string]struct{}
+	// written is set once a placeholder has been written
+	written bool
+}
+
+// Push renders t onto the named stack. The content of the stack is written
+// wherever the stack is placed with WriteStack, even when that is earlier in
+// the document than the push.
+//
+// When key is not empty, only the first push of a key onto the stack is kept.
+func (b Buffer) Push(ctx context.Context, stack, key string, t Template) error {
+	if b.stacks == nil || b.discarding() {
+		return nil
+	}
+	if key != "" {
+		k := stack + "\x00" + key
+		if _, ok := b.stacks.keys[k]; ok {
+			return nil
+		}
+		if b.stacks.keys == nil {
+			b.stacks.keys = make(map[string]struct{})
+		}
+		b.stacks.keys[k] = struct{}{}
+	}
+	if b.stacks.content == nil {
+		b.stacks.content = make(map[string]*bytes.Buffer)
+	}
+	content, ok := b.stacks.content[stack]
+	if !ok {
+		content = new(bytes.Buffer)
+		b.stacks.content[stack] = content
+	}
+	// the content shares the stacks so that it may push content of its own
+	return t.Render(ctx, Buffer{Buffer: content, stacks: b.stacks})
+}
+
+// WriteStack writes the placeholder for the named stack.
+//
+// When the buffer is streamed, the content from the placeholder on is only
+// flushed once the template has been rendered and the stack is complete.
+func (b Buffer) WriteStack(stack string) (int, error) {
+	if b.stacks == nil {
+		return 0, nil
+	}
+	b.stacks.written = true
+	return b.WriteString(stackPlaceholder + stack + "\x00")
+}
+
+// resolve replaces the stack placeholders in data with the content of the
+// stacks.
+func (s *stacks) resolve(data []byte) []byte {
+	if s == nil || !s.written || !bytes.Contains(data, []byte(stackPlaceholder)) {
+		return data
+	}
+	resolved := make([]byte, 0, len(data))
+	for {
+		i := bytes.Index(data, []byte(stackPlaceholder))
+		if i < 0 {
+			break
+		}
+		resolved = append(resolved, data[:i]...)
+		data = data[i+len(stackPlaceholder):]
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			break
+		}
+		if content, ok := s.content[string(data[:end])]; ok {
+			resolved = append(resolved, content.Bytes()...)
+		}
+		data = data[end+1:]
+	}
+	return append(resolved, data...)
+}
+
+func (s *stacks) reset() {
+	clear(s.content)
+	clear(s.keys)
+	s.written = false
+}
@@ -0,0 +1,67 @@
+package goht_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stackus/goht"
+	"github.com/stackus/goht/compiler/testdata"
+)
+
+func TestStacks(t *testing.T) {
+	tests := map[string]struct {
+		template goht.Template
+		want     string
+	}{
+		"empty stacks": {
+			template: testdata.StackTest(),
+			want:     "<head>\n</head>\n<body>\n</body>\n",
+		},
+		"pushed after the stack": {
+			template: testdata.StackTest(testdata.PushScriptTest("", "/a.js"), testdata.PushScriptTest("", "/b.js")),
+			want:     "<head>\n<script src=\"/a.js\"></script>\n<script src=\"/b.js\"></script>\n</head>\n<body>\n</body>\n",
+		},
+		"other stacks": {
+			template: testdata.StackTest(testdata.PushStyleTest("", "/a.css")),
+			want:     "<head>\n<link rel=\"stylesheet\" href=\"/a.css\"></head>\n<body>\n</body>\n",
+		},
+		"de-duplicated by key": {
+			template: testdata.StackTest(testdata.PushScriptTest("app", "/a.js"), testdata.PushScriptTest("app", "/b.js")),
+			want:     "<head>\n<script src=\"/a.js\"></script>\n</head>\n<body>\n</body>\n",
+		},
+		"keys are per stack": {
+			template: testdata.StackTest(testdata.PushStyleTest("app", "/a.css"), testdata.PushScriptTest("app", "/a.js")),
+			want:     "<head>\n<link rel=\"stylesheet\" href=\"/a.css\"><script src=\"/a.js\"></script>\n</head>\n<body>\n</body>\n",
+		},
+		"nested pushes": {
+			template: testdata.StackTest(testdata.PushWidgetTest(), testdata.PushWidgetTest()),
+			want:     "<head>\n<link rel=\"stylesheet\" href=\"/widget.css\"><script src=\"/widget.js\"></script>\n</head>\n<body>\n<div class=\"widget\"></div>\n<div class=\"widget\"></div>\n</body>\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.template.Render(context.Background(), &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("want %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestStacksReleased(t *testing.T) {
+	tmpl := testdata.StackTest(testdata.PushScriptTest("app", "/a.js"))
+	want := "<head>\n<script src=\"/a.js\"></script>\n</head>\n<body>\n</body>\n"
+	for range 2 {
+		var buf bytes.Buffer
+		if err := tmpl.Render(context.Background(), &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != want {
+			t.Errorf("want %q, got %q", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package goht
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+type streamContextKey struct{}
+
+// StreamOption configures how a streaming render flushes its output.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	flushAfter []string
+}
+
+// FlushAfter flushes the output to the destination each time the closing tag
+// of one of the given elements has been written.
+//
+// (e.g. FlushAfter("head") flushes right after the "</head>" tag)
+func FlushAfter(tags ...string) StreamOption {
+	return func(cfg *streamConfig) {
+		for _, tag := range tags {
+			cfg.flushAfter = append(cfg.flushAfter, "</"+strings.ToLower(tag)+">")
+		}
+	}
+}
+
+// WithStreaming returns a context that renders templates in streaming mode.
+//
+// In streaming mode the rendered output is written to the destination each
+// time a flush point is reached, either from an explicit @flush command or
+// from one of the configured StreamOptions. If the destination implements
+// http.Flusher it will also be flushed.
+func WithStreaming(ctx context.Context, options ...StreamOption) context.Context {
+	cfg := &streamConfig{}
+	for _, option := range options {
+		option(cfg)
+	}
+	return context.WithValue(ctx, streamContextKey{}, cfg)
+}
+
+// RenderStream renders the template into w in streaming mode.
+//
+// This is the same as rendering the template with a context from WithStreaming.
+func RenderStream(ctx context.Context, w io.Writer, t Template, options ...StreamOption) error {
+	return t.Render(WithStreaming(ctx, options...), w)
+}
+
+type stream struct {
+	w          io.Writer
+	flushAfter []string
+}
+
+// AcquireBuffer returns a buffer from the pool to render a template into w.
+//
+// When the context has streaming enabled, the buffer will write its content
+// through to w each time it is flushed. When the context is rendering a
+// fragment, the first buffer acquired will discard the content outside of
+// the fragment.
+func AcquireBuffer(ctx context.Context, w io.Writer) Buffer {
+	buf := GetBuffer()
+	if cfg, ok := ctx.Value(streamContextKey{}).(*streamConfig); ok {
+		buf.stream = &stream{
+			w:          w,
+			flushAfter: cfg.flushAfter,
+		}
+	}
+	if f, ok := ctx.Value(fragmentContextKey{}).(*fragment); ok && !f.claimed {
+		f.claimed = true
+		buf.fragment = f
+	}
+	return buf
+}
+
+// WriteString appends s to the buffer; flushing the buffer afterward if s
+// contains one of the closing tags that the stream is configured to flush after.
+// When rendering a fragment, content written outside the fragment is discarded.
+func (b Buffer) WriteString(s string) (int, error) {
+	if b.discarding() {
+		return len(s), nil
+	}
+	n := len(s)
+	if s = b.trimLeadingSpace(s); s == "" {
+		return n, nil
+	}
+	_, err := b.Buffer.WriteString(s)
+	if err != nil || b.stream == nil {
+		return n, err
+	}
+	for _, tag := range b.stream.flushAfter {
+		if strings.Contains(s, tag) {
+			return n, b.Flush()
+		}
+	}
+	return n, nil
+}
+
+// Flush writes the content of a streaming buffer to its destination.
+//
+// Trailing whitespace is held back until more content has been written, so
+// whitespace removal works the same as it does for a non-streaming buffer.
+// Content from the first stack placeholder on is held back until the template
+// has been rendered, as more content can still be pushed onto the stack.
+// For a non-streaming buffer this is a no-op.
+func (b Buffer) Flush() error {
+	if b.stream == nil {
+		return nil
+	}
+	data := b.Buffer.Bytes()
+	if b.stacks != nil && b.stacks.written {
+		if i := bytes.Index(data, []byte(stackPlaceholder)); i >= 0 {
+			data = data[:i]
+		}
+	}
+	n := len(bytes.TrimRightFunc(data, unicode.IsSpace))
+	if n > 0 {
+		if _, err := b.stream.w.Write(data[:n]); err != nil {
+			return err
+		}
+		b.Buffer.Next(n)
+	}
+	if f, ok := b.stream.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
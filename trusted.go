@@ -0,0 +1,40 @@
+package goht
+
+// The trusted content types mark a value as safe to be written into a
+// template without being escaped for the matching context.
+//
+// Converting a value to one of these types is a promise that the value is
+// safe; only convert values that come from a trusted source, or use one of
+// the Sanitize functions to build them from untrusted input.
+type (
+	// HTML is markup that is written into element content without escaping.
+	//
+	// HTML values written into an attribute are still escaped.
+	HTML string
+	// URL is a URL that is written into a URL attribute without filtering
+	// its scheme. The URL is still normalized and HTML escaped.
+	URL string
+	// JS is JavaScript that is written into a script element or event
+	// handler attribute without quoting or escaping.
+	JS string
+	// CSS is CSS that is written into a style element or style attribute
+	// without filtering.
+	CSS string
+)
+
+// SanitizeURL returns the URL as a trusted URL after its scheme has been
+// checked and its characters normalized.
+//
+// URLs with a scheme other than http, https, mailto or tel are replaced with
+// "about:invalid#zGohtz".
+func SanitizeURL(s string) URL {
+	return URL(EscapeURL(s))
+}
+
+// SanitizeCSS returns the value as trusted CSS if it is a safe CSS value.
+//
+// Values which could break out of the declaration they are written into, or
+// could be used to run code, are replaced with "zGohtz".
+func SanitizeCSS(s string) CSS {
+	return CSS(EscapeCSS(s))
+}
@@ -0,0 +1,48 @@
+package goht
+
+import (
+	"bytes"
+	"strings"
+)
+
+// spaceChars are the characters that are removed by the Haml whitespace
+// removal operators.
+const spaceChars = " \t\n\f\r"
+
+// TrimPrecedingSpace removes the whitespace at the end of the buffer.
+//
+// The compiler removes the whitespace in the template itself wherever it can;
+// this is only used when the whitespace may come from dynamic content, such
+// as the output of Go code or of another template.
+func (b Buffer) TrimPrecedingSpace() {
+	if b.discarding() {
+		return
+	}
+	b.Buffer.Truncate(len(bytes.TrimRight(b.Buffer.Bytes(), spaceChars)))
+}
+
+// TrimFollowingSpace removes the whitespace at the start of the content that
+// is written to the buffer next.
+//
+// The compiler removes the whitespace in the template itself wherever it can;
+// this is only used when the whitespace may come from dynamic content, such
+// as the output of Go code or of another template.
+func (b Buffer) TrimFollowingSpace() {
+	if b.trimSpace == nil || b.discarding() {
+		return
+	}
+	*b.trimSpace = true
+}
+
+// trimLeadingSpace removes the leading whitespace from s while the buffer is
+// trimming the whitespace that follows a TrimFollowingSpace.
+func (b Buffer) trimLeadingSpace(s string) string {
+	if b.trimSpace == nil || !*b.trimSpace {
+		return s
+	}
+	s = strings.TrimLeft(s, spaceChars)
+	if s != "" {
+		*b.trimSpace = false
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package goht
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrimSpace(t *testing.T) {
+	tests := map[string]struct {
+		write func(b Buffer)
+		want  string
+	}{
+		"preceding": {
+			write: func(b Buffer) {
+				_, _ = b.WriteString("<p> \n\t")
+				b.TrimPrecedingSpace()
+				_, _ = b.WriteString(" a")
+			},
+			want: "<p> a",
+		},
+		"following": {
+			write: func(b Buffer) {
+				_, _ = b.WriteString("<p>")
+				b.TrimFollowingSpace()
+				_, _ = b.WriteString(" \n")
+				_, _ = b.Write([]byte("\ta "))
+				_, _ = b.WriteString("b")
+			},
+			want: "<p>a b",
+		},
+		"non-breaking space is kept": {
+			write: func(b Buffer) {
+				b.TrimFollowingSpace()
+				_, _ = b.WriteString("\u00a0a")
+			},
+			want: "\u00a0a",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := GetBuffer()
+			defer ReleaseBuffer(b)
+			tt.write(b)
+			if got := string(b.Bytes()); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTrimSpaceReleased(t *testing.T) {
+	b := GetBuffer()
+	b.TrimFollowingSpace()
+	ReleaseBuffer(b)
+
+	b = AcquireBuffer(context.Background(), nil)
+	defer ReleaseBuffer(b)
+	_, _ = b.WriteString(" a")
+	if got := string(b.Bytes()); got != " a" {
+		t.Errorf("want %q, got %q", " a", got)
+	}
+}